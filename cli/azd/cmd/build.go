@@ -119,7 +119,7 @@ func (ba *buildAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 			},
 		}
 
-		if err := ba.workflowRunner.Run(ctx, workflow); err != nil {
+		if _, err := ba.workflowRunner.Run(ctx, workflow); err != nil {
 			return nil, err
 		}
 	}
@@ -199,7 +199,7 @@ func (ba *buildAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 		ba.console.MessageUxItem(ctx, buildResult)
 	}
 
-	if ba.formatter.Kind() == output.JsonFormat {
+	if output.IsJsonFormat(ba.formatter.Kind()) {
 		buildResult := BuildResult{
 			Timestamp: time.Now(),
 			Services:  buildResults,
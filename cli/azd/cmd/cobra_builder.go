@@ -222,9 +222,18 @@ func (cb *CobraBuilder) bindCommand(cmd *cobra.Command, descriptor *actions.Acti
 		docsFlag, "docs", "", fmt.Sprintf("Opens the documentation for %s in your web browser.", cmd.CommandPath()))
 	flag.NoOptDefVal = "true"
 
-	// Consistently registers output formats for the descriptor
-	if len(descriptor.Options.OutputFormats) > 0 {
-		output.AddOutputParam(cmd, descriptor.Options.OutputFormats, descriptor.Options.DefaultFormat)
+	// Consistently registers output formats for the descriptor.
+	// Every command supports the jsonl event stream, in addition to the output formats of its descriptor.
+	outputFormats := descriptor.Options.OutputFormats
+	defaultFormat := descriptor.Options.DefaultFormat
+	if len(outputFormats) == 0 && descriptor.Options.ActionResolver != nil {
+		outputFormats = []output.Format{output.NoneFormat}
+		defaultFormat = output.NoneFormat
+	}
+
+	if len(outputFormats) > 0 {
+		outputFormats = append(slices.Clone(outputFormats), output.JsonLinesFormat)
+		output.AddOutputParam(cmd, outputFormats, defaultFormat)
 	}
 
 	// Create, register and bind flags when required
@@ -214,7 +214,7 @@ func Test_BuildCommandsWithAutomaticHelpAndOutputFlags(t *testing.T) {
 	require.NotNil(t, outputFlag)
 	require.Equal(t, "output", outputFlag.Name)
 	require.Equal(t, "o", outputFlag.Shorthand)
-	require.Equal(t, "The output format (the supported formats are json, table).", outputFlag.Usage)
+	require.Equal(t, "The output format (the supported formats are json, table, jsonl).", outputFlag.Usage)
 }
 
 func Test_RunDocsFlow(t *testing.T) {
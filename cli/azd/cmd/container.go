@@ -59,6 +59,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/dotnet"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/github"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/golang"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/javac"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/kubectl"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/maven"
@@ -495,6 +496,8 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 
 	container.MustRegisterSingleton(templates.NewTemplateManager)
 	container.MustRegisterSingleton(templates.NewSourceManager)
+	container.MustRegisterSingleton(templates.NewValidator)
+	container.MustRegisterSingleton(templates.NewTester)
 	container.MustRegisterScoped(project.NewResourceManager)
 	container.MustRegisterScoped(func(serviceLocator ioc.ServiceLocator) *lazy.Lazy[project.ResourceManager] {
 		return lazy.NewLazy(func() (project.ResourceManager, error) {
@@ -507,6 +510,7 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 	container.MustRegisterScoped(project.NewProjectManager)
 	// Currently caches manifest across command executions
 	container.MustRegisterSingleton(project.NewDotNetImporter)
+	container.MustRegisterSingleton(project.NewComposeImporter)
 	container.MustRegisterScoped(project.NewImportManager)
 	container.MustRegisterScoped(project.NewServiceManager)
 
@@ -525,6 +529,7 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		})
 	})
 	container.MustRegisterSingleton(repository.NewInitializer)
+	container.MustRegisterSingleton(repository.NewUpgrader)
 	container.MustRegisterSingleton(alpha.NewFeaturesManager)
 	container.MustRegisterSingleton(config.NewUserConfigManager)
 	container.MustRegisterSingleton(config.NewManager)
@@ -572,6 +577,7 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 	container.MustRegisterSingleton(azcli.NewContainerRegistryService)
 	container.MustRegisterSingleton(containerapps.NewContainerAppService)
 	container.MustRegisterSingleton(containerregistry.NewRemoteBuildManager)
+	container.MustRegisterSingleton(containerregistry.NewOciBuilder)
 	container.MustRegisterSingleton(keyvault.NewKeyVaultService)
 	container.MustRegisterSingleton(storage.NewFileShareService)
 	container.MustRegisterScoped(project.NewContainerHelper)
@@ -586,10 +592,27 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 
 	// Tools
 	container.MustRegisterSingleton(azapi.NewResourceService)
-	container.MustRegisterSingleton(docker.NewCli)
+	container.MustRegisterSingleton(func(
+		commandRunner exec.CommandRunner,
+		userConfigManager config.UserConfigManager,
+	) (*docker.Cli, error) {
+		userConfig, err := userConfigManager.Load()
+		if err != nil {
+			return nil, fmt.Errorf("loading user config: %w", err)
+		}
+
+		engineValue, _ := userConfig.GetString(docker.EngineConfigPath)
+		engine, err := docker.ParseEngineKind(engineValue)
+		if err != nil {
+			return nil, fmt.Errorf("parsing '%s' user config: %w", docker.EngineConfigPath, err)
+		}
+
+		return docker.NewCliWithEngine(commandRunner, engine), nil
+	})
 	container.MustRegisterSingleton(dotnet.NewCli)
 	container.MustRegisterSingleton(git.NewCli)
 	container.MustRegisterSingleton(github.NewGitHubCli)
+	container.MustRegisterSingleton(golang.NewCli)
 	container.MustRegisterSingleton(javac.NewCli)
 	container.MustRegisterSingleton(kubectl.NewCli)
 	container.MustRegisterSingleton(maven.NewCli)
@@ -675,6 +698,7 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		project.ServiceLanguageJavaScript: project.NewNpmProject,
 		project.ServiceLanguageTypeScript: project.NewNpmProject,
 		project.ServiceLanguageJava:       project.NewMavenProject,
+		project.ServiceLanguageGo:         project.NewGoProject,
 		project.ServiceLanguageDocker:     project.NewDockerProject,
 		project.ServiceLanguageSwa:        project.NewSwaProject,
 	}
@@ -696,6 +720,8 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		"github-scm": pipeline.NewGitHubScmProvider,
 		"azdo-ci":    pipeline.NewAzdoCiProvider,
 		"azdo-scm":   pipeline.NewAzdoScmProvider,
+		"gitlab-ci":  pipeline.NewGitLabCiProvider,
+		"gitlab-scm": pipeline.NewGitLabScmProvider,
 	}
 
 	for provider, constructor := range pipelineProviderMap {
@@ -785,7 +811,7 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		return &workflowCmdAdapter{cmd: rootCmd}, nil
 
 	})
-	container.MustRegisterSingleton(workflow.NewRunner)
+	container.MustRegisterScoped(workflow.NewRunner)
 
 	// Required for nested actions called from composite actions like 'up'
 	registerAction[*cmd.ProvisionAction](container, "azd-provision-action")
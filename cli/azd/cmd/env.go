@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/internal"
@@ -85,6 +86,18 @@ func envActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
 		ActionResolver: newEnvGetValueAction,
 	})
 
+	group.Add("diff", &actions.ActionDescriptorOptions{
+		Command:        newEnvDiffCmd(),
+		FlagsResolver:  newEnvDiffFlags,
+		ActionResolver: newEnvDiffAction,
+		OutputFormats:  []output.Format{output.JsonFormat, output.TableFormat},
+		DefaultFormat:  output.TableFormat,
+		HelpOptions: actions.ActionHelpOptions{
+			Description: getCmdEnvDiffHelpDescription,
+			Footer:      getCmdEnvDiffHelpFooter,
+		},
+	})
+
 	return group
 }
 
@@ -106,11 +119,19 @@ func newEnvSetCmd() *cobra.Command {
 type envSetFlags struct {
 	internal.EnvFlag
 	global *internal.GlobalCommandOptions
+	secret bool
 }
 
 func (f *envSetFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
 	f.EnvFlag.Bind(local, global)
 	f.global = global
+
+	local.BoolVar(
+		&f.secret,
+		"secret",
+		false,
+		"Encrypts the value at rest in the .env file, using a key stored in the user config directory.",
+	)
 }
 
 type envSetAction struct {
@@ -141,7 +162,13 @@ func newEnvSetAction(
 }
 
 func (e *envSetAction) Run(ctx context.Context) (*actions.ActionResult, error) {
-	e.env.DotenvSet(e.args[0], e.args[1])
+	if e.flags.secret {
+		if err := e.env.DotenvSetSecret(e.args[0], e.args[1]); err != nil {
+			return nil, err
+		}
+	} else {
+		e.env.DotenvSet(e.args[0], e.args[1])
+	}
 
 	if err := e.envManager.Save(ctx, e.env); err != nil {
 		return nil, fmt.Errorf("saving environment: %w", err)
@@ -487,7 +514,7 @@ func (ef *envRefreshAction) Run(ctx context.Context) (*actions.ActionResult, err
 		return nil, err
 	}
 
-	if ef.formatter.Kind() == output.JsonFormat {
+	if output.IsJsonFormat(ef.formatter.Kind()) {
 		err = ef.formatter.Format(provisioning.NewEnvRefreshResultFromState(getStateResult.State), ef.writer, nil)
 		if err != nil {
 			return nil, fmt.Errorf("writing deployment result in JSON format: %w", err)
@@ -539,12 +566,20 @@ func newEnvGetValuesCmd() *cobra.Command {
 
 type envGetValuesFlags struct {
 	internal.EnvFlag
-	global *internal.GlobalCommandOptions
+	global      *internal.GlobalCommandOptions
+	maskSecrets bool
 }
 
 func (eg *envGetValuesFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
 	eg.EnvFlag.Bind(local, global)
 	eg.global = global
+
+	local.BoolVar(
+		&eg.maskSecrets,
+		"mask-secrets",
+		false,
+		"Masks the values of secrets instead of showing their decrypted values.",
+	)
 }
 
 type envGetValuesAction struct {
@@ -595,7 +630,18 @@ func (eg *envGetValuesAction) Run(ctx context.Context) (*actions.ActionResult, e
 		return nil, fmt.Errorf("ensuring environment exists: %w", err)
 	}
 
-	return nil, eg.formatter.Format(env.Dotenv(), eg.writer, nil)
+	values := env.Dotenv()
+	if eg.flags.maskSecrets {
+		for key := range values {
+			if env.IsSecret(key) {
+				values[key] = environment.SecretMask
+			}
+		}
+	} else if err := env.CheckSecrets(); err != nil {
+		return nil, err
+	}
+
+	return nil, eg.formatter.Format(values, eg.writer, nil)
 }
 
 func newEnvGetValueFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *envGetValueFlags {
@@ -696,6 +742,216 @@ func (eg *envGetValueAction) Run(ctx context.Context) (*actions.ActionResult, er
 	return nil, nil
 }
 
+func newEnvDiffFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *envDiffFlags {
+	flags := &envDiffFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newEnvDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff [<environment>]",
+		Short: "Compare the values of two environments.",
+	}
+	cmd.Args = cobra.MaximumNArgs(1)
+
+	return cmd
+}
+
+type envDiffFlags struct {
+	internal.EnvFlag
+	remote      bool
+	showSecrets bool
+	global      *internal.GlobalCommandOptions
+}
+
+func (ed *envDiffFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	ed.EnvFlag.Bind(local, global)
+	local.BoolVar(
+		&ed.remote,
+		"remote",
+		false,
+		"Compares the local environment with its copy in the remote state.",
+	)
+	local.BoolVar(
+		&ed.showSecrets,
+		"show-secrets",
+		false,
+		"Shows the values of secrets instead of masking them.",
+	)
+	ed.global = global
+}
+
+// envDiffResult is the JSON output of `azd env diff`
+type envDiffResult struct {
+	Left        string                   `json:"left"`
+	Right       string                   `json:"right"`
+	Differences []*environment.ValueDiff `json:"differences"`
+}
+
+type envDiffAction struct {
+	azdCtx     *azdcontext.AzdContext
+	console    input.Console
+	envManager environment.Manager
+	formatter  output.Formatter
+	writer     io.Writer
+	flags      *envDiffFlags
+	args       []string
+}
+
+func newEnvDiffAction(
+	azdCtx *azdcontext.AzdContext,
+	envManager environment.Manager,
+	console input.Console,
+	formatter output.Formatter,
+	writer io.Writer,
+	flags *envDiffFlags,
+	args []string,
+) actions.Action {
+	return &envDiffAction{
+		azdCtx:     azdCtx,
+		console:    console,
+		envManager: envManager,
+		formatter:  formatter,
+		writer:     writer,
+		flags:      flags,
+		args:       args,
+	}
+}
+
+func (ed *envDiffAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	if ed.flags.remote && len(ed.args) > 0 {
+		return nil, errors.New("an environment cannot be specified with --remote, use --environment to select the environment")
+	}
+
+	if !ed.flags.remote && len(ed.args) == 0 {
+		return nil, errors.New("specify the environment to compare with, or --remote to compare with the remote state")
+	}
+
+	name, err := ed.azdCtx.GetDefaultEnvironmentName()
+	if err != nil {
+		return nil, err
+	}
+	if ed.flags.EnvironmentName != "" {
+		name = ed.flags.EnvironmentName
+	}
+
+	left, err := ed.getEnvironment(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var right *environment.Environment
+	result := &envDiffResult{}
+
+	if ed.flags.remote {
+		right, err = ed.envManager.GetRemote(ctx, name)
+		if errors.Is(err, environment.ErrRemoteNotConfigured) {
+			return nil, fmt.Errorf(
+				"%w. Configure the 'state.remote' section of azure.yaml to compare with the remote state", err)
+		} else if err != nil {
+			return nil, fmt.Errorf("getting remote environment '%s': %w", name, err)
+		}
+
+		result.Left = fmt.Sprintf("%s (local)", name)
+		result.Right = fmt.Sprintf("%s (remote)", name)
+	} else {
+		right, err = ed.getEnvironment(ctx, ed.args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		result.Left = left.Name()
+		result.Right = right.Name()
+	}
+
+	diffs, err := environment.Diff(left, right)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ed.flags.showSecrets {
+		environment.MaskSecrets(diffs)
+	}
+	result.Differences = diffs
+
+	if ed.formatter.Kind() != output.TableFormat {
+		return nil, ed.formatter.Format(result, ed.writer, nil)
+	}
+
+	if len(diffs) == 0 {
+		ed.console.Message(ctx, fmt.Sprintf("No differences found between '%s' and '%s'.", result.Left, result.Right))
+		return nil, nil
+	}
+
+	columns := []output.Column{
+		{
+			Heading:       "SOURCE",
+			ValueTemplate: "{{.Source}}",
+		},
+		{
+			Heading:       "KEY",
+			ValueTemplate: "{{.Key}}",
+		},
+		{
+			Heading:       "STATUS",
+			ValueTemplate: "{{.Status}}",
+		},
+		{
+			Heading:       strings.ToUpper(result.Left),
+			ValueTemplate: "{{if .Left}}{{.Left}}{{end}}",
+		},
+		{
+			Heading:       strings.ToUpper(result.Right),
+			ValueTemplate: "{{if .Right}}{{.Right}}{{end}}",
+		},
+	}
+
+	return nil, ed.formatter.Format(diffs, ed.writer, output.TableFormatterOptions{
+		Columns: columns,
+	})
+}
+
+func (ed *envDiffAction) getEnvironment(ctx context.Context, name string) (*environment.Environment, error) {
+	env, err := ed.envManager.Get(ctx, name)
+	if errors.Is(err, environment.ErrNotFound) {
+		return nil, fmt.Errorf(
+			`environment '%s' does not exist. You can create it with "azd env new %s"`,
+			name,
+			name,
+		)
+	} else if err != nil {
+		return nil, fmt.Errorf("ensuring environment exists: %w", err)
+	}
+
+	return env, nil
+}
+
+func getCmdEnvDiffHelpDescription(*cobra.Command) string {
+	return generateCmdHelpDescription(
+		"Compare the .env values and config.json values of the current environment with another environment,"+
+			" or with its copy in the remote state.",
+		[]string{
+			formatHelpNote("Values of secrets are masked unless --show-secrets is specified."),
+			formatHelpNote("Configure remote state in the 'state.remote' section of azure.yaml to use --remote."),
+		})
+}
+
+func getCmdEnvDiffHelpFooter(*cobra.Command) string {
+	return generateCmdHelpSamplesBlock(map[string]string{
+		"Compare the current environment with the prod environment.": output.WithHighLightFormat(
+			"azd env diff prod",
+		),
+		"Compare the dev environment with its copy in the remote state.": output.WithHighLightFormat(
+			"azd env diff -e dev --remote",
+		),
+		"Compare the current environment with the prod environment, as JSON.": output.WithHighLightFormat(
+			"azd env diff prod --output json",
+		),
+	})
+}
+
 func getCmdEnvHelpDescription(*cobra.Command) string {
 	return generateCmdHelpDescription(
 		"Manage your application environments. With this command group, you can create a new environment or get, set,"+
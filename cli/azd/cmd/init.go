@@ -7,8 +7,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
@@ -64,7 +67,7 @@ func (i *initFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOpt
 		"t",
 		"",
 		//nolint:lll
-		"Initializes a new application from a template. You can use Full URI, <owner>/<repository>, or <repository> if it's part of the azure-samples organization.",
+		"Initializes a new application from a template. You can use Full URI, <owner>/<repository>, <repository> if it's part of the azure-samples organization, or the name of a template of your template sources. Append @<version> to use a version of the template.",
 	)
 	local.StringVarP(
 		&i.templateBranch,
@@ -324,8 +327,9 @@ func (i *initAction) initializeTemplate(
 
 	if i.flags.templatePath != "" {
 		if template == nil {
-			template = &templates.Template{
-				RepositoryPath: i.flags.templatePath,
+			template, err = i.resolveTemplate(ctx)
+			if err != nil {
+				return nil, err
 			}
 		}
 
@@ -343,6 +347,46 @@ func (i *initAction) initializeTemplate(
 	return template, nil
 }
 
+// resolveTemplate resolves the template argument: the id or the name of a template of the template sources, or the path
+// of a template repository, optionally followed by @<version> or @<ref>.
+//
+// Listing the template sources requests each source, so they are only looked up for versioned templates and for
+// names which aren't repository paths. Repository URLs are never looked up.
+func (i *initAction) resolveTemplate(ctx context.Context) (*templates.Template, error) {
+	path, ref := templates.ParseRef(i.flags.templatePath)
+	if ref != "" && i.flags.templateBranch != "" {
+		return nil, errors.New(
+			"using branch argument (-b or --branch) requires a template argument without a version (<template>@<version>)")
+	}
+
+	isUrl := strings.HasPrefix(path, "git") || strings.HasPrefix(path, "http")
+	if !isUrl && (ref != "" || !isRepositoryPath(path)) {
+		template, err := i.templateManager.GetTemplateAtRef(ctx, path, ref)
+		if err == nil {
+			return template, nil
+		}
+
+		log.Printf("template '%s' not found in the template sources, using it as a repository path: %v", path, err)
+	}
+
+	return &templates.Template{
+		RepositoryPath: path,
+		Ref:            ref,
+	}, nil
+}
+
+// repositoryPathRegex matches the GitHub repository forms of templates.Absolute, <owner>/<repo> and <repo>.
+var repositoryPathRegex = regexp.MustCompile(`^[\w.-]+(/[\w.-]+)?/?$`)
+
+// isRepositoryPath returns true when the template path is a local directory or a GitHub repository path.
+func isRepositoryPath(path string) bool {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return true
+	}
+
+	return repositoryPathRegex.MatchString(path)
+}
+
 func (i *initAction) initializeEnv(
 	ctx context.Context,
 	azdCtx *azdcontext.AzdContext,
@@ -431,6 +475,11 @@ func getCmdInitHelpFooter(*cobra.Command) string {
 			output.WithHighLightFormat("azd init --template"),
 			output.WithWarningFormat("[GitHub repo URL]"),
 		),
+		"Initialize a version of a template of your template sources to your current local directory.": fmt.Sprintf(
+			"%s %s",
+			output.WithHighLightFormat("azd init --template"),
+			output.WithWarningFormat("[Template name]@[Version]"),
+		),
 		"Initialize a template to your current local directory from a branch other than main.": fmt.Sprintf("%s %s %s %s",
 			output.WithHighLightFormat("azd init --template"),
 			output.WithWarningFormat("[GitHub repo URL]"),
@@ -0,0 +1,26 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_isRepositoryPath(t *testing.T) {
+	tests := map[string]bool{
+		"todo-nodejs-mongo":                 true,
+		"Azure-Samples/todo-nodejs-mongo":   true,
+		"Azure-Samples/todo-nodejs-mongo/":  true,
+		t.TempDir():                         true,
+		"Contoso API":                       false,
+		"contoso/templates/api":             false,
+		"contoso/templates/does-not-exist/": false,
+	}
+
+	for path, expected := range tests {
+		require.Equal(t, expected, isRepositoryPath(path), path)
+	}
+}
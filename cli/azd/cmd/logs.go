@@ -0,0 +1,242 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type logsFlags struct {
+	follow bool
+	system bool
+	global *internal.GlobalCommandOptions
+	internal.EnvFlag
+}
+
+func (l *logsFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.BoolVarP(&l.follow, "follow", "f", false, "Streams the new log entries until the command is stopped.")
+	local.BoolVar(
+		&l.system,
+		"system",
+		false,
+		"Streams the system logs of the hosting platform instead of the application logs (Container Apps only).",
+	)
+	l.EnvFlag.Bind(local, global)
+	l.global = global
+}
+
+func newLogsFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *logsFlags {
+	flags := &logsFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newLogsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs [<service>]",
+		Short: "Stream the logs of a deployed application.",
+	}
+	cmd.Args = cobra.MaximumNArgs(1)
+
+	return cmd
+}
+
+type logsAction struct {
+	flags           *logsFlags
+	args            []string
+	console         input.Console
+	env             *environment.Environment
+	projectConfig   *project.ProjectConfig
+	importManager   *project.ImportManager
+	serviceManager  project.ServiceManager
+	resourceManager project.ResourceManager
+	alphaManager    *alpha.FeatureManager
+}
+
+func newLogsAction(
+	flags *logsFlags,
+	args []string,
+	console input.Console,
+	env *environment.Environment,
+	projectConfig *project.ProjectConfig,
+	importManager *project.ImportManager,
+	serviceManager project.ServiceManager,
+	resourceManager project.ResourceManager,
+	alphaManager *alpha.FeatureManager,
+) actions.Action {
+	return &logsAction{
+		flags:           flags,
+		args:            args,
+		console:         console,
+		env:             env,
+		projectConfig:   projectConfig,
+		importManager:   importManager,
+		serviceManager:  serviceManager,
+		resourceManager: resourceManager,
+		alphaManager:    alphaManager,
+	}
+}
+
+var logsFeature = alpha.MustFeatureKey("logs")
+
+func (la *logsAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	if !la.alphaManager.IsEnabled(logsFeature) {
+		return nil, fmt.Errorf(
+			"streaming logs is currently under alpha support and must be explicitly enabled."+
+				" Run `%s` to enable this feature", alpha.GetEnableCommand(logsFeature),
+		)
+	}
+
+	la.console.WarnForFeature(ctx, logsFeature)
+
+	if la.env.GetSubscriptionId() == "" {
+		return nil, errors.New(
+			"infrastructure has not been provisioned. Run `azd provision`",
+		)
+	}
+
+	targetServiceName := ""
+	if len(la.args) == 1 {
+		targetServiceName = la.args[0]
+		if has, err := la.importManager.HasService(ctx, la.projectConfig, targetServiceName); err != nil {
+			return nil, err
+		} else if !has {
+			return nil, fmt.Errorf("service name '%s' doesn't exist", targetServiceName)
+		}
+	}
+
+	stableServices, err := la.importManager.ServiceStable(ctx, la.projectConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	streamers := map[*project.ServiceConfig]project.ServiceLogStreamer{}
+	for _, svc := range stableServices {
+		if targetServiceName != "" && targetServiceName != svc.Name {
+			continue
+		}
+
+		serviceTarget, err := la.serviceManager.GetServiceTarget(ctx, svc)
+		if err != nil {
+			return nil, err
+		}
+
+		streamer, ok := serviceTarget.(project.ServiceLogStreamer)
+		if !ok {
+			if targetServiceName != "" {
+				return nil, fmt.Errorf("streaming logs is not supported for service host '%s'", svc.Host)
+			}
+
+			la.console.Message(ctx, output.WithWarningFormat(
+				"WARNING: Skipping service '%s', streaming logs is not supported for service host '%s'.",
+				svc.Name,
+				svc.Host,
+			))
+			continue
+		}
+
+		streamers[svc] = streamer
+	}
+
+	if len(streamers) == 0 {
+		return nil, errors.New("no service supports streaming logs")
+	}
+
+	// Align the lines of the services
+	prefixWidth := 0
+	for svc := range streamers {
+		prefixWidth = max(prefixWidth, len(svc.Name))
+	}
+
+	stdout := &syncWriter{writer: la.console.Handles().Stdout}
+	streamErrors := make([]error, len(stableServices))
+	wg := sync.WaitGroup{}
+
+	for i, svc := range stableServices {
+		streamer, has := streamers[svc]
+		if !has {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			prefix := output.WithHighLightFormat("%s | ", svc.Name+strings.Repeat(" ", prefixWidth-len(svc.Name)))
+			writer := output.NewPrefixWriter(stdout, prefix)
+			defer writer.Flush()
+
+			targetResource, err := la.resourceManager.GetTargetResource(ctx, la.env.GetSubscriptionId(), svc)
+			if err != nil {
+				streamErrors[i] = fmt.Errorf("getting target resource of service '%s': %w", svc.Name, err)
+				return
+			}
+
+			err = streamer.StreamLogs(ctx, svc, targetResource, project.LogStreamOptions{
+				Follow: la.flags.follow,
+				System: la.flags.system,
+			}, writer)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				streamErrors[i] = fmt.Errorf("streaming logs of service '%s': %w", svc.Name, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return nil, errors.Join(streamErrors...)
+}
+
+// syncWriter serializes the writes to the underlying writer, shared by the log streams of the services
+type syncWriter struct {
+	writer io.Writer
+	mu     sync.Mutex
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.writer.Write(p)
+}
+
+func getCmdLogsHelpDescription(*cobra.Command) string {
+	return generateCmdHelpDescription(
+		"Stream the logs of a deployed application.",
+		[]string{
+			formatHelpNote(fmt.Sprintf("This command is in alpha stage, run %s to enable it.",
+				output.WithHighLightFormat(alpha.GetEnableCommand(logsFeature)))),
+			formatHelpNote("Each line is prefixed with the name of the service it comes from."),
+			formatHelpNote("Logs are supported for services hosted on Container Apps, App Service, Azure Functions" +
+				" and AKS."),
+		})
+}
+
+func getCmdLogsHelpFooter(*cobra.Command) string {
+	return generateCmdHelpSamplesBlock(map[string]string{
+		"Display the recent logs of all the services.": output.WithHighLightFormat("azd logs"),
+		"Stream the logs of the service 'api' until stopped.": output.WithHighLightFormat(
+			"azd logs api --follow",
+		),
+		"Stream the system logs of the container app of the service 'api'.": output.WithHighLightFormat(
+			"azd logs api --system",
+		),
+	})
+}
@@ -22,7 +22,8 @@ type packageFlags struct {
 	all    bool
 	global *internal.GlobalCommandOptions
 	*internal.EnvFlag
-	outputPath string
+	outputPath   string
+	listExcluded bool
 }
 
 func newPackageFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *packageFlags {
@@ -51,6 +52,12 @@ func (pf *packageFlags) Bind(local *pflag.FlagSet, global *internal.GlobalComman
 		"",
 		"File or folder path where the generated packages will be saved.",
 	)
+	local.BoolVar(
+		&pf.listExcluded,
+		"list-excluded",
+		false,
+		"Lists the files excluded from the packages by ignore files or service ignore patterns.",
+	)
 }
 
 func newPackageCmd() *cobra.Command {
@@ -214,12 +221,15 @@ func (pa *packageAction) Run(ctx context.Context) (*actions.ActionResult, error)
 
 		// report package output
 		pa.console.MessageUxItem(ctx, packageResult)
+		if pa.flags.listExcluded {
+			pa.reportExcludedFiles(ctx, packageResult)
+		}
 		if index < serviceCount-1 {
 			pa.console.Message(ctx, "")
 		}
 	}
 
-	if pa.formatter.Kind() == output.JsonFormat {
+	if output.IsJsonFormat(pa.formatter.Kind()) {
 		packageResult := PackageResult{
 			Timestamp: time.Now(),
 			Services:  packageResults,
@@ -237,6 +247,19 @@ func (pa *packageAction) Run(ctx context.Context) (*actions.ActionResult, error)
 	}, nil
 }
 
+// reportExcludedFiles displays the files that were excluded from the service package
+func (pa *packageAction) reportExcludedFiles(ctx context.Context, packageResult *project.ServicePackageResult) {
+	if len(packageResult.ExcludedFiles) == 0 {
+		pa.console.Message(ctx, "  - No files were excluded")
+		return
+	}
+
+	pa.console.Message(ctx, "  - Excluded files:")
+	for _, excludedFile := range packageResult.ExcludedFiles {
+		pa.console.Message(ctx, fmt.Sprintf("    %s", output.WithGrayFormat(excludedFile)))
+	}
+}
+
 func getCmdPackageHelpDescription(*cobra.Command) string {
 	return generateCmdHelpDescription(fmt.Sprintf(
 		"Packages application's code to be deployed to Azure. %s",
@@ -248,6 +271,9 @@ func getCmdPackageHelpDescription(*cobra.Command) string {
 		formatHelpNote(
 			fmt.Sprintf("When %s is set, only the specific service is packaged.", output.WithHighLightFormat("<service>"))),
 		formatHelpNote("After the packaging is complete, the package locations are printed."),
+		formatHelpNote(
+			"Files matching patterns in .gitignore, .azdignore, .funcignore (functions) or .webappignore (app service)" +
+				" files, or the service 'ignore' patterns, are excluded from zip based packages."),
 	})
 }
 
@@ -262,5 +288,8 @@ func getCmdPackageHelpFooter(*cobra.Command) string {
 		"Packages the service named 'api' to the specified output path.": output.WithHighLightFormat(
 			"azd package api --output-path ./dist/api.zip",
 		),
+		"Packages the service named 'api' and lists the excluded files.": output.WithHighLightFormat(
+			"azd package api --list-excluded",
+		),
 	})
 }
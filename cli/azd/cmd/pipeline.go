@@ -53,7 +53,7 @@ func (pc *pipelineConfigFlags) Bind(local *pflag.FlagSet, global *internal.Globa
 		&pc.PipelineAuthTypeName,
 		"auth-type",
 		"",
-		"The authentication type used between the pipeline provider and Azure for deployment (Only valid for GitHub provider). Valid values: federated, client-credentials.",
+		"The authentication type used between the pipeline provider and Azure for deployment (Only valid for GitHub and GitLab providers). Valid values: federated, client-credentials.",
 	)
 	//nolint:lll
 	local.StringArrayVar(
@@ -65,7 +65,7 @@ func (pc *pipelineConfigFlags) Bind(local *pflag.FlagSet, global *internal.Globa
 	// default provider is empty because it can be set from azure.yaml. By letting default here be empty, we know that
 	// there no customer input using --provider
 	local.StringVar(&pc.PipelineProvider, "provider", "",
-		"The pipeline provider to use (github for Github Actions and azdo for Azure Pipelines).")
+		"The pipeline provider to use (github for Github Actions, azdo for Azure Pipelines and gitlab for GitLab CI/CD).")
 	local.StringVarP(&pc.ServiceManagementReference, "applicationServiceManagementReference", "m", "",
 		"Service Management Reference. "+
 			"References application or service contact information from a Service or Asset Management database. "+
@@ -225,7 +225,7 @@ func getCmdPipelineConfigHelpDescription(*cobra.Command) string {
 		"Configure your deployment pipeline to connect securely to Azure",
 		[]string{
 			formatHelpNote(
-				"Supports GitHub Actions and Azure Pipelines. To configure using a specific pipeline provider, " +
+				"Supports GitHub Actions, Azure Pipelines and GitLab CI/CD. To configure using a specific pipeline provider, " +
 					"provide a value for the '--provider' flag."),
 			formatHelpNote(
 				output.WithHighLightFormat("pipeline config") +
@@ -235,6 +235,10 @@ func getCmdPipelineConfigHelpDescription(*cobra.Command) string {
 				output.WithHighLightFormat("pipeline config") +
 				" will set deployment pipeline variables and secrets using the current environment. " +
 				"To configure for a new or an existing environment, provide a value for the '-e' flag."),
+			formatHelpNote("For GitLab, " +
+				output.WithHighLightFormat("pipeline config") +
+				" uses the access token from the GITLAB_TOKEN environment variable. " +
+				"To use a self-hosted GitLab instance, set its URL in the GITLAB_HOST environment variable."),
 		})
 }
 
@@ -253,5 +257,10 @@ func getCmdPipelineConfigHelpFooter(c *cobra.Command) string {
 			output.WithWarningFormat("app-test"),
 			output.WithHighLightFormat("--provider azdo"),
 		),
+		"Configure a deployment pipeline for 'app-test' environment on GitLab CI/CD.": fmt.Sprintf("%s %s %s",
+			output.WithHighLightFormat("azd pipeline config -e"),
+			output.WithWarningFormat("app-test"),
+			output.WithHighLightFormat("--provider gitlab"),
+		),
 	})
 }
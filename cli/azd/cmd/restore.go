@@ -191,7 +191,7 @@ func (ra *restoreAction) Run(ctx context.Context) (*actions.ActionResult, error)
 		restoreResults[svc.Name] = restoreResult
 	}
 
-	if ra.formatter.Kind() == output.JsonFormat {
+	if output.IsJsonFormat(ra.formatter.Kind()) {
 		restoreResult := RestoreResult{
 			Timestamp: time.Now(),
 			Services:  restoreResults,
@@ -208,6 +208,19 @@ func NewRootCmd(
 		}).
 		UseMiddleware("hooks", middleware.NewHooksMiddleware)
 
+	root.Add("run", &actions.ActionDescriptorOptions{
+		Command:        newRunCmd(),
+		FlagsResolver:  newRunFlags,
+		ActionResolver: newRunAction,
+		HelpOptions: actions.ActionHelpOptions{
+			Description: getCmdRunHelpDescription,
+			Footer:      getCmdRunHelpFooter,
+		},
+		GroupingOptions: actions.CommandGroupOptions{
+			RootLevelHelp: actions.CmdGroupConfig,
+		},
+	})
+
 	root.
 		Add("build", &actions.ActionDescriptorOptions{
 			Command:        newBuildCmd(),
@@ -304,6 +317,19 @@ func NewRootCmd(
 		},
 	})
 
+	root.Add("logs", &actions.ActionDescriptorOptions{
+		Command:        newLogsCmd(),
+		FlagsResolver:  newLogsFlags,
+		ActionResolver: newLogsAction,
+		HelpOptions: actions.ActionHelpOptions{
+			Description: getCmdLogsHelpDescription,
+			Footer:      getCmdLogsHelpFooter,
+		},
+		GroupingOptions: actions.CommandGroupOptions{
+			RootLevelHelp: actions.CmdGroupMonitor,
+		},
+	})
+
 	root.
 		Add("down", &actions.ActionDescriptorOptions{
 			Command:        newDownCmd(),
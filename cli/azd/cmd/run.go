@@ -0,0 +1,338 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type runFlags struct {
+	noWatch bool
+	global  *internal.GlobalCommandOptions
+	internal.EnvFlag
+}
+
+func (r *runFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.BoolVar(
+		&r.noWatch,
+		"no-watch",
+		false,
+		"Does not restart the services when their files change.",
+	)
+	r.EnvFlag.Bind(local, global)
+	r.global = global
+}
+
+func newRunFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *runFlags {
+	flags := &runFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newRunCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "run [<service>...]",
+		Short: "Run the application's services locally.",
+	}
+}
+
+type runAction struct {
+	flags          *runFlags
+	args           []string
+	console        input.Console
+	env            *environment.Environment
+	projectConfig  *project.ProjectConfig
+	projectManager project.ProjectManager
+	importManager  *project.ImportManager
+	serviceManager project.ServiceManager
+	alphaManager   *alpha.FeatureManager
+}
+
+func newRunAction(
+	flags *runFlags,
+	args []string,
+	console input.Console,
+	env *environment.Environment,
+	projectConfig *project.ProjectConfig,
+	projectManager project.ProjectManager,
+	importManager *project.ImportManager,
+	serviceManager project.ServiceManager,
+	alphaManager *alpha.FeatureManager,
+) actions.Action {
+	return &runAction{
+		flags:          flags,
+		args:           args,
+		console:        console,
+		env:            env,
+		projectConfig:  projectConfig,
+		projectManager: projectManager,
+		importManager:  importManager,
+		serviceManager: serviceManager,
+		alphaManager:   alphaManager,
+	}
+}
+
+var runFeature = alpha.MustFeatureKey("run")
+
+// watchInterval is the interval the files of the services are polled for changes
+var watchInterval = time.Second
+
+func (ra *runAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	if !ra.alphaManager.IsEnabled(runFeature) {
+		return nil, fmt.Errorf(
+			"running services locally is currently under alpha support and must be explicitly enabled."+
+				" Run `%s` to enable this feature", alpha.GetEnableCommand(runFeature),
+		)
+	}
+
+	ra.console.WarnForFeature(ctx, runFeature)
+
+	ra.console.MessageUxItem(ctx, &ux.MessageTitle{
+		Title: "Running services locally (azd run)",
+	})
+
+	for _, name := range ra.args {
+		if has, err := ra.importManager.HasService(ctx, ra.projectConfig, name); err != nil {
+			return nil, err
+		} else if !has {
+			return nil, fmt.Errorf("service name '%s' doesn't exist", name)
+		}
+	}
+
+	isTarget := func(svc *project.ServiceConfig) bool {
+		return len(ra.args) == 0 || slices.Contains(ra.args, svc.Name)
+	}
+
+	if err := ra.projectManager.Initialize(ctx, ra.projectConfig); err != nil {
+		return nil, err
+	}
+
+	if err := ra.projectManager.EnsureFrameworkTools(ctx, ra.projectConfig, isTarget); err != nil {
+		return nil, err
+	}
+
+	stableServices, err := ra.importManager.ServiceStable(ctx, ra.projectConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	services := []*project.ServiceConfig{}
+	runners := map[*project.ServiceConfig]project.LocalRunner{}
+	for _, svc := range stableServices {
+		if !isTarget(svc) {
+			continue
+		}
+
+		frameworkService, err := ra.serviceManager.GetFrameworkService(ctx, svc)
+		if err != nil {
+			return nil, err
+		}
+
+		runner, ok := project.GetLocalRunner(frameworkService)
+		if !ok {
+			if len(ra.args) > 0 {
+				return nil, fmt.Errorf("running locally is not supported for service '%s' of language '%s'",
+					svc.Name, svc.Language)
+			}
+
+			ra.console.Message(ctx, output.WithWarningFormat(
+				"WARNING: Skipping service '%s', running locally is not supported for language '%s'.",
+				svc.Name,
+				svc.Language,
+			))
+			continue
+		}
+
+		services = append(services, svc)
+		runners[svc] = runner
+	}
+
+	if len(services) == 0 {
+		return nil, errors.New("no service supports running locally")
+	}
+
+	// The dependencies are restored once, before the services are started
+	for _, svc := range services {
+		stepMessage := fmt.Sprintf("Restoring service %s", svc.Name)
+		ra.console.ShowSpinner(ctx, stepMessage, input.Step)
+
+		_, err := async.RunWithProgress(
+			func(restoreProgress project.ServiceProgress) {
+				progressMessage := fmt.Sprintf("Restoring service %s (%s)", svc.Name, restoreProgress.Message)
+				ra.console.ShowSpinner(ctx, progressMessage, input.Step)
+			},
+			func(progress *async.Progress[project.ServiceProgress]) (*project.ServiceRestoreResult, error) {
+				return ra.serviceManager.Restore(ctx, svc, progress)
+			},
+		)
+		if err != nil {
+			ra.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+			return nil, err
+		}
+
+		ra.console.StopSpinner(ctx, stepMessage, input.StepDone)
+	}
+
+	ra.console.Message(ctx, output.WithGrayFormat("Press Ctrl+C to stop the services.\n"))
+
+	// Align the lines of the services
+	prefixWidth := 0
+	for _, svc := range services {
+		prefixWidth = max(prefixWidth, len(svc.Name))
+	}
+
+	// The environment of the services has the values of the azd environment, including the outputs of the provisioning
+	if err := ra.env.CheckSecrets(); err != nil {
+		return nil, err
+	}
+
+	options := project.LocalRunOptions{
+		Env: ra.env.Environ(),
+	}
+
+	stdout := &syncWriter{writer: ra.console.Handles().Stdout}
+	runErrors := make([]error, len(services))
+	wg := sync.WaitGroup{}
+
+	for i, svc := range services {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			prefix := output.WithSourceColor(i, fmt.Sprintf("%s | ", svc.Name+strings.Repeat(" ", prefixWidth-len(svc.Name))))
+			writer := output.NewPrefixWriter(stdout, prefix)
+			defer writer.Flush()
+
+			var changes <-chan struct{}
+			if !ra.flags.noWatch {
+				changes = osutil.WatchDir(ctx, svc.Path(), watchInterval, skipWatchDir)
+			}
+
+			runErrors[i] = runLocal(ctx, svc, runners[svc], options, changes, writer)
+		}()
+	}
+
+	wg.Wait()
+
+	return nil, errors.Join(runErrors...)
+}
+
+// runLocal runs the service until the context is cancelled, restarting it when its files change. Without changes, the
+// service isn't restarted, and its error is returned when it exits.
+func runLocal(
+	ctx context.Context,
+	svc *project.ServiceConfig,
+	runner project.LocalRunner,
+	options project.LocalRunOptions,
+	changes <-chan struct{},
+	writer io.Writer,
+) error {
+	for {
+		runCtx, cancel := context.WithCancel(ctx)
+		done := make(chan error, 1)
+		go func() {
+			done <- runner.RunLocal(runCtx, svc, options, writer)
+		}()
+
+		select {
+		case <-ctx.Done():
+			cancel()
+			<-done
+			return nil
+		case <-changes:
+			fmt.Fprintln(writer, output.WithGrayFormat("Files changed, restarting..."))
+			cancel()
+			<-done
+			continue
+		case err := <-done:
+			cancel()
+			if changes == nil {
+				if err != nil {
+					return fmt.Errorf("running service '%s': %w", svc.Name, err)
+				}
+
+				return nil
+			}
+
+			// The service is restarted when its files change, e.g. once an error is fixed
+			if err != nil {
+				fmt.Fprintln(writer, output.WithErrorFormat("Exited: %v", err))
+			} else {
+				fmt.Fprintln(writer, output.WithGrayFormat("Exited"))
+			}
+			fmt.Fprintln(writer, output.WithGrayFormat("Waiting for file changes to restart..."))
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-changes:
+			}
+		}
+	}
+}
+
+// watchSkippedDirs are the directories of dependencies, build outputs and tools, which don't restart the services when
+// they change
+var watchSkippedDirs = []string{
+	".git", ".azure", ".venv", "node_modules", "__pycache__", "bin", "obj", "target", "dist", "build",
+}
+
+func skipWatchDir(path string, d fs.DirEntry) bool {
+	if slices.Contains(watchSkippedDirs, d.Name()) {
+		return true
+	}
+
+	// Python virtual environments have a pyvenv.cfg file
+	_, err := os.Stat(filepath.Join(path, "pyvenv.cfg"))
+	return err == nil
+}
+
+func getCmdRunHelpDescription(*cobra.Command) string {
+	return generateCmdHelpDescription(
+		"Run the application's services locally.",
+		[]string{
+			formatHelpNote(fmt.Sprintf("This command is in alpha stage, run %s to enable it.",
+				output.WithHighLightFormat(alpha.GetEnableCommand(runFeature)))),
+			formatHelpNote("The values of the environment, including the outputs of the provisioning, are set in the" +
+				" environment of the services."),
+			formatHelpNote("Each line is prefixed with the name of the service it comes from."),
+			formatHelpNote("A service is restarted when its files change, unless --no-watch is specified."),
+			formatHelpNote("Running locally is supported for services in JavaScript, TypeScript, Python, .NET and Java" +
+				" (Spring Boot with Maven)."),
+		})
+}
+
+func getCmdRunHelpFooter(*cobra.Command) string {
+	return generateCmdHelpSamplesBlock(map[string]string{
+		"Run all the services.":             output.WithHighLightFormat("azd run"),
+		"Run the services 'api' and 'web'.": output.WithHighLightFormat("azd run api web"),
+		"Run the service 'api' without restarting it when its files change.": output.WithHighLightFormat(
+			"azd run api --no-watch",
+		),
+	})
+}
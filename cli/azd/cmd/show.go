@@ -197,7 +197,7 @@ func (s *showAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 		}
 	}
 
-	if s.formatter.Kind() == output.JsonFormat {
+	if output.IsJsonFormat(s.formatter.Kind()) {
 		return nil, s.formatter.Format(res, s.writer, nil)
 	}
 
@@ -48,6 +48,19 @@ func templatesActions(root *actions.ActionDescriptor) *actions.ActionDescriptor
 	})
 
 	_ = templateSourceActions(group)
+	templateAuthoringActions(group)
+
+	group.Add("upgrade", &actions.ActionDescriptorOptions{
+		Command:        newTemplateUpgradeCmd(),
+		ActionResolver: newTemplateUpgradeAction,
+		FlagsResolver:  newTemplateUpgradeFlags,
+		OutputFormats:  []output.Format{output.JsonFormat, output.NoneFormat},
+		DefaultFormat:  output.NoneFormat,
+		HelpOptions: actions.ActionHelpOptions{
+			Description: getCmdTemplateUpgradeHelpDescription,
+			Footer:      getCmdTemplateUpgradeHelpFooter,
+		},
+	})
 
 	return group
 }
@@ -237,6 +250,10 @@ func getCmdTemplateSourceAddHelpFooter(*cobra.Command) string {
 		"Add templates form a GitHub repository": output.WithHighLightFormat(
 			"azd template source add --type gh --location <GitHub URL>",
 		),
+		"Add templates from a private url with a personal access token": output.WithHighLightFormat(
+			"azd template source add --type url --location https://example.com/templates.json " +
+				"--auth-type pat --auth-token-env TEMPLATES_PAT",
+		),
 		"Add templates from a public url": output.WithHighLightFormat(
 			"azd template source add --type url --location https://example.com/templates.json",
 		),
@@ -358,9 +375,12 @@ func newTemplateSourceAddCmd() *cobra.Command {
 }
 
 type templateSourceAddFlags struct {
-	name     string
-	location string
-	kind     string
+	name         string
+	location     string
+	kind         string
+	authType     string
+	authScope    string
+	authTokenEnv string
 }
 
 func newTemplateSourceAddFlags(cmd *cobra.Command) *templateSourceAddFlags {
@@ -371,6 +391,12 @@ func newTemplateSourceAddFlags(cmd *cobra.Command) *templateSourceAddFlags {
 	cmd.Flags().StringVarP(&flags.location, "location", "l", "", "Location of the template source. "+
 		"Required when using type flag.")
 	cmd.Flags().StringVarP(&flags.name, "name", "n", "", "Display name of the template source.")
+	cmd.Flags().StringVar(&flags.authType, "auth-type", "", "Authentication of the requests to a private 'url' "+
+		"template source. Supported types are 'bearer' and 'pat'.")
+	cmd.Flags().StringVar(&flags.authScope, "auth-scope", "", "Scope of the Microsoft Entra token of azd for "+
+		"'bearer' authentication.")
+	cmd.Flags().StringVar(&flags.authTokenEnv, "auth-token-env", "", "Environment variable holding the token for "+
+		"'bearer' or 'pat' authentication.")
 
 	return flags
 }
@@ -433,6 +459,17 @@ func (a *templateSourceAddAction) Run(ctx context.Context) (*actions.ActionResul
 			Name:     a.flags.name,
 		}
 
+		if a.flags.authType != "" {
+			sourceConfig.Auth = &templates.SourceAuth{
+				Type:        templates.SourceAuthKind(strings.ToLower(a.flags.authType)),
+				Scope:       a.flags.authScope,
+				TokenEnvVar: a.flags.authTokenEnv,
+			}
+		} else if a.flags.authScope != "" || a.flags.authTokenEnv != "" {
+			a.console.StopSpinner(ctx, spinnerMessage, input.StepFailed)
+			return nil, errors.New("--auth-scope and --auth-token-env require --auth-type")
+		}
+
 		// Validate the custom source config
 		_, err := a.sourceManager.CreateSource(ctx, sourceConfig)
 		a.console.StopSpinner(ctx, spinnerMessage, input.GetStepResultFormat(err))
@@ -528,6 +565,11 @@ func getCmdTemplateHelpFooter(*cobra.Command) string {
 		"View the details of an azd template.": output.WithHighLightFormat(
 			"azd template show <template-name>",
 		),
+		"Validate the azd template in the current directory.": output.WithHighLightFormat(
+			"azd template validate",
+		),
+		"Test the services of the azd template in the current directory restore, build and package.": output.
+			WithHighLightFormat("azd template test"),
 	})
 }
 
@@ -0,0 +1,321 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/templates"
+	"github.com/spf13/cobra"
+)
+
+// templateAuthoringActions adds the commands of the template authoring toolkit to the 'template' command group
+func templateAuthoringActions(group *actions.ActionDescriptor) {
+	group.Add("validate", &actions.ActionDescriptorOptions{
+		Command:        newTemplateValidateCmd(),
+		ActionResolver: newTemplateValidateAction,
+		FlagsResolver:  newTemplateValidateFlags,
+		OutputFormats:  []output.Format{output.JsonFormat, output.NoneFormat},
+		DefaultFormat:  output.NoneFormat,
+		HelpOptions: actions.ActionHelpOptions{
+			Description: getCmdTemplateValidateHelpDescription,
+		},
+	})
+
+	group.Add("test", &actions.ActionDescriptorOptions{
+		Command:        newTemplateTestCmd(),
+		ActionResolver: newTemplateTestAction,
+		OutputFormats:  []output.Format{output.JsonFormat, output.NoneFormat},
+		DefaultFormat:  output.NoneFormat,
+		HelpOptions: actions.ActionHelpOptions{
+			Description: getCmdTemplateTestHelpDescription,
+		},
+	})
+}
+
+// templateDirectory returns the directory of the template of the command, the optional argument or the current directory
+func templateDirectory(args []string) (string, error) {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	if stat, err := os.Stat(absDir); err != nil || !stat.IsDir() {
+		return "", fmt.Errorf("'%s' is not a directory", dir)
+	}
+
+	return absDir, nil
+}
+
+type templateValidateFlags struct {
+	schema string
+}
+
+func newTemplateValidateFlags(cmd *cobra.Command) *templateValidateFlags {
+	flags := &templateValidateFlags{}
+	cmd.Flags().StringVar(
+		&flags.schema,
+		"schema",
+		"",
+		"The path or URL of the azure.yaml schema. Defaults to the schema referenced by azure.yaml.",
+	)
+
+	return flags
+}
+
+func newTemplateValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate [<path>]",
+		Short: fmt.Sprintf("Validate an azd template. %s", output.WithWarningFormat("(Beta)")),
+		Args:  cobra.MaximumNArgs(1),
+	}
+}
+
+// The titles of the checks of a template validation
+var templateValidationCheckTitles = map[string]string{
+	templates.ValidationCheckSchema:          "Validating azure.yaml against its schema",
+	templates.ValidationCheckServicePaths:    "Checking the projects of the services exist",
+	templates.ValidationCheckBicepParameters: "Checking the Bicep parameters are declared",
+	templates.ValidationCheckHooks:           "Checking the scripts of the hooks exist",
+}
+
+type templateValidateAction struct {
+	flags     *templateValidateFlags
+	args      []string
+	console   input.Console
+	formatter output.Formatter
+	writer    io.Writer
+	validator *templates.Validator
+}
+
+func newTemplateValidateAction(
+	flags *templateValidateFlags,
+	args []string,
+	console input.Console,
+	formatter output.Formatter,
+	writer io.Writer,
+	validator *templates.Validator,
+) actions.Action {
+	return &templateValidateAction{
+		flags:     flags,
+		args:      args,
+		console:   console,
+		formatter: formatter,
+		writer:    writer,
+		validator: validator,
+	}
+}
+
+func (a *templateValidateAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	templateDir, err := templateDirectory(a.args)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := a.validator.Validate(ctx, templateDir, templates.ValidateOptions{
+		Schema: a.flags.schema,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if a.formatter.Kind() == output.JsonFormat {
+		if err := a.formatter.Format(report, a.writer, nil); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, check := range report.Checks {
+			title := templateValidationCheckTitles[check]
+			a.console.ShowSpinner(ctx, title, input.Step)
+
+			var issues []templates.ValidationIssue
+			for _, issue := range report.Issues {
+				if issue.Check == check {
+					issues = append(issues, issue)
+				}
+			}
+
+			if len(issues) == 0 {
+				a.console.StopSpinner(ctx, title, input.StepDone)
+				continue
+			}
+
+			a.console.StopSpinner(ctx, title, input.StepFailed)
+			for _, issue := range issues {
+				a.console.Message(ctx, fmt.Sprintf("    %s", issue.String()))
+			}
+		}
+
+		if a.formatter.Kind() == output.JsonLinesFormat {
+			if err := a.formatter.Format(report, a.writer, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if !report.Passed {
+		return nil, fmt.Errorf("template validation failed with %d issue(s)", len(report.Issues))
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: "The template is valid.",
+		},
+	}, nil
+}
+
+func getCmdTemplateValidateHelpDescription(*cobra.Command) string {
+	return generateCmdHelpDescription(
+		fmt.Sprintf("Validate an azd template, in the current directory by default. %s",
+			output.WithWarningFormat("(Beta)")),
+		[]string{
+			formatHelpNote("azure.yaml is validated against its schema."),
+			formatHelpNote("The projects of the services and the scripts of the hooks must exist."),
+			formatHelpNote("The parameters of the Bicep parameters file must be declared by the Bicep module."),
+			formatHelpNote(fmt.Sprintf("Use %s for a report to check in pull requests.",
+				output.WithHighLightFormat("--output json"))),
+		})
+}
+
+func newTemplateTestCmd() *cobra.Command {
+	return &cobra.Command{
+		Use: "test [<path>]",
+		Short: fmt.Sprintf("Test the services of an azd template restore, build and package. %s",
+			output.WithWarningFormat("(Beta)")),
+		Args: cobra.MaximumNArgs(1),
+	}
+}
+
+type templateTestAction struct {
+	args      []string
+	console   input.Console
+	formatter output.Formatter
+	writer    io.Writer
+	tester    *templates.Tester
+}
+
+func newTemplateTestAction(
+	args []string,
+	console input.Console,
+	formatter output.Formatter,
+	writer io.Writer,
+	tester *templates.Tester,
+) actions.Action {
+	return &templateTestAction{
+		args:      args,
+		console:   console,
+		formatter: formatter,
+		writer:    writer,
+		tester:    tester,
+	}
+}
+
+func (a *templateTestAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	templateDir, err := templateDirectory(a.args)
+	if err != nil {
+		return nil, err
+	}
+
+	a.console.ShowSpinner(ctx, "Copying the template", input.Step)
+	run, err := a.tester.Start(ctx, templateDir)
+	if err != nil {
+		a.console.StopSpinner(ctx, "Copying the template", input.StepFailed)
+		return nil, err
+	}
+	defer run.Close()
+	a.console.StopSpinner(ctx, "Copying the template", input.StepDone)
+
+	report := &templates.TestReport{
+		Template: templateDir,
+		Passed:   true,
+		Results:  []templates.TestResult{},
+	}
+
+	for _, service := range run.Services {
+		failed := false
+
+		for _, step := range templates.TestSteps {
+			stepMessage := fmt.Sprintf("Running azd %s for service %s", step, service)
+
+			// The next steps of a service depend on the previous ones
+			if failed {
+				a.console.ShowSpinner(ctx, stepMessage, input.Step)
+				a.console.StopSpinner(ctx, stepMessage, input.StepSkipped)
+				report.Results = append(report.Results, templates.TestResult{
+					Service: service,
+					Step:    step,
+					Skipped: true,
+				})
+				continue
+			}
+
+			a.console.ShowSpinner(ctx, stepMessage, input.Step)
+			result := run.Run(ctx, service, step)
+			report.Results = append(report.Results, result)
+
+			if result.Passed {
+				a.console.StopSpinner(ctx, stepMessage, input.StepDone)
+				continue
+			}
+
+			failed = true
+			report.Passed = false
+			a.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+			if a.formatter.Kind() == output.NoneFormat {
+				a.console.Message(ctx, output.WithGrayFormat("%s", indentLines(result.Output, "    ")))
+			}
+		}
+	}
+
+	if output.IsJsonFormat(a.formatter.Kind()) {
+		if err := a.formatter.Format(report, a.writer, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if !report.Passed {
+		failures := 0
+		for _, result := range report.Results {
+			if !result.Passed && !result.Skipped {
+				failures++
+			}
+		}
+
+		return nil, fmt.Errorf("template test failed with %d failed step(s)", failures)
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: "The services of the template restore, build and package.",
+		},
+	}, nil
+}
+
+func indentLines(text string, indent string) string {
+	return indent + strings.ReplaceAll(text, "\n", "\n"+indent)
+}
+
+func getCmdTemplateTestHelpDescription(*cobra.Command) string {
+	return generateCmdHelpDescription(
+		fmt.Sprintf("Test the services of an azd template, in the current directory by default, restore, build and"+
+			" package. %s", output.WithWarningFormat("(Beta)")),
+		[]string{
+			formatHelpNote("The steps run in a clean copy of the template, with a new environment."),
+			formatHelpNote(fmt.Sprintf("Use %s for a report to check in pull requests.",
+				output.WithHighLightFormat("--output json"))),
+		})
+}
@@ -0,0 +1,167 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal/repository"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+	"github.com/spf13/cobra"
+)
+
+type templateUpgradeFlags struct {
+	ref string
+}
+
+func newTemplateUpgradeFlags(cmd *cobra.Command) *templateUpgradeFlags {
+	flags := &templateUpgradeFlags{}
+	cmd.Flags().StringVar(
+		&flags.ref,
+		"ref",
+		"",
+		"The branch or tag of the template to upgrade to. Defaults to the ref the project was initialized or last "+
+			"upgraded from.",
+	)
+
+	return flags
+}
+
+func newTemplateUpgradeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "upgrade",
+		Short: fmt.Sprintf("Upgrade the project with the changes of its template. %s", output.WithWarningFormat("(Beta)")),
+		Args:  cobra.NoArgs,
+	}
+}
+
+type templateUpgradeAction struct {
+	flags     *templateUpgradeFlags
+	azdCtx    *azdcontext.AzdContext
+	console   input.Console
+	formatter output.Formatter
+	writer    io.Writer
+	gitCli    *git.Cli
+	upgrader  *repository.Upgrader
+}
+
+func newTemplateUpgradeAction(
+	flags *templateUpgradeFlags,
+	azdCtx *azdcontext.AzdContext,
+	console input.Console,
+	formatter output.Formatter,
+	writer io.Writer,
+	gitCli *git.Cli,
+	upgrader *repository.Upgrader,
+) actions.Action {
+	return &templateUpgradeAction{
+		flags:     flags,
+		azdCtx:    azdCtx,
+		console:   console,
+		formatter: formatter,
+		writer:    writer,
+		gitCli:    gitCli,
+		upgrader:  upgrader,
+	}
+}
+
+func (a *templateUpgradeAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	if err := tools.EnsureInstalled(ctx, a.gitCli); err != nil {
+		return nil, err
+	}
+
+	a.console.MessageUxItem(ctx, &ux.MessageTitle{
+		Title: "Upgrade the project with the changes of its template (azd template upgrade)",
+	})
+
+	stepMessage := "Merging the changes of the template"
+	a.console.ShowSpinner(ctx, stepMessage, input.Step)
+	report, err := a.upgrader.Upgrade(ctx, a.azdCtx.ProjectDirectory(), a.flags.ref)
+	a.console.StopSpinner(ctx, stepMessage, input.GetStepResultFormat(err))
+	if errors.Is(err, repository.ErrNoTemplateRecord) {
+		return nil, fmt.Errorf(
+			"%w. Only projects initialized from a template with this version of azd record the template in the "+
+				"metadata of %s", err, azdcontext.ProjectFileName)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if output.IsJsonFormat(a.formatter.Kind()) {
+		if err := a.formatter.Format(report, a.writer, nil); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, file := range report.Files {
+			line := fmt.Sprintf("  %-9s %s", file.Status, file.Path)
+			if file.Status == repository.UpgradeFileConflict {
+				line = output.WithWarningFormat("%s", line) + output.WithGrayFormat(" (%s)", file.Reason)
+			}
+
+			a.console.Message(ctx, line)
+		}
+	}
+
+	if report.FromCommit == report.ToCommit {
+		return &actions.ActionResult{
+			Message: &actions.ResultMessage{
+				Header: "The project is up to date with its template.",
+			},
+		}, nil
+	}
+
+	header := fmt.Sprintf("Upgraded the project to commit %s of its template.", shortRevision(report.ToCommit))
+	followUp := "Review the changes with git diff before committing them."
+	if report.Conflicts > 0 {
+		header = fmt.Sprintf(
+			"Upgraded the project to commit %s of its template, with %d conflict(s).",
+			shortRevision(report.ToCommit), report.Conflicts)
+		followUp = "Resolve the conflicts of the files listed above, then review the changes with git diff before " +
+			"committing them."
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header:   header,
+			FollowUp: followUp,
+		},
+	}, nil
+}
+
+func shortRevision(commit string) string {
+	return commit[:min(len(commit), 7)]
+}
+
+func getCmdTemplateUpgradeHelpDescription(*cobra.Command) string {
+	return generateCmdHelpDescription(
+		fmt.Sprintf("Upgrade the project with the changes of its template. %s", output.WithWarningFormat("(Beta)")),
+		[]string{
+			formatHelpNote(fmt.Sprintf("The changes of the template since %s or the last upgrade are merged into the "+
+				"project, as recorded in the metadata of %s.",
+				output.WithHighLightFormat("azd init"),
+				output.WithHighLightFormat(azdcontext.ProjectFileName))),
+			formatHelpNote("Changes which conflict with the changes of the project are marked in the files, " +
+				"or listed when they can't be marked."),
+			formatHelpNote("Commit the changes of the project before upgrading, to review the upgrade with git diff."),
+		})
+}
+
+func getCmdTemplateUpgradeHelpFooter(*cobra.Command) string {
+	return generateCmdHelpSamplesBlock(map[string]string{
+		"Upgrade the project with the latest changes of its template.": output.WithHighLightFormat(
+			"azd template upgrade",
+		),
+		"Upgrade the project to a version of its template.": output.WithHighLightFormat(
+			"azd template upgrade --ref <tag>",
+		),
+	})
+}
@@ -123,14 +123,18 @@ func (u *upAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 
 	startTime := time.Now()
 
-	upWorkflow, has := u.projectConfig.Workflows["up"]
-	if !has {
+	upWorkflow, isCustom := u.projectConfig.Workflows["up"]
+	if !isCustom {
 		upWorkflow = defaultUpWorkflow
 	} else {
 		u.console.Message(ctx, output.WithGrayFormat("Note: Running custom 'up' workflow from azure.yaml"))
 	}
 
-	if err := u.workflowRunner.Run(ctx, upWorkflow); err != nil {
+	runResult, err := u.workflowRunner.Run(ctx, upWorkflow)
+	if isCustom && runResult != nil {
+		u.showWorkflowSummary(ctx, runResult)
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -142,6 +146,29 @@ func (u *upAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 	}, nil
 }
 
+// showWorkflowSummary displays the outcome of every step of a custom workflow
+func (u *upAction) showWorkflowSummary(ctx context.Context, runResult *workflow.RunResult) {
+	u.console.Message(ctx, "")
+	u.console.Message(ctx, output.WithBold("Workflow steps:"))
+
+	for _, step := range runResult.Steps {
+		switch step.Status {
+		case workflow.StepStatusSucceeded:
+			u.console.StopSpinner(
+				ctx,
+				fmt.Sprintf("%s (%s)", step.Name, ux.DurationAsText(step.Duration)),
+				input.StepDone,
+			)
+		case workflow.StepStatusFailed:
+			u.console.StopSpinner(ctx, step.Name, input.StepFailed)
+		case workflow.StepStatusSkipped:
+			u.console.StopSpinner(ctx, step.Name, input.StepSkipped)
+		}
+	}
+
+	u.console.Message(ctx, "")
+}
+
 func getCmdUpHelpDescription(c *cobra.Command) string {
 	return generateCmdHelpDescription(
 		heredoc.Docf(
@@ -160,7 +187,9 @@ func getCmdUpHelpDescription(c *cobra.Command) string {
 			    - azd: deploy --all
 			-------------------------
 
-			Any azd command and flags are supported in the workflow steps.`,
+			Any azd command and flags are supported in the workflow steps.
+			Steps can also execute a script with %s, execute named hooks with %s,
+			be skipped based on environment values with %s and tolerate failures with %s.`,
 			output.WithHighLightFormat("package"),
 			output.WithHighLightFormat("provision"),
 			output.WithHighLightFormat("deploy"),
@@ -168,6 +197,10 @@ func getCmdUpHelpDescription(c *cobra.Command) string {
 			output.WithHighLightFormat("workflows"),
 			output.WithHighLightFormat("azure.yaml"),
 			output.WithGrayFormat("# azure.yaml"),
+			output.WithHighLightFormat("run"),
+			output.WithHighLightFormat("hook"),
+			output.WithHighLightFormat("if"),
+			output.WithHighLightFormat("continueOnError"),
 		),
 		nil,
 	)
@@ -57,7 +57,7 @@ func (v *versionAction) Run(ctx context.Context) (*actions.ActionResult, error)
 	switch v.formatter.Kind() {
 	case output.NoneFormat:
 		fmt.Fprintf(v.console.Handles().Stdout, "azd version %s\n", internal.Version)
-	case output.JsonFormat:
+	case output.JsonFormat, output.JsonLinesFormat:
 		var result contracts.VersionResult
 		versionSpec := internal.VersionInfo()
 
@@ -26,6 +26,7 @@ const (
 	JavaScript    Language = "js"
 	TypeScript    Language = "ts"
 	Python        Language = "python"
+	Go            Language = "go"
 )
 
 func (pt Language) Display() string {
@@ -42,6 +43,8 @@ func (pt Language) Display() string {
 		return "TypeScript"
 	case Python:
 		return "Python"
+	case Go:
+		return "Go"
 	}
 
 	return ""
@@ -59,6 +62,11 @@ const (
 	PyFlask   Dependency = "flask"
 	PyDjango  Dependency = "django"
 	PyFastApi Dependency = "fastapi"
+
+	GoGin     Dependency = "gin"
+	GoEcho    Dependency = "echo"
+	GoChi     Dependency = "chi"
+	GoNetHttp Dependency = "net/http"
 )
 
 var WebUIFrameworks = map[Dependency]struct{}{
@@ -131,6 +139,25 @@ func (db DatabaseDep) Display() string {
 	return ""
 }
 
+// An Azure service used by the project, inferred through heuristics while scanning project information.
+type AzureDep string
+
+const (
+	AzureDepServiceBus     AzureDep = "servicebus"
+	AzureDepStorageAccount AzureDep = "storage"
+)
+
+func (dep AzureDep) Display() string {
+	switch dep {
+	case AzureDepServiceBus:
+		return "Azure Service Bus"
+	case AzureDepStorageAccount:
+		return "Azure Storage Account"
+	}
+
+	return ""
+}
+
 type Project struct {
 	// The language associated with the project.
 	Language Language
@@ -141,6 +168,9 @@ type Project struct {
 	// Experimental: Database dependencies inferred through heuristics while scanning dependencies in the project.
 	DatabaseDeps []DatabaseDep
 
+	// Experimental: Azure services inferred through heuristics while scanning dependencies in the project.
+	AzureDeps []AzureDep
+
 	// The path to the project directory.
 	Path string
 
@@ -189,6 +219,7 @@ var allDetectors = []projectDetector{
 	},
 	&pythonDetector{},
 	&javaScriptDetector{},
+	&goDetector{},
 }
 
 // Detect detects projects located under a directory.
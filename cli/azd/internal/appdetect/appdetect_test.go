@@ -49,6 +49,10 @@ func TestDetect(t *testing.T) {
 						DbMySql,
 						DbPostgres,
 					},
+					AzureDeps: []AzureDep{
+						AzureDepServiceBus,
+						AzureDepStorageAccount,
+					},
 				},
 				{
 					Language:      Java,
@@ -77,6 +81,10 @@ func TestDetect(t *testing.T) {
 						DbRedis,
 						DbSqlServer,
 					},
+					AzureDeps: []AzureDep{
+						AzureDepServiceBus,
+						AzureDepStorageAccount,
+					},
 				},
 				{
 					Language:      Python,
@@ -97,6 +105,11 @@ func TestDetect(t *testing.T) {
 						DbMySql,
 						DbPostgres,
 						DbRedis,
+						DbSqlServer,
+					},
+					AzureDeps: []AzureDep{
+						AzureDepServiceBus,
+						AzureDepStorageAccount,
 					},
 				},
 				{
@@ -133,6 +146,10 @@ func TestDetect(t *testing.T) {
 						DbMySql,
 						DbPostgres,
 					},
+					AzureDeps: []AzureDep{
+						AzureDepServiceBus,
+						AzureDepStorageAccount,
+					},
 				},
 				{
 					Language:      Java,
@@ -166,6 +183,10 @@ func TestDetect(t *testing.T) {
 						DbMySql,
 						DbPostgres,
 					},
+					AzureDeps: []AzureDep{
+						AzureDepServiceBus,
+						AzureDepStorageAccount,
+					},
 				},
 				{
 					Language:      Java,
@@ -202,6 +223,10 @@ func TestDetect(t *testing.T) {
 						DbMySql,
 						DbPostgres,
 					},
+					AzureDeps: []AzureDep{
+						AzureDepServiceBus,
+						AzureDepStorageAccount,
+					},
 				},
 				{
 					Language:      Java,
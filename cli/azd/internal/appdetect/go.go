@@ -0,0 +1,212 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package appdetect
+
+import (
+	"bufio"
+	"context"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+type goDetector struct {
+}
+
+func (gd *goDetector) Language() Language {
+	return Go
+}
+
+func (gd *goDetector) DetectProject(ctx context.Context, path string, entries []fs.DirEntry) (*Project, error) {
+	for _, entry := range entries {
+		if entry.Name() == "go.mod" {
+			project := &Project{
+				Language:      Go,
+				Path:          path,
+				DetectionRule: "Inferred by presence of: " + entry.Name(),
+			}
+
+			modules, err := readGoModRequirements(filepath.Join(path, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+
+			databaseDepMap := map[DatabaseDep]struct{}{}
+			azureDepMap := map[AzureDep]struct{}{}
+			for _, module := range modules {
+				switch module {
+				case "github.com/gin-gonic/gin":
+					project.Dependencies = append(project.Dependencies, GoGin)
+				case "github.com/labstack/echo":
+					project.Dependencies = append(project.Dependencies, GoEcho)
+				case "github.com/go-chi/chi":
+					project.Dependencies = append(project.Dependencies, GoChi)
+				}
+
+				switch module {
+				case "github.com/go-sql-driver/mysql":
+					databaseDepMap[DbMySql] = struct{}{}
+				case "github.com/jackc/pgx",
+					"github.com/lib/pq":
+					databaseDepMap[DbPostgres] = struct{}{}
+				case "github.com/microsoft/go-mssqldb",
+					"github.com/denisenkom/go-mssqldb":
+					databaseDepMap[DbSqlServer] = struct{}{}
+				case "go.mongodb.org/mongo-driver":
+					databaseDepMap[DbMongo] = struct{}{}
+				case "github.com/redis/go-redis",
+					"github.com/go-redis/redis":
+					databaseDepMap[DbRedis] = struct{}{}
+				}
+
+				switch module {
+				case "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus":
+					azureDepMap[AzureDepServiceBus] = struct{}{}
+				case "github.com/Azure/azure-sdk-for-go/sdk/storage/azblob",
+					"github.com/Azure/azure-sdk-for-go/sdk/storage/azqueue",
+					"github.com/Azure/azure-sdk-for-go/sdk/storage/azfile":
+					azureDepMap[AzureDepStorageAccount] = struct{}{}
+				}
+			}
+
+			// Without a web framework, the app may be served by the standard library
+			if len(project.Dependencies) == 0 {
+				servesHttp, err := goServesHttp(path)
+				if err != nil {
+					return nil, err
+				}
+
+				if servesHttp {
+					project.Dependencies = append(project.Dependencies, GoNetHttp)
+				}
+			}
+
+			if len(databaseDepMap) > 0 {
+				project.DatabaseDeps = slices.SortedFunc(maps.Keys(databaseDepMap),
+					func(a, b DatabaseDep) int {
+						return strings.Compare(string(a), string(b))
+					})
+			}
+
+			if len(azureDepMap) > 0 {
+				project.AzureDeps = slices.SortedFunc(maps.Keys(azureDepMap),
+					func(a, b AzureDep) int {
+						return strings.Compare(string(a), string(b))
+					})
+			}
+
+			slices.SortFunc(project.Dependencies, func(a, b Dependency) int {
+				return strings.Compare(string(a), string(b))
+			})
+
+			return project, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// goModuleMajorVersion matches the major version suffix of a module path, like /v5 in github.com/jackc/pgx/v5
+var goModuleMajorVersion = regexp.MustCompile(`/v[0-9]+$`)
+
+// readGoModRequirements reads the paths of the modules required in a go.mod file, without their major version suffix.
+func readGoModRequirements(goModPath string) ([]string, error) {
+	file, err := os.Open(goModPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	modules := []string{}
+	inRequireBlock := false
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case inRequireBlock && fields[0] == ")":
+			inRequireBlock = false
+			continue
+		case inRequireBlock:
+			// module version
+		case fields[0] == "require" && len(fields) > 1 && fields[1] == "(":
+			inRequireBlock = true
+			continue
+		case fields[0] == "require":
+			// require module version
+			fields = fields[1:]
+		default:
+			continue
+		}
+
+		if len(fields) > 0 {
+			modules = append(modules, goModuleMajorVersion.ReplaceAllString(strings.Trim(fields[0], `"`), ""))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return modules, nil
+}
+
+// goServesHttp returns true when a Go source file of the project starts an HTTP server with the net/http package.
+func goServesHttp(projectPath string) (bool, error) {
+	servesHttp := false
+
+	err := filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			name := d.Name()
+			if path != projectPath && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if filepath.Ext(path) != ".go" || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		// match on something that looks like:
+		//   http.ListenAndServe(":8080", mux)
+		//   server := &http.Server{Addr: ":8080"}
+		source := string(contents)
+		if strings.Contains(source, `"net/http"`) &&
+			(strings.Contains(source, "ListenAndServe") || strings.Contains(source, "http.Server{")) {
+			servesHttp = true
+			return filepath.SkipAll
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return servesHttp, nil
+}
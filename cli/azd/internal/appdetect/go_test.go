@@ -0,0 +1,114 @@
+package appdetect
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The Go projects are written by the tests, since a go.mod file can't be embedded from testdata.
+func TestDetectGo(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  Project
+	}{
+		{
+			name: "Minimal",
+			files: map[string]string{
+				"go.mod":  "module example.com/app\n\ngo 1.22\n",
+				"main.go": "package main\n\nfunc main() {}\n",
+			},
+			want: Project{
+				Language:      Go,
+				DetectionRule: "Inferred by presence of: go.mod",
+			},
+		},
+		{
+			name: "Full",
+			files: map[string]string{
+				"go.mod": `module example.com/app
+
+go 1.22
+
+require github.com/gin-gonic/gin v1.10.0
+
+require (
+	github.com/go-chi/chi/v5 v5.0.12
+	github.com/labstack/echo/v4 v4.12.0 // indirect
+	github.com/jackc/pgx/v5 v5.6.0
+	github.com/go-sql-driver/mysql v1.8.1
+	github.com/microsoft/go-mssqldb v1.7.2
+	go.mongodb.org/mongo-driver v1.16.0
+	github.com/redis/go-redis/v9 v9.6.1
+	github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus v1.7.1
+	github.com/Azure/azure-sdk-for-go/sdk/storage/azblob v1.4.0
+)
+
+replace github.com/gin-gonic/gin => ../gin
+`,
+			},
+			want: Project{
+				Language:      Go,
+				DetectionRule: "Inferred by presence of: go.mod",
+				Dependencies: []Dependency{
+					GoChi,
+					GoEcho,
+					GoGin,
+				},
+				DatabaseDeps: []DatabaseDep{
+					DbMongo,
+					DbMySql,
+					DbPostgres,
+					DbRedis,
+					DbSqlServer,
+				},
+				AzureDeps: []AzureDep{
+					AzureDepServiceBus,
+					AzureDepStorageAccount,
+				},
+			},
+		},
+		{
+			name: "NetHttp",
+			files: map[string]string{
+				"go.mod": "module example.com/app\n\ngo 1.22\n",
+				"cmd/server/main.go": `package main
+
+import "net/http"
+
+func main() {
+	http.ListenAndServe(":8080", nil)
+}
+`,
+			},
+			want: Project{
+				Language:      Go,
+				DetectionRule: "Inferred by presence of: go.mod",
+				Dependencies: []Dependency{
+					GoNetHttp,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, contents := range tt.files {
+				path := filepath.Join(dir, filepath.FromSlash(name))
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+				require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+			}
+
+			projects, err := Detect(context.Background(), dir)
+			require.NoError(t, err)
+
+			tt.want.Path = dir
+			require.Equal(t, []Project{tt.want}, projects)
+		})
+	}
+}
@@ -122,6 +122,7 @@ func readMavenProject(filePath string) (*mavenProject, error) {
 
 func detectDependencies(mavenProject *mavenProject, project *Project) (*Project, error) {
 	databaseDepMap := map[DatabaseDep]struct{}{}
+	azureDepMap := map[AzureDep]struct{}{}
 	for _, dep := range mavenProject.Dependencies {
 		if dep.GroupId == "com.mysql" && dep.ArtifactId == "mysql-connector-j" {
 			databaseDepMap[DbMySql] = struct{}{}
@@ -130,6 +131,19 @@ func detectDependencies(mavenProject *mavenProject, project *Project) (*Project,
 		if dep.GroupId == "org.postgresql" && dep.ArtifactId == "postgresql" {
 			databaseDepMap[DbPostgres] = struct{}{}
 		}
+
+		if dep.GroupId == "com.microsoft.sqlserver" && dep.ArtifactId == "mssql-jdbc" {
+			databaseDepMap[DbSqlServer] = struct{}{}
+		}
+
+		switch {
+		case dep.GroupId == "com.azure" && dep.ArtifactId == "azure-messaging-servicebus",
+			dep.GroupId == "com.azure.spring" && strings.Contains(dep.ArtifactId, "servicebus"):
+			azureDepMap[AzureDepServiceBus] = struct{}{}
+		case dep.GroupId == "com.azure" && strings.HasPrefix(dep.ArtifactId, "azure-storage-"),
+			dep.GroupId == "com.azure.spring" && strings.HasPrefix(dep.ArtifactId, "spring-cloud-azure-starter-storage"):
+			azureDepMap[AzureDepStorageAccount] = struct{}{}
+		}
 	}
 
 	if len(databaseDepMap) > 0 {
@@ -139,5 +153,12 @@ func detectDependencies(mavenProject *mavenProject, project *Project) (*Project,
 			})
 	}
 
+	if len(azureDepMap) > 0 {
+		project.AzureDeps = slices.SortedFunc(maps.Keys(azureDepMap),
+			func(a, b AzureDep) int {
+				return strings.Compare(string(a), string(b))
+			})
+	}
+
 	return project, nil
 }
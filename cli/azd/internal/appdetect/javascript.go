@@ -46,6 +46,7 @@ func (nd *javaScriptDetector) DetectProject(ctx context.Context, path string, en
 			angularAdded := false
 			viteAdded := false
 			databaseDepMap := map[DatabaseDep]struct{}{}
+			azureDepMap := map[AzureDep]struct{}{}
 
 			for dep := range packagesJson.Dependencies {
 				switch dep {
@@ -77,6 +78,15 @@ func (nd *javaScriptDetector) DetectProject(ctx context.Context, path string, en
 				case "redis", "redis-om":
 					databaseDepMap[DbRedis] = struct{}{}
 				}
+
+				switch dep {
+				case "@azure/service-bus":
+					azureDepMap[AzureDepServiceBus] = struct{}{}
+				case "@azure/storage-blob",
+					"@azure/storage-queue",
+					"@azure/storage-file-share":
+					azureDepMap[AzureDepStorageAccount] = struct{}{}
+				}
 			}
 
 			for dep := range packagesJson.DevDependencies {
@@ -95,6 +105,13 @@ func (nd *javaScriptDetector) DetectProject(ctx context.Context, path string, en
 					})
 			}
 
+			if len(azureDepMap) > 0 {
+				project.AzureDeps = slices.SortedFunc(maps.Keys(azureDepMap),
+					func(a, b AzureDep) int {
+						return strings.Compare(string(a), string(b))
+					})
+			}
+
 			slices.SortFunc(project.Dependencies, func(a, b Dependency) int {
 				return strings.Compare(string(a), string(b))
 			})
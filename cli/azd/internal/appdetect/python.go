@@ -35,6 +35,7 @@ func (pd *pythonDetector) DetectProject(ctx context.Context, path string, entrie
 
 			scanner := bufio.NewScanner(file)
 			databaseDepMap := map[DatabaseDep]struct{}{}
+			azureDepMap := map[AzureDep]struct{}{}
 
 			for scanner.Scan() {
 				split := strings.Split(scanner.Text(), "==")
@@ -71,9 +72,21 @@ func (pd *pythonDetector) DetectProject(ctx context.Context, path string, entrie
 					"beanie",
 					"motor":
 					databaseDepMap[DbMongo] = struct{}{}
+				case "pymssql",
+					"mssql-django":
+					databaseDepMap[DbSqlServer] = struct{}{}
 				case "redis", "redis-om":
 					databaseDepMap[DbRedis] = struct{}{}
 				}
+
+				switch module {
+				case "azure-servicebus":
+					azureDepMap[AzureDepServiceBus] = struct{}{}
+				case "azure-storage-blob",
+					"azure-storage-queue",
+					"azure-storage-file-share":
+					azureDepMap[AzureDepStorageAccount] = struct{}{}
+				}
 			}
 
 			if err := file.Close(); err != nil {
@@ -87,6 +100,13 @@ func (pd *pythonDetector) DetectProject(ctx context.Context, path string, entrie
 					})
 			}
 
+			if len(azureDepMap) > 0 {
+				project.AzureDeps = slices.SortedFunc(maps.Keys(azureDepMap),
+					func(a, b AzureDep) int {
+						return strings.Compare(string(a), string(b))
+					})
+			}
+
 			slices.SortFunc(project.Dependencies, func(a, b Dependency) int {
 				return strings.Compare(string(a), string(b))
 			})
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
@@ -33,6 +34,9 @@ type DeployFlags struct {
 	serviceName string
 	All         bool
 	fromPackage string
+	maxParallel int
+	rollback    bool
+	swap        bool
 	global      *internal.GlobalCommandOptions
 	*internal.EnvFlag
 }
@@ -73,6 +77,24 @@ func (d *DeployFlags) bindCommon(local *pflag.FlagSet, global *internal.GlobalCo
 		"",
 		"Deploys the application from an existing package.",
 	)
+	local.IntVar(
+		&d.maxParallel,
+		"max-parallel",
+		project.DefaultMaxParallelDeployments,
+		"The maximum number of services packaged and deployed at the same time.",
+	)
+	local.BoolVar(
+		&d.rollback,
+		"rollback",
+		false,
+		"Rolls back the services to their previous deployment, instead of deploying the application.",
+	)
+	local.BoolVar(
+		&d.swap,
+		"swap",
+		false,
+		"Swaps the deployment slot of the services with their production slot, once deployed.",
+	)
 }
 
 func (d *DeployFlags) SetCommon(envFlag *internal.EnvFlag) {
@@ -207,6 +229,14 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 		)
 	}
 
+	if da.flags.rollback && da.flags.fromPackage != "" {
+		return nil, errors.New("'--from-package' cannot be specified when '--rollback' is set")
+	}
+
+	if da.flags.rollback && da.flags.swap {
+		return nil, errors.New("'--swap' cannot be specified when '--rollback' is set")
+	}
+
 	if err := da.projectManager.Initialize(ctx, da.projectConfig); err != nil {
 		return nil, err
 	}
@@ -218,26 +248,30 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 	}
 
 	// Command title
-	da.console.MessageUxItem(ctx, &ux.MessageTitle{
-		Title: "Deploying services (azd deploy)",
-	})
+	if da.flags.rollback {
+		da.console.MessageUxItem(ctx, &ux.MessageTitle{
+			Title: "Rolling back services (azd deploy --rollback)",
+		})
+	} else {
+		da.console.MessageUxItem(ctx, &ux.MessageTitle{
+			Title: "Deploying services (azd deploy)",
+		})
+	}
 
 	startTime := time.Now()
-
-	deployResults := map[string]*project.ServiceDeployResult{}
 	stableServices, err := da.importManager.ServiceStable(ctx, da.projectConfig)
 	if err != nil {
 		return nil, err
 	}
 
+	servicesToDeploy := []*project.ServiceConfig{}
 	for _, svc := range stableServices {
-		stepMessage := fmt.Sprintf("Deploying service %s", svc.Name)
-		da.console.ShowSpinner(ctx, stepMessage, input.Step)
-
 		// Skip this service if both cases are true:
 		// 1. The user specified a service name
 		// 2. This service is not the one the user specified
 		if targetServiceName != "" && targetServiceName != svc.Name {
+			stepMessage := fmt.Sprintf("Deploying service %s", svc.Name)
+			da.console.ShowSpinner(ctx, stepMessage, input.Step)
 			da.console.StopSpinner(ctx, stepMessage, input.StepSkipped)
 			continue
 		}
@@ -248,50 +282,44 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 			da.console.WarnForFeature(ctx, alphaFeatureId)
 		}
 
-		var packageResult *project.ServicePackageResult
-		if da.flags.fromPackage != "" {
-			// --from-package set, skip packaging
-			packageResult = &project.ServicePackageResult{
-				PackagePath: da.flags.fromPackage,
-			}
-		} else {
-			//  --from-package not set, package the application
-			packageResult, err = async.RunWithProgress(
-				func(packageProgress project.ServiceProgress) {
-					progressMessage := fmt.Sprintf("Deploying service %s (%s)", svc.Name, packageProgress.Message)
-					da.console.ShowSpinner(ctx, progressMessage, input.Step)
-				},
-				func(progress *async.Progress[project.ServiceProgress]) (*project.ServicePackageResult, error) {
-					return da.serviceManager.Package(ctx, svc, nil, progress, nil)
-				},
-			)
+		servicesToDeploy = append(servicesToDeploy, svc)
+	}
 
-			// do not stop progress here as next step is to deploy
+	if da.flags.swap {
+		// The slot is validated once expanded, since a slot like ${SLOT_NAME} can expand to nothing or to production
+		hasSlot := false
+		for _, svc := range servicesToDeploy {
+			slot, err := project.DeploymentSlot(da.env, svc)
 			if err != nil {
-				da.console.StopSpinner(ctx, stepMessage, input.StepFailed)
 				return nil, err
 			}
-		}
 
-		deployResult, err := async.RunWithProgress(
-			func(deployProgress project.ServiceProgress) {
-				progressMessage := fmt.Sprintf("Deploying service %s (%s)", svc.Name, deployProgress.Message)
-				da.console.ShowSpinner(ctx, progressMessage, input.Step)
-			},
-			func(progress *async.Progress[project.ServiceProgress]) (*project.ServiceDeployResult, error) {
-				return da.serviceManager.Deploy(ctx, svc, packageResult, progress)
-			},
-		)
-
-		da.console.StopSpinner(ctx, stepMessage, input.GetStepResultFormat(err))
-		if err != nil {
-			return nil, err
+			hasSlot = hasSlot || slot != ""
 		}
 
-		deployResults[svc.Name] = deployResult
+		if !hasSlot {
+			return nil, fmt.Errorf(
+				"'--swap' requires a service deployed to a deployment slot other than production, set 'slot' in %s",
+				azdcontext.ProjectFileName,
+			)
+		}
+	}
 
-		// report deploy outputs
-		da.console.MessageUxItem(ctx, deployResult)
+	var deployResults map[string]*project.ServiceDeployResult
+	if da.flags.rollback {
+		deployResults, err = da.rollback(ctx, servicesToDeploy, targetServiceName)
+	} else {
+		// Services are packaged & deployed concurrently, following the dependencies declared between them
+		progressDisplay := newDeployProgressDisplay(ctx, da.console, servicesToDeploy)
+		deployResults, err = da.serviceManager.DeployServices(ctx, servicesToDeploy, &project.DeployServicesOptions{
+			MaxParallel: da.flags.maxParallel,
+			FromPackage: da.flags.fromPackage,
+			SwapSlots:   da.flags.swap,
+			OnProgress:  progressDisplay.update,
+		})
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	aspireDashboardUrl := apphost.AspireDashboardUrl(ctx, da.env, da.alphaFeatureManager)
@@ -299,7 +327,7 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 		da.console.MessageUxItem(ctx, aspireDashboardUrl)
 	}
 
-	if da.formatter.Kind() == output.JsonFormat {
+	if output.IsJsonFormat(da.formatter.Kind()) {
 		deployResult := DeploymentResult{
 			Timestamp: time.Now(),
 			Services:  deployResults,
@@ -310,9 +338,14 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 		}
 	}
 
+	header := fmt.Sprintf("Your application was deployed to Azure in %s.", ux.DurationAsText(since(startTime)))
+	if da.flags.rollback {
+		header = fmt.Sprintf("Your application was rolled back in %s.", ux.DurationAsText(since(startTime)))
+	}
+
 	return &actions.ActionResult{
 		Message: &actions.ResultMessage{
-			Header: fmt.Sprintf("Your application was deployed to Azure in %s.", ux.DurationAsText(since(startTime))),
+			Header: header,
 			FollowUp: getResourceGroupFollowUp(ctx,
 				da.formatter,
 				da.portalUrlBase,
@@ -325,6 +358,47 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 	}, nil
 }
 
+// rollback rolls back the services to their previous deployment, one service at a time. When all the services are rolled
+// back, the services whose host doesn't support rolling back are skipped.
+func (da *DeployAction) rollback(
+	ctx context.Context,
+	services []*project.ServiceConfig,
+	targetServiceName string,
+) (map[string]*project.ServiceDeployResult, error) {
+	results := map[string]*project.ServiceDeployResult{}
+
+	for _, svc := range services {
+		stepMessage := fmt.Sprintf("Rolling back service %s", svc.Name)
+		da.console.ShowSpinner(ctx, stepMessage, input.Step)
+
+		result, err := async.RunWithProgress(
+			func(progress project.ServiceProgress) {
+				da.console.ShowSpinner(ctx, fmt.Sprintf("%s (%s)", stepMessage, progress.Message), input.Step)
+			},
+			func(progress *async.Progress[project.ServiceProgress]) (*project.ServiceDeployResult, error) {
+				return da.serviceManager.Rollback(ctx, svc, progress)
+			},
+		)
+
+		if targetServiceName == "" && errors.Is(err, project.ErrRollbackNotSupported) {
+			da.console.StopSpinner(ctx, stepMessage, input.StepSkipped)
+			da.console.Message(ctx, output.WithGrayFormat("  %s", err.Error()))
+			continue
+		}
+
+		if err != nil {
+			da.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+			return nil, err
+		}
+
+		da.console.StopSpinner(ctx, stepMessage, input.StepDone)
+		da.console.MessageUxItem(ctx, result)
+		results[svc.Name] = result
+	}
+
+	return results, nil
+}
+
 func GetCmdDeployHelpDescription(*cobra.Command) string {
 	return generateCmdHelpDescription("Deploy application to Azure.", []string{
 		formatHelpNote(
@@ -332,8 +406,16 @@ func GetCmdDeployHelpDescription(*cobra.Command) string {
 				" or the service described in the project that matches the current directory."),
 		formatHelpNote(
 			fmt.Sprintf("When %s is set, only the specific service is deployed.", output.WithHighLightFormat("<service>"))),
+		formatHelpNote("Services that don't depend on each other are deployed in parallel. Use 'dependsOn' or 'uses'" +
+			" in 'azure.yaml' to deploy a service after the services it depends on."),
 		formatHelpNote("After the deployment is complete, the endpoint is printed. To start the service, select" +
 			" the endpoint or paste it in a browser."),
+		formatHelpNote(fmt.Sprintf("When %s is set, the services are reverted to their previous deployment,"+
+			" for the hosts that support it: Container Apps, App Service and AKS with Helm releases.",
+			output.WithHighLightFormat("--rollback"))),
+		formatHelpNote(fmt.Sprintf("When %s is set, the services deployed to a deployment 'slot' of an App Service or a"+
+			" Function App are swapped with the production slot once deployed.",
+			output.WithHighLightFormat("--swap"))),
 	})
 }
 
@@ -351,5 +433,94 @@ func GetCmdDeployHelpFooter(*cobra.Command) string {
 		"Deploy the service named 'api' to Azure from a previously generated package.": output.WithHighLightFormat(
 			"azd deploy api --from-package <package-path>",
 		),
+		"Deploy all services in the current project to Azure, one service at a time.": output.WithHighLightFormat(
+			"azd deploy --all --max-parallel 1",
+		),
+		"Roll back the service named 'api' to its previous deployment.": output.WithHighLightFormat(
+			"azd deploy api --rollback",
+		),
+		"Deploy the service named 'web' to its deployment slot, then swap the slot with production.": output.WithHighLightFormat(
+			"azd deploy web --swap",
+		),
 	})
 }
+
+// deployProgressDisplay renders the progress of services deployed concurrently. The services being deployed share the
+// spinner line, and a result line is printed as soon as each service completes.
+type deployProgressDisplay struct {
+	ctx     context.Context
+	console input.Console
+	// The names of the services, in the order they are displayed
+	order []string
+	// The latest progress message of the services being deployed
+	running map[string]string
+}
+
+func newDeployProgressDisplay(
+	ctx context.Context,
+	console input.Console,
+	services []*project.ServiceConfig,
+) *deployProgressDisplay {
+	order := make([]string, 0, len(services))
+	for _, svc := range services {
+		order = append(order, svc.Name)
+	}
+
+	return &deployProgressDisplay{
+		ctx:     ctx,
+		console: console,
+		order:   order,
+		running: map[string]string{},
+	}
+}
+
+func (d *deployProgressDisplay) update(svc *project.ServiceConfig, progress project.ServiceDeployProgress) {
+	stepMessage := fmt.Sprintf("Deploying service %s", svc.Name)
+
+	switch progress.State {
+	case project.ServiceDeployStateRunning:
+		d.running[svc.Name] = progress.Message
+	case project.ServiceDeployStateSucceeded:
+		delete(d.running, svc.Name)
+		d.console.StopSpinner(d.ctx, stepMessage, input.StepDone)
+
+		// report deploy outputs
+		d.console.MessageUxItem(d.ctx, progress.Result)
+	case project.ServiceDeployStateFailed:
+		delete(d.running, svc.Name)
+		d.console.StopSpinner(d.ctx, stepMessage, input.StepFailed)
+	case project.ServiceDeployStateSkipped:
+		d.console.StopSpinner(d.ctx, stepMessage, input.StepSkipped)
+		d.console.Message(d.ctx, output.WithGrayFormat("  %s", progress.Error.Error()))
+	default:
+		return
+	}
+
+	d.showSpinner()
+}
+
+// showSpinner displays the progress of all the services being deployed on the spinner line
+func (d *deployProgressDisplay) showSpinner() {
+	services := []string{}
+	for _, name := range d.order {
+		message, has := d.running[name]
+		if !has {
+			continue
+		}
+
+		if message == "" {
+			services = append(services, name)
+		} else {
+			services = append(services, fmt.Sprintf("%s (%s)", name, message))
+		}
+	}
+
+	switch len(services) {
+	case 0:
+		return
+	case 1:
+		d.console.ShowSpinner(d.ctx, fmt.Sprintf("Deploying service %s", services[0]), input.Step)
+	default:
+		d.console.ShowSpinner(d.ctx, fmt.Sprintf("Deploying services %s", strings.Join(services, ", ")), input.Step)
+	}
+}
@@ -253,7 +253,7 @@ func (p *ProvisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 	})
 
 	if err != nil {
-		if p.formatter.Kind() == output.JsonFormat {
+		if output.IsJsonFormat(p.formatter.Kind()) {
 			stateResult, err := p.provisionManager.State(ctx, nil)
 			if err != nil {
 				return nil, fmt.Errorf(
@@ -355,7 +355,7 @@ func (p *ProvisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 		}
 	}
 
-	if p.formatter.Kind() == output.JsonFormat {
+	if output.IsJsonFormat(p.formatter.Kind()) {
 		stateResult, err := p.provisionManager.State(ctx, nil)
 		if err != nil {
 			return nil, fmt.Errorf(
@@ -394,10 +394,25 @@ func (p *ProvisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 func deployResultToUx(previewResult *provisioning.DeployPreviewResult) ux.UxItem {
 	var operations []*ux.Resource
 	for _, change := range previewResult.Preview.Properties.Changes {
+		var properties []*ux.PropertyChange
+		for _, delta := range change.Delta {
+			if delta.ChangeType == provisioning.PropertyChangeTypeNoEffect {
+				continue
+			}
+
+			properties = append(properties, &ux.PropertyChange{
+				Operation: ux.OperationType(delta.ChangeType),
+				Path:      delta.Path,
+				Before:    delta.Before,
+				After:     delta.After,
+			})
+		}
+
 		operations = append(operations, &ux.Resource{
-			Operation: ux.OperationType(change.ChangeType),
-			Type:      change.ResourceType,
-			Name:      change.Name,
+			Operation:  ux.OperationType(change.ChangeType),
+			Type:       change.ResourceType,
+			Name:       change.Name,
+			Properties: properties,
 		})
 	}
 	return &ux.PreviewProvision{
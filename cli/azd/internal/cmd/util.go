@@ -24,7 +24,7 @@ func getResourceGroupFollowUp(
 	env *environment.Environment,
 	whatIf bool,
 ) (followUp string) {
-	if formatter.Kind() == output.JsonFormat {
+	if output.IsJsonFormat(formatter.Kind()) {
 		return followUp
 	}
 
@@ -30,12 +30,20 @@ var languageMap = map[appdetect.Language]project.ServiceLanguageKind{
 	appdetect.JavaScript: project.ServiceLanguageJavaScript,
 	appdetect.TypeScript: project.ServiceLanguageTypeScript,
 	appdetect.Python:     project.ServiceLanguagePython,
+	appdetect.Go:         project.ServiceLanguageGo,
 }
 
 var dbMap = map[appdetect.DatabaseDep]struct{}{
-	appdetect.DbMongo:    {},
-	appdetect.DbPostgres: {},
-	appdetect.DbRedis:    {},
+	appdetect.DbMongo:     {},
+	appdetect.DbPostgres:  {},
+	appdetect.DbMySql:     {},
+	appdetect.DbSqlServer: {},
+	appdetect.DbRedis:     {},
+}
+
+var azureDepMap = map[appdetect.AzureDep]struct{}{
+	appdetect.AzureDepServiceBus:     {},
+	appdetect.AzureDepStorageAccount: {},
 }
 
 // InitFromApp initializes the infra directory and project file from the current existing app.
@@ -42,11 +42,12 @@ const (
 	EntryKindModified EntryKind = "modified"
 )
 
-// detectConfirm handles prompting for confirming the detected services and databases
+// detectConfirm handles prompting for confirming the detected services, databases and Azure services
 type detectConfirm struct {
-	// detected services and databases
+	// detected services, databases and Azure services
 	Services  []appdetect.Project
 	Databases map[appdetect.DatabaseDep]EntryKind
+	AzureDeps map[appdetect.AzureDep]EntryKind
 
 	// the root directory of the project
 	root string
@@ -59,6 +60,7 @@ type detectConfirm struct {
 // Init initializes state from initial detection output
 func (d *detectConfirm) Init(projects []appdetect.Project, root string) {
 	d.Databases = make(map[appdetect.DatabaseDep]EntryKind)
+	d.AzureDeps = make(map[appdetect.AzureDep]EntryKind)
 	d.Services = make([]appdetect.Project, 0, len(projects))
 	d.modified = false
 	d.root = root
@@ -73,6 +75,12 @@ func (d *detectConfirm) Init(projects []appdetect.Project, root string) {
 				d.Databases[dbType] = EntryKindDetected
 			}
 		}
+
+		for _, azureDep := range project.AzureDeps {
+			if _, supported := azureDepMap[azureDep]; supported {
+				d.AzureDeps[azureDep] = EntryKindDetected
+			}
+		}
 	}
 
 	d.captureUsage(
@@ -207,6 +215,10 @@ func (d *detectConfirm) render(ctx context.Context) error {
 		switch db {
 		case appdetect.DbPostgres:
 			recommendedServices = append(recommendedServices, "Azure Database for PostgreSQL flexible server")
+		case appdetect.DbMySql:
+			recommendedServices = append(recommendedServices, "Azure Database for MySQL flexible server")
+		case appdetect.DbSqlServer:
+			recommendedServices = append(recommendedServices, "Azure SQL Database")
 		case appdetect.DbMongo:
 			recommendedServices = append(recommendedServices, "Azure CosmosDB API for MongoDB")
 		case appdetect.DbRedis:
@@ -224,6 +236,20 @@ func (d *detectConfirm) render(ctx context.Context) error {
 		d.console.Message(ctx, "")
 	}
 
+	for azureDep, entry := range d.AzureDeps {
+		recommendedServices = append(recommendedServices, azureDep.Display())
+
+		status := ""
+		if entry == EntryKindModified {
+			status = " " + output.WithSuccessFormat("[Updated]")
+		} else if entry == EntryKindManual {
+			status = " " + output.WithSuccessFormat("[Added]")
+		}
+
+		d.console.Message(ctx, "  "+color.BlueString(azureDep.Display())+status)
+		d.console.Message(ctx, "")
+	}
+
 	displayedServices := make([]string, 0, len(recommendedServices))
 	for _, svc := range recommendedServices {
 		displayedServices = append(displayedServices, color.MagentaString(svc))
@@ -239,7 +265,7 @@ func (d *detectConfirm) render(ctx context.Context) error {
 }
 
 func (d *detectConfirm) remove(ctx context.Context) error {
-	modifyOptions := make([]string, 0, len(d.Services)+len(d.Databases))
+	modifyOptions := make([]string, 0, len(d.Services)+len(d.Databases)+len(d.AzureDeps))
 	for _, svc := range d.Services {
 		modifyOptions = append(
 			modifyOptions, fmt.Sprintf("%s in %s", projectDisplayName(svc), relSafe(d.root, svc.Path)))
@@ -250,6 +276,11 @@ func (d *detectConfirm) remove(ctx context.Context) error {
 		modifyOptions = append(modifyOptions, db.Display())
 	}
 
+	displayAzureDeps := slices.Collect(maps.Keys(d.AzureDeps))
+	for _, azureDep := range displayAzureDeps {
+		modifyOptions = append(modifyOptions, azureDep.Display())
+	}
+
 	i, err := d.console.Select(ctx, input.ConsoleOptions{
 		Message: "Select the service you want to remove",
 		Options: modifyOptions,
@@ -304,6 +335,35 @@ func (d *detectConfirm) remove(ctx context.Context) error {
 			}
 		}
 		d.modified = true
+	} else if i < len(d.Services)+len(d.Databases)+len(d.AzureDeps) {
+		azureDep := displayAzureDeps[i-len(d.Services)-len(d.Databases)]
+
+		confirm, err := d.console.Confirm(ctx, input.ConsoleOptions{
+			Message: fmt.Sprintf(
+				"Remove %s?", azureDep.Display()),
+			DefaultValue: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !confirm {
+			return nil
+		}
+
+		delete(d.AzureDeps, azureDep)
+
+		for i := range d.Services {
+			for j, dependency := range d.Services[i].AzureDeps {
+				if dependency == azureDep {
+					d.Services[i].AzureDeps = append(
+						d.Services[i].AzureDeps[:j],
+						d.Services[i].AzureDeps[j+1:]...)
+					d.Services[i].DetectionRule = string(EntryKindModified)
+				}
+			}
+		}
+		d.modified = true
 	}
 
 	return nil
@@ -332,8 +392,19 @@ func (d *detectConfirm) add(ctx context.Context) error {
 		return strings.Compare(a.Display(), b.Display())
 	})
 
-	selections := make([]string, 0, len(languages)+len(frameworks)+len(databases))
-	entries := make([]any, 0, len(languages)+len(frameworks)+len(databases))
+	// only include Azure services not already added
+	azureDeps := make([]appdetect.AzureDep, 0, len(azureDepMap))
+	for azureDep := range azureDepMap {
+		if _, ok := d.AzureDeps[azureDep]; !ok {
+			azureDeps = append(azureDeps, azureDep)
+		}
+	}
+	slices.SortFunc(azureDeps, func(a, b appdetect.AzureDep) int {
+		return strings.Compare(a.Display(), b.Display())
+	})
+
+	selections := make([]string, 0, len(languages)+len(frameworks)+len(databases)+len(azureDeps))
+	entries := make([]any, 0, len(languages)+len(frameworks)+len(databases)+len(azureDeps))
 
 	for _, lang := range languages {
 		selections = append(selections, fmt.Sprintf("%s\t%s", lang.Display(), "[Language]"))
@@ -350,6 +421,11 @@ func (d *detectConfirm) add(ctx context.Context) error {
 		entries = append(entries, db)
 	}
 
+	for _, azureDep := range azureDeps {
+		selections = append(selections, fmt.Sprintf("%s\t%s", azureDep.Display(), "[Azure service]"))
+		entries = append(entries, azureDep)
+	}
+
 	// only apply tab-align if interactive
 	if d.console.IsSpinnerInteractive() {
 		formatted, err := tabWrite(selections, 3)
@@ -361,7 +437,7 @@ func (d *detectConfirm) add(ctx context.Context) error {
 	}
 
 	i, err := d.console.Select(ctx, input.ConsoleOptions{
-		Message: "Select a language or database to add",
+		Message: "Select a language, database or Azure service to add",
 		Options: selections,
 	})
 	if err != nil {
@@ -400,6 +476,28 @@ func (d *detectConfirm) add(ctx context.Context) error {
 		d.Services[idx].DetectionRule = string(EntryKindModified)
 		d.modified = true
 		return nil
+	case appdetect.AzureDep:
+		azureDep := entries[i].(appdetect.AzureDep)
+		d.AzureDeps[azureDep] = EntryKindManual
+
+		svcSelect := make([]string, 0, len(d.Services))
+		for _, svc := range d.Services {
+			svcSelect = append(svcSelect,
+				fmt.Sprintf("%s in %s", projectDisplayName(svc), filepath.Base(svc.Path)))
+		}
+
+		idx, err := d.console.Select(ctx, input.ConsoleOptions{
+			Message: "Select the service that uses this Azure service",
+			Options: svcSelect,
+		})
+		if err != nil {
+			return err
+		}
+
+		d.Services[idx].AzureDeps = append(d.Services[idx].AzureDeps, azureDep)
+		d.Services[idx].DetectionRule = string(EntryKindModified)
+		d.modified = true
+		return nil
 	default:
 		log.Panic("unhandled entry type")
 	}
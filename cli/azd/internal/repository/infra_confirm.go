@@ -90,11 +90,39 @@ func (i *Initializer) infraSpecFromDetect(
 				spec.DbPostgres = &scaffold.DatabasePostgres{
 					DatabaseName: dbName,
 				}
+			case appdetect.DbMySql:
+				if dbName == "" {
+					i.console.Message(ctx, "Database name is required.")
+					continue
+				}
+
+				spec.DbMySql = &scaffold.DatabaseMySql{
+					DatabaseName: dbName,
+				}
+			case appdetect.DbSqlServer:
+				if dbName == "" {
+					i.console.Message(ctx, "Database name is required.")
+					continue
+				}
+
+				spec.DbSqlServer = &scaffold.DatabaseSqlServer{
+					DatabaseName: dbName,
+				}
 			}
 			break dbPrompt
 		}
 	}
 
+	for azureDep := range detect.AzureDeps {
+		// no further configuration needed for Azure services
+		switch azureDep {
+		case appdetect.AzureDepServiceBus:
+			spec.AzureServiceBus = &scaffold.AzureDepServiceBus{}
+		case appdetect.AzureDepStorageAccount:
+			spec.AzureStorageAccount = &scaffold.AzureDepStorageAccount{}
+		}
+	}
+
 	for _, svc := range detect.Services {
 		name := names.LabelName(filepath.Base(svc.Path))
 		serviceSpec := scaffold.ServiceSpec{
@@ -165,12 +193,34 @@ func (i *Initializer) infraSpecFromDetect(
 				serviceSpec.DbPostgres = &scaffold.DatabaseReference{
 					DatabaseName: spec.DbPostgres.DatabaseName,
 				}
+			case appdetect.DbMySql:
+				serviceSpec.DbMySql = &scaffold.DatabaseReference{
+					DatabaseName: spec.DbMySql.DatabaseName,
+				}
+			case appdetect.DbSqlServer:
+				serviceSpec.DbSqlServer = &scaffold.DatabaseReference{
+					DatabaseName: spec.DbSqlServer.DatabaseName,
+				}
 			case appdetect.DbRedis:
 				serviceSpec.DbRedis = &scaffold.DatabaseReference{
 					DatabaseName: "redis",
 				}
 			}
 		}
+
+		for _, azureDep := range svc.AzureDeps {
+			// filter out Azure services that were removed
+			if _, ok := detect.AzureDeps[azureDep]; !ok {
+				continue
+			}
+
+			switch azureDep {
+			case appdetect.AzureDepServiceBus:
+				serviceSpec.AzureServiceBus = &scaffold.AzureDepReference{}
+			case appdetect.AzureDepStorageAccount:
+				serviceSpec.AzureStorageAccount = &scaffold.AzureDepReference{}
+			}
+		}
 		spec.Services = append(spec.Services, serviceSpec)
 	}
 
@@ -197,6 +197,52 @@ func TestInitializer_infraSpecFromDetect(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "api with sql and azure services",
+			detect: detectConfirm{
+				Services: []appdetect.Project{
+					{
+						Language: appdetect.Java,
+						Path:     "java",
+						DatabaseDeps: []appdetect.DatabaseDep{
+							appdetect.DbSqlServer,
+						},
+						AzureDeps: []appdetect.AzureDep{
+							appdetect.AzureDepServiceBus,
+							appdetect.AzureDepStorageAccount,
+						},
+					},
+				},
+				Databases: map[appdetect.DatabaseDep]EntryKind{
+					appdetect.DbSqlServer: EntryKindDetected,
+				},
+				// the storage account was removed during confirmation
+				AzureDeps: map[appdetect.AzureDep]EntryKind{
+					appdetect.AzureDepServiceBus: EntryKindDetected,
+				},
+			},
+			interactions: []string{
+				"", // db name is required
+				"myappdb",
+			},
+			want: scaffold.InfraSpec{
+				DbSqlServer: &scaffold.DatabaseSqlServer{
+					DatabaseName: "myappdb",
+				},
+				AzureServiceBus: &scaffold.AzureDepServiceBus{},
+				Services: []scaffold.ServiceSpec{
+					{
+						Name:    "java",
+						Port:    8080,
+						Backend: &scaffold.Backend{},
+						DbSqlServer: &scaffold.DatabaseReference{
+							DatabaseName: "myappdb",
+						},
+						AzureServiceBus: &scaffold.AzureDepReference{},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -81,7 +81,11 @@ func (i *Initializer) Initialize(
 		return err
 	}
 
-	filesWithExecPerms, err := i.fetchCode(ctx, templateUrl, templateBranch, staging)
+	if templateBranch == "" {
+		templateBranch = template.FetchRef()
+	}
+
+	filesWithExecPerms, commit, err := i.fetchCode(ctx, templateUrl, templateBranch, staging)
 	if err != nil {
 		return err
 	}
@@ -120,6 +124,22 @@ func (i *Initializer) Initialize(
 		return fmt.Errorf("initializing project: %w", err)
 	}
 
+	// The record and the snapshot of the template are the base of `azd template upgrade`
+	if commit != "" {
+		err = SaveTemplateRecord(ctx, azdCtx.ProjectPath(), &TemplateRecord{
+			Repository: templateUrl,
+			Ref:        templateBranch,
+			Commit:     commit,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := SaveTemplateSnapshot(staging, target); err != nil {
+			return err
+		}
+	}
+
 	err = i.gitInitialize(ctx, target, filesWithExecPerms, isEmpty)
 	if err != nil {
 		return err
@@ -134,27 +154,34 @@ func (i *Initializer) fetchCode(
 	ctx context.Context,
 	templateUrl string,
 	templateBranch string,
-	destination string) (executableFilePaths []string, err error) {
+	destination string) (executableFilePaths []string, commit string, err error) {
 	err = i.gitCli.ShallowClone(ctx, templateUrl, templateBranch, destination)
 	if err != nil {
-		return nil, fmt.Errorf("fetching template: %w", err)
+		return nil, "", fmt.Errorf("fetching template: %w", err)
 	}
 
 	stagedFilesOutput, err := i.gitCli.ListStagedFiles(ctx, destination)
 	if err != nil {
-		return nil, fmt.Errorf("listing files with permissions: %w", err)
+		return nil, "", fmt.Errorf("listing files with permissions: %w", err)
 	}
 
 	executableFilePaths, err = parseExecutableFiles(stagedFilesOutput)
 	if err != nil {
-		return nil, fmt.Errorf("parsing file permissions output: %w", err)
+		return nil, "", fmt.Errorf("parsing file permissions output: %w", err)
+	}
+
+	// The commit is only needed to upgrade the project later, the template is still initialized without it
+	commit, err = i.gitCli.RevParse(ctx, destination, "HEAD")
+	if err != nil {
+		log.Printf("resolving the commit of the template: %v", err)
+		commit = ""
 	}
 
 	if err := os.RemoveAll(filepath.Join(destination, ".git")); err != nil {
-		return nil, fmt.Errorf("removing .git folder after clone: %w", err)
+		return nil, "", fmt.Errorf("removing .git folder after clone: %w", err)
 	}
 
-	return executableFilePaths, nil
+	return executableFilePaths, commit, nil
 }
 
 // promptForDuplicates prompts the user for any duplicate files detected.
@@ -0,0 +1,599 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package repository
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/pkg/rzip"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+)
+
+// TemplateSnapshotFileName is the name of the archive of the files of the template at the recorded commit, in the
+// .azure directory of the project. The archive is local to the project, as the .azure directory isn't committed.
+const TemplateSnapshotFileName = "template.zip"
+
+var ErrNoTemplateRecord = errors.New("the project has no record of the template it was initialized from")
+
+// TemplateRecord records the template a project was initialized or last upgraded from, at the commit of the files
+// copied into the project. It is stored in the metadata of the project file, and is the base of the three-way merge
+// of the changes of the template into the project.
+type TemplateRecord struct {
+	// The URL of the repository of the template
+	Repository string
+	// The git ref the template was fetched at, empty for the default branch
+	Ref string
+	// The commit of the files of the template copied into the project
+	Commit string
+}
+
+// LoadTemplateRecord loads the template record from the metadata of the project file, or returns ErrNoTemplateRecord.
+func LoadTemplateRecord(ctx context.Context, projectPath string) (*TemplateRecord, error) {
+	projectConfig, err := project.Load(ctx, projectPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoTemplateRecord
+	} else if err != nil {
+		return nil, err
+	}
+
+	metadata := projectConfig.Metadata
+	if metadata == nil || (metadata.TemplateRepository == "" && metadata.TemplateCommit == "") {
+		return nil, ErrNoTemplateRecord
+	}
+
+	if metadata.TemplateRepository == "" || metadata.TemplateCommit == "" {
+		return nil, fmt.Errorf(
+			"the template of the project requires both metadata.templateRepository and metadata.templateCommit in %s",
+			filepath.Base(projectPath))
+	}
+
+	return &TemplateRecord{
+		Repository: metadata.TemplateRepository,
+		Ref:        metadata.TemplateRef,
+		Commit:     metadata.TemplateCommit,
+	}, nil
+}
+
+// SaveTemplateRecord saves the template record to the metadata of the project file.
+func SaveTemplateRecord(ctx context.Context, projectPath string, record *TemplateRecord) error {
+	projectConfig, err := project.LoadConfig(ctx, projectPath)
+	if err != nil {
+		return fmt.Errorf("loading project config: %w", err)
+	}
+
+	values := []struct {
+		key   string
+		value string
+	}{
+		{"metadata.templateRepository", record.Repository},
+		{"metadata.templateRef", record.Ref},
+		{"metadata.templateCommit", record.Commit},
+	}
+
+	for _, v := range values {
+		if v.value == "" {
+			err = projectConfig.Unset(v.key)
+		} else {
+			err = projectConfig.Set(v.key, v.value)
+		}
+
+		if err != nil {
+			return fmt.Errorf("setting project config: %w", err)
+		}
+	}
+
+	return project.SaveConfig(ctx, projectConfig, projectPath)
+}
+
+// SaveTemplateSnapshot archives the files of the template in the directory to the .azure directory of the project, as
+// the base of the next upgrade.
+func SaveTemplateSnapshot(templateDir string, projectDir string) error {
+	snapshotDir := filepath.Join(projectDir, azdcontext.EnvironmentDirectoryName)
+	if err := os.MkdirAll(snapshotDir, osutil.PermissionDirectory); err != nil {
+		return fmt.Errorf("creating %s: %w", snapshotDir, err)
+	}
+
+	snapshot, err := os.Create(filepath.Join(snapshotDir, TemplateSnapshotFileName))
+	if err != nil {
+		return fmt.Errorf("creating template snapshot: %w", err)
+	}
+	defer snapshot.Close()
+
+	if _, err := rzip.CreateFromDirectory(templateDir, snapshot, nil); err != nil {
+		return fmt.Errorf("creating template snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// extractTemplateSnapshot extracts the snapshot of the template of the project to the directory. False is returned when
+// the project has no snapshot.
+func extractTemplateSnapshot(projectDir string, dir string) (bool, error) {
+	reader, err := zip.OpenReader(
+		filepath.Join(projectDir, azdcontext.EnvironmentDirectoryName, TemplateSnapshotFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("opening template snapshot: %w", err)
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		if !filepath.IsLocal(file.Name) {
+			return false, fmt.Errorf("template snapshot contains an invalid path: %s", file.Name)
+		}
+
+		if err := extractFile(file, filepath.Join(dir, file.Name)); err != nil {
+			return false, fmt.Errorf("extracting template snapshot: %w", err)
+		}
+	}
+
+	return true, nil
+}
+
+func extractFile(file *zip.File, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), osutil.PermissionDirectory); err != nil {
+		return err
+	}
+
+	in, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, osutil.PermissionFile)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+// UpgradeFileStatus is how a file of the project changed with an upgrade.
+type UpgradeFileStatus string
+
+const (
+	// The file was added to the template, and to the project.
+	UpgradeFileAdded UpgradeFileStatus = "added"
+	// The file was unchanged in the project, and is updated to the version of the template.
+	UpgradeFileUpdated UpgradeFileStatus = "updated"
+	// The changes of the template and the project were merged without conflicts.
+	UpgradeFileMerged UpgradeFileStatus = "merged"
+	// The file was deleted from the template, and from the project.
+	UpgradeFileDeleted UpgradeFileStatus = "deleted"
+	// The changes of the template conflict with the changes of the project, and need to be resolved.
+	UpgradeFileConflict UpgradeFileStatus = "conflict"
+)
+
+// UpgradeFile is a file of the project changed or left in conflict by an upgrade.
+type UpgradeFile struct {
+	Path   string            `json:"path"`
+	Status UpgradeFileStatus `json:"status"`
+	// Why the changes conflict, and how they are left in the project
+	Reason string `json:"reason,omitempty"`
+}
+
+// UpgradeReport is the result of an upgrade of a project from its template.
+type UpgradeReport struct {
+	Repository string        `json:"repository"`
+	Ref        string        `json:"ref,omitempty"`
+	FromCommit string        `json:"fromCommit"`
+	ToCommit   string        `json:"toCommit"`
+	Files      []UpgradeFile `json:"files"`
+	Conflicts  int           `json:"conflicts"`
+}
+
+// Upgrader upgrades projects with the changes made to their template since they were initialized.
+type Upgrader struct {
+	gitCli *git.Cli
+}
+
+func NewUpgrader(gitCli *git.Cli) *Upgrader {
+	return &Upgrader{
+		gitCli: gitCli,
+	}
+}
+
+// Upgrade merges the changes of the template of the project, from the recorded commit to the ref, into the project.
+// An empty ref is the recorded ref, the ref the project was initialized or last upgraded from.
+//
+// The changes of files unchanged in the project are applied, and the changes of files changed in the project are
+// merged. Conflicting changes are left with conflict markers, or reported when they can't be marked. Once every file has
+// merged, the record and the snapshot of the template are updated to the new commit, the base of the next upgrade.
+func (u *Upgrader) Upgrade(ctx context.Context, projectDir string, ref string) (*UpgradeReport, error) {
+	projectPath := filepath.Join(projectDir, azdcontext.ProjectFileName)
+	record, err := LoadTemplateRecord(ctx, projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if ref == "" {
+		ref = record.Ref
+	}
+
+	staging, err := os.MkdirTemp("", "az-dev-template-upgrade")
+	if err != nil {
+		return nil, fmt.Errorf("creating temp folder: %w", err)
+	}
+
+	defer func() {
+		_ = os.RemoveAll(staging)
+	}()
+
+	baseDir := filepath.Join(staging, "base")
+	hasSnapshot, err := extractTemplateSnapshot(projectDir, baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// The snapshot isn't committed with the project, fetch the recorded commit in other clones of the project
+	if !hasSnapshot {
+		if err := u.gitCli.ShallowCloneCommit(ctx, record.Repository, record.Commit, baseDir); err != nil {
+			return nil, fmt.Errorf("fetching the template at the recorded commit: %w", err)
+		}
+	}
+
+	targetDir := filepath.Join(staging, "target")
+	if err := u.gitCli.ShallowClone(ctx, record.Repository, ref, targetDir); err != nil {
+		return nil, fmt.Errorf("fetching the template: %w", err)
+	}
+
+	targetCommit, err := u.gitCli.RevParse(ctx, targetDir, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("fetching the template: %w", err)
+	}
+
+	report := &UpgradeReport{
+		Repository: record.Repository,
+		Ref:        ref,
+		FromCommit: record.Commit,
+		ToCommit:   targetCommit,
+		Files:      []UpgradeFile{},
+	}
+
+	if targetCommit == record.Commit {
+		return report, nil
+	}
+
+	if err := os.RemoveAll(filepath.Join(targetDir, ".git")); err != nil {
+		return nil, err
+	}
+
+	baseFiles, err := templateFiles(baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	targetFiles, err := templateFiles(targetDir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{}
+	for path := range baseFiles {
+		paths = append(paths, path)
+	}
+	for path := range targetFiles {
+		if !baseFiles[path] {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+
+	// The project file is merged last, with the new record
+	paths = slices.DeleteFunc(paths, func(path string) bool {
+		return path == azdcontext.ProjectFileName
+	})
+
+	emptyPath := filepath.Join(staging, "empty")
+	if err := os.WriteFile(emptyPath, nil, osutil.PermissionFile); err != nil {
+		return nil, err
+	}
+
+	otherLabel := fmt.Sprintf("template %s", shortCommit(targetCommit))
+	if ref != "" {
+		otherLabel = fmt.Sprintf("template %s", ref)
+	}
+
+	merge := &upgradeMerge{
+		gitCli:     u.gitCli,
+		projectDir: projectDir,
+		baseDir:    baseDir,
+		targetDir:  targetDir,
+		emptyPath:  emptyPath,
+		otherLabel: otherLabel,
+	}
+
+	addFile := func(file *UpgradeFile) {
+		if file == nil {
+			return
+		}
+
+		report.Files = append(report.Files, *file)
+		if file.Status == UpgradeFileConflict {
+			report.Conflicts++
+		}
+	}
+
+	for _, path := range paths {
+		file, err := merge.mergeFile(ctx, path, baseFiles[path], targetFiles[path])
+		if err != nil {
+			return nil, err
+		}
+
+		addFile(file)
+	}
+
+	// Every other file has merged. The record is saved to a copy of the project file, which is then merged, as the merge
+	// can leave conflict markers that keep the project file from loading. The project is only left with the new record
+	// once the copy has merged.
+	stagedProjectDir := filepath.Join(staging, "project")
+	stagedProjectPath := filepath.Join(stagedProjectDir, azdcontext.ProjectFileName)
+	if err := copyProjectFile(projectPath, stagedProjectPath); err != nil {
+		return nil, err
+	}
+
+	record.Ref = ref
+	record.Commit = targetCommit
+	if err := SaveTemplateRecord(ctx, stagedProjectPath, record); err != nil {
+		return nil, err
+	}
+
+	inBase, inTarget := baseFiles[azdcontext.ProjectFileName], targetFiles[azdcontext.ProjectFileName]
+	if inBase || inTarget {
+		merge.projectDir = stagedProjectDir
+		file, err := merge.mergeFile(ctx, azdcontext.ProjectFileName, inBase, inTarget)
+		if err != nil {
+			return nil, err
+		}
+
+		addFile(file)
+		slices.SortFunc(report.Files, func(a, b UpgradeFile) int {
+			return strings.Compare(a.Path, b.Path)
+		})
+	}
+
+	if err := SaveTemplateSnapshot(targetDir, projectDir); err != nil {
+		return nil, err
+	}
+
+	if err := copyProjectFile(stagedProjectPath, projectPath); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// copyProjectFile copies the project file, keeping its permissions.
+func copyProjectFile(from string, to string) error {
+	content, err := os.ReadFile(from)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", azdcontext.ProjectFileName, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(to), osutil.PermissionDirectory); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(to, content, fileMode(from)); err != nil {
+		return fmt.Errorf("writing %s: %w", azdcontext.ProjectFileName, err)
+	}
+
+	return nil
+}
+
+// upgradeMerge merges the changes of a template, from the base directory to the target directory, into a project.
+type upgradeMerge struct {
+	gitCli     *git.Cli
+	projectDir string
+	baseDir    string
+	targetDir  string
+	// An empty file, the base of the files added to the template and to the project
+	emptyPath  string
+	otherLabel string
+}
+
+// mergeFile merges the changes of the file, a path relative to the directories, into the project. A nil file is returned
+// when the project is unchanged.
+func (m *upgradeMerge) mergeFile(ctx context.Context, path string, inBase bool, inTarget bool) (*UpgradeFile, error) {
+	basePath := filepath.Join(m.baseDir, path)
+	targetPath := filepath.Join(m.targetDir, path)
+	projectPath := filepath.Join(m.projectDir, path)
+
+	base, err := readOptionalFile(basePath, inBase)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := readOptionalFile(targetPath, inTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	// The file is unchanged in the template
+	if inBase && inTarget && bytes.Equal(base, target) {
+		return nil, nil
+	}
+
+	current, err := os.ReadFile(projectPath)
+	inProject := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	slashPath := filepath.ToSlash(path)
+
+	switch {
+	// The file was deleted from the template
+	case !inTarget:
+		if !inProject {
+			return nil, nil
+		}
+
+		if !bytes.Equal(current, base) {
+			return &UpgradeFile{
+				Path:   slashPath,
+				Status: UpgradeFileConflict,
+				Reason: "the file was deleted from the template but changed in the project, it is kept",
+			}, nil
+		}
+
+		if err := os.Remove(projectPath); err != nil {
+			return nil, fmt.Errorf("deleting %s: %w", path, err)
+		}
+
+		return &UpgradeFile{Path: slashPath, Status: UpgradeFileDeleted}, nil
+
+	// The file was added to the template
+	case !inBase && !inProject:
+		if err := copyTemplateFile(targetPath, projectPath, target); err != nil {
+			return nil, err
+		}
+
+		return &UpgradeFile{Path: slashPath, Status: UpgradeFileAdded}, nil
+
+	// The file was changed in the template, but deleted from the project
+	case !inProject:
+		return &UpgradeFile{
+			Path:   slashPath,
+			Status: UpgradeFileConflict,
+			Reason: "the file was changed in the template but deleted from the project, it is not restored",
+		}, nil
+
+	// The project already has the changes of the template
+	case bytes.Equal(current, target):
+		return nil, nil
+
+	// The file is unchanged in the project
+	case inBase && bytes.Equal(current, base):
+		if err := copyTemplateFile(targetPath, projectPath, target); err != nil {
+			return nil, err
+		}
+
+		return &UpgradeFile{Path: slashPath, Status: UpgradeFileUpdated}, nil
+	}
+
+	// The file was changed in both the template and the project, or added to both with different contents
+	if isBinary(current) || isBinary(target) || isBinary(base) {
+		return &UpgradeFile{
+			Path:   slashPath,
+			Status: UpgradeFileConflict,
+			Reason: "the binary file was changed in both the template and the project, the project version is kept",
+		}, nil
+	}
+
+	if !inBase {
+		basePath = m.emptyPath
+	}
+
+	merged, conflicts, err := m.gitCli.MergeFile(ctx, projectPath, basePath, targetPath, "project", m.otherLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(projectPath, []byte(merged), fileMode(projectPath)); err != nil {
+		return nil, fmt.Errorf("writing %s: %w", path, err)
+	}
+
+	if conflicts > 0 {
+		return &UpgradeFile{
+			Path:   slashPath,
+			Status: UpgradeFileConflict,
+			Reason: fmt.Sprintf("%d conflicting change(s) are marked in the file", conflicts),
+		}, nil
+	}
+
+	return &UpgradeFile{Path: slashPath, Status: UpgradeFileMerged}, nil
+}
+
+// templateFiles returns the relative paths of the files of the template in the directory.
+func templateFiles(dir string) (map[string]bool, error) {
+	files := map[string]bool{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		files[rel] = true
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing template files: %w", err)
+	}
+
+	return files, nil
+}
+
+func readOptionalFile(path string, exists bool) ([]byte, error) {
+	if !exists {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading template file: %w", err)
+	}
+
+	return content, nil
+}
+
+// copyTemplateFile copies the content of the template file to the project, with the permissions of the template file.
+func copyTemplateFile(templatePath string, projectPath string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(projectPath), osutil.PermissionDirectory); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(projectPath, content, fileMode(templatePath)); err != nil {
+		return fmt.Errorf("writing %s: %w", projectPath, err)
+	}
+
+	return nil
+}
+
+func fileMode(path string) fs.FileMode {
+	if info, err := os.Stat(path); err == nil {
+		return info.Mode().Perm()
+	}
+
+	return osutil.PermissionFile
+}
+
+// isBinary returns true for contents git considers binary, which contain a NUL byte in their first 8000 bytes.
+func isBinary(content []byte) bool {
+	return bytes.IndexByte(content[:min(len(content), 8000)], 0) != -1
+}
+
+func shortCommit(commit string) string {
+	return commit[:min(len(commit), 7)]
+}
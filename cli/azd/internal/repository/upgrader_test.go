@@ -0,0 +1,189 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Upgrader_Upgrade(t *testing.T) {
+	ctx := context.Background()
+	runner := exec.NewCommandRunner(nil)
+	templateDir := t.TempDir()
+	projectDir := t.TempDir()
+
+	runGit(t, runner, templateDir, "init", "--quiet")
+	writeFiles(t, templateDir, map[string]string{
+		"README.md": "hello\n",
+		"app.txt":   "line1\nline2\nline3\n",
+		"conf.txt":  "a\n",
+		"keep.txt":  "keep\n",
+		"old.txt":   "old\n",
+	})
+	baseCommit := commitAll(t, runner, templateDir, "v1")
+
+	// The project is initialized from the first version of the template, then changed
+	writeFiles(t, projectDir, map[string]string{
+		"README.md":  "hello\n",
+		"app.txt":    "line1 project\nline2\nline3\n",
+		"conf.txt":   "project\n",
+		"keep.txt":   "keep\n",
+		"old.txt":    "old\n",
+		"azure.yaml": "name: app\n",
+	})
+	projectPath := filepath.Join(projectDir, "azure.yaml")
+	require.NoError(t, SaveTemplateRecord(ctx, projectPath, &TemplateRecord{Repository: templateDir, Commit: baseCommit}))
+
+	writeFiles(t, templateDir, map[string]string{
+		"README.md": "hello v2\n",
+		"app.txt":   "line1\nline2\nline3 template\n",
+		"conf.txt":  "template\n",
+		"new.txt":   "new\n",
+	})
+	require.NoError(t, os.Remove(filepath.Join(templateDir, "old.txt")))
+	targetCommit := commitAll(t, runner, templateDir, "v2")
+
+	upgrader := NewUpgrader(git.NewCli(runner))
+	report, err := upgrader.Upgrade(ctx, projectDir, "")
+	require.NoError(t, err)
+
+	require.Equal(t, baseCommit, report.FromCommit)
+	require.Equal(t, targetCommit, report.ToCommit)
+	require.Equal(t, 1, report.Conflicts)
+	require.Equal(t, []UpgradeFile{
+		{Path: "README.md", Status: UpgradeFileUpdated},
+		{Path: "app.txt", Status: UpgradeFileMerged},
+		{Path: "conf.txt", Status: UpgradeFileConflict, Reason: "1 conflicting change(s) are marked in the file"},
+		{Path: "new.txt", Status: UpgradeFileAdded},
+		{Path: "old.txt", Status: UpgradeFileDeleted},
+	}, report.Files)
+
+	require.Equal(t, "hello v2\n", readFile(t, filepath.Join(projectDir, "README.md")))
+	require.Equal(t, "line1 project\nline2\nline3 template\n", readFile(t, filepath.Join(projectDir, "app.txt")))
+	require.Equal(t, "new\n", readFile(t, filepath.Join(projectDir, "new.txt")))
+	require.NoFileExists(t, filepath.Join(projectDir, "old.txt"))
+
+	conflict := readFile(t, filepath.Join(projectDir, "conf.txt"))
+	require.Contains(t, conflict, "<<<<<<< project\nproject\n=======\ntemplate\n>>>>>>> template "+targetCommit[:7])
+
+	record, err := LoadTemplateRecord(ctx, projectPath)
+	require.NoError(t, err)
+	require.Equal(t, targetCommit, record.Commit)
+	require.FileExists(t, filepath.Join(projectDir, ".azure", TemplateSnapshotFileName))
+
+	// The project is now up to date
+	report, err = upgrader.Upgrade(ctx, projectDir, "")
+	require.NoError(t, err)
+	require.Empty(t, report.Files)
+	require.Equal(t, report.FromCommit, report.ToCommit)
+}
+
+func Test_Upgrader_Upgrade_FromSnapshot(t *testing.T) {
+	ctx := context.Background()
+	runner := exec.NewCommandRunner(nil)
+	templateDir := t.TempDir()
+	snapshotDir := t.TempDir()
+	projectDir := t.TempDir()
+
+	runGit(t, runner, templateDir, "init", "--quiet")
+	writeFiles(t, templateDir, map[string]string{"app.txt": "v1\n"})
+	commitAll(t, runner, templateDir, "v1")
+	runGit(t, runner, templateDir, "checkout", "--quiet", "-b", "release")
+	writeFiles(t, templateDir, map[string]string{"app.txt": "release\n"})
+	releaseCommit := commitAll(t, runner, templateDir, "release")
+	runGit(t, runner, templateDir, "checkout", "--quiet", "-")
+	writeFiles(t, templateDir, map[string]string{"app.txt": "main\n"})
+	commitAll(t, runner, templateDir, "main")
+
+	// The recorded commit can't be fetched, the base of the upgrade is the snapshot of the template
+	writeFiles(t, snapshotDir, map[string]string{"app.txt": "v1\n"})
+	require.NoError(t, SaveTemplateSnapshot(snapshotDir, projectDir))
+	writeFiles(t, projectDir, map[string]string{
+		"app.txt":    "v1\n",
+		"azure.yaml": "name: app\n",
+	})
+	require.NoError(t, SaveTemplateRecord(ctx, filepath.Join(projectDir, "azure.yaml"), &TemplateRecord{
+		Repository: templateDir,
+		Ref:        "release",
+		Commit:     "0000000000000000000000000000000000000000",
+	}))
+
+	// The ref defaults to the recorded ref
+	upgrader := NewUpgrader(git.NewCli(runner))
+	report, err := upgrader.Upgrade(ctx, projectDir, "")
+	require.NoError(t, err)
+	require.Equal(t, "release", report.Ref)
+	require.Equal(t, releaseCommit, report.ToCommit)
+	require.Equal(t, []UpgradeFile{{Path: "app.txt", Status: UpgradeFileUpdated}}, report.Files)
+	require.Equal(t, "release\n", readFile(t, filepath.Join(projectDir, "app.txt")))
+}
+
+func Test_Upgrader_Upgrade_MergeFails(t *testing.T) {
+	ctx := context.Background()
+	runner := exec.NewCommandRunner(nil)
+	templateDir := t.TempDir()
+	projectDir := t.TempDir()
+
+	runGit(t, runner, templateDir, "init", "--quiet")
+	writeFiles(t, templateDir, map[string]string{"app.txt": "v1\n"})
+	baseCommit := commitAll(t, runner, templateDir, "v1")
+
+	writeFiles(t, projectDir, map[string]string{
+		"app.txt":    "v1\n",
+		"azure.yaml": "name: app\n",
+	})
+	projectPath := filepath.Join(projectDir, "azure.yaml")
+	require.NoError(t, SaveTemplateRecord(ctx, projectPath, &TemplateRecord{Repository: templateDir, Commit: baseCommit}))
+
+	// The template adds a file where the project has a directory, which can't be merged
+	require.NoError(t, os.Mkdir(filepath.Join(projectDir, "data"), osutil.PermissionDirectory))
+	writeFiles(t, templateDir, map[string]string{"data": "data\n"})
+	commitAll(t, runner, templateDir, "v2")
+
+	upgrader := NewUpgrader(git.NewCli(runner))
+	_, err := upgrader.Upgrade(ctx, projectDir, "")
+	require.Error(t, err)
+
+	// The project is still recorded at the old commit, without a snapshot of the new one
+	record, err := LoadTemplateRecord(ctx, projectPath)
+	require.NoError(t, err)
+	require.Equal(t, baseCommit, record.Commit)
+	require.NoFileExists(t, filepath.Join(projectDir, ".azure", TemplateSnapshotFileName))
+}
+
+func Test_Upgrader_Upgrade_NoRecord(t *testing.T) {
+	upgrader := NewUpgrader(git.NewCli(exec.NewCommandRunner(nil)))
+	_, err := upgrader.Upgrade(context.Background(), t.TempDir(), "")
+	require.ErrorIs(t, err, ErrNoTemplateRecord)
+}
+
+func runGit(t *testing.T, runner exec.CommandRunner, dir string, args ...string) string {
+	args = append([]string{"-C", dir, "-c", "user.name=azd", "-c", "user.email=azd@example.com"}, args...)
+	res, err := runner.Run(context.Background(), exec.NewRunArgs("git", args...))
+	require.NoError(t, err)
+
+	return strings.TrimSpace(res.Stdout)
+}
+
+func commitAll(t *testing.T, runner exec.CommandRunner, dir string, message string) string {
+	runGit(t, runner, dir, "add", "--all")
+	runGit(t, runner, dir, "commit", "--quiet", "-m", message)
+
+	return runGit(t, runner, dir, "rev-parse", "HEAD")
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), osutil.PermissionFile))
+	}
+}
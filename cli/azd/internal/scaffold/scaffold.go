@@ -134,8 +134,8 @@ func ExecInfra(
 }
 
 func preExecExpand(spec *InfraSpec) {
-	// postgres requires specific password seeding parameters
-	if spec.DbPostgres != nil {
+	// postgres, mysql and sql server require specific password seeding parameters
+	if spec.DbPostgres != nil || spec.DbMySql != nil || spec.DbSqlServer != nil {
 		spec.Parameters = append(spec.Parameters,
 			Parameter{
 				Name:   "databasePassword",
@@ -150,6 +150,6 @@ func preExecExpand(spec *InfraSpec) {
 		spec.Parameters = append(spec.Parameters,
 			containerAppExistsParameter(svc.Name))
 		spec.Parameters = append(spec.Parameters,
-			serviceDefPlaceholder(svc.Name))
+			serviceDefinition(svc))
 	}
 }
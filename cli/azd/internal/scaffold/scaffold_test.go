@@ -35,6 +35,21 @@ func TestExecInfra(t *testing.T) {
 				},
 			},
 		},
+		{
+			"API with environment variables",
+			InfraSpec{
+				Services: []ServiceSpec{
+					{
+						Name: "api",
+						Port: 3100,
+						Env: map[string]string{
+							"LOG_LEVEL": "debug",
+							"API_KEY":   "${API_KEY}",
+						},
+					},
+				},
+			},
+		},
 		{
 			"Web only",
 			InfraSpec{
@@ -85,7 +100,15 @@ func TestExecInfra(t *testing.T) {
 				DbCosmosMongo: &DatabaseCosmosMongo{
 					DatabaseName: "appdb",
 				},
-				DbRedis: &DatabaseRedis{},
+				DbMySql: &DatabaseMySql{
+					DatabaseName: "appdb",
+				},
+				DbSqlServer: &DatabaseSqlServer{
+					DatabaseName: "appdb",
+				},
+				DbRedis:             &DatabaseRedis{},
+				AzureServiceBus:     &AzureDepServiceBus{},
+				AzureStorageAccount: &AzureDepStorageAccount{},
 				Services: []ServiceSpec{
 					{
 						Name: "api",
@@ -106,6 +129,14 @@ func TestExecInfra(t *testing.T) {
 						DbPostgres: &DatabaseReference{
 							DatabaseName: "appdb",
 						},
+						DbMySql: &DatabaseReference{
+							DatabaseName: "appdb",
+						},
+						DbSqlServer: &DatabaseReference{
+							DatabaseName: "appdb",
+						},
+						AzureServiceBus:     &AzureDepReference{},
+						AzureStorageAccount: &AzureDepReference{},
 					},
 					{
 						Name: "web",
@@ -156,6 +187,55 @@ func TestExecInfra(t *testing.T) {
 				},
 			},
 		},
+		{
+			"API with MySQL",
+			InfraSpec{
+				DbMySql: &DatabaseMySql{
+					DatabaseName: "appdb",
+				},
+				Services: []ServiceSpec{
+					{
+						Name: "api",
+						Port: 3100,
+						DbMySql: &DatabaseReference{
+							DatabaseName: "appdb",
+						},
+					},
+				},
+			},
+		},
+		{
+			"API with SQL Server",
+			InfraSpec{
+				DbSqlServer: &DatabaseSqlServer{
+					DatabaseName: "appdb",
+				},
+				Services: []ServiceSpec{
+					{
+						Name: "api",
+						Port: 3100,
+						DbSqlServer: &DatabaseReference{
+							DatabaseName: "appdb",
+						},
+					},
+				},
+			},
+		},
+		{
+			"API with Service Bus and Storage",
+			InfraSpec{
+				AzureServiceBus:     &AzureDepServiceBus{},
+				AzureStorageAccount: &AzureDepStorageAccount{},
+				Services: []ServiceSpec{
+					{
+						Name:                "api",
+						Port:                3100,
+						AzureServiceBus:     &AzureDepReference{},
+						AzureStorageAccount: &AzureDepReference{},
+					},
+				},
+			},
+		},
 		{
 			"API with Redis",
 			InfraSpec{
@@ -2,6 +2,8 @@ package scaffold
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 )
 
@@ -11,8 +13,14 @@ type InfraSpec struct {
 
 	// Databases to create
 	DbPostgres    *DatabasePostgres
+	DbMySql       *DatabaseMySql
+	DbSqlServer   *DatabaseSqlServer
 	DbCosmosMongo *DatabaseCosmosMongo
 	DbRedis       *DatabaseRedis
+
+	// Azure services to create
+	AzureServiceBus     *AzureDepServiceBus
+	AzureStorageAccount *AzureDepStorageAccount
 }
 
 type Parameter struct {
@@ -27,6 +35,14 @@ type DatabasePostgres struct {
 	DatabaseName string
 }
 
+type DatabaseMySql struct {
+	DatabaseName string
+}
+
+type DatabaseSqlServer struct {
+	DatabaseName string
+}
+
 type DatabaseCosmosMongo struct {
 	DatabaseName string
 }
@@ -34,10 +50,19 @@ type DatabaseCosmosMongo struct {
 type DatabaseRedis struct {
 }
 
+type AzureDepServiceBus struct {
+}
+
+type AzureDepStorageAccount struct {
+}
+
 type ServiceSpec struct {
 	Name string
 	Port int
 
+	// Environment variables of the service. When empty, placeholders are scaffolded for the user to fill in.
+	Env map[string]string
+
 	// Front-end properties.
 	Frontend *Frontend
 
@@ -46,8 +71,14 @@ type ServiceSpec struct {
 
 	// Connection to a database
 	DbPostgres    *DatabaseReference
+	DbMySql       *DatabaseReference
+	DbSqlServer   *DatabaseReference
 	DbCosmosMongo *DatabaseReference
 	DbRedis       *DatabaseReference
+
+	// Connection to an Azure service
+	AzureServiceBus     *AzureDepReference
+	AzureStorageAccount *AzureDepReference
 }
 
 type Frontend struct {
@@ -66,6 +97,9 @@ type DatabaseReference struct {
 	DatabaseName string
 }
 
+type AzureDepReference struct {
+}
+
 func containerAppExistsParameter(serviceName string) Parameter {
 	return Parameter{
 		Name: BicepName(serviceName) + "Exists",
@@ -114,3 +148,25 @@ func serviceDefPlaceholder(serviceName string) Parameter {
 		Secret: true,
 	}
 }
+
+// serviceDefinition returns the definition parameter of the service, with the settings of its environment variables.
+func serviceDefinition(svc ServiceSpec) Parameter {
+	if len(svc.Env) == 0 {
+		return serviceDefPlaceholder(svc.Name)
+	}
+
+	settings := make([]serviceDefSettings, 0, len(svc.Env))
+	for _, name := range slices.Sorted(maps.Keys(svc.Env)) {
+		settings = append(settings, serviceDefSettings{
+			Name:  name,
+			Value: svc.Env[name],
+		})
+	}
+
+	return Parameter{
+		Name:   BicepName(svc.Name) + "Definition",
+		Value:  serviceDef{Settings: settings},
+		Type:   "object",
+		Secret: true,
+	}
+}
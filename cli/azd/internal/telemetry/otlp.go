@@ -0,0 +1,204 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// The user configuration keys of the OTLP endpoint azd exports its traces to, i.e.
+// `azd config set telemetry.otlp.endpoint http://localhost:4317`
+const (
+	otlpEndpointConfigKey = "telemetry.otlp.endpoint"
+	otlpProtocolConfigKey = "telemetry.otlp.protocol"
+	otlpHeadersConfigKey  = "telemetry.otlp.headers"
+)
+
+// The protocols of the OTLP exporter, as defined by the OpenTelemetry specification for OTEL_EXPORTER_OTLP_PROTOCOL
+const (
+	otlpProtocolGrpc = "grpc"
+	otlpProtocolHttp = "http/protobuf"
+)
+
+// The default ports of the OTLP receivers of a collector, by protocol, for the endpoints without scheme
+var otlpDefaultPorts = map[string]string{
+	otlpProtocolGrpc: "4317",
+	otlpProtocolHttp: "4318",
+}
+
+// The default ports of the schemes of the endpoints
+var schemeDefaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// otlpConfig is the configuration of the exporter sending the traces of azd to an OTLP endpoint
+type otlpConfig struct {
+	// The endpoint of the collector. Only the host and port are used for gRPC.
+	endpoint *url.URL
+	// The path the traces are posted to, for HTTP
+	urlPath  string
+	protocol string
+	headers  map[string]string
+}
+
+// loadOtlpConfig loads the OTLP exporter configuration from the standard OTEL_EXPORTER_OTLP_* environment variables, or
+// from the user configuration when they're not set. Returns nil when no endpoint is configured.
+func loadOtlpConfig(userConfig config.Config, getenv func(string) string) (*otlpConfig, error) {
+	// The value of a setting, from the traces specific environment variable, the generic environment variable, and
+	// then the user configuration. isTraces is true when the value comes from the traces specific variable.
+	setting := func(name string, configKey string) (value string, isTraces bool) {
+		if value := getenv("OTEL_EXPORTER_OTLP_TRACES_" + name); value != "" {
+			return value, true
+		}
+
+		if value := getenv("OTEL_EXPORTER_OTLP_" + name); value != "" {
+			return value, false
+		}
+
+		if configKey != "" {
+			value, _ = userConfig.GetString(configKey)
+		}
+
+		return value, false
+	}
+
+	endpoint, isTracesEndpoint := setting("ENDPOINT", otlpEndpointConfigKey)
+	if endpoint == "" {
+		return nil, nil
+	}
+
+	protocol, _ := setting("PROTOCOL", otlpProtocolConfigKey)
+	switch protocol {
+	case "":
+		protocol = otlpProtocolHttp
+	case otlpProtocolGrpc, otlpProtocolHttp:
+	default:
+		return nil, fmt.Errorf(
+			"unsupported OTLP protocol '%s', the supported protocols are '%s' and '%s'",
+			protocol,
+			otlpProtocolGrpc,
+			otlpProtocolHttp,
+		)
+	}
+
+	// As a convenience, an endpoint without scheme, like localhost:4317, is an insecure endpoint
+	hasScheme := strings.Contains(endpoint, "://")
+	if !hasScheme {
+		endpoint = "http://" + endpoint
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OTLP endpoint: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported OTLP endpoint scheme '%s', only http and https are supported", u.Scheme)
+	}
+
+	// An endpoint with a scheme, like https://collector.contoso.com, uses the default port of the scheme
+	if u.Port() == "" && hasScheme {
+		u.Host = net.JoinHostPort(u.Hostname(), schemeDefaultPorts[u.Scheme])
+	} else if u.Port() == "" {
+		u.Host = net.JoinHostPort(u.Hostname(), otlpDefaultPorts[protocol])
+	}
+
+	// The generic endpoint is the base URL of the collector, while the traces specific endpoint is the full URL
+	urlPath := u.Path
+	if !isTracesEndpoint || urlPath == "" {
+		urlPath = strings.TrimSuffix(urlPath, "/") + "/v1/traces"
+	}
+
+	headers := map[string]string{}
+	if rawHeaders, _ := setting("HEADERS", ""); rawHeaders != "" {
+		headers, err = parseOtlpHeaders(rawHeaders)
+		if err != nil {
+			return nil, err
+		}
+	} else if configHeaders, has := userConfig.GetMap(otlpHeadersConfigKey); has {
+		for key, value := range configHeaders {
+			headers[key] = fmt.Sprint(value)
+		}
+	}
+
+	return &otlpConfig{
+		endpoint: u,
+		urlPath:  urlPath,
+		protocol: protocol,
+		headers:  headers,
+	}, nil
+}
+
+// parseOtlpHeaders parses the headers of OTEL_EXPORTER_OTLP_HEADERS, a comma separated list of URL encoded key=value
+// pairs, like api-key=secret,tenant=contoso
+func parseOtlpHeaders(rawHeaders string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(rawHeaders, ",") {
+		key, value, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid OTLP header '%s', headers must be key=value pairs", pair)
+		}
+
+		key, keyErr := url.QueryUnescape(strings.TrimSpace(key))
+		value, valueErr := url.QueryUnescape(strings.TrimSpace(value))
+		if err := errors.Join(keyErr, valueErr); err != nil {
+			return nil, fmt.Errorf("invalid OTLP header '%s': %w", pair, err)
+		}
+
+		headers[key] = value
+	}
+
+	return headers, nil
+}
+
+// newOtlpExporter creates the exporter sending spans to the OTLP endpoint
+func newOtlpExporter(ctx context.Context, cfg *otlpConfig) (trace.SpanExporter, error) {
+	insecureEndpoint := cfg.endpoint.Scheme == "http"
+
+	if cfg.protocol == otlpProtocolGrpc {
+		options := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(cfg.endpoint.Host),
+			otlptracegrpc.WithHeaders(cfg.headers),
+		}
+
+		if insecureEndpoint {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+
+		exporter, err := otlptracegrpc.New(ctx, options...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create grpc trace exporter: %w", err)
+		}
+
+		return exporter, nil
+	}
+
+	options := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(cfg.endpoint.Host),
+		otlptracehttp.WithURLPath(cfg.urlPath),
+		otlptracehttp.WithHeaders(cfg.headers),
+	}
+
+	if insecureEndpoint {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http trace exporter: %w", err)
+	}
+
+	return exporter, nil
+}
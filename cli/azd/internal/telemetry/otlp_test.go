@@ -0,0 +1,201 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestLoadOtlpConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          map[string]string
+		userConfig   map[string]any
+		wantNil      bool
+		wantHost     string
+		wantScheme   string
+		wantPath     string
+		wantProtocol string
+		wantHeaders  map[string]string
+	}{
+		{
+			name:    "NotConfigured",
+			wantNil: true,
+		},
+		{
+			name: "EnvDefaultHttp",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "localhost",
+			},
+			wantHost:     "localhost:4318",
+			wantScheme:   "http",
+			wantPath:     "/v1/traces",
+			wantProtocol: otlpProtocolHttp,
+			wantHeaders:  map[string]string{},
+		},
+		{
+			name: "EnvGrpcWithoutScheme",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "collector",
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc",
+				"OTEL_EXPORTER_OTLP_HEADERS":  "api-key=secret,x-tenant=contoso%20ltd",
+			},
+			wantHost:     "collector:4317",
+			wantScheme:   "http",
+			wantPath:     "/v1/traces",
+			wantProtocol: otlpProtocolGrpc,
+			wantHeaders:  map[string]string{"api-key": "secret", "x-tenant": "contoso ltd"},
+		},
+		{
+			name: "TracesEndpointIsUsedAsIs",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT":        "http://ignored:4318",
+				"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "https://collector.contoso.com/otlp/traces",
+			},
+			wantHost:     "collector.contoso.com:443",
+			wantScheme:   "https",
+			wantPath:     "/otlp/traces",
+			wantProtocol: otlpProtocolHttp,
+			wantHeaders:  map[string]string{},
+		},
+		{
+			name: "UserConfig",
+			userConfig: map[string]any{
+				"telemetry": map[string]any{
+					"otlp": map[string]any{
+						"endpoint": "https://collector.contoso.com:4317",
+						"protocol": "grpc",
+						"headers": map[string]any{
+							"api-key": "secret",
+						},
+					},
+				},
+			},
+			wantHost:     "collector.contoso.com:4317",
+			wantScheme:   "https",
+			wantPath:     "/v1/traces",
+			wantProtocol: otlpProtocolGrpc,
+			wantHeaders:  map[string]string{"api-key": "secret"},
+		},
+		{
+			name: "SchemeDefaultPort",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "https://collector.example.com",
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc",
+			},
+			wantHost:     "collector.example.com:443",
+			wantScheme:   "https",
+			wantPath:     "/v1/traces",
+			wantProtocol: otlpProtocolGrpc,
+			wantHeaders:  map[string]string{},
+		},
+		{
+			name: "EnvOverridesUserConfig",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318",
+			},
+			userConfig: map[string]any{
+				"telemetry": map[string]any{
+					"otlp": map[string]any{
+						"endpoint": "https://collector.contoso.com:4317",
+					},
+				},
+			},
+			wantHost:     "localhost:4318",
+			wantScheme:   "http",
+			wantPath:     "/v1/traces",
+			wantProtocol: otlpProtocolHttp,
+			wantHeaders:  map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := loadOtlpConfig(config.NewConfig(tt.userConfig), func(name string) string {
+				return tt.env[name]
+			})
+			require.NoError(t, err)
+
+			if tt.wantNil {
+				require.Nil(t, cfg)
+				return
+			}
+
+			require.NotNil(t, cfg)
+			require.Equal(t, tt.wantHost, cfg.endpoint.Host)
+			require.Equal(t, tt.wantScheme, cfg.endpoint.Scheme)
+			require.Equal(t, tt.wantPath, cfg.urlPath)
+			require.Equal(t, tt.wantProtocol, cfg.protocol)
+			require.Equal(t, tt.wantHeaders, cfg.headers)
+		})
+	}
+
+	t.Run("InvalidProtocol", func(t *testing.T) {
+		_, err := loadOtlpConfig(config.NewEmptyConfig(), func(name string) string {
+			return map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost",
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "http/json",
+			}[name]
+		})
+		require.ErrorContains(t, err, "unsupported OTLP protocol")
+	})
+
+	t.Run("InvalidHeaders", func(t *testing.T) {
+		_, err := loadOtlpConfig(config.NewEmptyConfig(), func(name string) string {
+			return map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost",
+				"OTEL_EXPORTER_OTLP_HEADERS":  "api-key",
+			}[name]
+		})
+		require.ErrorContains(t, err, "invalid OTLP header")
+	})
+}
+
+// The spans are exported to a local stand-in of the OTLP HTTP receiver of a collector
+func TestOtlpHttpExporter(t *testing.T) {
+	var mu sync.Mutex
+	requests := []*http.Request{}
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	cfg, err := loadOtlpConfig(config.NewEmptyConfig(), func(name string) string {
+		return map[string]string{
+			"OTEL_EXPORTER_OTLP_ENDPOINT": collector.URL,
+			"OTEL_EXPORTER_OTLP_HEADERS":  "api-key=secret",
+		}[name]
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	exporter, err := newOtlpExporter(ctx, cfg)
+	require.NoError(t, err)
+
+	tp := trace.NewTracerProvider(trace.WithSyncer(exporter))
+	_, span := tp.Tracer("azd").Start(ctx, "cmd.deploy")
+	span.End()
+	require.NoError(t, tp.Shutdown(ctx))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, requests, 1)
+	require.Equal(t, http.MethodPost, requests[0].Method)
+	require.Equal(t, "/v1/traces", requests[0].URL.Path)
+	require.Equal(t, "secret", requests[0].Header.Get("api-key"))
+	require.True(t, strings.HasPrefix(requests[0].Header.Get("Content-Type"), "application/x-protobuf"))
+}
@@ -94,10 +94,43 @@ func GetTelemetrySystem() *TelemetrySystem {
 	return instance
 }
 
+// loadOtlpExporter creates the exporter of the OTLP endpoint configured by the user, or returns nil when there is none.
+// An invalid configuration only drops the OTLP exporter, with a warning, so the other exporters keep working.
+func loadOtlpExporter() trace.SpanExporter {
+	otlpConfig, err := loadOtlpConfig(loadUserConfig(), os.Getenv)
+	if err != nil {
+		log.Printf("warning: traces are not exported to the OTLP endpoint, its configuration is invalid: %v", err)
+		return nil
+	} else if otlpConfig == nil {
+		return nil
+	}
+
+	otlpExporter, err := newOtlpExporter(context.Background(), otlpConfig)
+	if err != nil {
+		log.Printf("warning: traces are not exported to the OTLP endpoint: %v", err)
+		return nil
+	}
+
+	return otlpExporter
+}
+
 func initialize() (*TelemetrySystem, error) {
+	otlpExporter := loadOtlpExporter()
+
 	if !IsTelemetryEnabled() {
-		log.Println("telemetry is disabled by user and will not be initialized.")
-		return nil, nil
+		if otlpExporter == nil {
+			log.Println("telemetry is disabled by user and will not be initialized.")
+			return nil, nil
+		}
+
+		// The traces are still sent to the OTLP endpoint configured by the user, but not to Microsoft
+		log.Println("telemetry is disabled by user, traces are only exported to the configured OTLP endpoint.")
+		tp := trace.NewTracerProvider(trace.WithBatcher(otlpExporter), trace.WithResource(resource.New()))
+		otel.SetTracerProvider(tp)
+
+		return &TelemetrySystem{
+			tracerProvider: tp,
+		}, nil
 	}
 
 	appinsightsexporter.SetListener(func(msg string) {
@@ -187,6 +220,10 @@ func initialize() (*TelemetrySystem, error) {
 		options = append(options, trace.WithBatcher(httpExporter))
 	}
 
+	if otlpExporter != nil {
+		options = append(options, trace.WithBatcher(otlpExporter))
+	}
+
 	tp := trace.NewTracerProvider(options...)
 
 	otel.SetTracerProvider(tp)
@@ -219,7 +256,8 @@ func (ts *TelemetrySystem) GetTelemetryQueue() Queue {
 
 // Returns true if any telemetry was emitted.
 func (ts *TelemetrySystem) EmittedAnyTelemetry() bool {
-	return ts.exporter.ExportedAny()
+	// Without exporter, the traces are only exported to the OTLP endpoint of the user and there is nothing to upload
+	return ts.exporter != nil && ts.exporter.ExportedAny()
 }
 
 func (ts *TelemetrySystem) NewUploader(enableDebugLogging bool) Uploader {
@@ -230,6 +268,10 @@ func (ts *TelemetrySystem) NewUploader(enableDebugLogging bool) Uploader {
 }
 
 func (ts *TelemetrySystem) RunBackgroundUpload(ctx context.Context, enableDebugLogging bool) error {
+	if ts.storageQueue == nil {
+		return nil
+	}
+
 	fileLock, locked, err := ts.tryUploadLock()
 	if err != nil {
 		return fmt.Errorf("failed to acquire upload lock %w", err)
@@ -273,6 +315,17 @@ func (ts *TelemetrySystem) tryUploadLock() (*flock.Flock, bool, error) {
 	return fileLock, locked, err
 }
 
+// loadUserConfig loads the user configuration, which is empty when it can't be loaded
+func loadUserConfig() config.Config {
+	userConfig, err := config.NewUserConfigManager(config.NewFileConfigManager(config.NewManager())).Load()
+	if err != nil {
+		log.Printf("failed to load user config: %v", err)
+		return config.NewEmptyConfig()
+	}
+
+	return userConfig
+}
+
 // getTraceFlags returns the values of the `--trace-log-file` and `--trace-log-url` flags.
 func getTraceFlags() (logFile string, logUrl string) {
 	help := false
@@ -81,6 +81,45 @@ func TestGetTelemetrySystem(t *testing.T) {
 	}
 }
 
+func TestGetTelemetrySystem_InvalidOtlpConfig(t *testing.T) {
+	devEndpointConfig, err := appinsightsexporter.NewEndpointConfig(devConnectionString)
+	require.NoError(t, err)
+
+	tests := map[string]map[string]string{
+		"UnsupportedProtocol": {
+			"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318",
+			"OTEL_EXPORTER_OTLP_PROTOCOL": "http/json",
+		},
+		"MalformedEndpoint": {
+			"OTEL_EXPORTER_OTLP_ENDPOINT": "http://local host:4318",
+		},
+	}
+
+	for name, envVars := range tests {
+		t.Run(name, func(t *testing.T) {
+			orig := internal.Version
+			defer func() { internal.Version = orig }()
+			internal.Version = "0.0.0-dev.0 (commit 0000000000000000000000000000000000000000)"
+
+			ostest.Unsetenv(t, collectTelemetryEnvVar)
+			for key, value := range envVars {
+				ostest.Setenv(t, key, value)
+			}
+			defer func() { once = sync.Once{} }()
+
+			// Only the OTLP exporter is dropped, the traces are still exported to Application Insights
+			ts := GetTelemetrySystem()
+			require.NotNil(t, ts)
+			assert.Equal(t, devEndpointConfig, ts.config)
+			assert.NotNil(t, ts.exporter)
+			assert.NotNil(t, ts.GetTelemetryQueue())
+
+			err := ts.Shutdown(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestTelemetrySystem_RunBackgroundUpload(t *testing.T) {
 	type args struct {
 		ctx                context.Context
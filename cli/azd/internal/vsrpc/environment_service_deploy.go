@@ -5,11 +5,13 @@ package vsrpc
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/internal/cmd"
 	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
 )
 
 // DeployAsync is the server implementation of:
@@ -64,17 +66,6 @@ func (s *environmentService) DeployAsync(
 		},
 	)
 
-	deployFlags := cmd.NewDeployFlagsFromEnvAndOptions(
-		&internal.EnvFlag{
-			EnvironmentName: name,
-		},
-		&internal.GlobalCommandOptions{
-			Cwd:      session.rootPath,
-			NoPrompt: true,
-		},
-	)
-	deployFlags.All = true
-
 	container.MustRegisterScoped(func() internal.EnvFlag {
 		return internal.EnvFlag{
 			EnvironmentName: name,
@@ -82,15 +73,16 @@ func (s *environmentService) DeployAsync(
 	})
 
 	ioc.RegisterInstance(container.NestedContainer, provisionFlags)
-	ioc.RegisterInstance(container.NestedContainer, deployFlags)
 	ioc.RegisterInstance(container.NestedContainer, []string{})
 
 	container.MustRegisterNamedTransient("provisionAction", cmd.NewProvisionAction)
-	container.MustRegisterNamedTransient("deployAction", cmd.NewDeployAction)
 
 	var c struct {
-		deployAction    actions.Action `container:"name"`
-		provisionAction actions.Action `container:"name"`
+		provisionAction actions.Action         `container:"name"`
+		projectManager  project.ProjectManager `container:"type"`
+		projectConfig   *project.ProjectConfig `container:"type"`
+		importManager   *project.ImportManager `container:"type"`
+		serviceManager  project.ServiceManager `container:"type"`
 	}
 
 	if err := container.Fill(&c); err != nil {
@@ -101,7 +93,22 @@ func (s *environmentService) DeployAsync(
 		return nil, err
 	}
 
-	if _, err := c.deployAction.Run(ctx); err != nil {
+	if err := c.projectManager.Initialize(ctx, c.projectConfig); err != nil {
+		return nil, err
+	}
+
+	if err := c.projectManager.EnsureServiceTargetTools(ctx, c.projectConfig, nil); err != nil {
+		return nil, err
+	}
+
+	services, err := c.importManager.ServiceStable(ctx, c.projectConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := deployServices(ctx, c.serviceManager, services, func(message ProgressMessage) {
+		_ = observer.OnNext(ctx, message)
+	}); err != nil {
 		return nil, err
 	}
 
@@ -115,3 +122,38 @@ func (s *environmentService) DeployAsync(
 
 	return s.refreshEnvironmentAsync(ctx, container, name, observer)
 }
+
+// deployServices packages & deploys the services with the dependency-aware scheduler of the service manager, like
+// `azd deploy --all` does, so services that don't depend on each other are deployed concurrently.
+func deployServices(
+	ctx context.Context,
+	serviceManager project.ServiceManager,
+	services []*project.ServiceConfig,
+	report func(ProgressMessage),
+) (map[string]*project.ServiceDeployResult, error) {
+	return serviceManager.DeployServices(ctx, services, &project.DeployServicesOptions{
+		MaxParallel: project.DefaultMaxParallelDeployments,
+		OnProgress: func(serviceConfig *project.ServiceConfig, progress project.ServiceDeployProgress) {
+			switch progress.State {
+			case project.ServiceDeployStateRunning:
+				if progress.Message == "" {
+					report(newImportantProgressMessage(fmt.Sprintf("Deploying service %s", serviceConfig.Name)))
+				} else {
+					report(newInfoProgressMessage(
+						fmt.Sprintf("Deploying service %s (%s)", serviceConfig.Name, progress.Message)))
+				}
+			case project.ServiceDeployStateSucceeded:
+				report(newImportantProgressMessage(fmt.Sprintf("Deployed service %s", serviceConfig.Name)))
+			case project.ServiceDeployStateFailed:
+				message := newImportantProgressMessage(
+					fmt.Sprintf("Failed deploying service %s: %v", serviceConfig.Name, progress.Error))
+				message.Severity = Error
+				report(message)
+			case project.ServiceDeployStateSkipped:
+				message := newImportantProgressMessage(progress.Error.Error())
+				message.Severity = Warning
+				report(message)
+			}
+		},
+	})
+}
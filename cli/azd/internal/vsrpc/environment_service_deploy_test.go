@@ -0,0 +1,76 @@
+package vsrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/stretchr/testify/require"
+)
+
+// schedulingServiceManager records the calls to DeployServices, and reports the progress of the services as the
+// scheduler does.
+type schedulingServiceManager struct {
+	project.ServiceManager
+	calls [][]string
+}
+
+func (sm *schedulingServiceManager) DeployServices(
+	ctx context.Context,
+	serviceConfigs []*project.ServiceConfig,
+	options *project.DeployServicesOptions,
+) (map[string]*project.ServiceDeployResult, error) {
+	names := []string{}
+	for _, serviceConfig := range serviceConfigs {
+		names = append(names, serviceConfig.Name)
+	}
+	sm.calls = append(sm.calls, names)
+
+	if options.MaxParallel <= 1 {
+		return nil, fmt.Errorf("services are deployed one at a time, max parallel is %d", options.MaxParallel)
+	}
+
+	api, web := serviceConfigs[0], serviceConfigs[1]
+	options.OnProgress(api, project.ServiceDeployProgress{State: project.ServiceDeployStateRunning})
+	options.OnProgress(web, project.ServiceDeployProgress{State: project.ServiceDeployStateRunning})
+	options.OnProgress(web, project.ServiceDeployProgress{
+		State:   project.ServiceDeployStateRunning,
+		Message: "Uploading package",
+	})
+	options.OnProgress(api, project.ServiceDeployProgress{State: project.ServiceDeployStateSucceeded})
+
+	err := errors.New("deployment failed")
+	options.OnProgress(web, project.ServiceDeployProgress{State: project.ServiceDeployStateFailed, Error: err})
+
+	return map[string]*project.ServiceDeployResult{"api": {}}, err
+}
+
+func Test_deployServices(t *testing.T) {
+	sm := &schedulingServiceManager{}
+	services := []*project.ServiceConfig{{Name: "api"}, {Name: "web"}}
+
+	messages := []ProgressMessage{}
+	results, err := deployServices(context.Background(), sm, services, func(message ProgressMessage) {
+		messages = append(messages, message)
+	})
+	require.ErrorContains(t, err, "deployment failed")
+	require.Contains(t, results, "api")
+
+	// All the services are handed to the scheduler at once, so independent services overlap
+	require.Equal(t, [][]string{{"api", "web"}}, sm.calls)
+
+	texts := []string{}
+	for _, message := range messages {
+		texts = append(texts, message.Message)
+	}
+	require.Equal(t, []string{
+		"Deploying service api",
+		"Deploying service web",
+		"Deploying service web (Uploading package)",
+		"Deployed service api",
+		"Failed deploying service web: deployment failed",
+	}, texts)
+	require.Equal(t, Error, messages[4].Severity)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	infraBicep "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/bicep"
+	infraPulumi "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/pulumi"
 	infraTerraform "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/terraform"
 	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
 	"github.com/azure/azure-dev/cli/azd/pkg/platform"
@@ -18,6 +19,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/state"
 	"github.com/azure/azure-dev/cli/azd/pkg/templates"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/bicep"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/pulumi"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/terraform"
 )
 
@@ -47,11 +49,13 @@ func (p *DefaultPlatform) ConfigureContainer(container *ioc.NestedContainer) err
 	// Tools
 	container.MustRegisterSingleton(terraform.NewCli)
 	container.MustRegisterSingleton(bicep.NewCli)
+	container.MustRegisterSingleton(pulumi.NewCli)
 
 	// Provisioning Providers
 	provisionProviderMap := map[provisioning.ProviderKind]any{
 		provisioning.Bicep:     infraBicep.NewBicepProvider,
 		provisioning.Terraform: infraTerraform.NewTerraformProvider,
+		provisioning.Pulumi:    infraPulumi.NewPulumiProvider,
 	}
 
 	for provider, constructor := range provisionProviderMap {
@@ -68,6 +72,7 @@ func (p *DefaultPlatform) ConfigureContainer(container *ioc.NestedContainer) err
 	// Remote Environment State Providers
 	remoteStateProviderMap := map[environment.RemoteKind]any{
 		environment.RemoteKindAzureBlobStorage: environment.NewStorageBlobDataStore,
+		environment.RemoteKindGit:              environment.NewGitDataStore,
 	}
 
 	for remoteKind, constructor := range remoteStateProviderMap {
@@ -103,6 +108,37 @@ func (p *DefaultPlatform) ConfigureContainer(container *ioc.NestedContainer) err
 		return storageAccountConfig, nil
 	})
 
+	container.MustRegisterSingleton(func(
+		remoteStateConfig *state.RemoteConfig,
+		projectConfig *project.ProjectConfig,
+	) (*environment.GitConfig, error) {
+		if remoteStateConfig == nil {
+			return nil, nil
+		}
+
+		var gitConfig *environment.GitConfig
+		jsonBytes, err := json.Marshal(remoteStateConfig.Config)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling remote state config: %w", err)
+		}
+
+		if err := json.Unmarshal(jsonBytes, &gitConfig); err != nil {
+			return nil, fmt.Errorf("unmarshalling remote state config: %w", err)
+		}
+
+		if gitConfig == nil {
+			gitConfig = &environment.GitConfig{}
+		}
+
+		// If a path has not been explicitly configured
+		// Default to store the environments of the project within a directory named after the project
+		if gitConfig.Path == "" {
+			gitConfig.Path = projectConfig.Name
+		}
+
+		return gitConfig, nil
+	})
+
 	// Storage components
 	container.MustRegisterSingleton(storage.NewBlobClient)
 	container.MustRegisterSingleton(storage.NewBlobSdkClient)
@@ -20,6 +20,12 @@ const SecretOrRandomPasswordCommandName string = "secretOrRandomPassword"
 type SecretOrRandomPasswordCommandExecutor struct {
 	keyvaultService keyvault.KeyVaultService
 	subscriptionId  string
+	values          []string
+}
+
+// Values returns the passwords and secrets substituted by the executor, which callers treat as secrets.
+func (e *SecretOrRandomPasswordCommandExecutor) Values() []string {
+	return e.values
 }
 
 func NewSecretOrRandomPasswordExecutor(
@@ -42,7 +48,12 @@ func (e *SecretOrRandomPasswordCommandExecutor) Run(
 	generatePassword := func() (bool, string, error) {
 		substitute, err := password.Generate(
 			password.GenerateConfig{MinLower: to.Ptr[uint](5), MinUpper: to.Ptr[uint](5), MinNumeric: to.Ptr[uint](5)})
-		return err == nil, substitute, err
+		if err != nil {
+			return false, "", err
+		}
+
+		e.values = append(e.values, substitute)
+		return true, substitute, nil
 	}
 
 	// We expect two arguments: the KeyVault name and the secret name
@@ -78,5 +89,6 @@ func (e *SecretOrRandomPasswordCommandExecutor) Run(
 		return generatePassword() // Do not use empty password secret even if the secret exists
 	}
 
+	e.values = append(e.values, secret.Value)
 	return true, secret.Value, nil
 }
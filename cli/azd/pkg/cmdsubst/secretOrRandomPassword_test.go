@@ -0,0 +1,23 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmdsubst
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretOrRandomPasswordValues(t *testing.T) {
+	executor := NewSecretOrRandomPasswordExecutor(nil, "")
+
+	// Without a vault, a random password is generated
+	result, err := Eval(context.Background(), `{"a": "$(secretOrRandomPassword)", "b": "$(other)"}`, executor)
+	require.NoError(t, err)
+
+	values := executor.Values()
+	require.Len(t, values, 1)
+	require.Equal(t, `{"a": "`+values[0]+`", "b": "$(other)"}`, result)
+}
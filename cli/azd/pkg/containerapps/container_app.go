@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"slices"
@@ -25,6 +27,8 @@ import (
 
 const (
 	pathLatestRevisionName                 = "properties.latestRevisionName"
+	pathRevisionActive                     = "properties.active"
+	pathEventStreamEndpoint                = "properties.eventStreamEndpoint"
 	pathTemplate                           = "properties.template"
 	pathTemplateRevisionSuffix             = "properties.template.revisionSuffix"
 	pathTemplateContainers                 = "properties.template.containers"
@@ -54,7 +58,7 @@ type ContainerAppService interface {
 		containerAppYaml []byte,
 		options *ContainerAppOptions,
 	) error
-	// Adds and activates a new revision to the specified container app
+	// Adds and activates a new revision to the specified container app, and returns the name of the new revision
 	AddRevision(
 		ctx context.Context,
 		subscriptionId string,
@@ -62,6 +66,66 @@ type ContainerAppService interface {
 		appName string,
 		imageName string,
 		options *ContainerAppOptions,
+	) (string, error)
+	// Rolls back the specified container app to a previous revision, and returns the name of the revision now serving
+	// the app. In multiple revisions mode, the previous revision is activated and receives all the traffic. In single
+	// revision mode, a new revision is created from the template of the previous revision.
+	RollbackRevision(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		revisionName string,
+		options *ContainerAppOptions,
+	) (string, error)
+	// Adds a new revision to the specified container app without routing any traffic to it, and returns the name of the
+	// new revision. The container app must be in multiple revisions mode. When a label is specified, the new revision is
+	// reachable on the URL of the label.
+	StageRevision(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		imageName string,
+		label string,
+		options *ContainerAppOptions,
+	) (string, error)
+	// Gets the traffic weights of the specified container app. The traffic following the latest revision is attributed
+	// to the latest revision.
+	GetTrafficWeights(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		options *ContainerAppOptions,
+	) ([]TrafficWeight, error)
+	// Routes the traffic of the specified container app to its revisions with the specified weights
+	SetTrafficWeights(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		trafficWeights []TrafficWeight,
+		options *ContainerAppOptions,
+	) error
+	// Gets the status of the specified revision of the container app
+	GetRevisionStatus(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		revisionName string,
+		options *ContainerAppOptions,
+	) (*RevisionStatus, error)
+	// Streams the logs of the specified container app to the writer
+	StreamLogs(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		logOptions *LogStreamOptions,
+		options *ContainerAppOptions,
+		writer io.Writer,
 	) error
 }
 
@@ -95,6 +159,25 @@ type ContainerAppIngressConfiguration struct {
 	HostNames []string
 }
 
+// TrafficWeight is the share of the traffic of a container app routed to one of its revisions
+type TrafficWeight struct {
+	RevisionName string
+	// The percentage of the traffic routed to the revision
+	Weight int32
+	// The optional label of the revision, whose URL routes the requests to the revision regardless of its weight
+	Label string
+}
+
+// RevisionStatus is the status of a revision of a container app
+type RevisionStatus struct {
+	Name   string
+	Active bool
+	// The health of the revision reported by the health probes of its containers: Healthy, Unhealthy or None
+	HealthState armappcontainers.RevisionHealthState
+	// The running state of the revision, like Running, Processing, Degraded or Failed
+	RunningState armappcontainers.RevisionRunningState
+}
+
 // Gets the ingress configuration for the specified container app
 func (cas *containerAppService) GetIngressConfiguration(
 	ctx context.Context,
@@ -265,7 +348,7 @@ func (cas *containerAppService) DeployYaml(
 	return nil
 }
 
-// Adds and activates a new revision to the specified container app
+// Adds and activates a new revision to the specified container app, and returns the name of the new revision
 func (cas *containerAppService) AddRevision(
 	ctx context.Context,
 	subscriptionId string,
@@ -273,94 +356,329 @@ func (cas *containerAppService) AddRevision(
 	appName string,
 	imageName string,
 	options *ContainerAppOptions,
-) error {
+) (string, error) {
 	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, options)
 	if err != nil {
-		return fmt.Errorf("getting container app: %w", err)
+		return "", fmt.Errorf("getting container app: %w", err)
 	}
 
 	// Get the latest revision name
 	currentRevisionName, has := containerApp.GetString(pathLatestRevisionName)
 	if !has {
-		return fmt.Errorf("getting latest revision name: %w", err)
+		return "", fmt.Errorf("getting latest revision name: %w", err)
 	}
 
-	apiVersionPolicy := createApiVersionPolicy(options)
-	revisionsClient, err := cas.createRevisionsClient(ctx, subscriptionId, apiVersionPolicy)
+	revision, err := cas.getRevision(ctx, subscriptionId, resourceGroupName, appName, currentRevisionName, options)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	var revisionResponse *http.Response
-	ctx = policy.WithCaptureResponse(ctx, &revisionResponse)
+	// Update the revision with the new image name
+	var containers []map[string]any
+	if ok, err := revision.GetSection(pathTemplateContainers, &containers); !ok || err != nil {
+		return "", fmt.Errorf("getting containers: %w", err)
+	}
 
-	if _, err := revisionsClient.GetRevision(ctx, resourceGroupName, appName, currentRevisionName, nil); err != nil {
-		return fmt.Errorf("getting revision '%s': %w", currentRevisionName, err)
+	containers[0]["image"] = imageName
+	if err := revision.Set(pathTemplateContainers, containers); err != nil {
+		return "", fmt.Errorf("setting containers: %w", err)
 	}
 
-	var revisionMap map[string]any
-	if err := convert.FromHttpResponse(revisionResponse, &revisionMap); err != nil {
-		return err
+	return cas.applyRevision(ctx, subscriptionId, resourceGroupName, appName, containerApp, revision, true, options)
+}
+
+// Adds a new revision to the specified container app without routing any traffic to it, and returns its name
+func (cas *containerAppService) StageRevision(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	imageName string,
+	label string,
+	options *ContainerAppOptions,
+) (string, error) {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, options)
+	if err != nil {
+		return "", fmt.Errorf("getting container app: %w", err)
 	}
 
-	revision := config.NewConfig(revisionMap)
+	revisionMode, _ := containerApp.GetString(pathConfigurationActiveRevisionsMode)
+	if revisionMode != string(armappcontainers.ActiveRevisionsModeMultiple) {
+		return "", fmt.Errorf(
+			"container app '%s' must be in multiple revisions mode to add a revision without routing traffic to it", appName)
+	}
+
+	currentRevisionName, has := containerApp.GetString(pathLatestRevisionName)
+	if !has {
+		return "", errors.New("getting latest revision name")
+	}
 
-	// Update the revision with the new image name and suffix
-	if err := revision.Set(pathTemplateRevisionSuffix, fmt.Sprintf("azd-%d", cas.clock.Now().Unix())); err != nil {
-		return fmt.Errorf("setting revision suffix: %w", err)
+	trafficWeights, err := readTrafficWeights(containerApp, currentRevisionName)
+	if err != nil {
+		return "", err
+	}
+
+	revision, err := cas.getRevision(ctx, subscriptionId, resourceGroupName, appName, currentRevisionName, options)
+	if err != nil {
+		return "", err
 	}
 
 	var containers []map[string]any
 	if ok, err := revision.GetSection(pathTemplateContainers, &containers); !ok || err != nil {
-		return fmt.Errorf("getting containers: %w", err)
+		return "", fmt.Errorf("getting containers: %w", err)
 	}
 
 	containers[0]["image"] = imageName
 	if err := revision.Set(pathTemplateContainers, containers); err != nil {
-		return fmt.Errorf("setting containers: %w", err)
+		return "", fmt.Errorf("setting containers: %w", err)
+	}
+
+	// The traffic following the latest revision is pinned to the current revision, so the new revision receives none
+	if err := writeTrafficWeights(containerApp, trafficWeights); err != nil {
+		return "", err
+	}
+
+	newRevisionName, err := cas.applyRevision(
+		ctx, subscriptionId, resourceGroupName, appName, containerApp, revision, false, options)
+	if err != nil {
+		return "", err
+	}
+
+	if label != "" {
+		trafficWeights = append(trafficWeights, TrafficWeight{RevisionName: newRevisionName, Label: label})
+		err := cas.setTrafficWeights(ctx, subscriptionId, resourceGroupName, appName, containerApp, trafficWeights, options)
+		if err != nil {
+			return "", fmt.Errorf("setting traffic weights: %w", err)
+		}
+	}
+
+	return newRevisionName, nil
+}
+
+// Gets the traffic weights of the specified container app
+func (cas *containerAppService) GetTrafficWeights(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	options *ContainerAppOptions,
+) ([]TrafficWeight, error) {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, options)
+	if err != nil {
+		return nil, fmt.Errorf("getting container app: %w", err)
+	}
+
+	latestRevisionName, _ := containerApp.GetString(pathLatestRevisionName)
+	return readTrafficWeights(containerApp, latestRevisionName)
+}
+
+// Routes the traffic of the specified container app to its revisions with the specified weights
+func (cas *containerAppService) SetTrafficWeights(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	trafficWeights []TrafficWeight,
+	options *ContainerAppOptions,
+) error {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, options)
+	if err != nil {
+		return fmt.Errorf("getting container app: %w", err)
+	}
+
+	containerApp, err = cas.syncSecrets(ctx, subscriptionId, resourceGroupName, appName, containerApp)
+	if err != nil {
+		return fmt.Errorf("syncing secrets: %w", err)
+	}
+
+	err = cas.setTrafficWeights(ctx, subscriptionId, resourceGroupName, appName, containerApp, trafficWeights, options)
+	if err != nil {
+		return fmt.Errorf("setting traffic weights: %w", err)
+	}
+
+	return nil
+}
+
+// Gets the status of the specified revision of the container app
+func (cas *containerAppService) GetRevisionStatus(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	revisionName string,
+	options *ContainerAppOptions,
+) (*RevisionStatus, error) {
+	revisionsClient, err := cas.createRevisionsClient(ctx, subscriptionId, createApiVersionPolicy(options))
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := revisionsClient.GetRevision(ctx, resourceGroupName, appName, revisionName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting revision '%s': %w", revisionName, err)
+	}
+
+	status := &RevisionStatus{
+		Name: revisionName,
+	}
+
+	if properties := response.Revision.Properties; properties != nil {
+		status.Active = properties.Active != nil && *properties.Active
+		if properties.HealthState != nil {
+			status.HealthState = *properties.HealthState
+		}
+
+		if properties.RunningState != nil {
+			status.RunningState = *properties.RunningState
+		}
+	}
+
+	return status, nil
+}
+
+// Rolls back the specified container app to a previous revision, and returns the name of the revision now serving the app
+func (cas *containerAppService) RollbackRevision(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	revisionName string,
+	options *ContainerAppOptions,
+) (string, error) {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, options)
+	if err != nil {
+		return "", fmt.Errorf("getting container app: %w", err)
+	}
+
+	revision, err := cas.getRevision(ctx, subscriptionId, resourceGroupName, appName, revisionName, options)
+	if err != nil {
+		return "", err
+	}
+
+	revisionMode, ok := containerApp.GetString(pathConfigurationActiveRevisionsMode)
+	if !ok {
+		return "", errors.New("getting active revisions mode")
+	}
+
+	// In single revision mode, only the latest revision can serve the app, so the previous revision is redeployed
+	if revisionMode != string(armappcontainers.ActiveRevisionsModeMultiple) {
+		return cas.applyRevision(ctx, subscriptionId, resourceGroupName, appName, containerApp, revision, true, options)
+	}
+
+	// Revisions are deactivated once they don't receive any traffic
+	if active, _ := revision.Get(pathRevisionActive); active != true {
+		revisionsClient, err := cas.createRevisionsClient(ctx, subscriptionId, createApiVersionPolicy(options))
+		if err != nil {
+			return "", err
+		}
+
+		if _, err := revisionsClient.ActivateRevision(ctx, resourceGroupName, appName, revisionName, nil); err != nil {
+			return "", fmt.Errorf("activating revision '%s': %w", revisionName, err)
+		}
+	}
+
+	err = cas.SetTrafficWeights(ctx, subscriptionId, resourceGroupName, appName, []TrafficWeight{
+		{
+			RevisionName: revisionName,
+			Weight:       100,
+		},
+	}, options)
+	if err != nil {
+		return "", err
+	}
+
+	return revisionName, nil
+}
+
+// getRevision gets the specified revision of the container app
+func (cas *containerAppService) getRevision(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	revisionName string,
+	options *ContainerAppOptions,
+) (config.Config, error) {
+	apiVersionPolicy := createApiVersionPolicy(options)
+	revisionsClient, err := cas.createRevisionsClient(ctx, subscriptionId, apiVersionPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	var revisionResponse *http.Response
+	ctx = policy.WithCaptureResponse(ctx, &revisionResponse)
+
+	if _, err := revisionsClient.GetRevision(ctx, resourceGroupName, appName, revisionName, nil); err != nil {
+		return nil, fmt.Errorf("getting revision '%s': %w", revisionName, err)
+	}
+
+	var revisionMap map[string]any
+	if err := convert.FromHttpResponse(revisionResponse, &revisionMap); err != nil {
+		return nil, err
+	}
+
+	return config.NewConfig(revisionMap), nil
+}
+
+// applyRevision updates the container app with the template of the revision, which creates a new revision, and returns
+// the name of the new revision. When shifting the traffic, the new revision receives all the traffic.
+func (cas *containerAppService) applyRevision(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	containerApp config.Config,
+	revision config.Config,
+	shiftTraffic bool,
+	options *ContainerAppOptions,
+) (string, error) {
+	revisionSuffix := fmt.Sprintf("azd-%d", cas.clock.Now().Unix())
+	if err := revision.Set(pathTemplateRevisionSuffix, revisionSuffix); err != nil {
+		return "", fmt.Errorf("setting revision suffix: %w", err)
 	}
 
 	// Update the container app with the new revision
 	revisionTemplate, ok := revision.GetMap(pathTemplate)
 	if !ok {
-		return fmt.Errorf("getting revision template: %w", err)
+		return "", errors.New("getting revision template")
 	}
 
 	if err := containerApp.Set(pathTemplate, revisionTemplate); err != nil {
-		return fmt.Errorf("setting template: %w", err)
+		return "", fmt.Errorf("setting template: %w", err)
 	}
 
-	containerApp, err = cas.syncSecrets(ctx, subscriptionId, resourceGroupName, appName, containerApp)
+	containerApp, err := cas.syncSecrets(ctx, subscriptionId, resourceGroupName, appName, containerApp)
 	if err != nil {
-		return fmt.Errorf("syncing secrets: %w", err)
+		return "", fmt.Errorf("syncing secrets: %w", err)
 	}
 
 	// Update the container app
 	err = cas.updateContainerApp(ctx, subscriptionId, resourceGroupName, appName, containerApp, options)
 	if err != nil {
-		return fmt.Errorf("updating container app revision: %w", err)
+		return "", fmt.Errorf("updating container app revision: %w", err)
 	}
 
 	revisionMode, ok := containerApp.GetString(pathConfigurationActiveRevisionsMode)
 	if !ok {
-		return fmt.Errorf("getting active revisions mode: %w", err)
+		return "", errors.New("getting active revisions mode")
 	}
 
-	// If the container app is in multiple revision mode, update the traffic to point to the new revision
-	if revisionMode == string(armappcontainers.ActiveRevisionsModeMultiple) {
-		revisionSuffix, ok := revision.GetString(pathTemplateRevisionSuffix)
-		if !ok {
-			return fmt.Errorf("getting revision suffix: %w", err)
-		}
-		newRevisionName := fmt.Sprintf("%s--%s", appName, revisionSuffix)
+	newRevisionName := fmt.Sprintf("%s--%s", appName, revisionSuffix)
 
-		err = cas.setTrafficWeights(ctx, subscriptionId, resourceGroupName, appName, containerApp, newRevisionName, options)
+	// If the container app is in multiple revision mode, update the traffic to point to the new revision
+	if shiftTraffic && revisionMode == string(armappcontainers.ActiveRevisionsModeMultiple) {
+		err = cas.setTrafficWeights(ctx, subscriptionId, resourceGroupName, appName, containerApp, []TrafficWeight{
+			{
+				RevisionName: newRevisionName,
+				Weight:       100,
+			},
+		}, options)
 		if err != nil {
-			return fmt.Errorf("setting traffic weights: %w", err)
+			return "", fmt.Errorf("setting traffic weights: %w", err)
 		}
 	}
 
-	return nil
+	return newRevisionName, nil
 }
 
 func (cas *containerAppService) syncSecrets(
@@ -409,28 +727,70 @@ func (cas *containerAppService) setTrafficWeights(
 	resourceGroupName string,
 	appName string,
 	containerApp config.Config,
-	revisionName string,
+	trafficWeights []TrafficWeight,
 	options *ContainerAppOptions,
 ) error {
-	trafficWeights := []*armappcontainers.TrafficWeight{
-		{
-			RevisionName: &revisionName,
-			Weight:       to.Ptr[int32](100),
-		},
+	if err := writeTrafficWeights(containerApp, trafficWeights); err != nil {
+		return err
 	}
 
-	trafficWeightsJson, err := convert.ToJsonArray(trafficWeights)
+	err := cas.updateContainerApp(ctx, subscriptionId, resourceGroupName, appName, containerApp, options)
 	if err != nil {
-		return fmt.Errorf("converting traffic weights to JSON: %w", err)
+		return fmt.Errorf("updating traffic weights: %w", err)
 	}
 
-	if err := containerApp.Set(pathConfigurationIngressTraffic, trafficWeightsJson); err != nil {
-		return fmt.Errorf("setting traffic weights: %w", err)
+	return nil
+}
+
+// readTrafficWeights reads the traffic weights of the container app, attributing the traffic following the latest
+// revision to the latest revision
+func readTrafficWeights(containerApp config.Config, latestRevisionName string) ([]TrafficWeight, error) {
+	var traffic []*armappcontainers.TrafficWeight
+	if _, err := containerApp.GetSection(pathConfigurationIngressTraffic, &traffic); err != nil {
+		return nil, fmt.Errorf("getting traffic weights: %w", err)
 	}
 
-	err = cas.updateContainerApp(ctx, subscriptionId, resourceGroupName, appName, containerApp, options)
+	trafficWeights := []TrafficWeight{}
+	for _, weight := range traffic {
+		trafficWeight := TrafficWeight{
+			RevisionName: convert.ToValueWithDefault(weight.RevisionName, ""),
+			Weight:       convert.ToValueWithDefault(weight.Weight, 0),
+			Label:        convert.ToValueWithDefault(weight.Label, ""),
+		}
+
+		if convert.ToValueWithDefault(weight.LatestRevision, false) {
+			trafficWeight.RevisionName = latestRevisionName
+		}
+
+		trafficWeights = append(trafficWeights, trafficWeight)
+	}
+
+	return trafficWeights, nil
+}
+
+// writeTrafficWeights sets the traffic weights of the container app
+func writeTrafficWeights(containerApp config.Config, trafficWeights []TrafficWeight) error {
+	traffic := make([]*armappcontainers.TrafficWeight, 0, len(trafficWeights))
+	for _, trafficWeight := range trafficWeights {
+		weight := &armappcontainers.TrafficWeight{
+			RevisionName: to.Ptr(trafficWeight.RevisionName),
+			Weight:       to.Ptr(trafficWeight.Weight),
+		}
+
+		if trafficWeight.Label != "" {
+			weight.Label = to.Ptr(trafficWeight.Label)
+		}
+
+		traffic = append(traffic, weight)
+	}
+
+	trafficWeightsJson, err := convert.ToJsonArray(traffic)
 	if err != nil {
-		return fmt.Errorf("updating traffic weights: %w", err)
+		return fmt.Errorf("converting traffic weights to JSON: %w", err)
+	}
+
+	if err := containerApp.Set(pathConfigurationIngressTraffic, trafficWeightsJson); err != nil {
+		return fmt.Errorf("setting traffic weights: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,175 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package containerapps
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appcontainers/armappcontainers/v3"
+)
+
+// LogStreamOptions are the options used to stream the logs of a container app
+type LogStreamOptions struct {
+	// Keeps the stream open and writes the new log entries until the context is cancelled
+	Follow bool
+	// The number of recent log lines to write first
+	TailLines int
+	// Streams the system logs of the container app, like revision provisioning and container restarts, instead of the
+	// console logs of the containers
+	System bool
+}
+
+// Streams the logs of the specified container app to the writer.
+// The console logs are read from the first container of the first replica of the latest revision, like
+// `az containerapp logs show`.
+func (cas *containerAppService) StreamLogs(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	logOptions *LogStreamOptions,
+	options *ContainerAppOptions,
+	writer io.Writer,
+) error {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, options)
+	if err != nil {
+		return fmt.Errorf("getting container app: %w", err)
+	}
+
+	eventStreamEndpoint, has := containerApp.GetString(pathEventStreamEndpoint)
+	if !has {
+		return fmt.Errorf("container app '%s' does not expose a log stream endpoint", appName)
+	}
+
+	logStreamEndpoint := eventStreamEndpoint
+	if !logOptions.System {
+		revisionName, has := containerApp.GetString(pathLatestRevisionName)
+		if !has {
+			return fmt.Errorf("container app '%s' does not have any revision", appName)
+		}
+
+		logStreamEndpoint, err = cas.consoleLogStreamEndpoint(
+			ctx, subscriptionId, resourceGroupName, appName, revisionName, eventStreamEndpoint)
+		if err != nil {
+			return err
+		}
+	}
+
+	appClient, err := cas.createContainerAppsClient(ctx, subscriptionId, nil)
+	if err != nil {
+		return err
+	}
+
+	// The log stream endpoint is not an ARM endpoint and requires a token specific to the container app
+	authToken, err := appClient.GetAuthToken(ctx, resourceGroupName, appName, nil)
+	if err != nil {
+		return fmt.Errorf("getting log stream token: %w", err)
+	}
+
+	if authToken.Properties == nil || authToken.Properties.Token == nil {
+		return fmt.Errorf("getting log stream token: the token of container app '%s' is empty", appName)
+	}
+
+	streamUrl, err := url.Parse(logStreamEndpoint)
+	if err != nil {
+		return fmt.Errorf("parsing log stream endpoint: %w", err)
+	}
+
+	query := streamUrl.Query()
+	query.Set("follow", strconv.FormatBool(logOptions.Follow))
+	query.Set("output", "text")
+	if logOptions.TailLines > 0 {
+		query.Set("tailLines", strconv.Itoa(logOptions.TailLines))
+	}
+	streamUrl.RawQuery = query.Encode()
+
+	pipeline := runtime.NewPipeline(
+		"containerapps-logstream", "1.0.0", runtime.PipelineOptions{}, &cas.armClientOptions.ClientOptions)
+	request, err := runtime.NewRequest(ctx, http.MethodGet, streamUrl.String())
+	if err != nil {
+		return err
+	}
+
+	request.Raw().Header.Set("Authorization", "Bearer "+*authToken.Properties.Token)
+	// The body is streamed to the writer instead of being read in memory
+	runtime.SkipBodyDownload(request)
+
+	response, err := pipeline.Do(request)
+	if err != nil {
+		return fmt.Errorf("opening log stream: %w", err)
+	}
+	defer response.Body.Close()
+
+	if !runtime.HasStatusCode(response, http.StatusOK) {
+		return runtime.NewResponseError(response)
+	}
+
+	if _, err := io.Copy(writer, response.Body); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("reading log stream: %w", err)
+	}
+
+	return nil
+}
+
+// consoleLogStreamEndpoint gets the endpoint streaming the console logs of the first container of the first replica of the
+// latest revision. The endpoint shares the host of the event stream endpoint.
+func (cas *containerAppService) consoleLogStreamEndpoint(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	revisionName string,
+	eventStreamEndpoint string,
+) (string, error) {
+	credential, err := cas.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return "", err
+	}
+
+	replicasClient, err := armappcontainers.NewContainerAppsRevisionReplicasClient(
+		subscriptionId, credential, cas.armClientOptions)
+	if err != nil {
+		return "", fmt.Errorf("creating ContainerAppsRevisionReplicas client: %w", err)
+	}
+
+	replicas, err := replicasClient.ListReplicas(ctx, resourceGroupName, appName, revisionName, nil)
+	if err != nil {
+		return "", fmt.Errorf("listing replicas of revision '%s': %w", revisionName, err)
+	}
+
+	if len(replicas.Value) == 0 || replicas.Value[0].Properties == nil ||
+		len(replicas.Value[0].Properties.Containers) == 0 {
+		return "", fmt.Errorf(
+			"revision '%s' of container app '%s' has no running replica, it may be scaled to zero",
+			revisionName,
+			appName,
+		)
+	}
+
+	replica := replicas.Value[0]
+	container := replica.Properties.Containers[0]
+
+	baseUrl, _, found := strings.Cut(eventStreamEndpoint, "/subscriptions/")
+	if !found {
+		return "", fmt.Errorf("unexpected event stream endpoint '%s'", eventStreamEndpoint)
+	}
+
+	return fmt.Sprintf(
+		"%s/subscriptions/%s/resourceGroups/%s/containerApps/%s/revisions/%s/replicas/%s/containers/%s/logstream",
+		baseUrl,
+		subscriptionId,
+		resourceGroupName,
+		appName,
+		revisionName,
+		*replica.Name,
+		*container.Name,
+	), nil
+}
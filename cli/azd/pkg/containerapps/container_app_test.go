@@ -1,9 +1,13 @@
 package containerapps
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
@@ -138,8 +142,9 @@ func Test_ContainerApp_AddRevision(t *testing.T) {
 		mockContext.ArmClientOptions,
 		mockContext.AlphaFeaturesManager,
 	)
-	err := cas.AddRevision(*mockContext.Context, subscriptionId, resourceGroup, appName, updatedImageName, nil)
+	revisionName, err := cas.AddRevision(*mockContext.Context, subscriptionId, resourceGroup, appName, updatedImageName, nil)
 	require.NoError(t, err)
+	require.Equal(t, "APP_NAME--azd-0", revisionName)
 
 	// Verify lastest revision is read
 	expectedGetRevisionPath := fmt.Sprintf(
@@ -161,6 +166,257 @@ func Test_ContainerApp_AddRevision(t *testing.T) {
 	require.Equal(t, "azd-0", *updatedContainerApp.Properties.Template.RevisionSuffix)
 }
 
+func Test_ContainerApp_RollbackRevision(t *testing.T) {
+	subscriptionId := "SUBSCRIPTION_ID"
+	location := "eastus2"
+	resourceGroup := "RESOURCE_GROUP"
+	appName := "APP_NAME"
+	previousRevisionName := "APP_NAME--azd-1"
+
+	t.Run("MultipleRevisions", func(t *testing.T) {
+		containerApp := &armappcontainers.ContainerApp{
+			Location: &location,
+			Name:     &appName,
+			Properties: &armappcontainers.ContainerAppProperties{
+				LatestRevisionName: to.Ptr("APP_NAME--azd-2"),
+				Configuration: &armappcontainers.Configuration{
+					ActiveRevisionsMode: to.Ptr(armappcontainers.ActiveRevisionsModeMultiple),
+				},
+			},
+		}
+
+		revision := &armappcontainers.Revision{
+			Name: &previousRevisionName,
+			Properties: &armappcontainers.RevisionProperties{
+				Active: to.Ptr(false),
+			},
+		}
+
+		mockContext := mocks.NewMockContext(context.Background())
+		_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+		_ = mockazsdk.MockContainerAppRevisionGet(
+			mockContext,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			previousRevisionName,
+			revision,
+		)
+		activateRevisionRequest := mockazsdk.MockContainerAppRevisionActivate(
+			mockContext,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			previousRevisionName,
+		)
+		updateContainerAppRequest := mockazsdk.MockContainerAppUpdate(
+			mockContext,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			containerApp,
+		)
+
+		cas := NewContainerAppService(
+			mockContext.SubscriptionCredentialProvider,
+			clock.NewMock(),
+			mockContext.ArmClientOptions,
+			mockContext.AlphaFeaturesManager,
+		)
+		revisionName, err := cas.RollbackRevision(
+			*mockContext.Context, subscriptionId, resourceGroup, appName, previousRevisionName, nil)
+		require.NoError(t, err)
+		require.Equal(t, previousRevisionName, revisionName)
+		require.NotNil(t, activateRevisionRequest.URL)
+
+		// Verify all the traffic is shifted to the previous revision
+		var updatedContainerApp *armappcontainers.ContainerApp
+		err = json.NewDecoder(updateContainerAppRequest.Body).Decode(&updatedContainerApp)
+		require.NoError(t, err)
+		traffic := updatedContainerApp.Properties.Configuration.Ingress.Traffic
+		require.Len(t, traffic, 1)
+		require.Equal(t, previousRevisionName, *traffic[0].RevisionName)
+		require.Equal(t, int32(100), *traffic[0].Weight)
+	})
+
+	t.Run("SingleRevision", func(t *testing.T) {
+		previousImageName := "PREVIOUS_IMAGE_NAME"
+		containerApp := &armappcontainers.ContainerApp{
+			Location: &location,
+			Name:     &appName,
+			Properties: &armappcontainers.ContainerAppProperties{
+				LatestRevisionName: to.Ptr("APP_NAME--azd-2"),
+				Configuration: &armappcontainers.Configuration{
+					ActiveRevisionsMode: to.Ptr(armappcontainers.ActiveRevisionsModeSingle),
+				},
+				Template: &armappcontainers.Template{
+					Containers: []*armappcontainers.Container{
+						{
+							Image: to.Ptr("CURRENT_IMAGE_NAME"),
+						},
+					},
+				},
+			},
+		}
+
+		revision := &armappcontainers.Revision{
+			Name: &previousRevisionName,
+			Properties: &armappcontainers.RevisionProperties{
+				Active: to.Ptr(false),
+				Template: &armappcontainers.Template{
+					Containers: []*armappcontainers.Container{
+						{
+							Image: &previousImageName,
+						},
+					},
+				},
+			},
+		}
+
+		mockContext := mocks.NewMockContext(context.Background())
+		_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+		_ = mockazsdk.MockContainerAppRevisionGet(
+			mockContext,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			previousRevisionName,
+			revision,
+		)
+		updateContainerAppRequest := mockazsdk.MockContainerAppUpdate(
+			mockContext,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			containerApp,
+		)
+
+		cas := NewContainerAppService(
+			mockContext.SubscriptionCredentialProvider,
+			clock.NewMock(),
+			mockContext.ArmClientOptions,
+			mockContext.AlphaFeaturesManager,
+		)
+		revisionName, err := cas.RollbackRevision(
+			*mockContext.Context, subscriptionId, resourceGroup, appName, previousRevisionName, nil)
+		require.NoError(t, err)
+		require.Equal(t, "APP_NAME--azd-0", revisionName)
+
+		// Verify a new revision is created from the template of the previous revision
+		var updatedContainerApp *armappcontainers.ContainerApp
+		err = json.NewDecoder(updateContainerAppRequest.Body).Decode(&updatedContainerApp)
+		require.NoError(t, err)
+		require.Equal(t, previousImageName, *updatedContainerApp.Properties.Template.Containers[0].Image)
+		require.Equal(t, "azd-0", *updatedContainerApp.Properties.Template.RevisionSuffix)
+	})
+}
+
+func Test_ContainerApp_StageRevision(t *testing.T) {
+	subscriptionId := "SUBSCRIPTION_ID"
+	location := "eastus2"
+	resourceGroup := "RESOURCE_GROUP"
+	appName := "APP_NAME"
+	currentRevisionName := "APP_NAME--azd-1"
+	updatedImageName := "UPDATED_IMAGE_NAME"
+
+	newContainerApp := func(revisionsMode armappcontainers.ActiveRevisionsMode) *armappcontainers.ContainerApp {
+		return &armappcontainers.ContainerApp{
+			Location: &location,
+			Name:     &appName,
+			Properties: &armappcontainers.ContainerAppProperties{
+				LatestRevisionName: &currentRevisionName,
+				Configuration: &armappcontainers.Configuration{
+					ActiveRevisionsMode: to.Ptr(revisionsMode),
+					Ingress: &armappcontainers.Ingress{
+						Traffic: []*armappcontainers.TrafficWeight{
+							{
+								LatestRevision: to.Ptr(true),
+								Weight:         to.Ptr(int32(100)),
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	revision := &armappcontainers.Revision{
+		Name: &currentRevisionName,
+		Properties: &armappcontainers.RevisionProperties{
+			Template: &armappcontainers.Template{
+				Containers: []*armappcontainers.Container{
+					{
+						Image: to.Ptr("CURRENT_IMAGE_NAME"),
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("Labelled", func(t *testing.T) {
+		containerApp := newContainerApp(armappcontainers.ActiveRevisionsModeMultiple)
+
+		mockContext := mocks.NewMockContext(context.Background())
+		_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+		_ = mockazsdk.MockContainerAppRevisionGet(
+			mockContext,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			currentRevisionName,
+			revision,
+		)
+		updateContainerAppRequest := mockazsdk.MockContainerAppUpdate(
+			mockContext,
+			subscriptionId,
+			resourceGroup,
+			appName,
+			containerApp,
+		)
+
+		cas := NewContainerAppService(
+			mockContext.SubscriptionCredentialProvider,
+			clock.NewMock(),
+			mockContext.ArmClientOptions,
+			mockContext.AlphaFeaturesManager,
+		)
+		revisionName, err := cas.StageRevision(
+			*mockContext.Context, subscriptionId, resourceGroup, appName, updatedImageName, "green", nil)
+		require.NoError(t, err)
+		require.Equal(t, "APP_NAME--azd-0", revisionName)
+
+		// Verify the traffic stays on the current revision, and the new revision is labelled
+		var updatedContainerApp *armappcontainers.ContainerApp
+		err = json.NewDecoder(updateContainerAppRequest.Body).Decode(&updatedContainerApp)
+		require.NoError(t, err)
+		traffic := updatedContainerApp.Properties.Configuration.Ingress.Traffic
+		require.Len(t, traffic, 2)
+		require.Equal(t, currentRevisionName, *traffic[0].RevisionName)
+		require.Equal(t, int32(100), *traffic[0].Weight)
+		require.Equal(t, revisionName, *traffic[1].RevisionName)
+		require.Equal(t, int32(0), *traffic[1].Weight)
+		require.Equal(t, "green", *traffic[1].Label)
+		require.Equal(t, updatedImageName, *updatedContainerApp.Properties.Template.Containers[0].Image)
+	})
+
+	t.Run("SingleRevisionMode", func(t *testing.T) {
+		containerApp := newContainerApp(armappcontainers.ActiveRevisionsModeSingle)
+
+		mockContext := mocks.NewMockContext(context.Background())
+		_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+
+		cas := NewContainerAppService(
+			mockContext.SubscriptionCredentialProvider,
+			clock.NewMock(),
+			mockContext.ArmClientOptions,
+			mockContext.AlphaFeaturesManager,
+		)
+		_, err := cas.StageRevision(
+			*mockContext.Context, subscriptionId, resourceGroup, appName, updatedImageName, "", nil)
+		require.ErrorContains(t, err, "multiple revisions mode")
+	})
+}
+
 func Test_ContainerApp_DeployYaml(t *testing.T) {
 	mockContext := mocks.NewMockContext(context.Background())
 
@@ -249,3 +505,108 @@ properties:
 	require.Equal(t, expected.Properties.Configuration, actual.Properties.Configuration)
 	require.Equal(t, expected.Properties.Template, actual.Properties.Template)
 }
+
+func Test_ContainerApp_StreamLogs(t *testing.T) {
+	subscriptionId := "SUBSCRIPTION_ID"
+	location := "eastus2"
+	resourceGroup := "RESOURCE_GROUP"
+	appName := "APP_NAME"
+	revisionName := "REVISION_NAME"
+	eventStreamEndpoint := fmt.Sprintf(
+		"https://%s.azurecontainerapps.dev/subscriptions/%s/resourceGroups/%s/containerApps/%s/eventstream",
+		location,
+		subscriptionId,
+		resourceGroup,
+		appName,
+	)
+
+	containerApp := &armappcontainers.ContainerApp{
+		Location: &location,
+		Name:     &appName,
+		Properties: &armappcontainers.ContainerAppProperties{
+			LatestRevisionName:  &revisionName,
+			EventStreamEndpoint: &eventStreamEndpoint,
+		},
+	}
+
+	mockContext := mocks.NewMockContext(context.Background())
+	_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet && strings.HasSuffix(request.URL.Path, "/replicas")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armappcontainers.ReplicaCollection{
+			Value: []*armappcontainers.Replica{
+				{
+					Name: to.Ptr("REPLICA_NAME"),
+					Properties: &armappcontainers.ReplicaProperties{
+						Containers: []*armappcontainers.ReplicaContainer{
+							{Name: to.Ptr("CONTAINER_NAME")},
+						},
+					},
+				},
+			},
+		})
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost && strings.HasSuffix(strings.ToLower(request.URL.Path), "/getauthtoken")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armappcontainers.ContainerAppAuthToken{
+			Properties: &armappcontainers.ContainerAppAuthTokenProperties{
+				Token: to.Ptr("LOG_STREAM_TOKEN"),
+			},
+		})
+	})
+
+	logStreamRequest := &http.Request{}
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.URL.Host == fmt.Sprintf("%s.azurecontainerapps.dev", location)
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		*logStreamRequest = *request
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Request:    request,
+			Body:       io.NopCloser(strings.NewReader("first line\nsecond line\n")),
+		}, nil
+	})
+
+	cas := NewContainerAppService(
+		mockContext.SubscriptionCredentialProvider,
+		clock.NewMock(),
+		mockContext.ArmClientOptions,
+		mockContext.AlphaFeaturesManager,
+	)
+
+	logs := &bytes.Buffer{}
+	err := cas.StreamLogs(
+		*mockContext.Context,
+		subscriptionId,
+		resourceGroup,
+		appName,
+		&LogStreamOptions{Follow: true, TailLines: 100},
+		nil,
+		logs,
+	)
+	require.NoError(t, err)
+	require.Equal(t, "first line\nsecond line\n", logs.String())
+
+	require.Equal(t, "Bearer LOG_STREAM_TOKEN", logStreamRequest.Header.Get("Authorization"))
+	require.Equal(
+		t,
+		fmt.Sprintf(
+			"/subscriptions/%s/resourceGroups/%s/containerApps/%s/revisions/%s/replicas/%s/containers/%s/logstream",
+			subscriptionId,
+			resourceGroup,
+			appName,
+			revisionName,
+			"REPLICA_NAME",
+			"CONTAINER_NAME",
+		),
+		logStreamRequest.URL.Path,
+	)
+	require.Equal(t, "true", logStreamRequest.URL.Query().Get("follow"))
+	require.Equal(t, "100", logStreamRequest.URL.Query().Get("tailLines"))
+}
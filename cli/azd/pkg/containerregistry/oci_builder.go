@@ -0,0 +1,796 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package containerregistry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/docker"
+)
+
+const (
+	mediaTypeOciIndex           = "application/vnd.oci.image.index.v1+json"
+	mediaTypeOciManifest        = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeOciLayer           = "application/vnd.oci.image.layer.v1.tar+gzip"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerLayer        = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+)
+
+// The registry and the repository namespace of the images without a registry, e.g. 'nginx:latest'
+const (
+	defaultRegistry  = "registry-1.docker.io"
+	defaultNamespace = "library"
+)
+
+// DefaultOciWorkingDir is the directory of the image the source is copied to by default.
+const DefaultOciWorkingDir = "/app"
+
+// OciBuildOptions are the options of an image built without a container engine.
+type OciBuildOptions struct {
+	// The image the image is built on, e.g. 'mcr.microsoft.com/dotnet/aspnet:8.0'
+	BaseImage string
+	// The path of the directory, or the file, copied to the image
+	Source string
+	// The directory of the image the source is copied to, which is the working directory of the image. Defaults to /app.
+	WorkingDir string
+	// The entrypoint of the image. The entrypoint and the command of the base image are kept when empty.
+	Entrypoint []string
+	// The platform of the image, e.g. 'linux/amd64', selecting the image of the base image when it is multi-platform
+	Platform string
+	// The image pushed, e.g. 'myregistry.azurecr.io/app/web:azd-deploy-1700000000'
+	Image string
+	// The credentials of the registry of the pushed image
+	Username string
+	Password string
+}
+
+// OciBuilder builds container images without a container engine, like ko or jib: it adds a layer with the source files
+// to the layers of a base image, and pushes the image to a registry.
+type OciBuilder struct {
+	transport policy.Transporter
+}
+
+func NewOciBuilder(transport policy.Transporter) *OciBuilder {
+	return &OciBuilder{
+		transport: transport,
+	}
+}
+
+// Build builds and pushes the image, returning the digest of its manifest.
+func (b *OciBuilder) Build(ctx context.Context, options OciBuildOptions) (string, error) {
+	baseImage, err := parseOciReference(options.BaseImage)
+	if err != nil {
+		return "", fmt.Errorf("parsing base image: %w", err)
+	}
+
+	targetImage, err := parseOciReference(options.Image)
+	if err != nil {
+		return "", fmt.Errorf("parsing image: %w", err)
+	}
+
+	platform := options.Platform
+	if platform == "" {
+		platform = docker.DefaultPlatform
+	}
+
+	if options.WorkingDir == "" {
+		options.WorkingDir = DefaultOciWorkingDir
+	}
+
+	base := &registryClient{
+		transport:  b.transport,
+		registry:   baseImage.registry,
+		repository: baseImage.repository,
+		scope:      fmt.Sprintf("repository:%s:pull", baseImage.repository),
+	}
+
+	target := &registryClient{
+		transport:  b.transport,
+		registry:   targetImage.registry,
+		repository: targetImage.repository,
+		scope:      fmt.Sprintf("repository:%s:pull,push", targetImage.repository),
+		username:   options.Username,
+		password:   options.Password,
+	}
+
+	log.Printf("reading base image %s", options.BaseImage)
+	baseManifest, err := base.platformManifest(ctx, baseImage.reference, platform)
+	if err != nil {
+		return "", fmt.Errorf("reading base image %s: %w", options.BaseImage, err)
+	}
+
+	baseConfig, err := base.blob(ctx, baseManifest.Config.Digest)
+	if err != nil {
+		return "", fmt.Errorf("reading the configuration of base image %s: %w", options.BaseImage, err)
+	}
+
+	layerFile, err := os.CreateTemp("", "azd-oci-layer-*.tar.gz")
+	if err != nil {
+		return "", fmt.Errorf("creating image layer: %w", err)
+	}
+	defer func() {
+		_ = layerFile.Close()
+		_ = os.Remove(layerFile.Name())
+	}()
+
+	layer, diffId, err := writeLayer(layerFile, options.Source, options.WorkingDir)
+	if err != nil {
+		return "", fmt.Errorf("creating image layer: %w", err)
+	}
+
+	layer.MediaType = mediaTypeOciLayer
+	if baseManifest.MediaType == mediaTypeDockerManifest {
+		layer.MediaType = mediaTypeDockerLayer
+	}
+
+	config, err := imageConfig(baseConfig, diffId, options)
+	if err != nil {
+		return "", err
+	}
+
+	manifest := ociManifest{
+		SchemaVersion: 2,
+		MediaType:     baseManifest.MediaType,
+		Config: ociDescriptor{
+			MediaType: baseManifest.Config.MediaType,
+			Digest:    digestOf(config),
+			Size:      int64(len(config)),
+		},
+		Layers: append(baseManifest.Layers[:len(baseManifest.Layers):len(baseManifest.Layers)], layer),
+	}
+
+	// The layers of the base image are copied to the registry of the image, unless it already has them
+	for _, baseLayer := range baseManifest.Layers {
+		err := target.pushBlob(ctx, baseLayer.Digest, baseLayer.Size, func() (io.ReadCloser, error) {
+			log.Printf("copying layer %s of base image %s", baseLayer.Digest, options.BaseImage)
+			return base.blobReader(ctx, baseLayer.Digest)
+		})
+		if err != nil {
+			return "", fmt.Errorf("copying layer %s of base image: %w", baseLayer.Digest, err)
+		}
+	}
+
+	err = target.pushBlob(ctx, layer.Digest, layer.Size, func() (io.ReadCloser, error) {
+		return os.Open(layerFile.Name())
+	})
+	if err != nil {
+		return "", fmt.Errorf("pushing image layer: %w", err)
+	}
+
+	err = target.pushBlob(ctx, manifest.Config.Digest, manifest.Config.Size, func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(config)), nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("pushing image configuration: %w", err)
+	}
+
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+
+	if err := target.putManifest(ctx, targetImage.reference, manifest.MediaType, manifestBytes); err != nil {
+		return "", fmt.Errorf("pushing image manifest: %w", err)
+	}
+
+	return digestOf(manifestBytes), nil
+}
+
+// imageConfig returns the configuration of the image, the configuration of the base image with the layer of the source.
+// The fields of the configuration which azd doesn't change are kept as is.
+func imageConfig(baseConfig []byte, diffId string, options OciBuildOptions) ([]byte, error) {
+	var config map[string]any
+	if err := json.Unmarshal(baseConfig, &config); err != nil {
+		return nil, fmt.Errorf("parsing the configuration of the base image: %w", err)
+	}
+
+	runConfig, _ := config["config"].(map[string]any)
+	if runConfig == nil {
+		runConfig = map[string]any{}
+	}
+
+	runConfig["WorkingDir"] = options.WorkingDir
+	if len(options.Entrypoint) > 0 {
+		runConfig["Entrypoint"] = options.Entrypoint
+		delete(runConfig, "Cmd")
+	}
+	config["config"] = runConfig
+
+	rootfs, _ := config["rootfs"].(map[string]any)
+	if rootfs == nil {
+		rootfs = map[string]any{"type": "layers"}
+	}
+	diffIds, _ := rootfs["diff_ids"].([]any)
+	rootfs["diff_ids"] = append(diffIds, diffId)
+	config["rootfs"] = rootfs
+
+	history, _ := config["history"].([]any)
+	config["history"] = append(history, map[string]any{
+		"created_by": fmt.Sprintf("azd: COPY . %s", options.WorkingDir),
+		"comment":    "azd oci builder",
+	})
+
+	return json.Marshal(config)
+}
+
+// writeLayer writes the source to a gzipped tar layer, in the directory of the image. It returns the descriptor of the
+// layer and the digest of the uncompressed tar, the diff id of the layer.
+//
+// The entries of the layer have no timestamps or owners, so that the same source produces the same layer.
+func writeLayer(w io.Writer, source string, imageDir string) (ociDescriptor, string, error) {
+	compressedHash := sha256.New()
+	counter := &countingWriter{}
+	gzipWriter := gzip.NewWriter(io.MultiWriter(w, compressedHash, counter))
+
+	diffHash := sha256.New()
+	tarWriter := tar.NewWriter(io.MultiWriter(gzipWriter, diffHash))
+
+	imageDir = strings.Trim(path.Clean("/"+filepath.ToSlash(imageDir)), "/")
+	epoch := time.Unix(0, 0)
+
+	// The directories of the image directory
+	if imageDir != "" {
+		parts := strings.Split(imageDir, "/")
+		for i := range parts {
+			err := tarWriter.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeDir,
+				Name:     strings.Join(parts[:i+1], "/") + "/",
+				Mode:     0755,
+				ModTime:  epoch,
+			})
+			if err != nil {
+				return ociDescriptor{}, "", err
+			}
+		}
+	}
+
+	sourceInfo, err := os.Stat(source)
+	if err != nil {
+		return ociDescriptor{}, "", err
+	}
+
+	root := source
+	if !sourceInfo.IsDir() {
+		root = filepath.Dir(source)
+	}
+
+	err = filepath.WalkDir(source, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return err
+		}
+
+		if relPath == "." {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		link := ""
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(filePath); err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+
+		header.Name = path.Join(imageDir, filepath.ToSlash(relPath))
+		if d.IsDir() {
+			header.Name += "/"
+		}
+		header.ModTime = epoch
+		header.AccessTime = time.Time{}
+		header.ChangeTime = time.Time{}
+		header.Uid, header.Gid = 0, 0
+		header.Uname, header.Gname = "", ""
+		header.Format = tar.FormatPAX
+
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(tarWriter, file)
+		return err
+	})
+	if err != nil {
+		return ociDescriptor{}, "", err
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return ociDescriptor{}, "", err
+	}
+
+	if err := gzipWriter.Close(); err != nil {
+		return ociDescriptor{}, "", err
+	}
+
+	return ociDescriptor{
+		Digest: fmt.Sprintf("sha256:%x", compressedHash.Sum(nil)),
+		Size:   counter.count,
+	}, fmt.Sprintf("sha256:%x", diffHash.Sum(nil)), nil
+}
+
+type countingWriter struct {
+	count int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.count += int64(len(p))
+	return len(p), nil
+}
+
+func digestOf(content []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+}
+
+type ociDescriptor struct {
+	MediaType string       `json:"mediaType"`
+	Digest    string       `json:"digest"`
+	Size      int64        `json:"size"`
+	Platform  *ociPlatform `json:"platform,omitempty"`
+}
+
+type ociPlatform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+type ociManifest struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType,omitempty"`
+	Config        ociDescriptor   `json:"config"`
+	Layers        []ociDescriptor `json:"layers"`
+}
+
+type ociIndex struct {
+	MediaType string          `json:"mediaType,omitempty"`
+	Manifests []ociDescriptor `json:"manifests"`
+}
+
+// ociReference is a reference to an image of a registry.
+type ociReference struct {
+	registry   string
+	repository string
+	// The tag or the digest of the image
+	reference string
+}
+
+func parseOciReference(image string) (ociReference, error) {
+	name, digest, hasDigest := strings.Cut(image, "@")
+
+	parsed, err := docker.ParseContainerImage(name)
+	if err != nil {
+		return ociReference{}, err
+	}
+
+	reference := ociReference{
+		registry:   parsed.Registry,
+		repository: parsed.Repository,
+		reference:  parsed.Tag,
+	}
+
+	// Registries without a dot in their name, like 'localhost:5000', aren't detected by ParseContainerImage
+	if first, rest, has := strings.Cut(reference.repository, "/"); reference.registry == "" && has &&
+		(first == "localhost" || strings.Contains(first, ":")) {
+		reference.registry = first
+		reference.repository = rest
+	}
+
+	if reference.registry == "docker.io" {
+		reference.registry = ""
+	}
+
+	if reference.registry == "" {
+		reference.registry = defaultRegistry
+		if !strings.Contains(reference.repository, "/") {
+			reference.repository = defaultNamespace + "/" + reference.repository
+		}
+	}
+
+	if hasDigest {
+		reference.reference = digest
+	} else if reference.reference == "" {
+		reference.reference = "latest"
+	}
+
+	return reference, nil
+}
+
+// registryClient is a client of the OCI distribution API for a repository of a registry. Requests are authenticated as
+// challenged by the registry, with the credentials of the client when it has them, anonymously otherwise.
+type registryClient struct {
+	transport  policy.Transporter
+	registry   string
+	repository string
+	// The scope of the tokens of the repository
+	scope    string
+	username string
+	password string
+
+	// The authorization header of the requests, once challenged
+	authorization string
+}
+
+func (c *registryClient) baseUrl() string {
+	// Local registries are usually served over plain HTTP
+	scheme := "https"
+	host := strings.Split(c.registry, ":")[0]
+	if host == "localhost" || host == "127.0.0.1" {
+		scheme = "http"
+	}
+
+	return fmt.Sprintf("%s://%s/v2/%s/", scheme, c.registry, c.repository)
+}
+
+// do sends the request made by newRequest, authenticating it when the registry challenges it.
+func (c *registryClient) do(
+	ctx context.Context, newRequest func() (*http.Request, error),
+) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		if c.authorization != "" {
+			req.Header.Set("Authorization", c.authorization)
+		}
+
+		res, err := c.transport.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusUnauthorized || attempt > 0 {
+			return res, nil
+		}
+
+		challenge := res.Header.Get("WWW-Authenticate")
+		res.Body.Close()
+
+		if err := c.authenticate(ctx, challenge); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// authenticate sets the authorization of the requests for the challenge of the registry.
+func (c *registryClient) authenticate(ctx context.Context, challenge string) error {
+	scheme, params := parseChallenge(challenge)
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.username == "" {
+			return fmt.Errorf("registry %s requires credentials", c.registry)
+		}
+
+		c.authorization = "Basic " + basicCredentials(c.username, c.password)
+		return nil
+	case "bearer":
+		realm, err := url.Parse(params["realm"])
+		if err != nil || params["realm"] == "" {
+			return fmt.Errorf("registry %s returned an invalid authentication challenge: %s", c.registry, challenge)
+		}
+
+		query := realm.Query()
+		if service := params["service"]; service != "" {
+			query.Set("service", service)
+		}
+		query.Set("scope", c.scope)
+		realm.RawQuery = query.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+		if err != nil {
+			return err
+		}
+
+		if c.username != "" {
+			req.Header.Set("Authorization", "Basic "+basicCredentials(c.username, c.password))
+		}
+
+		res, err := c.transport.Do(req)
+		if err != nil {
+			return fmt.Errorf("getting registry token: %w", err)
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusOK {
+			return fmt.Errorf("getting registry token of %s: %s", c.registry, res.Status)
+		}
+
+		var token struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&token); err != nil {
+			return fmt.Errorf("getting registry token: %w", err)
+		}
+
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+
+		c.authorization = "Bearer " + token.Token
+		return nil
+	default:
+		return fmt.Errorf("registry %s returned an unsupported authentication challenge: %s", c.registry, challenge)
+	}
+}
+
+// platformManifest returns the manifest of the image for the platform, selecting it from the index of a multi-platform
+// image.
+func (c *registryClient) platformManifest(ctx context.Context, reference string, platform string) (*ociManifest, error) {
+	content, mediaType, err := c.manifest(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	if mediaType == mediaTypeOciIndex || mediaType == mediaTypeDockerManifestList {
+		var index ociIndex
+		if err := json.Unmarshal(content, &index); err != nil {
+			return nil, fmt.Errorf("parsing image index: %w", err)
+		}
+
+		parts := strings.Split(platform, "/")
+		for _, descriptor := range index.Manifests {
+			if descriptor.Platform == nil ||
+				descriptor.Platform.OS != parts[0] ||
+				len(parts) < 2 || descriptor.Platform.Architecture != parts[1] ||
+				(len(parts) > 2 && descriptor.Platform.Variant != parts[2]) {
+				continue
+			}
+
+			return c.platformManifest(ctx, descriptor.Digest, platform)
+		}
+
+		return nil, fmt.Errorf("the image has no manifest for platform %s", platform)
+	}
+
+	var manifest ociManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing image manifest: %w", err)
+	}
+
+	if manifest.MediaType == "" {
+		manifest.MediaType = mediaType
+	}
+
+	if manifest.MediaType != mediaTypeOciManifest && manifest.MediaType != mediaTypeDockerManifest {
+		return nil, fmt.Errorf("unsupported image manifest type '%s'", manifest.MediaType)
+	}
+
+	return &manifest, nil
+}
+
+func (c *registryClient) manifest(ctx context.Context, reference string) ([]byte, string, error) {
+	res, err := c.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseUrl()+"manifests/"+reference, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Accept", strings.Join([]string{
+			mediaTypeOciIndex, mediaTypeDockerManifestList, mediaTypeOciManifest, mediaTypeDockerManifest,
+		}, ", "))
+		return req, nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("getting manifest %s of %s/%s: %s", reference, c.registry, c.repository, res.Status)
+	}
+
+	content, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	mediaType, _, _ := strings.Cut(res.Header.Get("Content-Type"), ";")
+	return content, strings.TrimSpace(mediaType), nil
+}
+
+func (c *registryClient) blobReader(ctx context.Context, digest string) (io.ReadCloser, error) {
+	res, err := c.do(ctx, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, c.baseUrl()+"blobs/"+digest, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("getting blob %s of %s/%s: %s", digest, c.registry, c.repository, res.Status)
+	}
+
+	return res.Body, nil
+}
+
+func (c *registryClient) blob(ctx context.Context, digest string) ([]byte, error) {
+	reader, err := c.blobReader(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+func (c *registryClient) blobExists(ctx context.Context, digest string) (bool, error) {
+	res, err := c.do(ctx, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodHead, c.baseUrl()+"blobs/"+digest, nil)
+	})
+	if err != nil {
+		return false, err
+	}
+	res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("checking blob %s of %s/%s: %s", digest, c.registry, c.repository, res.Status)
+	}
+}
+
+// pushBlob uploads the blob of the content opened by open, unless the repository already has it.
+func (c *registryClient) pushBlob(
+	ctx context.Context, digest string, size int64, open func() (io.ReadCloser, error),
+) error {
+	exists, err := c.blobExists(ctx, digest)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return nil
+	}
+
+	return c.uploadBlob(ctx, digest, size, open)
+}
+
+// uploadBlob uploads the blob of the content opened by open in a single request.
+func (c *registryClient) uploadBlob(
+	ctx context.Context, digest string, size int64, open func() (io.ReadCloser, error),
+) error {
+	res, err := c.do(ctx, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodPost, c.baseUrl()+"blobs/uploads/", nil)
+	})
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("starting the upload of blob %s to %s/%s: %s", digest, c.registry, c.repository, res.Status)
+	}
+
+	location, err := res.Request.URL.Parse(res.Header.Get("Location"))
+	if err != nil {
+		return fmt.Errorf("invalid upload location: %w", err)
+	}
+
+	query := location.Query()
+	query.Set("digest", digest)
+	location.RawQuery = query.Encode()
+
+	res, err = c.do(ctx, func() (*http.Request, error) {
+		body, err := open()
+		if err != nil {
+			return nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, location.String(), body)
+		if err != nil {
+			body.Close()
+			return nil, err
+		}
+
+		req.ContentLength = size
+		req.Header.Set("Content-Type", "application/octet-stream")
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return fmt.Errorf("uploading blob %s to %s/%s: %s", digest, c.registry, c.repository, res.Status)
+	}
+
+	return nil
+}
+
+func (c *registryClient) putManifest(ctx context.Context, reference string, mediaType string, content []byte) error {
+	res, err := c.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(
+			ctx, http.MethodPut, c.baseUrl()+"manifests/"+reference, bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Content-Type", mediaType)
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return fmt.Errorf("putting manifest %s to %s/%s: %s", reference, c.registry, c.repository, res.Status)
+	}
+
+	return nil
+}
+
+// parseChallenge parses a WWW-Authenticate header like 'Bearer realm="https://auth.docker.io/token",service="x"'.
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+
+		params[strings.ToLower(strings.TrimSpace(key))] = value
+	}
+
+	return scheme, params
+}
+
+func basicCredentials(username string, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+}
@@ -0,0 +1,305 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package containerregistry
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRegistry is a registry of the OCI distribution API keeping its blobs and manifests in memory. Requests require a
+// bearer token, issued anonymously for pulls and for the credentials 'user' and 'pass' for pushes.
+type fakeRegistry struct {
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	types     map[string]string
+	uploads   int
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{
+		blobs:     map[string][]byte{},
+		manifests: map[string][]byte{},
+		types:     map[string]string{},
+	}
+}
+
+func (r *fakeRegistry) addBlob(repository string, content []byte) string {
+	digest := digestOf(content)
+	r.blobs[repository+"@"+digest] = content
+	return digest
+}
+
+func (r *fakeRegistry) addManifest(repository string, reference string, mediaType string, manifest any) string {
+	content, _ := json.Marshal(manifest)
+	digest := digestOf(content)
+	for _, ref := range []string{reference, digest} {
+		r.manifests[repository+":"+ref] = content
+		r.types[repository+":"+ref] = mediaType
+	}
+
+	return digest
+}
+
+func (r *fakeRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if req.URL.Path == "/token" {
+		user, pass, _ := req.BasicAuth()
+		if strings.Contains(req.URL.Query().Get("scope"), "push") && (user != "user" || pass != "pass") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"token":"TOKEN"}`))
+		return
+	}
+
+	if req.Header.Get("Authorization") != "Bearer TOKEN" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="http://%s/token",service="fake"`, req.Host))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	repositoryPath := strings.TrimPrefix(req.URL.Path, "/v2/")
+	i := strings.LastIndex(repositoryPath, "/manifests/")
+	if i < 0 {
+		i = strings.Index(repositoryPath, "/blobs/")
+	}
+	if i < 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	repository, rest := repositoryPath[:i], repositoryPath[i+1:]
+
+	switch {
+	case strings.HasPrefix(rest, "manifests/"):
+		key := repository + ":" + strings.TrimPrefix(rest, "manifests/")
+		if req.Method == http.MethodPut {
+			content, _ := io.ReadAll(req.Body)
+			r.manifests[key] = content
+			r.types[key] = req.Header.Get("Content-Type")
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+
+		content, has := r.manifests[key]
+		if !has {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", r.types[key])
+		_, _ = w.Write(content)
+	case rest == "blobs/uploads/":
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%d?state=x", repository, r.uploads))
+		w.WriteHeader(http.StatusAccepted)
+	case strings.HasPrefix(rest, "blobs/uploads/"):
+		content, _ := io.ReadAll(req.Body)
+		digest := req.URL.Query().Get("digest")
+		if digestOf(content) != digest || req.URL.Query().Get("state") != "x" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		r.blobs[repository+"@"+digest] = content
+		r.uploads++
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(rest, "blobs/"):
+		content, has := r.blobs[repository+"@"+strings.TrimPrefix(rest, "blobs/")]
+		if !has {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(content)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func Test_OciBuilder_Build(t *testing.T) {
+	registry := newFakeRegistry()
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	baseLayer := registry.addBlob("base", []byte("base layer"))
+	baseConfig := registry.addBlob("base", []byte(`{
+		"architecture": "arm64",
+		"os": "linux",
+		"config": {"Env": ["PATH=/usr/bin"], "Cmd": ["sh"]},
+		"rootfs": {"type": "layers", "diff_ids": ["sha256:base"]}
+	}`))
+	armManifest := registry.addManifest("base", "arm64", mediaTypeOciManifest, ociManifest{
+		SchemaVersion: 2,
+		MediaType:     mediaTypeOciManifest,
+		Config:        ociDescriptor{MediaType: "application/vnd.oci.image.config.v1+json", Digest: baseConfig, Size: 1},
+		Layers:        []ociDescriptor{{MediaType: mediaTypeOciLayer, Digest: baseLayer, Size: 10}},
+	})
+	registry.addManifest("base", "latest", mediaTypeOciIndex, ociIndex{
+		MediaType: mediaTypeOciIndex,
+		Manifests: []ociDescriptor{
+			{MediaType: mediaTypeOciManifest, Digest: "sha256:amd64", Platform: &ociPlatform{OS: "linux", Architecture: "amd64"}},
+			{MediaType: mediaTypeOciManifest, Digest: armManifest, Platform: &ociPlatform{OS: "linux", Architecture: "arm64"}},
+		},
+	})
+
+	source := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(source, "lib"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(source, "app.js"), []byte("console.log(1)"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(source, "lib", "util.js"), []byte("exports.x = 1"), 0600))
+
+	builder := NewOciBuilder(http.DefaultClient)
+	digest, err := builder.Build(context.Background(), OciBuildOptions{
+		BaseImage:  host + "/base:latest",
+		Source:     source,
+		Entrypoint: []string{"node", "app.js"},
+		Platform:   "linux/arm64",
+		Image:      host + "/app/web:azd-deploy-1",
+		Username:   "user",
+		Password:   "pass",
+	})
+	require.NoError(t, err)
+
+	pushed := registry.manifests["app/web:azd-deploy-1"]
+	require.Equal(t, digestOf(pushed), digest)
+	require.Equal(t, mediaTypeOciManifest, registry.types["app/web:azd-deploy-1"])
+
+	var manifest ociManifest
+	require.NoError(t, json.Unmarshal(pushed, &manifest))
+	require.Len(t, manifest.Layers, 2)
+	require.Equal(t, baseLayer, manifest.Layers[0].Digest)
+	require.Contains(t, registry.blobs, "app/web@"+baseLayer)
+	require.Equal(t, mediaTypeOciLayer, manifest.Layers[1].MediaType)
+
+	// The configuration keeps the fields of the base image
+	var config struct {
+		Architecture string `json:"architecture"`
+		Config       struct {
+			Env        []string `json:"Env"`
+			Cmd        []string `json:"Cmd"`
+			Entrypoint []string `json:"Entrypoint"`
+			WorkingDir string   `json:"WorkingDir"`
+		} `json:"config"`
+		RootFS struct {
+			DiffIds []string `json:"diff_ids"`
+		} `json:"rootfs"`
+	}
+	require.NoError(t, json.Unmarshal(registry.blobs["app/web@"+manifest.Config.Digest], &config))
+	require.Equal(t, "arm64", config.Architecture)
+	require.Equal(t, []string{"PATH=/usr/bin"}, config.Config.Env)
+	require.Empty(t, config.Config.Cmd)
+	require.Equal(t, []string{"node", "app.js"}, config.Config.Entrypoint)
+	require.Equal(t, "/app", config.Config.WorkingDir)
+	require.Len(t, config.RootFS.DiffIds, 2)
+
+	// The layer has the source in the working directory
+	gzipReader, err := gzip.NewReader(strings.NewReader(string(registry.blobs["app/web@"+manifest.Layers[1].Digest])))
+	require.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+	names := []string{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		require.Equal(t, int64(0), header.ModTime.Unix())
+		names = append(names, header.Name)
+	}
+	require.Equal(t, []string{"app/", "app/app.js", "app/lib/", "app/lib/util.js"}, names)
+
+	// The blobs which the registry already has aren't uploaded again, only the new configuration is
+	uploads := registry.uploads
+	_, err = builder.Build(context.Background(), OciBuildOptions{
+		BaseImage: host + "/base:latest",
+		Source:    source,
+		Platform:  "linux/arm64",
+		Image:     host + "/app/web:azd-deploy-2",
+		Username:  "user",
+		Password:  "pass",
+	})
+	require.NoError(t, err)
+	require.Equal(t, uploads+1, registry.uploads)
+}
+
+func Test_OciBuilder_Build_Reproducible(t *testing.T) {
+	source := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(source, "main"), []byte("binary"), 0600))
+
+	first, firstDiffId, err := writeLayer(io.Discard, source, "/app")
+	require.NoError(t, err)
+
+	now := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(source, "main"), now, now))
+
+	second, secondDiffId, err := writeLayer(io.Discard, source, "/app")
+	require.NoError(t, err)
+
+	require.Equal(t, first, second)
+	require.Equal(t, firstDiffId, secondDiffId)
+}
+
+func Test_OciBuilder_Build_NoPlatform(t *testing.T) {
+	registry := newFakeRegistry()
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	registry.addManifest("base", "latest", mediaTypeOciIndex, ociIndex{
+		MediaType: mediaTypeOciIndex,
+		Manifests: []ociDescriptor{
+			{MediaType: mediaTypeOciManifest, Digest: "sha256:arm64", Platform: &ociPlatform{OS: "linux", Architecture: "arm64"}},
+		},
+	})
+
+	_, err := NewOciBuilder(http.DefaultClient).Build(context.Background(), OciBuildOptions{
+		BaseImage: host + "/base:latest",
+		Source:    t.TempDir(),
+		Image:     host + "/app:latest",
+		Username:  "user",
+		Password:  "pass",
+	})
+	require.ErrorContains(t, err, "no manifest for platform linux/amd64")
+}
+
+func Test_ParseOciReference(t *testing.T) {
+	tests := []struct {
+		image    string
+		expected ociReference
+	}{
+		{"nginx", ociReference{defaultRegistry, "library/nginx", "latest"}},
+		{"docker.io/grafana/grafana:10", ociReference{defaultRegistry, "grafana/grafana", "10"}},
+		{"mcr.microsoft.com/dotnet/aspnet:8.0", ociReference{"mcr.microsoft.com", "dotnet/aspnet", "8.0"}},
+		{"localhost:5000/app", ociReference{"localhost:5000", "app", "latest"}},
+		{"myregistry.azurecr.io/app@sha256:abc", ociReference{"myregistry.azurecr.io", "app", "sha256:abc"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.image, func(t *testing.T) {
+			reference, err := parseOciReference(test.image)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, reference)
+		})
+	}
+}
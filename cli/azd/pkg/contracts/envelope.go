@@ -9,6 +9,14 @@ type EventDataType string
 
 const (
 	ConsoleMessageEventDataType EventDataType = "consoleMessage"
+
+	// The event types of the `--output jsonl` event stream
+	StepStartedEventDataType     EventDataType = "stepStarted"
+	StepFinishedEventDataType    EventDataType = "stepFinished"
+	ResourceCreatedEventDataType EventDataType = "resourceCreated"
+	LogLineEventDataType         EventDataType = "logLine"
+	PromptNeededEventDataType    EventDataType = "promptNeeded"
+	ResultEventDataType          EventDataType = "result"
 )
 
 type EventEnvelope struct {
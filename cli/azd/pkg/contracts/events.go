@@ -0,0 +1,67 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package contracts
+
+// StepStatus is the outcome of a step, reported by a StepFinished event.
+type StepStatus string
+
+const (
+	StepStatusDone    StepStatus = "done"
+	StepStatusFailed  StepStatus = "failed"
+	StepStatusWarning StepStatus = "warning"
+	StepStatusSkipped StepStatus = "skipped"
+	// The step stopped without reporting an outcome.
+	StepStatusStopped StepStatus = "stopped"
+)
+
+// StepStarted is the data of the stepStarted event, emitted when azd starts a step of a command, or when the title of
+// the running step changes to report its progress.
+type StepStarted struct {
+	Title string `json:"title"`
+}
+
+// StepFinished is the data of the stepFinished event, emitted when a step of a command finishes.
+type StepFinished struct {
+	Title  string     `json:"title"`
+	Status StepStatus `json:"status"`
+}
+
+// ResourceCreated is the data of the resourceCreated event, emitted when a resource of a provisioning operation reaches
+// a terminal state.
+type ResourceCreated struct {
+	Type       string `json:"type"`
+	Name       string `json:"name"`
+	State      string `json:"state"`
+	DurationMs int64  `json:"durationMs,omitempty"`
+}
+
+// LogLine is the data of the logLine event, emitted for every message azd writes to the console. Source is set when the
+// line comes from a process azd runs, like a hook.
+type LogLine struct {
+	Message string `json:"message"`
+	Source  string `json:"source,omitempty"`
+}
+
+// PromptKind is the kind of value a prompt asks for.
+type PromptKind string
+
+const (
+	PromptKindString      PromptKind = "string"
+	PromptKindPassword    PromptKind = "password"
+	PromptKindDirectory   PromptKind = "directory"
+	PromptKindSelect      PromptKind = "select"
+	PromptKindMultiSelect PromptKind = "multiSelect"
+	PromptKindConfirm     PromptKind = "confirm"
+)
+
+// PromptNeeded is the data of the promptNeeded event, emitted before azd reads the response to a prompt from stdin, as
+// a single line. Select prompts are answered with the value of an option, multi select prompts with a y or n line for each
+// option, in order, and confirm prompts with y or n. An empty line accepts the default value.
+type PromptNeeded struct {
+	Kind         PromptKind `json:"kind"`
+	Message      string     `json:"message"`
+	Help         string     `json:"help,omitempty"`
+	Options      []string   `json:"options,omitempty"`
+	DefaultValue any        `json:"defaultValue,omitempty"`
+}
@@ -12,10 +12,12 @@ type RemoteKind string
 
 const (
 	RemoteKindAzureBlobStorage RemoteKind = "AzureBlobStorage"
+	RemoteKindGit              RemoteKind = "Git"
 )
 
 var ValidRemoteKinds = []string{
 	string(RemoteKindAzureBlobStorage),
+	string(RemoteKindGit),
 }
 
 // SaveOptions provide additional metadata for the save operation
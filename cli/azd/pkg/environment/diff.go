@@ -0,0 +1,249 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DiffSource is the location of an environment value compared by Diff
+type DiffSource string
+
+const (
+	// The value is stored within the environment .env file
+	DiffSourceDotenv DiffSource = "dotenv"
+	// The value is stored within the environment config.json file
+	DiffSourceConfig DiffSource = "config"
+)
+
+// DiffStatus describes how an environment value differs between two environments
+type DiffStatus string
+
+const (
+	// The value only exists in the right environment
+	DiffStatusAdded DiffStatus = "added"
+	// The value only exists in the left environment
+	DiffStatusRemoved DiffStatus = "removed"
+	// The value exists in both environments with different values
+	DiffStatusChanged DiffStatus = "changed"
+)
+
+// SecretMask is displayed in place of the value of secrets
+const SecretMask = "********"
+
+// vaultReferencePrefix is the prefix of config values that reference a secret stored in the user vault
+const vaultReferencePrefix = "vault://"
+
+// camelCaseBoundaryRegex matches the boundary between the words of camel case names, ex) adminPassword
+var camelCaseBoundaryRegex = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// ValueDiff is a single value that differs between two environments
+type ValueDiff struct {
+	Source DiffSource `json:"source"`
+	// The name of the .env value or the dotted path of the config value
+	Key    string     `json:"key"`
+	Status DiffStatus `json:"status"`
+	Left   *string    `json:"left,omitempty"`
+	Right  *string    `json:"right,omitempty"`
+	// Whether the value is considered a secret
+	Secret bool `json:"secret"`
+}
+
+// Diff compares the .env values and the config.json values of two environments.
+// Only the values that differ are returned, ordered by source and key.
+func Diff(left *Environment, right *Environment) ([]*ValueDiff, error) {
+	leftConfig, err := flattenConfig(left)
+	if err != nil {
+		return nil, fmt.Errorf("reading config of environment '%s': %w", left.Name(), err)
+	}
+
+	rightConfig, err := flattenConfig(right)
+	if err != nil {
+		return nil, fmt.Errorf("reading config of environment '%s': %w", right.Name(), err)
+	}
+
+	diffs := diffValues(DiffSourceDotenv, flattenDotenv(left), flattenDotenv(right))
+	diffs = append(diffs, diffValues(DiffSourceConfig, leftConfig, rightConfig)...)
+
+	return diffs, nil
+}
+
+// MaskSecrets replaces the values of the secrets of the specified diffs
+func MaskSecrets(diffs []*ValueDiff) {
+	mask := SecretMask
+	for _, diff := range diffs {
+		if !diff.Secret {
+			continue
+		}
+
+		if diff.Left != nil {
+			diff.Left = &mask
+		}
+
+		if diff.Right != nil {
+			diff.Right = &mask
+		}
+	}
+}
+
+// IsSecretKey returns true when the name of the environment value suggests the value is a secret,
+// ex) AZURE_CLIENT_SECRET, DB_PASSWORD, STORAGE_ACCOUNT_KEY or adminPassword
+func IsSecretKey(key string) bool {
+	// Split camel case names, ex) adminPassword => admin_Password
+	key = camelCaseBoundaryRegex.ReplaceAllString(key, "${1}_${2}")
+	segments := strings.FieldsFunc(strings.ToUpper(key), func(r rune) bool {
+		return r == '_' || r == '-' || r == '.'
+	})
+
+	for i, segment := range segments {
+		switch segment {
+		case "SECRET", "SECRETS", "PASSWORD", "PWD", "TOKEN", "CONNECTIONSTRING", "SAS", "CREDENTIAL", "CREDENTIALS":
+			return true
+		case "KEY":
+			// Names of key vaults, ex) AZURE_KEY_VAULT_NAME, are not secrets
+			if i == len(segments)-1 || segments[i+1] != "VAULT" {
+				return true
+			}
+		case "CONNECTION":
+			if i < len(segments)-1 && segments[i+1] == "STRING" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// diffValue is a value to compare, along with whether it is a secret
+type diffValue struct {
+	value  string
+	secret bool
+}
+
+func diffValues(source DiffSource, left map[string]diffValue, right map[string]diffValue) []*ValueDiff {
+	keys := slices.Collect(maps.Keys(left))
+	for key := range right {
+		if _, has := left[key]; !has {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	diffs := []*ValueDiff{}
+	for _, key := range keys {
+		leftValue, inLeft := left[key]
+		rightValue, inRight := right[key]
+
+		diff := &ValueDiff{
+			Source: source,
+			Key:    key,
+			Secret: leftValue.secret || rightValue.secret,
+		}
+
+		switch {
+		case inLeft && !inRight:
+			diff.Status = DiffStatusRemoved
+			diff.Left = &leftValue.value
+		case !inLeft && inRight:
+			diff.Status = DiffStatusAdded
+			diff.Right = &rightValue.value
+		case leftValue.value != rightValue.value:
+			diff.Status = DiffStatusChanged
+			diff.Left = &leftValue.value
+			diff.Right = &rightValue.value
+		default:
+			continue
+		}
+
+		diffs = append(diffs, diff)
+	}
+
+	return diffs
+}
+
+// flattenDotenv returns the decrypted .env values. Values stored encrypted are always considered secrets.
+func flattenDotenv(env *Environment) map[string]diffValue {
+	values := map[string]diffValue{}
+	for key, value := range env.Dotenv() {
+		values[key] = diffValue{
+			value:  value,
+			secret: IsSecretKey(key) || env.IsSecret(key),
+		}
+	}
+
+	return values
+}
+
+// flattenConfig returns the leaf values of the environment config keyed by their dotted path.
+// Secrets stored within the user vault are resolved and always considered secrets.
+func flattenConfig(env *Environment) (map[string]diffValue, error) {
+	values := map[string]diffValue{}
+	if env.Config == nil {
+		return values, nil
+	}
+
+	var walk func(prefix string, node map[string]any) error
+	walk = func(prefix string, node map[string]any) error {
+		for key, value := range node {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+
+			if child, isNode := value.(map[string]any); isNode {
+				if err := walk(path, child); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// The id of the vault is specific to each environment and is not a meaningful difference
+			if path == "vault" {
+				continue
+			}
+
+			if ref, isString := value.(string); isString && strings.HasPrefix(ref, vaultReferencePrefix) {
+				resolved, _ := env.Config.GetString(path)
+				values[path] = diffValue{value: resolved, secret: true}
+				continue
+			}
+
+			formatted, err := formatConfigValue(value)
+			if err != nil {
+				return fmt.Errorf("formatting config value '%s': %w", path, err)
+			}
+
+			values[path] = diffValue{
+				value:  formatted,
+				secret: IsSecretKey(key),
+			}
+		}
+
+		return nil
+	}
+
+	if err := walk("", env.Config.Raw()); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func formatConfigValue(value any) (string, error) {
+	if stringValue, isString := value.(string); isString {
+		return stringValue, nil
+	}
+
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
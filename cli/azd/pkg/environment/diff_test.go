@@ -0,0 +1,144 @@
+package environment
+
+import (
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Diff(t *testing.T) {
+	left := NewWithValues("dev", map[string]string{
+		"AZURE_ENV_NAME":      "dev",
+		"AZURE_LOCATION":      "eastus2",
+		"DB_PASSWORD":         "dev-password",
+		"SERVICE_API_URI":     "https://api-dev",
+		"AZURE_KEY_VAULT_URI": "https://kv-dev",
+	})
+	left.Config = config.NewConfig(map[string]any{
+		"infra": map[string]any{
+			"parameters": map[string]any{
+				"sku":      "B1",
+				"replicas": float64(1),
+			},
+		},
+	})
+
+	right := NewWithValues("prod", map[string]string{
+		"AZURE_ENV_NAME":      "prod",
+		"AZURE_LOCATION":      "eastus2",
+		"DB_PASSWORD":         "prod-password",
+		"AZURE_KEY_VAULT_URI": "https://kv-prod",
+		"FEATURE_FLAG":        "true",
+	})
+	right.Config = config.NewConfig(map[string]any{
+		"infra": map[string]any{
+			"parameters": map[string]any{
+				"sku":      "P1V3",
+				"replicas": float64(1),
+			},
+		},
+	})
+
+	diffs, err := Diff(left, right)
+	require.NoError(t, err)
+
+	value := func(v string) *string { return &v }
+	require.Equal(t, []*ValueDiff{
+		{
+			Source: DiffSourceDotenv,
+			Key:    "AZURE_ENV_NAME",
+			Status: DiffStatusChanged,
+			Left:   value("dev"),
+			Right:  value("prod"),
+		},
+		{
+			Source: DiffSourceDotenv,
+			Key:    "AZURE_KEY_VAULT_URI",
+			Status: DiffStatusChanged,
+			Left:   value("https://kv-dev"),
+			Right:  value("https://kv-prod"),
+		},
+		{
+			Source: DiffSourceDotenv,
+			Key:    "DB_PASSWORD",
+			Status: DiffStatusChanged,
+			Left:   value("dev-password"),
+			Right:  value("prod-password"),
+			Secret: true,
+		},
+		{
+			Source: DiffSourceDotenv,
+			Key:    "FEATURE_FLAG",
+			Status: DiffStatusAdded,
+			Right:  value("true"),
+		},
+		{
+			Source: DiffSourceDotenv,
+			Key:    "SERVICE_API_URI",
+			Status: DiffStatusRemoved,
+			Left:   value("https://api-dev"),
+		},
+		{
+			Source: DiffSourceConfig,
+			Key:    "infra.parameters.sku",
+			Status: DiffStatusChanged,
+			Left:   value("B1"),
+			Right:  value("P1V3"),
+		},
+	}, diffs)
+
+	MaskSecrets(diffs)
+	require.Equal(t, SecretMask, *diffs[2].Left)
+	require.Equal(t, SecretMask, *diffs[2].Right)
+	require.Equal(t, "https://kv-dev", *diffs[1].Left)
+}
+
+func Test_Diff_VaultSecrets(t *testing.T) {
+	left := NewWithValues("dev", map[string]string{"AZURE_ENV_NAME": "same"})
+	left.Config = config.NewEmptyConfig()
+	require.NoError(t, left.Config.SetSecret("infra.parameters.adminLogin", "dev-admin"))
+
+	right := NewWithValues("prod", map[string]string{"AZURE_ENV_NAME": "same"})
+	right.Config = config.NewEmptyConfig()
+	require.NoError(t, right.Config.SetSecret("infra.parameters.adminLogin", "dev-admin"))
+
+	// The secrets have the same value, only the vault references differ
+	diffs, err := Diff(left, right)
+	require.NoError(t, err)
+	require.Empty(t, diffs)
+
+	require.NoError(t, right.Config.SetSecret("infra.parameters.adminLogin", "prod-admin"))
+	diffs, err = Diff(left, right)
+	require.NoError(t, err)
+	require.Len(t, diffs, 1)
+	require.Equal(t, "infra.parameters.adminLogin", diffs[0].Key)
+	require.True(t, diffs[0].Secret)
+	require.Equal(t, "prod-admin", *diffs[0].Right)
+}
+
+func Test_IsSecretKey(t *testing.T) {
+	secrets := []string{
+		"AZURE_CLIENT_SECRET",
+		"DB_PASSWORD",
+		"STORAGE_ACCOUNT_KEY",
+		"GITHUB_TOKEN",
+		"SERVICEBUS_CONNECTION_STRING",
+		"REDIS_CONNECTIONSTRING",
+		"adminPassword",
+	}
+	for _, key := range secrets {
+		require.True(t, IsSecretKey(key), key)
+	}
+
+	notSecrets := []string{
+		"AZURE_ENV_NAME",
+		"AZURE_KEY_VAULT_NAME",
+		"AZURE_KEY_VAULT_ENDPOINT",
+		"SERVICE_API_URI",
+		"KEYCLOAK_URL",
+	}
+	for _, key := range notSecrets {
+		require.False(t, IsSecretKey(key), key)
+	}
+}
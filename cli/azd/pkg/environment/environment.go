@@ -6,11 +6,14 @@ package environment
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
 
 	"maps"
 
@@ -58,7 +61,11 @@ type Environment struct {
 	// happens in Save
 	deletedKeys map[string]struct{}
 
-	// Config is environment specific config
+	// mu guards dotenv, deletedKeys and Config, which can be updated concurrently when services are deployed in parallel
+	mu sync.RWMutex
+
+	// Config is environment specific config. It is safe for concurrent use, and kept up to date when the environment is
+	// reloaded.
 	Config config.Config
 }
 
@@ -70,8 +77,8 @@ func New(name string) *Environment {
 		name:        name,
 		dotenv:      make(map[string]string),
 		deletedKeys: make(map[string]struct{}),
-		Config:      getInitialConfig(),
 	}
+	env.Config = &environmentConfig{mu: &env.mu, config: getInitialConfig()}
 
 	env.DotenvSet(EnvNameEnvVarName, name)
 
@@ -144,20 +151,26 @@ func CleanName(name string) string {
 }
 
 // Getenv behaves like os.Getenv, except that any keys in the `.env` file associated with this environment are considered
-// first.
+// first. Secrets are returned decrypted.
 func (e *Environment) Getenv(key string) string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
 	if v, has := e.dotenv[key]; has {
-		return v
+		return decryptValue(key, v)
 	}
 
 	return os.Getenv(key)
 }
 
 // LookupEnv behaves like os.LookupEnv, except that any keys in the `.env` file associated with this environment are
-// considered first.
+// considered first. Secrets are returned decrypted.
 func (e *Environment) LookupEnv(key string) (string, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
 	if v, has := e.dotenv[key]; has {
-		return v, true
+		return decryptValue(key, v), true
 	}
 
 	return os.LookupEnv(key)
@@ -166,22 +179,56 @@ func (e *Environment) LookupEnv(key string) (string, bool) {
 // DotenvDelete removes the given key from the .env file in the environment, it is a no-op if the key
 // does not exist. [Save] should be called to ensure this change is persisted.
 func (e *Environment) DotenvDelete(key string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	delete(e.dotenv, key)
 	e.deletedKeys[key] = struct{}{}
 }
 
-// Dotenv returns a copy of the key value pairs from the .env file in the environment.
+// Dotenv returns a copy of the key value pairs from the .env file in the environment. Secrets are returned decrypted.
 func (e *Environment) Dotenv() map[string]string {
-	return maps.Clone(e.dotenv)
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	values := make(map[string]string, len(e.dotenv))
+	for k, v := range e.dotenv {
+		values[k] = decryptValue(k, v)
+	}
+
+	return values
 }
 
 // DotenvSet sets the value of [key] to [value] in the .env file associated with the environment. [Save] should be
 // called to ensure this change is persisted.
 func (e *Environment) DotenvSet(key string, value string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	e.dotenv[key] = value
 	delete(e.deletedKeys, key)
 }
 
+// DotenvSetSecret is like [DotenvSet], except that the value is encrypted at rest with the key of the current user,
+// stored in the user config directory. The value is decrypted transparently when read from the environment.
+func (e *Environment) DotenvSetSecret(key string, value string) error {
+	encrypted, err := encryptSecret(value)
+	if err != nil {
+		return fmt.Errorf("encrypting value of '%s': %w", key, err)
+	}
+
+	e.DotenvSet(key, encrypted)
+	return nil
+}
+
+// IsSecret returns true when the value of [key] in the .env file is encrypted.
+func (e *Environment) IsSecret(key string) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return IsEncryptedValue(e.dotenv[key])
+}
+
 // Name gets the name of the environment
 // If empty will fallback to the value of the AZURE_ENV_NAME environment variable
 func (e *Environment) Name() string {
@@ -232,16 +279,86 @@ func (e *Environment) SetServiceProperty(serviceName string, propertyName string
 }
 
 // Creates a slice of key value pairs, based on the entries in the `.env` file like `KEY=VALUE` that
-// can be used to pass into command runner or similar constructs.
+// can be used to pass into command runner or similar constructs. Secrets are returned decrypted.
 func (e *Environment) Environ() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
 	envVars := []string{}
 	for k, v := range e.dotenv {
-		envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
+		envVars = append(envVars, fmt.Sprintf("%s=%s", k, decryptValue(k, v)))
 	}
 
 	return envVars
 }
 
+// persistedDotenv returns a copy of the key value pairs of the .env file as persisted, with secrets still encrypted.
+func (e *Environment) persistedDotenv() map[string]string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return maps.Clone(e.dotenv)
+}
+
+// CheckSecrets returns an error naming each secret of the environment that cannot be decrypted, for example because it
+// was encrypted on another machine. Commands that hand the values of the environment to other processes call it first,
+// since the values read from the environment treat such secrets as empty.
+func (e *Environment) CheckSecrets() error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	keys := slices.Sorted(maps.Keys(e.dotenv))
+
+	var errs []error
+	for _, key := range keys {
+		if _, err := decryptSecret(e.dotenv[key]); err != nil {
+			errs = append(errs, &SecretDecryptionError{Key: key, Err: err})
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// decryptValue decrypts the value of key when it is a secret. Secrets that cannot be decrypted are treated as empty
+// values, see [Environment.CheckSecrets].
+func decryptValue(key string, value string) string {
+	decrypted, err := decryptSecret(value)
+	if err != nil {
+		log.Printf("failed to decrypt the value of '%s', treating it as empty: %v", key, err)
+		return ""
+	}
+
+	return decrypted
+}
+
+// setDotenv replaces all the values of the `.env` file, discarding any pending deletion.
+func (e *Environment) setDotenv(values map[string]string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.dotenv = values
+	e.deletedKeys = make(map[string]struct{})
+}
+
+// mergeDotenv adds the persisted values that are neither set nor deleted in the environment, and discards any pending
+// deletion. Values set in the environment take precedence over the persisted ones.
+func (e *Environment) mergeDotenv(persisted map[string]string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for key, value := range persisted {
+		if _, deleted := e.deletedKeys[key]; deleted {
+			continue
+		}
+
+		if _, has := e.dotenv[key]; !has {
+			e.dotenv[key] = value
+		}
+	}
+
+	e.deletedKeys = make(map[string]struct{})
+}
+
 // fixupUnquotedDotenv is a workaround for behavior in how godotenv.Marshal handles numeric like values.  Marshaling
 // a map[string]string to a dotenv file, if a value can be successfully parsed with strconv.Atoi, it will be written in
 // the dotenv file without quotes and the value written will be the value returned by strconv.Atoi. This can lead to dropping
@@ -281,6 +398,9 @@ func fixupUnquotedDotenv(values map[string]string, dotenv string) string {
 // Instead of calling `godotenv.Write` directly, we need to save the file ourselves, so we can fixup any numeric values
 // that were incorrectly unquoted.
 func marshallDotEnv(env *Environment) (string, error) {
+	env.mu.RLock()
+	defer env.mu.RUnlock()
+
 	marshalled, err := godotenv.Marshal(env.dotenv)
 	if err != nil {
 		return "", fmt.Errorf("marshalling .env: %w", err)
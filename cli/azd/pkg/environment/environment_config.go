@@ -0,0 +1,124 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"sync"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+)
+
+// environmentConfig is the config of an environment. The data stores replace the config it wraps when the environment
+// is reloaded, while services deployed in parallel read it, so the config is swapped and accessed under the lock of the
+// environment.
+type environmentConfig struct {
+	mu     *sync.RWMutex
+	config config.Config
+}
+
+func (c *environmentConfig) current() config.Config {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.config
+}
+
+func (c *environmentConfig) Raw() map[string]any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.config.Raw()
+}
+
+func (c *environmentConfig) ResolvedRaw() map[string]any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.config.ResolvedRaw()
+}
+
+func (c *environmentConfig) Get(path string) (any, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.config.Get(path)
+}
+
+func (c *environmentConfig) GetString(path string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.config.GetString(path)
+}
+
+func (c *environmentConfig) GetSection(path string, section any) (bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.config.GetSection(path, section)
+}
+
+func (c *environmentConfig) GetMap(path string) (map[string]any, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.config.GetMap(path)
+}
+
+func (c *environmentConfig) GetSlice(path string) ([]any, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.config.GetSlice(path)
+}
+
+func (c *environmentConfig) Set(path string, value any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.config.Set(path, value)
+}
+
+func (c *environmentConfig) SetSecret(path string, value string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.config.SetSecret(path, value)
+}
+
+func (c *environmentConfig) Unset(path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.config.Unset(path)
+}
+
+func (c *environmentConfig) IsEmpty() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.config.IsEmpty()
+}
+
+// setConfig replaces the config of the environment, when it is reloaded from its data store.
+func (e *Environment) setConfig(cfg config.Config) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if envConfig, ok := e.Config.(*environmentConfig); ok {
+		envConfig.config = cfg
+		return
+	}
+
+	e.Config = &environmentConfig{mu: &e.mu, config: cfg}
+}
+
+// persistedConfig returns the config of the environment to save to its data store.
+func (e *Environment) persistedConfig() config.Config {
+	if envConfig, ok := e.Config.(*environmentConfig); ok {
+		return envConfig.current()
+	}
+
+	return e.Config
+}
@@ -0,0 +1,672 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/azure/azure-dev/cli/azd/internal/tracing"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+	"github.com/joho/godotenv"
+)
+
+// DefaultGitStateBranch is the branch used to store environment state when not configured
+const DefaultGitStateBranch = "azd-state"
+
+const (
+	gitRemoteName = "origin"
+	// The maximum number of times a save is retried when the push is rejected by concurrent changes
+	gitMaxSaveAttempts = 5
+	// The prefix of the refs tracking the commit each environment was last synchronized with
+	gitBaseRefPrefix = "refs/azd/base/"
+)
+
+// GitConfig is the configuration of the git remote state backend
+type GitConfig struct {
+	// The URL or local path of the git repository
+	Repository string `json:"repository"`
+	// The branch storing the environment state
+	Branch string `json:"branch"`
+	// The directory within the repository storing the environment state
+	Path string `json:"path"`
+}
+
+// GitDataStore stores environments in a branch of a git repository.
+//
+// Changes are committed to a local working copy and pushed to the remote branch with optimistic concurrency.
+// When the push is rejected because the branch was updated concurrently, the values of the environment
+// are merged by key with the latest remote values and the push is retried.
+type GitDataStore struct {
+	config        *GitConfig
+	gitCli        *git.Cli
+	configManager config.Manager
+
+	// The directory containing the local working copies, defaults to the azd user config directory
+	workingCopyRoot string
+
+	// mu guards the local working copy
+	mu sync.Mutex
+}
+
+// NewGitDataStore creates a new remote data store backed by a git repository
+func NewGitDataStore(gitConfig *GitConfig, gitCli *git.Cli, configManager config.Manager) RemoteDataStore {
+	return &GitDataStore{
+		config:        gitConfig,
+		gitCli:        gitCli,
+		configManager: configManager,
+	}
+}
+
+// EnvPath returns the path to the .env file for the given environment within the repository
+func (gds *GitDataStore) EnvPath(env *Environment) string {
+	return path.Join(gds.envDirectory(env.name), DotEnvFileName)
+}
+
+// ConfigPath returns the path to the config.json file for the given environment within the repository
+func (gds *GitDataStore) ConfigPath(env *Environment) string {
+	return path.Join(gds.envDirectory(env.name), ConfigFileName)
+}
+
+func (gds *GitDataStore) List(ctx context.Context) ([]*contracts.EnvListEnvironment, error) {
+	gds.mu.Lock()
+	defer gds.mu.Unlock()
+
+	workingCopy, hasBranch, err := gds.sync(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasBranch {
+		return []*contracts.EnvListEnvironment{}, nil
+	}
+
+	return gds.list(ctx, workingCopy)
+}
+
+func (gds *GitDataStore) Get(ctx context.Context, name string) (*Environment, error) {
+	envs, err := gds.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.ContainsFunc(envs, func(env *contracts.EnvListEnvironment) bool { return env.Name == name }) {
+		return nil, fmt.Errorf("'%s': %w", name, ErrNotFound)
+	}
+
+	env := &Environment{
+		name: name,
+	}
+
+	if err := gds.Reload(ctx, env); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+func (gds *GitDataStore) Reload(ctx context.Context, env *Environment) error {
+	gds.mu.Lock()
+	defer gds.mu.Unlock()
+
+	workingCopy, hasBranch, err := gds.sync(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !hasBranch {
+		return fmt.Errorf("'%s': %w", env.name, ErrNotFound)
+	}
+
+	state, err := gds.readState(ctx, workingCopy, gds.remoteRef(), env.name)
+	if err != nil {
+		return err
+	}
+
+	if state == nil {
+		return fmt.Errorf("'%s': %w", env.name, ErrNotFound)
+	}
+
+	// The values loaded are the base of the next merge of the environment
+	if err := gds.gitCli.UpdateRef(ctx, workingCopy, gds.baseRef(env.name), gds.remoteRef()); err != nil {
+		return err
+	}
+
+	env.setDotenv(state.dotenv)
+	env.setConfig(state.config)
+
+	if env.Name() != "" {
+		tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.Name()))
+	}
+
+	return nil
+}
+
+func (gds *GitDataStore) Save(ctx context.Context, env *Environment, options *SaveOptions) error {
+	gds.mu.Lock()
+	defer gds.mu.Unlock()
+
+	ours := newGitEnvState(env.persistedDotenv(), env.persistedConfig())
+
+	for attempt := 1; attempt <= gitMaxSaveAttempts; attempt++ {
+		merged, err := gds.trySave(ctx, env.name, ours)
+		if errors.Is(err, git.ErrPushRejected) {
+			log.Printf("environment '%s' was updated concurrently, merging and retrying (attempt %d)", env.name, attempt)
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		// Values changed concurrently by others are now part of the environment
+		env.setDotenv(merged.dotenv)
+		env.setConfig(merged.config)
+
+		tracing.SetUsageAttributes(fields.StringHashed(fields.EnvNameKey, env.Name()))
+		return nil
+	}
+
+	return fmt.Errorf(
+		"saving environment '%s': the remote branch '%s' is being updated concurrently: %w",
+		env.name,
+		gds.branch(),
+		git.ErrPushRejected,
+	)
+}
+
+// trySave merges the values of the environment with the latest remote values, commits and pushes them.
+// Returns git.ErrPushRejected when the remote branch was updated after it was fetched.
+func (gds *GitDataStore) trySave(ctx context.Context, name string, ours *gitEnvState) (*gitEnvState, error) {
+	workingCopy, hasBranch, err := gds.sync(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := ours
+	startPoint := ""
+
+	if hasBranch {
+		startPoint = gds.remoteRef()
+
+		theirs, err := gds.readState(ctx, workingCopy, gds.remoteRef(), name)
+		if err != nil {
+			return nil, err
+		}
+
+		base, err := gds.readBaseState(ctx, workingCopy, name)
+		if err != nil {
+			return nil, err
+		}
+
+		// Without a base the environment has never been synchronized from this machine. Merging with an empty base
+		// keeps the values only the remote has, while the local values win for the values both have.
+		if base == nil {
+			base = newGitEnvState(map[string]string{}, nil)
+		}
+
+		if theirs != nil {
+			merged, err = mergeGitEnvStates(base, ours, theirs)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := gds.gitCli.CheckoutBranch(ctx, workingCopy, gds.branch(), startPoint); err != nil {
+		return nil, err
+	}
+
+	if err := gds.writeState(workingCopy, name, merged); err != nil {
+		return nil, err
+	}
+
+	envDirectory := gds.envDirectory(name)
+	if err := gds.gitCli.AddFile(ctx, workingCopy, envDirectory); err != nil {
+		return nil, err
+	}
+
+	hasChanges, err := gds.gitCli.HasStagedChanges(ctx, workingCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasChanges {
+		if err := gds.gitCli.Commit(ctx, workingCopy, fmt.Sprintf("Update environment %s", name)); err != nil {
+			return nil, err
+		}
+
+		if err := gds.gitCli.Push(ctx, workingCopy, gitRemoteName, gds.branch()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := gds.gitCli.UpdateRef(ctx, workingCopy, gds.baseRef(name), "HEAD"); err != nil {
+		return nil, err
+	}
+
+	return merged, nil
+}
+
+func (gds *GitDataStore) Delete(ctx context.Context, name string) error {
+	gds.mu.Lock()
+	defer gds.mu.Unlock()
+
+	for attempt := 1; attempt <= gitMaxSaveAttempts; attempt++ {
+		err := gds.tryDelete(ctx, name)
+		if errors.Is(err, git.ErrPushRejected) {
+			log.Printf("environment '%s' was updated concurrently, retrying delete (attempt %d)", name, attempt)
+			continue
+		}
+
+		return err
+	}
+
+	return fmt.Errorf(
+		"deleting environment '%s': the remote branch '%s' is being updated concurrently: %w",
+		name,
+		gds.branch(),
+		git.ErrPushRejected,
+	)
+}
+
+func (gds *GitDataStore) tryDelete(ctx context.Context, name string) error {
+	workingCopy, hasBranch, err := gds.sync(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !hasBranch {
+		return fmt.Errorf("'%s': %w", name, ErrNotFound)
+	}
+
+	envs, err := gds.list(ctx, workingCopy)
+	if err != nil {
+		return err
+	}
+
+	if !slices.ContainsFunc(envs, func(env *contracts.EnvListEnvironment) bool { return env.Name == name }) {
+		return fmt.Errorf("'%s': %w", name, ErrNotFound)
+	}
+
+	if err := gds.gitCli.CheckoutBranch(ctx, workingCopy, gds.branch(), gds.remoteRef()); err != nil {
+		return err
+	}
+
+	envDirectory := gds.envDirectory(name)
+	if err := os.RemoveAll(filepath.Join(workingCopy, envDirectory)); err != nil {
+		return fmt.Errorf("deleting environment files: %w", err)
+	}
+
+	if err := gds.gitCli.AddFile(ctx, workingCopy, envDirectory); err != nil {
+		return err
+	}
+
+	if err := gds.gitCli.Commit(ctx, workingCopy, fmt.Sprintf("Delete environment %s", name)); err != nil {
+		return err
+	}
+
+	return gds.gitCli.Push(ctx, workingCopy, gitRemoteName, gds.branch())
+}
+
+// list returns the environments stored in the latest fetched remote branch
+func (gds *GitDataStore) list(ctx context.Context, workingCopy string) ([]*contracts.EnvListEnvironment, error) {
+	files, err := gds.gitCli.ListFiles(ctx, workingCopy, gds.remoteRef(), gds.config.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	envMap := map[string]*contracts.EnvListEnvironment{}
+	for _, file := range files {
+		envDirectory := path.Dir(file)
+		// Only files stored directly within an environment directory are considered
+		if path.Dir(envDirectory) != path.Clean(gds.rootDirectory()) {
+			continue
+		}
+
+		envName := path.Base(envDirectory)
+		env, has := envMap[envName]
+		if !has {
+			env = &contracts.EnvListEnvironment{
+				Name: envName,
+			}
+			envMap[envName] = env
+		}
+
+		switch path.Base(file) {
+		case ConfigFileName:
+			env.ConfigPath = file
+		case DotEnvFileName:
+			env.DotEnvPath = file
+		}
+	}
+
+	envs := []*contracts.EnvListEnvironment{}
+	for _, env := range envMap {
+		if env.DotEnvPath != "" {
+			envs = append(envs, env)
+		}
+	}
+
+	slices.SortFunc(envs, func(a, b *contracts.EnvListEnvironment) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return envs, nil
+}
+
+// sync ensures the local working copy exists and fetches the latest remote branch.
+// Returns false when the remote branch does not exist yet.
+func (gds *GitDataStore) sync(ctx context.Context) (string, bool, error) {
+	if gds.config == nil || gds.config.Repository == "" {
+		return "", false, errors.New("the git remote state requires the 'repository' config to be set")
+	}
+
+	workingCopy, err := gds.workingCopyPath()
+	if err != nil {
+		return "", false, err
+	}
+
+	if _, err := os.Stat(filepath.Join(workingCopy, ".git")); errors.Is(err, os.ErrNotExist) {
+		if err := gds.initWorkingCopy(ctx, workingCopy); err != nil {
+			return "", false, err
+		}
+	} else if err != nil {
+		return "", false, err
+	}
+
+	err = gds.gitCli.Fetch(ctx, workingCopy, gitRemoteName, gds.branch())
+	if errors.Is(err, git.ErrRemoteBranchNotFound) {
+		// Start over from an empty working copy, the branch was never created or has been deleted.
+		if _, err := gds.gitCli.RevParse(ctx, workingCopy, "HEAD"); err == nil {
+			if err := os.RemoveAll(workingCopy); err != nil {
+				return "", false, fmt.Errorf("resetting git working copy: %w", err)
+			}
+
+			if err := gds.initWorkingCopy(ctx, workingCopy); err != nil {
+				return "", false, err
+			}
+		}
+
+		return workingCopy, false, nil
+	} else if err != nil {
+		return "", false, fmt.Errorf("fetching remote state from '%s': %w", gds.config.Repository, err)
+	}
+
+	return workingCopy, true, nil
+}
+
+func (gds *GitDataStore) initWorkingCopy(ctx context.Context, workingCopy string) error {
+	if err := os.MkdirAll(workingCopy, osutil.PermissionDirectoryOwnerOnly); err != nil {
+		return fmt.Errorf("creating git working copy: %w", err)
+	}
+
+	if err := gds.gitCli.InitRepo(ctx, workingCopy); err != nil {
+		return err
+	}
+
+	if err := gds.gitCli.AddRemote(ctx, workingCopy, gitRemoteName, gds.config.Repository); err != nil {
+		return err
+	}
+
+	// Commits require an identity, fallback to a generic one when the user has not configured any
+	identity := map[string]string{
+		"user.name":  "azd",
+		"user.email": "azd@users.noreply.github.com",
+	}
+	for key, value := range identity {
+		existing, err := gds.gitCli.GetConfig(ctx, workingCopy, key)
+		if err != nil {
+			return err
+		}
+
+		if existing == "" {
+			if err := gds.gitCli.SetConfig(ctx, workingCopy, key, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// workingCopyPath returns the path of the local working copy, stored within the azd user config directory
+// and unique for each repository & branch.
+func (gds *GitDataStore) workingCopyPath() (string, error) {
+	root := gds.workingCopyRoot
+	if root == "" {
+		configDir, err := config.GetUserConfigDir()
+		if err != nil {
+			return "", err
+		}
+
+		root = filepath.Join(configDir, "state", "git")
+	}
+
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s#%s", gds.config.Repository, gds.branch())))
+	return filepath.Join(root, fmt.Sprintf("%x", hash[:8])), nil
+}
+
+func (gds *GitDataStore) branch() string {
+	if gds.config.Branch == "" {
+		return DefaultGitStateBranch
+	}
+
+	return gds.config.Branch
+}
+
+func (gds *GitDataStore) remoteRef() string {
+	return fmt.Sprintf("refs/remotes/%s/%s", gitRemoteName, gds.branch())
+}
+
+func (gds *GitDataStore) baseRef(name string) string {
+	return gitBaseRefPrefix + name
+}
+
+func (gds *GitDataStore) rootDirectory() string {
+	if gds.config.Path == "" {
+		return "."
+	}
+
+	return gds.config.Path
+}
+
+func (gds *GitDataStore) envDirectory(name string) string {
+	return path.Join(gds.rootDirectory(), name)
+}
+
+// gitEnvState is the content of an environment stored within the repository
+type gitEnvState struct {
+	dotenv map[string]string
+	config config.Config
+}
+
+func newGitEnvState(dotenv map[string]string, cfg config.Config) *gitEnvState {
+	if cfg == nil {
+		cfg = config.NewEmptyConfig()
+	}
+
+	return &gitEnvState{
+		dotenv: dotenv,
+		config: cfg,
+	}
+}
+
+// readState reads the environment at the specified revision, returning nil when it does not exist
+func (gds *GitDataStore) readState(
+	ctx context.Context,
+	workingCopy string,
+	revision string,
+	name string,
+) (*gitEnvState, error) {
+	envDirectory := gds.envDirectory(name)
+	dotenvContent, err := gds.gitCli.ShowFile(ctx, workingCopy, revision, path.Join(envDirectory, DotEnvFileName))
+	if errors.Is(err, git.ErrPathNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	dotenv, err := godotenv.Unmarshal(dotenvContent)
+	if err != nil {
+		dotenv = map[string]string{}
+	}
+
+	cfg := config.NewEmptyConfig()
+	configContent, err := gds.gitCli.ShowFile(ctx, workingCopy, revision, path.Join(envDirectory, ConfigFileName))
+	if err != nil && !errors.Is(err, git.ErrPathNotFound) {
+		return nil, err
+	}
+
+	if err == nil {
+		cfg, err = gds.configManager.Load(strings.NewReader(configContent))
+		if err != nil {
+			return nil, fmt.Errorf("loading config: %w", err)
+		}
+	}
+
+	return newGitEnvState(dotenv, cfg), nil
+}
+
+// readBaseState reads the environment as it was last synchronized from this machine, returning nil when unknown
+func (gds *GitDataStore) readBaseState(ctx context.Context, workingCopy string, name string) (*gitEnvState, error) {
+	if _, err := gds.gitCli.RevParse(ctx, workingCopy, gds.baseRef(name)); err != nil {
+		return nil, nil
+	}
+
+	return gds.readState(ctx, workingCopy, gds.baseRef(name), name)
+}
+
+// writeState writes the environment files into the working copy
+func (gds *GitDataStore) writeState(workingCopy string, name string, state *gitEnvState) error {
+	envDirectory := filepath.Join(workingCopy, filepath.FromSlash(gds.envDirectory(name)))
+	if err := os.MkdirAll(envDirectory, osutil.PermissionDirectory); err != nil {
+		return fmt.Errorf("creating environment directory: %w", err)
+	}
+
+	marshalled, err := godotenv.Marshal(state.dotenv)
+	if err != nil {
+		return fmt.Errorf("marshalling .env: %w", err)
+	}
+	marshalled = fixupUnquotedDotenv(state.dotenv, marshalled)
+
+	if err := os.WriteFile(filepath.Join(envDirectory, DotEnvFileName), []byte(marshalled), osutil.PermissionFile); err != nil {
+		return fmt.Errorf("writing .env: %w", err)
+	}
+
+	configBuffer := new(bytes.Buffer)
+	if err := gds.configManager.Save(state.config, configBuffer); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+
+	configPath := filepath.Join(envDirectory, ConfigFileName)
+	if err := os.WriteFile(configPath, configBuffer.Bytes(), osutil.PermissionFile); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+
+	return nil
+}
+
+// mergeGitEnvStates merges the .env values and the config values by key.
+// Keys only changed on one side since the base keep that change, keys changed on both sides keep our value.
+func mergeGitEnvStates(base *gitEnvState, ours *gitEnvState, theirs *gitEnvState) (*gitEnvState, error) {
+	mergedDotenv := map[string]string{}
+	for key, value := range mergeByKey(toAnyMap(base.dotenv), toAnyMap(ours.dotenv), toAnyMap(theirs.dotenv)) {
+		mergedDotenv[key] = value.(string)
+	}
+
+	mergedConfig := config.NewEmptyConfig()
+	merged := mergeByKey(configLeaves(base.config), configLeaves(ours.config), configLeaves(theirs.config))
+	for _, key := range slices.Sorted(maps.Keys(merged)) {
+		if err := mergedConfig.Set(key, merged[key]); err != nil {
+			return nil, fmt.Errorf("merging config value '%s': %w", key, err)
+		}
+	}
+
+	return newGitEnvState(mergedDotenv, mergedConfig), nil
+}
+
+// mergeByKey performs a three way merge of the values
+func mergeByKey(base map[string]any, ours map[string]any, theirs map[string]any) map[string]any {
+	keys := map[string]struct{}{}
+	for _, values := range []map[string]any{base, ours, theirs} {
+		for key := range values {
+			keys[key] = struct{}{}
+		}
+	}
+
+	merged := map[string]any{}
+	for key := range keys {
+		baseValue, inBase := base[key]
+		ourValue, inOurs := ours[key]
+		theirValue, inTheirs := theirs[key]
+
+		oursChanged := inOurs != inBase || !reflect.DeepEqual(ourValue, baseValue)
+		theirsChanged := inTheirs != inBase || !reflect.DeepEqual(theirValue, baseValue)
+
+		if theirsChanged && !oursChanged {
+			if inTheirs {
+				merged[key] = theirValue
+			}
+			continue
+		}
+
+		if oursChanged && theirsChanged && (inOurs != inTheirs || !reflect.DeepEqual(ourValue, theirValue)) {
+			log.Printf("environment value '%s' was changed concurrently, keeping the local value", key)
+		}
+
+		if inOurs {
+			merged[key] = ourValue
+		}
+	}
+
+	return merged
+}
+
+// configLeaves returns the leaf values of the config keyed by their dotted path
+func configLeaves(cfg config.Config) map[string]any {
+	leaves := map[string]any{}
+
+	var walk func(prefix string, node map[string]any)
+	walk = func(prefix string, node map[string]any) {
+		for key, value := range node {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+
+			if child, isNode := value.(map[string]any); isNode {
+				walk(key, child)
+				continue
+			}
+
+			leaves[key] = value
+		}
+	}
+
+	walk("", cfg.Raw())
+	return leaves
+}
+
+func toAnyMap(values map[string]string) map[string]any {
+	result := map[string]any{}
+	for key, value := range values {
+		result[key] = value
+	}
+
+	return result
+}
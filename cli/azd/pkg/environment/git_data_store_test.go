@@ -0,0 +1,169 @@
+package environment
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GitDataStore_SaveAndGet(t *testing.T) {
+	ctx := context.Background()
+	repository := createBareRepository(t)
+	dataStore := createGitDataStore(t, repository)
+
+	envs, err := dataStore.List(ctx)
+	require.NoError(t, err)
+	require.Empty(t, envs)
+
+	env := New("dev")
+	env.DotenvSet("AZURE_LOCATION", "eastus2")
+	require.NoError(t, env.Config.Set("infra.parameters.sku", "B1"))
+	require.NoError(t, dataStore.Save(ctx, env, nil))
+
+	// Another machine sees the saved environment
+	otherDataStore := createGitDataStore(t, repository)
+	envs, err = otherDataStore.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, envs, 1)
+	require.Equal(t, "dev", envs[0].Name)
+	require.Equal(t, "test-proj/dev/.env", envs[0].DotEnvPath)
+
+	actual, err := otherDataStore.Get(ctx, "dev")
+	require.NoError(t, err)
+	require.Equal(t, "eastus2", actual.Getenv("AZURE_LOCATION"))
+	sku, _ := actual.Config.GetString("infra.parameters.sku")
+	require.Equal(t, "B1", sku)
+
+	_, err = otherDataStore.Get(ctx, "prod")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_GitDataStore_ConcurrentSaveMergesByKey(t *testing.T) {
+	ctx := context.Background()
+	repository := createBareRepository(t)
+	dataStore1 := createGitDataStore(t, repository)
+	dataStore2 := createGitDataStore(t, repository)
+
+	env := New("dev")
+	env.DotenvSet("SHARED", "initial")
+	require.NoError(t, dataStore1.Save(ctx, env, nil))
+
+	env1, err := dataStore1.Get(ctx, "dev")
+	require.NoError(t, err)
+	env2, err := dataStore2.Get(ctx, "dev")
+	require.NoError(t, err)
+
+	env1.DotenvSet("FROM_FIRST", "1")
+	env1.DotenvSet("SHARED", "first")
+	require.NoError(t, dataStore1.Save(ctx, env1, nil))
+
+	// The second machine has not seen the changes of the first one
+	env2.DotenvSet("FROM_SECOND", "2")
+	require.NoError(t, dataStore2.Save(ctx, env2, nil))
+
+	require.Equal(t, "1", env2.Getenv("FROM_FIRST"))
+	require.Equal(t, "2", env2.Getenv("FROM_SECOND"))
+	require.Equal(t, "first", env2.Getenv("SHARED"))
+
+	require.NoError(t, dataStore1.Reload(ctx, env1))
+	require.Equal(t, "1", env1.Getenv("FROM_FIRST"))
+	require.Equal(t, "2", env1.Getenv("FROM_SECOND"))
+
+	// Keys deleted on one machine are deleted from the merged values
+	env2.DotenvDelete("FROM_FIRST")
+	require.NoError(t, dataStore2.Save(ctx, env2, nil))
+	require.NoError(t, dataStore1.Reload(ctx, env1))
+	_, has := env1.LookupEnv("FROM_FIRST")
+	require.False(t, has)
+}
+
+func Test_GitDataStore_SaveWithoutBaseKeepsRemoteValues(t *testing.T) {
+	ctx := context.Background()
+	repository := createBareRepository(t)
+	dataStore1 := createGitDataStore(t, repository)
+	dataStore2 := createGitDataStore(t, repository)
+
+	env1 := New("dev")
+	env1.DotenvSet("SHARED", "first")
+	env1.DotenvSet("FROM_FIRST", "1")
+	require.NoError(t, env1.Config.Set("infra.parameters.sku", "B1"))
+	require.NoError(t, dataStore1.Save(ctx, env1, nil))
+
+	// The second machine has never synchronized the environment, there is no base to merge from
+	env2 := New("dev")
+	env2.DotenvSet("SHARED", "second")
+	env2.DotenvSet("FROM_SECOND", "2")
+	require.NoError(t, dataStore2.Save(ctx, env2, nil))
+
+	require.Equal(t, "1", env2.Getenv("FROM_FIRST"))
+	require.Equal(t, "2", env2.Getenv("FROM_SECOND"))
+	require.Equal(t, "second", env2.Getenv("SHARED"))
+	sku, _ := env2.Config.GetString("infra.parameters.sku")
+	require.Equal(t, "B1", sku)
+
+	require.NoError(t, dataStore1.Reload(ctx, env1))
+	require.Equal(t, "1", env1.Getenv("FROM_FIRST"))
+	require.Equal(t, "2", env1.Getenv("FROM_SECOND"))
+	require.Equal(t, "second", env1.Getenv("SHARED"))
+}
+
+func Test_GitDataStore_Delete(t *testing.T) {
+	ctx := context.Background()
+	repository := createBareRepository(t)
+	dataStore := createGitDataStore(t, repository)
+
+	require.NoError(t, dataStore.Save(ctx, New("dev"), nil))
+	require.NoError(t, dataStore.Save(ctx, New("prod"), nil))
+
+	require.NoError(t, dataStore.Delete(ctx, "dev"))
+
+	envs, err := dataStore.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, envs, 1)
+	require.Equal(t, "prod", envs[0].Name)
+
+	require.ErrorIs(t, dataStore.Delete(ctx, "dev"), ErrNotFound)
+}
+
+func Test_MergeByKey(t *testing.T) {
+	base := map[string]any{"a": "1", "b": "1", "c": "1", "d": "1"}
+	ours := map[string]any{"a": "2", "b": "1", "c": "2", "e": "1"}
+	theirs := map[string]any{"a": "1", "b": "2", "c": "3", "d": "1", "f": "1"}
+
+	require.Equal(t, map[string]any{
+		// Only changed by us
+		"a": "2",
+		// Only changed by them
+		"b": "2",
+		// Changed by both, we win
+		"c": "2",
+		// "d" deleted by us
+		"e": "1",
+		"f": "1",
+	}, mergeByKey(base, ours, theirs))
+}
+
+func createBareRepository(t *testing.T) string {
+	repository := filepath.Join(t.TempDir(), "state.git")
+	_, err := exec.NewCommandRunner(nil).Run(context.Background(), exec.NewRunArgs("git", "init", "--bare", repository))
+	require.NoError(t, err)
+
+	return repository
+}
+
+func createGitDataStore(t *testing.T, repository string) *GitDataStore {
+	gitConfig := &GitConfig{
+		Repository: repository,
+		Path:       "test-proj",
+	}
+
+	dataStore := NewGitDataStore(gitConfig, git.NewCli(exec.NewCommandRunner(nil)), config.NewManager()).(*GitDataStore)
+	dataStore.workingCopyRoot = t.TempDir()
+
+	return dataStore
+}
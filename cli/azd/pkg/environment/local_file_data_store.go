@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/azure/azure-dev/cli/azd/internal/tracing"
 	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
@@ -22,6 +23,9 @@ import (
 type LocalFileDataStore struct {
 	azdContext    *azdcontext.AzdContext
 	configManager config.FileConfigManager
+
+	// saveMu serializes writes to the environment files
+	saveMu sync.Mutex
 }
 
 // NewLocalFileDataStore creates a new LocalFileDataStore instance
@@ -99,24 +103,35 @@ func (fs *LocalFileDataStore) Get(ctx context.Context, name string) (*Environmen
 
 // Reload reloads the environment from the persistent data store
 func (fs *LocalFileDataStore) Reload(ctx context.Context, env *Environment) error {
+	return fs.reload(ctx, env, false)
+}
+
+// reload reloads the environment from the persistent data store. When keepPending is set, the values set or deleted in
+// the environment since it was loaded take precedence over the persisted ones. The persisted and pending values are
+// merged under the lock of the environment, so values set concurrently, e.g. by services deployed in parallel, are not
+// lost.
+func (fs *LocalFileDataStore) reload(ctx context.Context, env *Environment, keepPending bool) error {
 	// Reload env values
-	if envMap, err := godotenv.Read(fs.EnvPath(env)); errors.Is(err, os.ErrNotExist) {
-		env.dotenv = make(map[string]string)
-		env.deletedKeys = make(map[string]struct{})
+	envMap, err := godotenv.Read(fs.EnvPath(env))
+	if errors.Is(err, os.ErrNotExist) {
+		envMap = make(map[string]string)
 	} else if err != nil {
 		return fmt.Errorf("loading .env: %w", err)
+	}
+
+	if keepPending {
+		env.mergeDotenv(envMap)
 	} else {
-		env.dotenv = envMap
-		env.deletedKeys = make(map[string]struct{})
+		env.setDotenv(envMap)
 	}
 
 	// Reload env config
 	if cfg, err := fs.configManager.Load(fs.ConfigPath(env)); errors.Is(err, os.ErrNotExist) {
-		env.Config = config.NewEmptyConfig()
+		env.setConfig(config.NewEmptyConfig())
 	} else if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	} else {
-		env.Config = cfg
+		env.setConfig(cfg)
 	}
 
 	if env.Name() != "" {
@@ -134,28 +149,19 @@ func (fs *LocalFileDataStore) Reload(ctx context.Context, env *Environment) erro
 
 // Save saves the environment to the persistent data store
 func (fs *LocalFileDataStore) Save(ctx context.Context, env *Environment, options *SaveOptions) error {
+	fs.saveMu.Lock()
+	defer fs.saveMu.Unlock()
+
 	// Update configuration
-	if err := fs.configManager.Save(env.Config, fs.ConfigPath(env)); err != nil {
+	if err := fs.configManager.Save(env.persistedConfig(), fs.ConfigPath(env)); err != nil {
 		return fmt.Errorf("saving config: %w", err)
 	}
 
-	// Cache current values & reload to get any new env vars
-	currentValues := env.dotenv
-	deletedValues := env.deletedKeys
-	if err := fs.Reload(ctx, env); err != nil {
+	// Reload to get any new env vars, current values & deletions take precedence
+	if err := fs.reload(ctx, env, true); err != nil {
 		return fmt.Errorf("failed reloading env vars, %w", err)
 	}
 
-	// Overlay current values before saving
-	for key, value := range currentValues {
-		env.dotenv[key] = value
-	}
-
-	// Replay deletion
-	for key := range deletedValues {
-		delete(env.dotenv, key)
-	}
-
 	marshalled, err := marshallDotEnv(env)
 	if err != nil {
 		return fmt.Errorf("marshalling .env: %w", err)
@@ -2,7 +2,9 @@ package environment
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
@@ -60,6 +62,84 @@ func Test_LocalFileDataStore_SaveAndGet(t *testing.T) {
 	})
 }
 
+func Test_LocalFileDataStore_ConcurrentSave(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	fileConfigManager := config.NewFileConfigManager(config.NewManager())
+	dataStore := NewLocalFileDataStore(azdContext, fileConfigManager)
+
+	env := New("env1")
+	env.DotenvSet("removed", "value")
+	require.NoError(t, dataStore.Save(*mockContext.Context, env, nil))
+
+	// Another instance of the same environment persists a value the first instance doesn't know about
+	other, err := dataStore.Get(*mockContext.Context, "env1")
+	require.NoError(t, err)
+	other.DotenvSet("other", "value")
+	require.NoError(t, other.Config.Set("other.key", "value"))
+	require.NoError(t, dataStore.Save(*mockContext.Context, other, nil))
+
+	// Values are set and saved concurrently, as services deployed in parallel do
+	env.DotenvDelete("removed")
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			env.DotenvSet(fmt.Sprintf("key%d", i), fmt.Sprintf("value%d", i))
+			require.NoError(t, dataStore.Save(*mockContext.Context, env, nil))
+		}()
+	}
+	wg.Wait()
+
+	persisted, err := dataStore.Get(*mockContext.Context, "env1")
+	require.NoError(t, err)
+
+	// The in-memory environment isn't stale, it has the values of all the saves, including the other instance's
+	require.Equal(t, persisted.Dotenv(), env.Dotenv())
+	require.Len(t, env.Dotenv(), 12)
+	require.Equal(t, "value", env.Getenv("other"))
+	require.NotContains(t, env.Dotenv(), "removed")
+	for i := range 10 {
+		require.Equal(t, fmt.Sprintf("value%d", i), env.Getenv(fmt.Sprintf("key%d", i)))
+	}
+
+	// The config is reloaded after it is saved
+	require.Equal(t, persisted.Config.Raw(), env.Config.Raw())
+}
+
+func Test_LocalFileDataStore_ReloadWhileDeploying(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	fileConfigManager := config.NewFileConfigManager(config.NewManager())
+	dataStore := NewLocalFileDataStore(azdContext, fileConfigManager)
+
+	env := New("env1")
+	require.NoError(t, env.Config.Set("infra.parameters.sku", "B1"))
+	require.NoError(t, dataStore.Save(*mockContext.Context, env, nil))
+
+	// Services deployed in parallel use the environment while it is reloaded, the data races are reported with -race
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			sku, _ := env.Config.GetString("infra.parameters.sku")
+			require.Equal(t, "B1", sku)
+			require.NoError(t, env.Config.Set(fmt.Sprintf("services.api%d.deployed", i), true))
+			env.DotenvSet(fmt.Sprintf("key%d", i), fmt.Sprintf("value%d", i))
+		}()
+		go func() {
+			defer wg.Done()
+			require.NoError(t, dataStore.Reload(*mockContext.Context, env))
+		}()
+	}
+	wg.Wait()
+
+	sku, _ := env.Config.GetString("infra.parameters.sku")
+	require.Equal(t, "B1", sku)
+}
+
 func Test_LocalFileDataStore_Path(t *testing.T) {
 	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
 	fileConfigManager := config.NewFileConfigManager(config.NewManager())
@@ -50,6 +50,9 @@ var (
 
 	// Error returned when an environment name is not specified
 	ErrNameNotSpecified = errors.New("environment not specified")
+
+	// Error returned when remote environment state has not been configured for the project
+	ErrRemoteNotConfigured = errors.New("remote environment state is not configured")
 )
 
 // Manager is the interface used for managing instances of environments
@@ -66,6 +69,10 @@ type Manager interface {
 	// If the environment specified by the given name does not exist, ErrNotFound is returned.
 	Get(ctx context.Context, name string) (*Environment, error)
 
+	// GetRemote returns the copy of the environment stored in the remote data store, without updating the local copy.
+	// If remote state is not configured, ErrRemoteNotConfigured is returned.
+	GetRemote(ctx context.Context, name string) (*Environment, error)
+
 	Save(ctx context.Context, env *Environment) error
 	SaveWithOptions(ctx context.Context, env *Environment, options *SaveOptions) error
 	Reload(ctx context.Context, env *Environment) error
@@ -379,6 +386,19 @@ func (m *manager) Get(ctx context.Context, name string) (*Environment, error) {
 	return localEnv, nil
 }
 
+// GetRemote returns the environment instance stored in the remote data store for the specified environment name
+func (m *manager) GetRemote(ctx context.Context, name string) (*Environment, error) {
+	if name == "" {
+		return nil, ErrNameNotSpecified
+	}
+
+	if m.remote == nil {
+		return nil, ErrRemoteNotConfigured
+	}
+
+	return m.remote.Get(ctx, name)
+}
+
 // Save saves the environment to the persistent data store
 func (m *manager) Save(ctx context.Context, env *Environment) error {
 	return m.SaveWithOptions(ctx, env, nil)
@@ -0,0 +1,164 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package environment
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+// SecretKeyFileName is the name of the file, within the user config directory, that stores the key used to encrypt the
+// secrets of the environments.
+const SecretKeyFileName = "env-secrets.key"
+
+// encryptedValuePrefix identifies the values of the .env file that are encrypted. The version allows changing the
+// encryption scheme in the future while still being able to read existing values.
+const encryptedValuePrefix = "azd-secret:v1:"
+
+const (
+	secretKeySize   = 32
+	secretNonceSize = 24
+)
+
+// ErrSecretKeyNotFound is returned when an encrypted value is read on a machine that does not have the key used to
+// encrypt it.
+var ErrSecretKeyNotFound = errors.New("secret key not found")
+
+// SecretDecryptionError is returned when the value of a secret cannot be decrypted, for example because it was encrypted
+// on another machine or with a key that was since replaced.
+type SecretDecryptionError struct {
+	Key string
+	Err error
+}
+
+func (e *SecretDecryptionError) Error() string {
+	return fmt.Sprintf(
+		"the secret '%s' of the environment cannot be decrypted: %v. Set it again with 'azd env set %s <value> --secret'",
+		e.Key, e.Err, e.Key,
+	)
+}
+
+func (e *SecretDecryptionError) Unwrap() error {
+	return e.Err
+}
+
+// secretKeyMu serializes the creation of the secret key, since secrets can be set concurrently
+var secretKeyMu sync.Mutex
+
+// IsEncryptedValue returns true when the value, as persisted in the .env file, is an encrypted secret.
+func IsEncryptedValue(value string) bool {
+	return strings.HasPrefix(value, encryptedValuePrefix)
+}
+
+// encryptSecret encrypts the value with the key of the current user, creating the key when it does not exist yet.
+func encryptSecret(value string) (string, error) {
+	key, err := loadSecretKey(true)
+	if err != nil {
+		return "", err
+	}
+
+	var nonce [secretNonceSize]byte
+	if _, err := io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return "", fmt.Errorf("generating nonce: %w", err)
+	}
+
+	sealed := secretbox.Seal(nonce[:], []byte(value), &nonce, key)
+	return encryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptSecret decrypts a value previously encrypted by encryptSecret. Values that are not encrypted are returned as is.
+func decryptSecret(value string) (string, error) {
+	if !IsEncryptedValue(value) {
+		return value, nil
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedValuePrefix))
+	if err != nil {
+		return "", fmt.Errorf("decoding secret: %w", err)
+	}
+
+	if len(sealed) < secretNonceSize+secretbox.Overhead {
+		return "", errors.New("decoding secret: value is too short")
+	}
+
+	key, err := loadSecretKey(false)
+	if err != nil {
+		return "", err
+	}
+
+	var nonce [secretNonceSize]byte
+	copy(nonce[:], sealed[:secretNonceSize])
+
+	opened, ok := secretbox.Open(nil, sealed[secretNonceSize:], &nonce, key)
+	if !ok {
+		return "", errors.New("decrypting secret: the value was encrypted with a different key or is corrupted")
+	}
+
+	return string(opened), nil
+}
+
+// loadSecretKey reads the secret key from the user config directory. When create is true and the key does not exist, a
+// new random key is generated and persisted, readable only by the current user.
+func loadSecretKey(create bool) (*[secretKeySize]byte, error) {
+	configDir, err := config.GetUserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("getting user config directory: %w", err)
+	}
+
+	keyPath := filepath.Join(configDir, SecretKeyFileName)
+
+	secretKeyMu.Lock()
+	defer secretKeyMu.Unlock()
+
+	contents, err := os.ReadFile(keyPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		if !create {
+			return nil, fmt.Errorf("%w: %s", ErrSecretKeyNotFound, keyPath)
+		}
+
+		return createSecretKey(keyPath)
+	} else if err != nil {
+		return nil, fmt.Errorf("reading secret key: %w", err)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(contents)))
+	if err != nil || len(decoded) != secretKeySize {
+		return nil, fmt.Errorf("secret key '%s' is invalid", keyPath)
+	}
+
+	var key [secretKeySize]byte
+	copy(key[:], decoded)
+
+	return &key, nil
+}
+
+func createSecretKey(keyPath string) (*[secretKeySize]byte, error) {
+	var key [secretKeySize]byte
+	if _, err := io.ReadFull(rand.Reader, key[:]); err != nil {
+		return nil, fmt.Errorf("generating secret key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(keyPath), osutil.PermissionDirectoryOwnerOnly); err != nil {
+		return nil, fmt.Errorf("creating config directory: %w", err)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(key[:])
+	if err := os.WriteFile(keyPath, []byte(encoded), osutil.PermissionFileOwnerOnly); err != nil {
+		return nil, fmt.Errorf("writing secret key: %w", err)
+	}
+
+	return &key, nil
+}
@@ -0,0 +1,104 @@
+package environment
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DotenvSetSecret(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("AZD_CONFIG_DIR", configDir)
+
+	env := NewWithValues("dev", map[string]string{"PLAIN": "value"})
+	require.NoError(t, env.DotenvSetSecret("DB_PASSWORD", "p@ssw0rd"))
+
+	// The key is created on first use
+	_, err := os.Stat(filepath.Join(configDir, SecretKeyFileName))
+	require.NoError(t, err)
+
+	require.True(t, env.IsSecret("DB_PASSWORD"))
+	require.False(t, env.IsSecret("PLAIN"))
+
+	// The value is encrypted at rest
+	marshalled, err := marshallDotEnv(env)
+	require.NoError(t, err)
+	require.NotContains(t, marshalled, "p@ssw0rd")
+	require.Contains(t, marshalled, encryptedValuePrefix)
+
+	// The value is decrypted when read
+	require.Equal(t, "p@ssw0rd", env.Getenv("DB_PASSWORD"))
+	value, has := env.LookupEnv("DB_PASSWORD")
+	require.True(t, has)
+	require.Equal(t, "p@ssw0rd", value)
+	require.Equal(t, "p@ssw0rd", env.Dotenv()["DB_PASSWORD"])
+	require.Contains(t, env.Environ(), "DB_PASSWORD=p@ssw0rd")
+	require.Contains(t, env.Environ(), "PLAIN=value")
+
+	// Setting a plain value replaces the secret
+	env.DotenvSet("DB_PASSWORD", "plain")
+	require.False(t, env.IsSecret("DB_PASSWORD"))
+	require.Equal(t, "plain", env.Getenv("DB_PASSWORD"))
+}
+
+func Test_DecryptSecret(t *testing.T) {
+	t.Run("NotEncrypted", func(t *testing.T) {
+		t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+		value, err := decryptSecret("value")
+		require.NoError(t, err)
+		require.Equal(t, "value", value)
+	})
+
+	t.Run("KeyNotFound", func(t *testing.T) {
+		t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+		encrypted, err := encryptSecret("value")
+		require.NoError(t, err)
+
+		// Another machine without the key
+		t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+		_, err = decryptSecret(encrypted)
+		require.ErrorIs(t, err, ErrSecretKeyNotFound)
+
+		env := NewWithValues("dev", map[string]string{"SECRET": encrypted, "PLAIN": "value"})
+		require.Equal(t, "", env.Getenv("SECRET"))
+
+		// The secrets that cannot be decrypted are reported, with how to set them again
+		err = env.CheckSecrets()
+		var decryptionErr *SecretDecryptionError
+		require.ErrorAs(t, err, &decryptionErr)
+		require.Equal(t, "SECRET", decryptionErr.Key)
+		require.ErrorIs(t, err, ErrSecretKeyNotFound)
+		require.ErrorContains(t, err, "azd env set SECRET <value> --secret")
+
+		// Setting the value again fixes the environment
+		require.NoError(t, env.DotenvSetSecret("SECRET", "value"))
+		require.NoError(t, env.CheckSecrets())
+		require.Equal(t, "value", env.Getenv("SECRET"))
+	})
+
+	t.Run("DifferentKey", func(t *testing.T) {
+		t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+		encrypted, err := encryptSecret("value")
+		require.NoError(t, err)
+
+		t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+		_, err = encryptSecret("other")
+		require.NoError(t, err)
+
+		_, err = decryptSecret(encrypted)
+		require.Error(t, err)
+	})
+
+	t.Run("Corrupted", func(t *testing.T) {
+		t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+		encrypted, err := encryptSecret("value")
+		require.NoError(t, err)
+
+		_, err = decryptSecret(strings.TrimSuffix(encrypted, encrypted[len(encrypted)-8:]))
+		require.Error(t, err)
+	})
+}
@@ -121,7 +121,7 @@ func (sbd *StorageBlobDataStore) Save(ctx context.Context, env *Environment, opt
 	// Update configuration
 	cfgWriter := new(bytes.Buffer)
 
-	if err := sbd.configManager.Save(env.Config, cfgWriter); err != nil {
+	if err := sbd.configManager.Save(env.persistedConfig(), cfgWriter); err != nil {
 		return fmt.Errorf("saving config: %w", err)
 	}
 
@@ -155,11 +155,9 @@ func (sbd *StorageBlobDataStore) Reload(ctx context.Context, env *Environment) e
 
 	envMap, err := godotenv.Parse(dotEnvBuffer)
 	if err != nil {
-		env.dotenv = make(map[string]string)
-		env.deletedKeys = make(map[string]struct{})
+		env.setDotenv(make(map[string]string))
 	} else {
-		env.dotenv = envMap
-		env.deletedKeys = make(map[string]struct{})
+		env.setDotenv(envMap)
 	}
 
 	// Reload config file
@@ -171,11 +169,11 @@ func (sbd *StorageBlobDataStore) Reload(ctx context.Context, env *Environment) e
 	defer configBuffer.Close()
 
 	if cfg, err := sbd.configManager.Load(configBuffer); errors.Is(err, os.ErrNotExist) {
-		env.Config = config.NewEmptyConfig()
+		env.setConfig(config.NewEmptyConfig())
 	} else if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	} else {
-		env.Config = cfg
+		env.setConfig(cfg)
 	}
 
 	if env.Name() != "" {
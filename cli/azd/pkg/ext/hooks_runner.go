@@ -116,6 +116,10 @@ func (h *HooksRunner) GetScript(hookConfig *HookConfig) (tools.Script, error) {
 		return nil, err
 	}
 
+	if err := h.env.CheckSecrets(); err != nil {
+		return nil, err
+	}
+
 	switch hookConfig.Shell {
 	case ShellTypeBash:
 		return bash.NewBashScript(h.commandRunner, h.cwd, h.env.Environ()), nil
@@ -129,7 +133,7 @@ func (h *HooksRunner) GetScript(hookConfig *HookConfig) (tools.Script, error) {
 	}
 }
 
-func (h *HooksRunner) execHook(ctx context.Context, hookConfig *HookConfig, options *tools.ExecOptions) error {
+func (h *HooksRunner) execHook(ctx context.Context, hookConfig *HookConfig, options *tools.ExecOptions) (err error) {
 	if options == nil {
 		options = &tools.ExecOptions{}
 	}
@@ -143,6 +147,15 @@ func (h *HooksRunner) execHook(ctx context.Context, hookConfig *HookConfig, opti
 	consoleInteractive := (formatter == nil || formatter.Kind() == output.NoneFormat)
 	scriptInteractive := consoleInteractive && hookConfig.Interactive
 
+	// In the jsonl event stream, the hook is a step, and its output the log lines written by the previewer
+	if formatter != nil && formatter.Kind() == output.JsonLinesFormat {
+		stepMessage := fmt.Sprintf("Running %s hook", hookConfig.Name)
+		h.console.ShowSpinner(ctx, stepMessage, input.Step)
+		defer func() {
+			h.console.StopSpinner(ctx, stepMessage, input.GetStepResultFormat(err))
+		}()
+	}
+
 	if options.Interactive == nil {
 		options.Interactive = &scriptInteractive
 	}
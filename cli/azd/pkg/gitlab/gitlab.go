@@ -0,0 +1,339 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+var (
+	// hostname of the GitLab SaaS service.
+	GitLabHostName = "gitlab.com"
+	// environment variable that holds the GitLab personal access token
+	GitLabTokenName = "GITLAB_TOKEN"
+	// environment variable that holds the URL of the GitLab instance, e.g. https://gitlab.example.com for a self-hosted
+	// instance
+	GitLabEnvironmentHostName = "GITLAB_HOST"
+)
+
+var (
+	ErrProjectNotFound    = errors.New("gitlab project not found")
+	ErrProjectNameInUse   = errors.New("gitlab project name is already in use")
+	ErrVariableNotFound   = errors.New("gitlab variable not found")
+	ErrUntrustedHost      = errors.New("gitlab host is not trusted with the token")
+	maskableVariableRegex = regexp.MustCompile(`^[A-Za-z0-9+/=@:.~_-]{8,}$`)
+)
+
+// Project is a project of GitLab, as returned by the projects API.
+type Project struct {
+	Id                int    `json:"id"`
+	Name              string `json:"name"`
+	Path              string `json:"path"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebUrl            string `json:"web_url"`
+	HttpUrlToRepo     string `json:"http_url_to_repo"`
+	SshUrlToRepo      string `json:"ssh_url_to_repo"`
+	DefaultBranch     string `json:"default_branch"`
+	// BuildsAccessLevel is 'disabled' when the CI/CD of the project is turned off.
+	BuildsAccessLevel string `json:"builds_access_level"`
+}
+
+// Variable is a CI/CD variable of a GitLab project.
+type Variable struct {
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+	Masked    bool   `json:"masked"`
+	Protected bool   `json:"protected"`
+	// Raw variables aren't expanded, so values with '$' are kept as-is.
+	Raw              bool   `json:"raw"`
+	VariableType     string `json:"variable_type,omitempty"`
+	EnvironmentScope string `json:"environment_scope,omitempty"`
+}
+
+// User is the user of GitLab the token belongs to.
+type User struct {
+	Id       int    `json:"id"`
+	Username string `json:"username"`
+}
+
+// ResponseError is returned when the GitLab API responds with an unsuccessful status code.
+type ResponseError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("GitLab API returned %d: %s", e.StatusCode, e.Message)
+}
+
+// CanMask returns whether GitLab can mask the value in the job logs. Masked values are single line, have at least 8
+// characters and only use the characters of the Base64 alphabet, plus '@', ':', '.' and '~'.
+func CanMask(value string) bool {
+	return maskableVariableRegex.MatchString(value)
+}
+
+// CheckTokenHost returns an error unless the token can be sent to the instance at baseUrl, which must be served over
+// https by the configured host, GITLAB_HOST, or by gitlab.com when no host is configured. The token is never sent to
+// other hosts, like the host a git remote of the project names.
+func CheckTokenHost(baseUrl string, configuredHost string) error {
+	trustedUrl := NormalizeBaseUrl(configuredHost)
+	if trustedUrl == "" {
+		trustedUrl = "https://" + GitLabHostName
+	}
+
+	trusted, err := url.Parse(trustedUrl)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", GitLabEnvironmentHostName, err)
+	}
+
+	u, err := url.Parse(baseUrl)
+	if err != nil || !strings.EqualFold(u.Scheme, "https") || !strings.EqualFold(u.Host, trusted.Host) {
+		return fmt.Errorf(
+			"%w: the token is only sent over https to %s, set %s to use the instance at %s",
+			ErrUntrustedHost, trusted.Host, GitLabEnvironmentHostName, baseUrl)
+	}
+
+	return nil
+}
+
+// Client is a client of the REST API (v4) of GitLab, either gitlab.com or a self-hosted instance.
+type Client struct {
+	baseUrl   string
+	token     string
+	transport policy.Transporter
+}
+
+// NewClient creates a client of the GitLab instance at baseUrl, e.g. https://gitlab.com, authenticated with a personal,
+// group or project access token with the 'api' scope.
+func NewClient(baseUrl string, token string, transport policy.Transporter) *Client {
+	return &Client{
+		baseUrl:   strings.TrimSuffix(baseUrl, "/"),
+		token:     token,
+		transport: transport,
+	}
+}
+
+// BaseUrl returns the URL of the GitLab instance.
+func (c *Client) BaseUrl() string {
+	return c.baseUrl
+}
+
+// CurrentUser returns the user of the token, which validates the token.
+func (c *Client) CurrentUser(ctx context.Context) (*User, error) {
+	var user User
+	if err := c.do(ctx, http.MethodGet, "/user", nil, &user); err != nil {
+		return nil, fmt.Errorf("getting current user: %w", err)
+	}
+
+	return &user, nil
+}
+
+// GetProject returns the project with the given path, e.g. 'group/subgroup/project'.
+func (c *Client) GetProject(ctx context.Context, projectPath string) (*Project, error) {
+	var project Project
+	err := c.do(ctx, http.MethodGet, "/projects/"+url.PathEscape(projectPath), nil, &project)
+	if isStatus(err, http.StatusNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrProjectNotFound, projectPath)
+	} else if err != nil {
+		return nil, fmt.Errorf("getting project %s: %w", projectPath, err)
+	}
+
+	return &project, nil
+}
+
+// ListProjects returns the projects the user can configure the CI/CD of, i.e. with at least the maintainer role.
+func (c *Client) ListProjects(ctx context.Context) ([]Project, error) {
+	projects, err := getPages[Project](ctx, c, "/projects?membership=true&min_access_level=40&order_by=last_activity_at")
+	if err != nil {
+		return nil, fmt.Errorf("listing projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+// CreateProject creates a private project with the given name in the namespace of the user.
+func (c *Client) CreateProject(ctx context.Context, name string) (*Project, error) {
+	var project Project
+	err := c.do(ctx, http.MethodPost, "/projects", map[string]any{
+		"name":       name,
+		"visibility": "private",
+	}, &project)
+
+	var responseErr *ResponseError
+	if errors.As(err, &responseErr) &&
+		responseErr.StatusCode == http.StatusBadRequest &&
+		strings.Contains(responseErr.Message, "has already been taken") {
+		return nil, ErrProjectNameInUse
+	} else if err != nil {
+		return nil, fmt.Errorf("creating project %s: %w", name, err)
+	}
+
+	return &project, nil
+}
+
+// ListVariables returns the CI/CD variables of the project.
+func (c *Client) ListVariables(ctx context.Context, projectId int) ([]Variable, error) {
+	variables, err := getPages[Variable](ctx, c, fmt.Sprintf("/projects/%d/variables", projectId))
+	if err != nil {
+		return nil, fmt.Errorf("listing variables: %w", err)
+	}
+
+	return variables, nil
+}
+
+// SetVariable creates or updates the CI/CD variable of the project, for all the environments.
+func (c *Client) SetVariable(ctx context.Context, projectId int, variable Variable) error {
+	variable.EnvironmentScope = "*"
+	if variable.VariableType == "" {
+		variable.VariableType = "env_var"
+	}
+
+	err := c.do(ctx, http.MethodPut, variablePath(projectId, variable.Key), variable, nil)
+	if isStatus(err, http.StatusNotFound) {
+		err = c.do(ctx, http.MethodPost, fmt.Sprintf("/projects/%d/variables", projectId), variable, nil)
+	}
+
+	if err != nil {
+		return fmt.Errorf("setting variable %s: %w", variable.Key, err)
+	}
+
+	return nil
+}
+
+// DeleteVariable deletes the CI/CD variable of the project.
+func (c *Client) DeleteVariable(ctx context.Context, projectId int, key string) error {
+	err := c.do(ctx, http.MethodDelete, variablePath(projectId, key), nil, nil)
+	if isStatus(err, http.StatusNotFound) {
+		return fmt.Errorf("%w: %s", ErrVariableNotFound, key)
+	} else if err != nil {
+		return fmt.Errorf("deleting variable %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func variablePath(projectId int, key string) string {
+	return fmt.Sprintf(
+		"/projects/%d/variables/%s?%s", projectId, url.PathEscape(key), url.QueryEscape("filter[environment_scope]")+"=*")
+}
+
+// do sends the request to the API, with the JSON of body, and reads the JSON of the response into result, unless nil.
+func (c *Client) do(ctx context.Context, method string, path string, body any, result any) error {
+	_, err := c.send(ctx, method, path, body, result)
+	return err
+}
+
+func (c *Client) send(ctx context.Context, method string, path string, body any, result any) (http.Header, error) {
+	var reader io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+
+		reader = bytes.NewReader(content)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseUrl+"/api/v4"+path, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.transport.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	content, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, &ResponseError{StatusCode: res.StatusCode, Message: errorMessage(content)}
+	}
+
+	if result != nil {
+		if err := json.Unmarshal(content, result); err != nil {
+			return nil, fmt.Errorf("reading response: %w", err)
+		}
+	}
+
+	return res.Header, nil
+}
+
+// getPages returns the items of all the pages of the path.
+func getPages[T any](ctx context.Context, c *Client, path string) ([]T, error) {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	items := []T{}
+	for page := "1"; page != ""; {
+		var pageItems []T
+		header, err := c.send(ctx, http.MethodGet, path+separator+"per_page=100&page="+page, nil, &pageItems)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, pageItems...)
+
+		// The header is empty on the last page
+		page = header.Get("X-Next-Page")
+		if _, err := strconv.Atoi(page); page != "" && err != nil {
+			return nil, fmt.Errorf("invalid next page '%s'", page)
+		}
+	}
+
+	return items, nil
+}
+
+// errorMessage returns the message of the body of an error response, which is a string or an object with the errors of
+// the fields of the request.
+func errorMessage(content []byte) string {
+	var response struct {
+		Message json.RawMessage `json:"message"`
+		Error   string          `json:"error"`
+	}
+
+	if err := json.Unmarshal(content, &response); err != nil {
+		return strings.TrimSpace(string(content))
+	}
+
+	if len(response.Message) > 0 {
+		var message string
+		if err := json.Unmarshal(response.Message, &message); err == nil {
+			return message
+		}
+
+		return string(response.Message)
+	}
+
+	return response.Error
+}
+
+func isStatus(err error, statusCode int) bool {
+	var responseErr *ResponseError
+	return errors.As(err, &responseErr) && responseErr.StatusCode == statusCode
+}
@@ -0,0 +1,136 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package gitlab_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/gitlab"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockgitlab"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Client_Projects(t *testing.T) {
+	gitLab := mockgitlab.NewMockGitLab(t)
+	for i := range 3 {
+		gitLab.AddProject(fmt.Sprintf("group/subgroup/project%d", i))
+	}
+
+	client := gitlab.NewClient(gitLab.BaseUrl+"/", mockgitlab.Token, gitLab.Transport)
+	ctx := context.Background()
+
+	user, err := client.CurrentUser(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "user", user.Username)
+
+	project, err := client.GetProject(ctx, "group/subgroup/project1")
+	require.NoError(t, err)
+	require.Equal(t, 2, project.Id)
+	require.Equal(t, gitLab.BaseUrl+"/group/subgroup/project1", project.WebUrl)
+
+	_, err = client.GetProject(ctx, "group/missing")
+	require.ErrorIs(t, err, gitlab.ErrProjectNotFound)
+
+	// The projects of all the pages are returned
+	projects, err := client.ListProjects(ctx)
+	require.NoError(t, err)
+	require.Len(t, projects, 3)
+
+	created, err := client.CreateProject(ctx, "app")
+	require.NoError(t, err)
+	require.Equal(t, "user/app", created.PathWithNamespace)
+	require.Equal(t, gitLab.BaseUrl+"/user/app.git", created.HttpUrlToRepo)
+
+	_, err = client.CreateProject(ctx, "app")
+	require.ErrorIs(t, err, gitlab.ErrProjectNameInUse)
+
+	_, err = gitlab.NewClient(gitLab.BaseUrl, "WRONG", gitLab.Transport).CurrentUser(ctx)
+	require.ErrorContains(t, err, "GitLab API returned 401: 401 Unauthorized")
+}
+
+func Test_Client_Variables(t *testing.T) {
+	gitLab := mockgitlab.NewMockGitLab(t)
+	project := gitLab.AddProject("group/project")
+
+	client := gitlab.NewClient(gitLab.BaseUrl, mockgitlab.Token, gitLab.Transport)
+	ctx := context.Background()
+
+	// Variables are created, then updated
+	require.NoError(t, client.SetVariable(ctx, project.Id, gitlab.Variable{Key: "AZURE_ENV_NAME", Value: "dev", Raw: true}))
+	require.NoError(t, client.SetVariable(ctx, project.Id, gitlab.Variable{Key: "AZURE_ENV_NAME", Value: "prod", Raw: true}))
+	require.NoError(t, client.SetVariable(ctx, project.Id, gitlab.Variable{Key: "SECRET", Value: "secret-value", Masked: true}))
+	require.NoError(t, client.SetVariable(ctx, project.Id, gitlab.Variable{Key: "OTHER", Value: "value"}))
+
+	require.Equal(t, gitlab.Variable{
+		Key:              "AZURE_ENV_NAME",
+		Value:            "prod",
+		Raw:              true,
+		VariableType:     "env_var",
+		EnvironmentScope: "*",
+	}, gitLab.Variables(project.Id)["AZURE_ENV_NAME"])
+	require.True(t, gitLab.Variables(project.Id)["SECRET"].Masked)
+
+	variables, err := client.ListVariables(ctx, project.Id)
+	require.NoError(t, err)
+	require.Len(t, variables, 3)
+
+	require.NoError(t, client.DeleteVariable(ctx, project.Id, "OTHER"))
+	require.NotContains(t, gitLab.Variables(project.Id), "OTHER")
+	require.ErrorIs(t, client.DeleteVariable(ctx, project.Id, "OTHER"), gitlab.ErrVariableNotFound)
+}
+
+func Test_CheckTokenHost(t *testing.T) {
+	tests := []struct {
+		name           string
+		baseUrl        string
+		configuredHost string
+		trusted        bool
+	}{
+		{name: "default instance", baseUrl: "https://gitlab.com", trusted: true},
+		{name: "other host than the default", baseUrl: "https://attacker.example.com"},
+		{name: "http", baseUrl: "http://gitlab.com"},
+		{
+			name:           "configured host",
+			baseUrl:        "https://GitLab.Example.com/gitlab",
+			configuredHost: "gitlab.example.com",
+			trusted:        true,
+		},
+		{
+			name:           "other host than the configured one",
+			baseUrl:        "https://gitlab.com",
+			configuredHost: "https://gitlab.example.com",
+		},
+		{
+			name:           "configured host over http",
+			baseUrl:        "http://gitlab.example.com",
+			configuredHost: "http://gitlab.example.com",
+		},
+		{
+			name:           "other port than the configured one",
+			baseUrl:        "https://gitlab.example.com:8443",
+			configuredHost: "https://gitlab.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := gitlab.CheckTokenHost(tt.baseUrl, tt.configuredHost)
+			if tt.trusted {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, gitlab.ErrUntrustedHost)
+			}
+		})
+	}
+}
+
+func Test_CanMask(t *testing.T) {
+	require.True(t, gitlab.CanMask("abcd1234"))
+	require.True(t, gitlab.CanMask("Ab1~Cd2.Ef3_Gh4-Ij5@k:l+m/n="))
+	require.False(t, gitlab.CanMask("eastus"))
+	require.False(t, gitlab.CanMask(`{"key":"value"}`))
+	require.False(t, gitlab.CanMask("line one\nline two"))
+}
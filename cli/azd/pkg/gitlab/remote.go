@@ -0,0 +1,113 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package gitlab
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var ErrRemoteHostIsNotGitLab = errors.New("not a gitlab host")
+
+// defines the structure of an scp-like ssh git remote, e.g. git@gitlab.com:group/project.git
+var gitLabRemoteScpUrlRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]+@([a-zA-Z0-9.-]+):(.+)$`)
+
+// the hosts of other providers, which are never GitLab instances
+var nonGitLabHosts = []string{"github.com", "www.github.com", "dev.azure.com", "ssh.dev.azure.com"}
+
+// Remote is a git remote of a project on a GitLab instance.
+type Remote struct {
+	// BaseUrl is the URL of the GitLab instance, e.g. https://gitlab.com
+	BaseUrl string
+	// ProjectPath is the full path of the project, including its group and subgroups, e.g. group/subgroup/project
+	ProjectPath string
+}
+
+// NormalizeBaseUrl returns the URL of a GitLab instance from its host name or URL, e.g. https://gitlab.example.com for
+// gitlab.example.com.
+func NormalizeBaseUrl(hostOrUrl string) string {
+	hostOrUrl = strings.TrimSuffix(strings.TrimSpace(hostOrUrl), "/")
+	if hostOrUrl == "" {
+		return ""
+	}
+
+	if !strings.Contains(hostOrUrl, "://") {
+		hostOrUrl = "https://" + hostOrUrl
+	}
+
+	return hostOrUrl
+}
+
+// ParseRemote extracts the GitLab instance and the project from the https or ssh url of a git remote.
+//
+// Self-hosted instances are supported. When the instance is served under a relative path, e.g.
+// https://example.com/gitlab, its URL must be given as baseUrl, since it can't be told apart from the groups of the
+// project. For ssh remotes, baseUrl also provides the scheme and the port of the web URL of the instance.
+func ParseRemote(remoteUrl string, baseUrl string) (*Remote, error) {
+	baseUrl = NormalizeBaseUrl(baseUrl)
+
+	var remote *Remote
+	if captures := gitLabRemoteScpUrlRegex.FindStringSubmatch(remoteUrl); captures != nil &&
+		!strings.Contains(remoteUrl, "://") {
+		remote = &Remote{
+			BaseUrl:     sshBaseUrl(captures[1], baseUrl),
+			ProjectPath: captures[2],
+		}
+	} else {
+		u, err := url.Parse(remoteUrl)
+		if err != nil || u.Host == "" {
+			return nil, ErrRemoteHostIsNotGitLab
+		}
+
+		switch u.Scheme {
+		case "http", "https":
+			instance := u.Scheme + "://" + u.Host
+			projectPath := u.Path
+
+			// The instance may be served under a relative path
+			if base, err := url.Parse(baseUrl); err == nil && baseUrl != "" &&
+				strings.EqualFold(base.Host, u.Host) && strings.HasPrefix(u.Path, base.Path+"/") {
+				instance = u.Scheme + "://" + u.Host + base.Path
+				projectPath = strings.TrimPrefix(u.Path, base.Path)
+			}
+
+			remote = &Remote{
+				BaseUrl:     instance,
+				ProjectPath: projectPath,
+			}
+		case "ssh":
+			remote = &Remote{
+				BaseUrl:     sshBaseUrl(u.Hostname(), baseUrl),
+				ProjectPath: u.Path,
+			}
+		default:
+			return nil, ErrRemoteHostIsNotGitLab
+		}
+	}
+
+	if u, err := url.Parse(remote.BaseUrl); err != nil || slices.Contains(nonGitLabHosts, strings.ToLower(u.Hostname())) {
+		return nil, ErrRemoteHostIsNotGitLab
+	}
+
+	remote.ProjectPath = strings.TrimSuffix(strings.Trim(remote.ProjectPath, "/"), ".git")
+
+	// Projects always belong to a user or a group
+	if !strings.Contains(remote.ProjectPath, "/") {
+		return nil, ErrRemoteHostIsNotGitLab
+	}
+
+	return remote, nil
+}
+
+// sshBaseUrl returns the URL of the instance of an ssh remote, which is the given base URL when it is the same host.
+func sshBaseUrl(host string, baseUrl string) string {
+	if base, err := url.Parse(baseUrl); err == nil && baseUrl != "" && strings.EqualFold(base.Hostname(), host) {
+		return baseUrl
+	}
+
+	return "https://" + host
+}
@@ -0,0 +1,81 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseRemote(t *testing.T) {
+	tests := []struct {
+		name      string
+		remoteUrl string
+		baseUrl   string
+		expected  *Remote
+	}{
+		{
+			name:      "https",
+			remoteUrl: "https://gitlab.com/group/project.git",
+			expected:  &Remote{BaseUrl: "https://gitlab.com", ProjectPath: "group/project"},
+		},
+		{
+			name:      "https with user and subgroups",
+			remoteUrl: "https://oauth2@gitlab.com/group/subgroup/project",
+			expected:  &Remote{BaseUrl: "https://gitlab.com", ProjectPath: "group/subgroup/project"},
+		},
+		{
+			name:      "scp",
+			remoteUrl: "git@gitlab.com:group/project.git",
+			expected:  &Remote{BaseUrl: "https://gitlab.com", ProjectPath: "group/project"},
+		},
+		{
+			name:      "self-hosted with port",
+			remoteUrl: "http://localhost:8080/group/project.git",
+			expected:  &Remote{BaseUrl: "http://localhost:8080", ProjectPath: "group/project"},
+		},
+		{
+			name:      "self-hosted under relative path",
+			remoteUrl: "https://example.com/gitlab/group/project.git",
+			baseUrl:   "example.com/gitlab/",
+			expected:  &Remote{BaseUrl: "https://example.com/gitlab", ProjectPath: "group/project"},
+		},
+		{
+			name:      "self-hosted ssh",
+			remoteUrl: "ssh://git@gitlab.example.com:2222/group/project.git",
+			baseUrl:   "http://gitlab.example.com:8080",
+			expected:  &Remote{BaseUrl: "http://gitlab.example.com:8080", ProjectPath: "group/project"},
+		},
+		{
+			name:      "github",
+			remoteUrl: "https://github.com/owner/repo.git",
+		},
+		{
+			name:      "azure devops",
+			remoteUrl: "git@ssh.dev.azure.com:v3/org/project/repo",
+		},
+		{
+			name:      "no namespace",
+			remoteUrl: "https://gitlab.com/project.git",
+		},
+		{
+			name:      "local path",
+			remoteUrl: "/home/user/project",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			remote, err := ParseRemote(test.remoteUrl, test.baseUrl)
+			if test.expected == nil {
+				require.ErrorIs(t, err, ErrRemoteHostIsNotGitLab)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expected, remote)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+)
+
+// helper method to ensure a GitLab access token exists either in .env or system environment variables
+func EnsureTokenExists(ctx context.Context, env *environment.Environment, console input.Console) (
+	string, bool, error) {
+	if value, exists := env.LookupEnv(GitLabTokenName); exists && value != "" {
+		return value, false, nil
+	}
+
+	console.Message(ctx, fmt.Sprintf(
+		"You need a %s with the %s scope. Create a token by following the instructions here %s",
+		output.WithWarningFormat("GitLab Personal Access Token"),
+		output.WithHighLightFormat("api"),
+		output.WithLinkFormat("https://docs.gitlab.com/ee/user/profile/personal_access_tokens.html")))
+	console.Message(ctx, fmt.Sprintf("(%s this prompt by setting the token to env var: %s)",
+		output.WithWarningFormat("%s", "skip"),
+		output.WithHighLightFormat("%s", GitLabTokenName)))
+
+	token, err := console.Prompt(ctx, input.ConsoleOptions{
+		Message:    "Personal Access Token:",
+		IsPassword: true,
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("asking for token: %w", err)
+	}
+	// set the token as an environment variable for this cmd run
+	// note: the scope of this env var is only this shell invocation and won't be available in the caller parent shell
+	os.Setenv(GitLabTokenName, token)
+
+	return token, true, nil
+}
+
+// helper method to ensure the URL of the GitLab instance exists either in .env or system environment variables. When
+// it doesn't, the user is prompted for it, with gitlab.com as the default, and it is saved to the .env file.
+func EnsureBaseUrlExists(
+	ctx context.Context,
+	envManager environment.Manager,
+	env *environment.Environment,
+	console input.Console,
+) (string, error) {
+	if value, exists := env.LookupEnv(GitLabEnvironmentHostName); exists && value != "" {
+		return NormalizeBaseUrl(value), nil
+	}
+
+	value, err := console.Prompt(ctx, input.ConsoleOptions{
+		Message:      "Enter the URL of your GitLab instance:",
+		DefaultValue: "https://" + GitLabHostName,
+	})
+	if err != nil {
+		return "", fmt.Errorf("asking for gitlab url: %w", err)
+	}
+
+	baseUrl := NormalizeBaseUrl(value)
+	env.DotenvSet(GitLabEnvironmentHostName, baseUrl)
+	if err := envManager.Save(ctx, env); err != nil {
+		return "", err
+	}
+
+	return baseUrl, nil
+}
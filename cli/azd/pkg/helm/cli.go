@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
@@ -133,6 +134,21 @@ func (c *Cli) Status(ctx context.Context, release *Release) (*StatusResult, erro
 	return result, nil
 }
 
+// Rollback rolls back a helm release to the specified revision
+func (c *Cli) Rollback(ctx context.Context, release *Release, revision int) error {
+	runArgs := exec.NewRunArgs("helm", "rollback", release.Name, strconv.Itoa(revision), "--wait")
+	if release.Namespace != "" {
+		runArgs = runArgs.AppendParams("--namespace", release.Namespace)
+	}
+
+	_, err := c.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed to rollback helm release %s to revision %d: %w", release.Name, revision, err)
+	}
+
+	return nil
+}
+
 func (cli *Cli) getClientVersion(ctx context.Context) (string, error) {
 	runArgs := exec.NewRunArgs("helm", "version", "--template", "{{.Version}}")
 	versionResult, err := cli.commandRunner.Run(ctx, runArgs)
@@ -472,3 +472,58 @@ func Test_Cli_Status(t *testing.T) {
 		require.ErrorContains(t, err, "failed to get status")
 	})
 }
+
+func Test_Cli_Rollback(t *testing.T) {
+	release := &Release{
+		Name:      "test",
+		Chart:     "test/chart",
+		Namespace: "test-namespace",
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		ran := false
+		var runArgs exec.RunArgs
+
+		mockContext := mocks.NewMockContext(context.Background())
+		mockContext.CommandRunner.
+			When(func(args exec.RunArgs, command string) bool {
+				return strings.Contains(command, "helm rollback")
+			}).
+			RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+				ran = true
+				runArgs = args
+				return exec.NewRunResult(0, "", ""), nil
+			})
+
+		cli := NewCli(mockContext.CommandRunner)
+		err := cli.Rollback(*mockContext.Context, release, 2)
+		require.True(t, ran)
+		require.NoError(t, err)
+
+		require.Equal(t, "helm", runArgs.Cmd)
+		require.Equal(t, []string{
+			"rollback",
+			"test",
+			"2",
+			"--wait",
+			"--namespace",
+			"test-namespace",
+		}, runArgs.Args)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		mockContext.CommandRunner.
+			When(func(args exec.RunArgs, command string) bool {
+				return strings.Contains(command, "helm rollback")
+			}).
+			RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+				return exec.NewRunResult(1, "", ""), errors.New("release has no 2 version")
+			})
+
+		cli := NewCli(mockContext.CommandRunner)
+		err := cli.Rollback(*mockContext.Context, release, 2)
+		require.Error(t, err)
+		require.ErrorContains(t, err, "failed to rollback helm release test to revision 2")
+	})
+}
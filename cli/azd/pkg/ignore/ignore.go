@@ -0,0 +1,389 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Package ignore implements a gitignore compatible engine used to exclude files from packages.
+package ignore
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// GitIgnoreFileName is the name of the ignore file used by git.
+	GitIgnoreFileName = ".gitignore"
+	// AzdIgnoreFileName is the name of the ignore file only honored by azd.
+	AzdIgnoreFileName = ".azdignore"
+	// FuncIgnoreFileName is the name of the ignore file used by Azure Functions tooling.
+	FuncIgnoreFileName = ".funcignore"
+	// WebAppIgnoreFileName is the name of the ignore file used for Azure App Service deployments.
+	WebAppIgnoreFileName = ".webappignore"
+)
+
+// DefaultFileNames are the ignore files that are honored regardless of the hosting target.
+var DefaultFileNames = []string{GitIgnoreFileName, AzdIgnoreFileName}
+
+// Pattern is a single compiled gitignore pattern.
+type Pattern struct {
+	// The original text of the pattern
+	raw string
+	// The slash separated directory, relative to the matcher root, the pattern is scoped to
+	base    string
+	negate  bool
+	dirOnly bool
+	regex   *regexp.Regexp
+}
+
+// String returns the original text of the pattern.
+func (p *Pattern) String() string {
+	return p.raw
+}
+
+// Negate returns true when the pattern re-includes files previously excluded (patterns starting with '!').
+func (p *Pattern) Negate() bool {
+	return p.negate
+}
+
+// ParsePattern parses a single line of an ignore file. base is the slash separated directory, relative to the
+// matcher root, that the pattern is scoped to. Returns nil when the line is blank or a comment.
+func ParsePattern(line string, base string) (*Pattern, error) {
+	line = strings.TrimSuffix(line, "\r")
+	line = trimTrailingSpaces(line)
+
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil, nil
+	}
+
+	pattern := &Pattern{
+		raw:  line,
+		base: strings.Trim(filepath.ToSlash(base), "/"),
+	}
+
+	if strings.HasPrefix(line, "!") {
+		pattern.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") && !strings.HasSuffix(line, `\/`) {
+		pattern.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+
+	if line == "" {
+		return nil, nil
+	}
+
+	// A pattern with a separator at the beginning or in the middle is relative to the directory containing the
+	// ignore file, otherwise the pattern matches at any level below it.
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	expr := globToRegex(line)
+	if !anchored {
+		expr = "(?:.*/)?" + expr
+	}
+
+	regex, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid ignore pattern '%s': %w", pattern.raw, err)
+	}
+
+	pattern.regex = regex
+	return pattern, nil
+}
+
+// Parse reads all the patterns from the specified reader, scoping them to the base directory.
+func Parse(reader io.Reader, base string) ([]*Pattern, error) {
+	patterns := []*Pattern{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		pattern, err := ParsePattern(scanner.Text(), base)
+		if err != nil {
+			return nil, err
+		}
+
+		if pattern != nil {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return patterns, nil
+}
+
+// match returns true when the pattern matches the slash separated path relative to the matcher root.
+func (p *Pattern) match(relPath string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+
+	if p.base != "" {
+		if !strings.HasPrefix(relPath, p.base+"/") {
+			return false
+		}
+
+		relPath = strings.TrimPrefix(relPath, p.base+"/")
+	}
+
+	return p.regex.MatchString(relPath)
+}
+
+// Matcher evaluates paths rooted at a directory against the patterns found in the ignore files of that directory
+// tree, in the same way git evaluates .gitignore files. Ignore files in sub directories are loaded on demand.
+type Matcher struct {
+	root      string
+	fileNames []string
+	extra     []*Pattern
+
+	mu          sync.Mutex
+	dirPatterns map[string][]*Pattern
+}
+
+// NewMatcher creates a new matcher rooted at the specified directory. fileNames are the names of the ignore files
+// to honor, and extraPatterns are additional patterns relative to the root that take precedence over any pattern
+// loaded from ignore files.
+func NewMatcher(root string, fileNames []string, extraPatterns []string) (*Matcher, error) {
+	extra := []*Pattern{}
+	for _, line := range extraPatterns {
+		pattern, err := ParsePattern(line, "")
+		if err != nil {
+			return nil, err
+		}
+
+		if pattern != nil {
+			extra = append(extra, pattern)
+		}
+	}
+
+	return &Matcher{
+		root:        root,
+		fileNames:   fileNames,
+		extra:       extra,
+		dirPatterns: map[string][]*Pattern{},
+	}, nil
+}
+
+// Root returns the directory the matcher is rooted at.
+func (m *Matcher) Root() string {
+	return m.root
+}
+
+// Match returns true when the path, relative to the matcher root, is excluded.
+// A path is also excluded when any of its parent directories is excluded.
+func (m *Matcher) Match(relPath string, isDir bool) (bool, error) {
+	relPath = strings.Trim(filepath.ToSlash(relPath), "/")
+	if relPath == "" || relPath == "." {
+		return false, nil
+	}
+
+	segments := strings.Split(relPath, "/")
+	patterns := []*Pattern{}
+
+	for i := range segments {
+		dir := strings.Join(segments[:i], "/")
+		dirPatterns, err := m.patternsForDir(dir)
+		if err != nil {
+			return false, err
+		}
+
+		patterns = append(patterns, dirPatterns...)
+		current := strings.Join(segments[:i+1], "/")
+		currentIsDir := isDir || i < len(segments)-1
+
+		if matchAll(patterns, m.extra, current, currentIsDir) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// MatchPath returns true when the absolute or root relative path is excluded.
+func (m *Matcher) MatchPath(fullPath string, isDir bool) (bool, error) {
+	relPath := fullPath
+	if filepath.IsAbs(fullPath) {
+		rel, err := filepath.Rel(m.root, fullPath)
+		if err != nil {
+			return false, err
+		}
+		relPath = rel
+	}
+
+	return m.Match(relPath, isDir)
+}
+
+// matchAll evaluates the patterns in order. Like git, the last matching pattern decides the outcome.
+func matchAll(patterns []*Pattern, extra []*Pattern, relPath string, isDir bool) bool {
+	excluded := false
+	for _, list := range [][]*Pattern{patterns, extra} {
+		for _, pattern := range list {
+			if pattern.match(relPath, isDir) {
+				excluded = !pattern.negate
+			}
+		}
+	}
+
+	return excluded
+}
+
+// patternsForDir loads and caches the patterns of all ignore files within the slash separated directory.
+func (m *Matcher) patternsForDir(dir string) ([]*Pattern, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if patterns, has := m.dirPatterns[dir]; has {
+		return patterns, nil
+	}
+
+	patterns := []*Pattern{}
+	for _, fileName := range m.fileNames {
+		filePath := filepath.Join(m.root, filepath.FromSlash(dir), fileName)
+		file, err := os.Open(filePath)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("reading ignore file '%s': %w", filePath, err)
+		}
+
+		filePatterns, err := Parse(file, dir)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parsing ignore file '%s': %w", filePath, err)
+		}
+
+		patterns = append(patterns, filePatterns...)
+	}
+
+	m.dirPatterns[dir] = patterns
+	return patterns, nil
+}
+
+// Walk walks the matcher root, calling fn for every file or directory that is not excluded. Excluded paths are
+// reported to onExclude (when set) as slash separated paths relative to the root, and excluded directories are
+// not traversed.
+func (m *Matcher) Walk(fn fs.WalkDirFunc, onExclude func(relPath string, isDir bool)) error {
+	return filepath.WalkDir(m.root, func(fullPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return fn(fullPath, d, err)
+		}
+
+		rel, err := filepath.Rel(m.root, fullPath)
+		if err != nil {
+			return err
+		}
+
+		excluded, err := m.Match(rel, d.IsDir())
+		if err != nil {
+			return err
+		}
+
+		if excluded {
+			if onExclude != nil {
+				onExclude(filepath.ToSlash(rel), d.IsDir())
+			}
+
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		return fn(fullPath, d, nil)
+	})
+}
+
+// FormatExcluded formats a slash separated relative path the way excluded paths are reported to users, where
+// directories end with a trailing slash.
+func FormatExcluded(relPath string, isDir bool) string {
+	relPath = path.Clean(filepath.ToSlash(relPath))
+	if isDir {
+		return relPath + "/"
+	}
+
+	return relPath
+}
+
+// trimTrailingSpaces removes trailing spaces unless they are escaped with a backslash.
+func trimTrailingSpaces(line string) string {
+	for strings.HasSuffix(line, " ") {
+		if strings.HasSuffix(line, `\ `) {
+			return line
+		}
+		line = line[:len(line)-1]
+	}
+
+	return line
+}
+
+// globToRegex converts a gitignore glob into an equivalent regular expression over slash separated paths.
+func globToRegex(glob string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				atStart := i == 0 || glob[i-1] == '/'
+				atEnd := i+2 == len(glob)
+
+				switch {
+				case atStart && atEnd:
+					// "foo/**" matches everything inside foo
+					sb.WriteString(".*")
+					i++
+				case atStart && glob[i+2] == '/':
+					// "**/foo" and "a/**/b" match zero or more directories
+					sb.WriteString("(?:.*/)?")
+					i += 2
+				default:
+					// Any other consecutive asterisks are considered regular asterisks
+					sb.WriteString("[^/]*")
+					i++
+				}
+				continue
+			}
+			sb.WriteString("[^/]*")
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			class = strings.ReplaceAll(class, `\`, `\\`)
+			sb.WriteString("[" + class + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(glob) {
+				sb.WriteString(regexp.QuoteMeta(string(glob[i+1])))
+				i++
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,210 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ignore
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		matches []string
+		misses  []string
+	}{
+		{
+			name:    "FileName",
+			pattern: "*.log",
+			matches: []string{"debug.log", "logs/debug.log", "a/b/c.log"},
+			misses:  []string{"debug.log.txt", "log"},
+		},
+		{
+			name:    "Anchored",
+			pattern: "/debug.log",
+			matches: []string{"debug.log"},
+			misses:  []string{"logs/debug.log"},
+		},
+		{
+			name:    "MiddleSeparator",
+			pattern: "logs/*.log",
+			matches: []string{"logs/debug.log"},
+			misses:  []string{"src/logs/debug.log", "logs/a/debug.log"},
+		},
+		{
+			name:    "LeadingDoubleAsterisk",
+			pattern: "**/fixtures",
+			matches: []string{"fixtures", "test/fixtures", "a/b/fixtures"},
+			misses:  []string{"fixtures2"},
+		},
+		{
+			name:    "TrailingDoubleAsterisk",
+			pattern: "build/**",
+			matches: []string{"build/a", "build/a/b.txt"},
+			misses:  []string{"build", "src/build/a"},
+		},
+		{
+			name:    "MiddleDoubleAsterisk",
+			pattern: "a/**/b",
+			matches: []string{"a/b", "a/x/b", "a/x/y/b"},
+			misses:  []string{"a/xb", "c/a/b"},
+		},
+		{
+			name:    "QuestionMarkAndClass",
+			pattern: "file?.[ch]",
+			matches: []string{"file1.c", "src/fileA.h"},
+			misses:  []string{"file1.cpp", "file12.c"},
+		},
+		{
+			name:    "NegatedClass",
+			pattern: "file[!0-9].txt",
+			matches: []string{"filea.txt"},
+			misses:  []string{"file1.txt"},
+		},
+		{
+			name:    "EscapedHash",
+			pattern: `\#notes`,
+			matches: []string{"#notes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := ParsePattern(tt.pattern, "")
+			require.NoError(t, err)
+			require.NotNil(t, pattern)
+
+			for _, path := range tt.matches {
+				require.True(t, pattern.match(path, false), "expected '%s' to match '%s'", tt.pattern, path)
+			}
+
+			for _, path := range tt.misses {
+				require.False(t, pattern.match(path, false), "expected '%s' not to match '%s'", tt.pattern, path)
+			}
+		})
+	}
+}
+
+func TestParsePatternSkipsCommentsAndBlankLines(t *testing.T) {
+	for _, line := range []string{"", "   ", "# comment", "/"} {
+		pattern, err := ParsePattern(line, "")
+		require.NoError(t, err)
+		require.Nil(t, pattern)
+	}
+}
+
+func TestParsePatternDirOnly(t *testing.T) {
+	pattern, err := ParsePattern("node_modules/", "")
+	require.NoError(t, err)
+
+	require.True(t, pattern.match("node_modules", true))
+	require.True(t, pattern.match("src/node_modules", true))
+	require.False(t, pattern.match("node_modules", false))
+}
+
+func TestMatcher(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		".gitignore":         "node_modules/\n*.log\n.env\n!keep.log\n",
+		".azdignore":         "tests/\n",
+		"src/.gitignore":     "generated.js\n",
+		"src/generated.js":   "",
+		"src/app.js":         "",
+		"app.log":            "",
+		"keep.log":           "",
+		".env":               "",
+		"tests/test.js":      "",
+		"other/generated.js": "",
+		"node_modules/a.js":  "",
+		"secrets.json":       "",
+	})
+
+	matcher, err := NewMatcher(root, DefaultFileNames, []string{"secrets.json"})
+	require.NoError(t, err)
+
+	expected := map[string]bool{
+		"src/generated.js":   true,
+		"src/app.js":         false,
+		"app.log":            true,
+		"keep.log":           false,
+		".env":               true,
+		"tests/test.js":      true,
+		"other/generated.js": false,
+		"node_modules/a.js":  true,
+		"secrets.json":       true,
+		".gitignore":         false,
+	}
+
+	for path, excluded := range expected {
+		actual, err := matcher.Match(path, false)
+		require.NoError(t, err)
+		require.Equal(t, excluded, actual, path)
+	}
+}
+
+func TestMatcherExtraPatternsTakePrecedence(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		".gitignore": "*.json\n",
+	})
+
+	matcher, err := NewMatcher(root, DefaultFileNames, []string{"!appsettings.json"})
+	require.NoError(t, err)
+
+	excluded, err := matcher.Match("appsettings.json", false)
+	require.NoError(t, err)
+	require.False(t, excluded)
+
+	excluded, err = matcher.Match("other.json", false)
+	require.NoError(t, err)
+	require.True(t, excluded)
+}
+
+func TestMatcherWalk(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		".funcignore":         ".venv\nlocal.settings.json\n",
+		".venv/lib/a.py":      "",
+		"local.settings.json": "",
+		"function_app.py":     "",
+	})
+
+	matcher, err := NewMatcher(root, []string{FuncIgnoreFileName}, nil)
+	require.NoError(t, err)
+
+	included := []string{}
+	excluded := []string{}
+	err = matcher.Walk(func(path string, d fs.DirEntry, err error) error {
+		require.NoError(t, err)
+		if !d.IsDir() {
+			rel, err := filepath.Rel(root, path)
+			require.NoError(t, err)
+			included = append(included, filepath.ToSlash(rel))
+		}
+		return nil
+	}, func(relPath string, isDir bool) {
+		excluded = append(excluded, FormatExcluded(relPath, isDir))
+	})
+	require.NoError(t, err)
+
+	sort.Strings(included)
+	sort.Strings(excluded)
+	require.Equal(t, []string{".funcignore", "function_app.py"}, included)
+	require.Equal(t, []string{".venv/", "local.settings.json"}, excluded)
+}
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for path, contents := range files {
+		fullPath := filepath.Join(root, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(strings.TrimSpace(contents)+"\n"), 0600))
+	}
+}
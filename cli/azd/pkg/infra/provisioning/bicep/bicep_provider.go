@@ -23,6 +23,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
@@ -76,6 +77,9 @@ type BicepProvider struct {
 	compileBicepMemoryCache *compileBicepResult
 	keyvaultService         keyvault.KeyVaultService
 	portalUrlBase           string
+	// secretValues are the values substituted by secretOrRandomPassword in the parameters. Outputs that echo them are
+	// stored as secrets in the environment.
+	secretValues map[string]struct{}
 }
 
 // Name gets the name of the infra provider
@@ -680,6 +684,7 @@ func (p *BicepProvider) Preview(ctx context.Context) (*provisioning.DeployPrevie
 			},
 			ResourceType: resourceAfter["type"].(string),
 			Name:         resourceAfter["name"].(string),
+			Delta:        convertWhatIfPropertyChanges(change.Delta),
 		})
 	}
 
@@ -693,6 +698,29 @@ func (p *BicepProvider) Preview(ctx context.Context) (*provisioning.DeployPrevie
 	}, nil
 }
 
+// convertWhatIfPropertyChanges converts the property changes of a what-if operation into the property changes of a
+// deployment preview
+func convertWhatIfPropertyChanges(
+	whatIfChanges []*armresources.WhatIfPropertyChange,
+) []provisioning.DeploymentPreviewPropertyChange {
+	if len(whatIfChanges) == 0 {
+		return nil
+	}
+
+	changes := make([]provisioning.DeploymentPreviewPropertyChange, 0, len(whatIfChanges))
+	for _, whatIfChange := range whatIfChanges {
+		changes = append(changes, provisioning.DeploymentPreviewPropertyChange{
+			ChangeType: provisioning.PropertyChangeType(convert.ToValueWithDefault(whatIfChange.PropertyChangeType, "")),
+			Path:       convert.ToValueWithDefault(whatIfChange.Path, ""),
+			Before:     whatIfChange.Before,
+			After:      whatIfChange.After,
+			Children:   convertWhatIfPropertyChanges(whatIfChange.Children),
+		})
+	}
+
+	return changes
+}
+
 type itemToPurge struct {
 	resourceType      string
 	count             int
@@ -1473,9 +1501,13 @@ func (p *BicepProvider) createOutputParameters(
 			paramName = strings.ToUpper(key)
 		}
 
+		// Outputs that echo a password generated or read from a vault by secretOrRandomPassword are secrets
+		_, secret := p.secretValues[fmt.Sprint(azureParam.Value)]
+
 		outputParams[paramName] = provisioning.OutputParameter{
-			Type:  p.mapBicepTypeToInterfaceType(azureParam.Type),
-			Value: azureParam.Value,
+			Type:   p.mapBicepTypeToInterfaceType(azureParam.Type),
+			Value:  azureParam.Value,
+			Secret: secret,
 		}
 	}
 
@@ -1520,6 +1552,13 @@ func (p *BicepProvider) loadParameters(ctx context.Context) (map[string]azure.Ar
 		if err != nil {
 			return nil, fmt.Errorf("substituting command output inside parameter file: %w", err)
 		}
+
+		if p.secretValues == nil {
+			p.secretValues = map[string]struct{}{}
+		}
+		for _, value := range cmdExecutor.Values() {
+			p.secretValues[value] = struct{}{}
+		}
 	}
 
 	var armParameters azure.ArmParameterFile
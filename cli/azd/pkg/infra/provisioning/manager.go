@@ -83,6 +83,11 @@ var AzdOperationsFeatureKey = alpha.MustFeatureKey("azd.operations")
 
 // Deploys the Azure infrastructure for the specified project
 func (m *Manager) Deploy(ctx context.Context) (*DeployResult, error) {
+	// Parameters are resolved from the environment, so secrets must be readable
+	if err := m.env.CheckSecrets(); err != nil {
+		return nil, err
+	}
+
 	// Apply the infrastructure deployment
 	deployResult, err := m.provider.Deploy(ctx)
 	if err != nil {
@@ -253,6 +258,10 @@ func bindMountOperation(
 
 // Preview generates the list of changes to be applied as part of the provisioning.
 func (m *Manager) Preview(ctx context.Context) (*DeployPreviewResult, error) {
+	if err := m.env.CheckSecrets(); err != nil {
+		return nil, err
+	}
+
 	// Apply the infrastructure deployment
 	deployResult, err := m.provider.Preview(ctx)
 
@@ -313,14 +322,22 @@ func (m *Manager) UpdateEnvironment(
 	if len(outputs) > 0 {
 		for key, param := range outputs {
 			// Complex types marshalled as JSON strings, simple types marshalled as simple strings
+			value := fmt.Sprintf("%v", param.Value)
 			if param.Type == ParameterTypeArray || param.Type == ParameterTypeObject {
 				bytes, err := json.Marshal(param.Value)
 				if err != nil {
 					return fmt.Errorf("invalid value for output parameter '%s' (%s): %w", key, string(param.Type), err)
 				}
-				m.env.DotenvSet(key, string(bytes))
+				value = string(bytes)
+			}
+
+			// Values that were stored as secrets remain encrypted when updated
+			if param.Secret || m.env.IsSecret(key) {
+				if err := m.env.DotenvSetSecret(key, value); err != nil {
+					return err
+				}
 			} else {
-				m.env.DotenvSet(key, fmt.Sprintf("%v", param.Value))
+				m.env.DotenvSet(key, value)
 			}
 		}
 
@@ -150,6 +150,48 @@ func TestManagerDeploy(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestManagerSecrets(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+	env := environment.NewWithValues("test-env", map[string]string{
+		"AZURE_SUBSCRIPTION_ID": "SUBSCRIPTION_ID",
+		"AZURE_LOCATION":        "eastus2",
+	})
+
+	mockContext := mocks.NewMockContext(context.Background())
+	registerContainerDependencies(mockContext, env)
+
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Save", *mockContext.Context, env).Return(nil)
+	mgr := provisioning.NewManager(
+		mockContext.Container,
+		defaultProvider,
+		envManager,
+		env,
+		mockContext.Console,
+		mockContext.AlphaFeaturesManager,
+		nil,
+		cloud.AzurePublic(),
+	)
+	err := mgr.Initialize(*mockContext.Context, "", provisioning.Options{Provider: "test"})
+	require.NoError(t, err)
+
+	// Secret outputs are stored encrypted
+	err = mgr.UpdateEnvironment(*mockContext.Context, map[string]provisioning.OutputParameter{
+		"DB_PASSWORD": {Type: provisioning.ParameterTypeString, Value: "p@ssw0rd", Secret: true},
+		"DB_HOST":     {Type: provisioning.ParameterTypeString, Value: "db.example.com"},
+	})
+	require.NoError(t, err)
+	require.True(t, env.IsSecret("DB_PASSWORD"))
+	require.False(t, env.IsSecret("DB_HOST"))
+	require.Equal(t, "p@ssw0rd", env.Getenv("DB_PASSWORD"))
+
+	// Secrets encrypted with another key fail the deployment instead of being deployed as empty values
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+	deployResult, err := mgr.Deploy(*mockContext.Context)
+	require.Nil(t, deployResult)
+	require.ErrorContains(t, err, "azd env set DB_PASSWORD <value> --secret")
+}
+
 func TestManagerDestroyWithPositiveConfirmation(t *testing.T) {
 	env := environment.NewWithValues("test-env", map[string]string{
 		"AZURE_SUBSCRIPTION_ID": "SUBSCRIPTION_ID",
@@ -0,0 +1,841 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pulumi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk/storage"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/password"
+	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
+	"github.com/azure/azure-dev/cli/azd/pkg/state"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/pulumi"
+	"github.com/drone/envsubst"
+)
+
+const (
+	defaultModule = "main"
+	defaultPath   = "infra"
+)
+
+const (
+	// The environment variable used to override the pulumi backend where the stack state is stored
+	backendUrlEnvVarName = "PULUMI_BACKEND_URL"
+	// The environment variable holding the passphrase used to encrypt secrets on self-managed backends
+	passphraseEnvVarName = "PULUMI_CONFIG_PASSPHRASE"
+	// The environment variable holding the path to a file containing the passphrase
+	passphraseFileEnvVarName = "PULUMI_CONFIG_PASSPHRASE_FILE"
+)
+
+// The type of the input parameters holding secrets, whose values are not reported
+const secureStringParameterType = "securestring"
+
+// PulumiProvider exposes infrastructure provisioning using Pulumi programs
+type PulumiProvider struct {
+	envManager           environment.Manager
+	env                  *environment.Environment
+	prompters            prompt.Prompter
+	console              input.Console
+	cli                  *pulumi.Cli
+	curPrincipal         provisioning.CurrentPrincipalIdProvider
+	remoteStateConfig    *state.RemoteConfig
+	storageAccountConfig *storage.AccountConfig
+	projectPath          string
+	options              provisioning.Options
+}
+
+// Name gets the name of the infra provider
+func (p *PulumiProvider) Name() string {
+	return "Pulumi"
+}
+
+func (p *PulumiProvider) RequiredExternalTools() []tools.ExternalTool {
+	return []tools.ExternalTool{p.cli}
+}
+
+// NewPulumiProvider creates a new instance of a Pulumi Infra provider
+func NewPulumiProvider(
+	cli *pulumi.Cli,
+	envManager environment.Manager,
+	env *environment.Environment,
+	console input.Console,
+	curPrincipal provisioning.CurrentPrincipalIdProvider,
+	prompters prompt.Prompter,
+	remoteStateConfig *state.RemoteConfig,
+	storageAccountConfig *storage.AccountConfig,
+) provisioning.Provider {
+	return &PulumiProvider{
+		envManager:           envManager,
+		env:                  env,
+		console:              console,
+		cli:                  cli,
+		curPrincipal:         curPrincipal,
+		prompters:            prompters,
+		remoteStateConfig:    remoteStateConfig,
+		storageAccountConfig: storageAccountConfig,
+	}
+}
+
+func (p *PulumiProvider) Initialize(ctx context.Context, projectPath string, options provisioning.Options) error {
+	p.projectPath = projectPath
+	p.options = options
+	if p.options.Module == "" {
+		p.options.Module = defaultModule
+	}
+	if p.options.Path == "" {
+		p.options.Path = defaultPath
+	}
+
+	requiredTools := p.RequiredExternalTools()
+	if err := tools.EnsureInstalled(ctx, requiredTools...); err != nil {
+		return err
+	}
+
+	if err := p.EnsureEnv(ctx); err != nil {
+		return err
+	}
+
+	return p.configureCli(ctx)
+}
+
+// configureCli sets the environment variables used on all pulumi commands, which include the backend configuration
+// and the Azure credentials.
+func (p *PulumiProvider) configureCli(ctx context.Context) error {
+	backend, err := p.backend()
+	if err != nil {
+		return err
+	}
+
+	envVars := []string{
+		fmt.Sprintf("%s=%s", backendUrlEnvVarName, backend.url),
+		"PULUMI_SKIP_UPDATE_CHECK=true",
+		// Required when using service principal login
+		fmt.Sprintf("ARM_TENANT_ID=%s", os.Getenv("ARM_TENANT_ID")),
+		fmt.Sprintf("ARM_SUBSCRIPTION_ID=%s", p.env.GetSubscriptionId()),
+		fmt.Sprintf("ARM_CLIENT_ID=%s", os.Getenv("ARM_CLIENT_ID")),
+		fmt.Sprintf("ARM_CLIENT_SECRET=%s", os.Getenv("ARM_CLIENT_SECRET")),
+		fmt.Sprintf("ARM_LOCATION=%s", p.env.GetLocation()),
+	}
+	envVars = append(envVars, backend.env...)
+
+	// Self-managed backends encrypt stack secrets with a passphrase
+	if backend.selfManaged {
+		passphrase, err := p.ensurePassphrase(ctx, backend.shared)
+		if err != nil {
+			return err
+		}
+
+		if passphrase != "" {
+			envVars = append(envVars, fmt.Sprintf("%s=%s", passphraseEnvVarName, passphrase))
+		}
+	}
+
+	p.cli.SetEnv(envVars)
+	return nil
+}
+
+// EnsureEnv ensures that the environment is in a provision-ready state with required values set, prompting the user if
+// values are unset.
+//
+// An environment is considered to be in a provision-ready state if it contains both an AZURE_SUBSCRIPTION_ID and
+// AZURE_LOCATION value.
+func (p *PulumiProvider) EnsureEnv(ctx context.Context) error {
+	return provisioning.EnsureSubscriptionAndLocation(
+		ctx,
+		p.envManager,
+		p.env,
+		p.prompters,
+		nil,
+	)
+}
+
+// Preview the infrastructure changes through pulumi preview
+func (p *PulumiProvider) Preview(ctx context.Context) (*provisioning.DeployPreviewResult, error) {
+	if _, err := p.prepareStack(ctx); err != nil {
+		return nil, err
+	}
+
+	p.console.Message(ctx, "Previewing pulumi stack changes...")
+	previewJson, err := p.cli.Preview(ctx, p.modulePath(), p.stackName())
+	if err != nil {
+		return nil, err
+	}
+
+	var preview pulumiPreviewOutput
+	if err := json.Unmarshal([]byte(previewJson), &preview); err != nil {
+		return nil, fmt.Errorf("parsing pulumi preview output: %w", err)
+	}
+
+	return &provisioning.DeployPreviewResult{
+		Preview: &provisioning.DeploymentPreview{
+			Status: "done",
+			Properties: &provisioning.DeploymentPreviewProperties{
+				Changes: convertPreviewSteps(preview.Steps),
+			},
+		},
+	}, nil
+}
+
+// Deploy the infrastructure of the pulumi program through pulumi up
+func (p *PulumiProvider) Deploy(ctx context.Context) (*provisioning.DeployResult, error) {
+	deployment, err := p.prepareStack(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	modulePath := p.modulePath()
+
+	// pulumi doesn't use the `p.console`, we must ensure no spinner is running before calling Up
+	p.console.StopSpinner(ctx, "", input.Step)
+	// The output of pulumi up is written to the console, it isn't captured
+	if _, err := p.cli.Up(ctx, modulePath, p.stackName()); err != nil {
+		return nil, fmt.Errorf("pulumi up failed: %w", err)
+	}
+
+	outputs, err := p.stackOutputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment.Outputs = outputs
+	return &provisioning.DeployResult{
+		Deployment: deployment,
+	}, nil
+}
+
+// Destroys all the resources of the pulumi stack through pulumi destroy
+func (p *PulumiProvider) Destroy(
+	ctx context.Context,
+	options provisioning.DestroyOptions,
+) (*provisioning.DestroyResult, error) {
+	modulePath := p.modulePath()
+
+	if err := p.cli.SelectStack(ctx, modulePath, p.stackName(), false); err != nil {
+		return nil, err
+	}
+
+	// load the outputs to invalidate them once the resources are deleted
+	outputs, err := p.stackOutputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.console.Message(ctx, "Deleting pulumi stack resources...")
+	// pulumi doesn't use the `p.console`, we must ensure no spinner is running before calling Destroy
+	// as it could be an interactive operation if it needs confirmation
+	p.console.StopSpinner(ctx, "", input.Step)
+	// The output of pulumi destroy is written to the console, it isn't captured
+	if _, err := p.cli.Destroy(ctx, modulePath, p.stackName(), options.Force()); err != nil {
+		return nil, fmt.Errorf("pulumi destroy failed: %w", err)
+	}
+
+	return &provisioning.DestroyResult{
+		InvalidatedEnvKeys: slices.Collect(maps.Keys(outputs)),
+	}, nil
+}
+
+// State gets the outputs and resources from the last deployment of the pulumi stack
+func (p *PulumiProvider) State(
+	ctx context.Context,
+	options *provisioning.StateOptions,
+) (*provisioning.StateResult, error) {
+	modulePath := p.modulePath()
+
+	p.console.Message(ctx, "Retrieving pulumi stack state...")
+	if err := p.cli.SelectStack(ctx, modulePath, p.stackName(), false); err != nil {
+		return nil, err
+	}
+
+	outputs, err := p.stackOutputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	exportJson, err := p.cli.StackExport(ctx, modulePath, p.stackName())
+	if err != nil {
+		return nil, err
+	}
+
+	var export pulumiStackExport
+	if err := json.Unmarshal([]byte(exportJson), &export); err != nil {
+		return nil, fmt.Errorf("parsing pulumi stack export: %w", err)
+	}
+
+	return &provisioning.StateResult{
+		State: &provisioning.State{
+			Outputs:   outputs,
+			Resources: collectAzureResources(export.Deployment.Resources),
+		},
+	}, nil
+}
+
+// prepareStack selects (creating as needed) the stack for the current environment and applies the stack configuration.
+func (p *PulumiProvider) prepareStack(ctx context.Context) (*provisioning.Deployment, error) {
+	modulePath := p.modulePath()
+	if _, err := os.Stat(filepath.Join(modulePath, "Pulumi.yaml")); err != nil {
+		return nil, fmt.Errorf("reading pulumi project file in '%s': %w", modulePath, err)
+	}
+
+	if err := p.cli.SelectStack(ctx, modulePath, p.stackName(), true); err != nil {
+		return nil, err
+	}
+
+	stackConfig, stackSecrets, err := p.stackConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.cli.SetConfig(ctx, modulePath, p.stackName(), stackConfig); err != nil {
+		return nil, err
+	}
+
+	if err := p.cli.SetSecretConfig(ctx, modulePath, p.stackName(), stackSecrets); err != nil {
+		return nil, err
+	}
+
+	parameters := make(map[string]provisioning.InputParameter, len(stackConfig)+len(stackSecrets))
+	for key, value := range stackConfig {
+		parameters[key] = provisioning.InputParameter{
+			Type:  string(provisioning.ParameterTypeString),
+			Value: value,
+		}
+	}
+
+	for key := range stackSecrets {
+		parameters[key] = provisioning.InputParameter{
+			Type: secureStringParameterType,
+		}
+	}
+
+	return &provisioning.Deployment{
+		Parameters: parameters,
+	}, nil
+}
+
+// stackConfig builds the stack configuration from the environment and the optional parameters file of the module, and
+// returns the plain text values and the secret values separately.
+//
+// The parameters file is a flat JSON object of configuration keys and values which supports environment variable
+// substitution, ex) { "appName": "app-${AZURE_ENV_NAME}" }. A value is a secret when it is declared as one, ex)
+// { "dbPassword": { "value": "${DB_PASSWORD}", "secret": true } }, or when it includes the value of a secret of the azd
+// environment.
+func (p *PulumiProvider) stackConfig(ctx context.Context) (map[string]string, map[string]string, error) {
+	config := map[string]string{
+		"azure-native:location":       p.env.GetLocation(),
+		"azure-native:subscriptionId": p.env.GetSubscriptionId(),
+	}
+	secrets := map[string]string{}
+
+	parametersFilePath := p.parametersFilePath()
+	log.Printf("Reading parameters file from: %s", parametersFilePath)
+	parametersBytes, err := os.ReadFile(parametersFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, secrets, nil
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("reading parameters file: %w", err)
+	}
+
+	principalId, err := p.curPrincipal.CurrentPrincipalId(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetching current principal id: %w", err)
+	}
+
+	replaced, err := envsubst.Eval(string(parametersBytes), func(name string) string {
+		if name == environment.PrincipalIdEnvVarName {
+			return principalId
+		}
+
+		return p.env.Getenv(name)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("substituting parameters file: %w", err)
+	}
+
+	var parameters map[string]any
+	if err := json.Unmarshal([]byte(replaced), &parameters); err != nil {
+		return nil, nil, fmt.Errorf("parsing parameters file: %w", err)
+	}
+
+	envSecrets := p.envSecretValues()
+	for key, value := range parameters {
+		value, secret := secretParameter(value)
+
+		var configValue string
+		switch v := value.(type) {
+		case string:
+			configValue = v
+		default:
+			// pulumi parses structured values set through the CLI as JSON
+			valueBytes, err := json.Marshal(v)
+			if err != nil {
+				return nil, nil, fmt.Errorf("converting parameter '%s': %w", key, err)
+			}
+			configValue = string(valueBytes)
+		}
+
+		if !secret {
+			secret = slices.ContainsFunc(envSecrets, func(envSecret string) bool {
+				return strings.Contains(configValue, envSecret)
+			})
+		}
+
+		if secret {
+			secrets[key] = configValue
+		} else {
+			config[key] = configValue
+		}
+	}
+
+	return config, secrets, nil
+}
+
+// envSecretValues returns the non-empty values of the secrets of the azd environment.
+func (p *PulumiProvider) envSecretValues() []string {
+	values := []string{}
+	for key := range p.env.Dotenv() {
+		if !p.env.IsSecret(key) {
+			continue
+		}
+
+		if value := p.env.Getenv(key); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// secretParameter unwraps the value of a parameter declared as { "value": <value>, "secret": true }, and returns
+// whether the parameter is a secret.
+func secretParameter(value any) (any, bool) {
+	declaration, ok := value.(map[string]any)
+	if !ok || len(declaration) != 2 {
+		return value, false
+	}
+
+	secret, isBool := declaration["secret"].(bool)
+	inner, hasValue := declaration["value"]
+	if !isBool || !hasValue {
+		return value, false
+	}
+
+	return inner, secret
+}
+
+// stackOutputs gets the outputs of the current stack in the canonical format shared by all provider implementations.
+func (p *PulumiProvider) stackOutputs(ctx context.Context) (map[string]provisioning.OutputParameter, error) {
+	outputJson, err := p.cli.StackOutput(ctx, p.modulePath(), p.stackName())
+	if err != nil {
+		return nil, err
+	}
+
+	var outputMap map[string]any
+	if err := json.Unmarshal([]byte(outputJson), &outputMap); err != nil {
+		return nil, fmt.Errorf("parsing pulumi stack outputs: %w", err)
+	}
+
+	return convertOutputs(outputMap), nil
+}
+
+// ensurePassphrase returns the passphrase used to encrypt the stack secrets on self-managed backends. When the
+// passphrase is not provided by the user, a new one is generated and stored within the azd environment, which is only
+// done for the local file backend. The stacks of a shared backend must be decrypted by everyone using the backend, so
+// the passphrase must be provided by the user instead of being generated in a single local environment.
+// An empty passphrase is returned when pulumi is configured to read the passphrase from a file.
+func (p *PulumiProvider) ensurePassphrase(ctx context.Context, shared bool) (string, error) {
+	if _, has := os.LookupEnv(passphraseFileEnvVarName); has {
+		return "", nil
+	}
+
+	if passphrase, has := os.LookupEnv(passphraseEnvVarName); has {
+		return passphrase, nil
+	}
+
+	if passphrase, has := p.env.LookupEnv(passphraseEnvVarName); has {
+		return passphrase, nil
+	}
+
+	if shared {
+		return "", &internal.ErrorWithSuggestion{
+			Err: fmt.Errorf(
+				"%s is required to encrypt the secrets of the pulumi stacks stored in a shared backend",
+				passphraseEnvVarName,
+			),
+			Suggestion: fmt.Sprintf(
+				"Set the passphrase shared with your team in the %s or %s environment variables, "+
+					"ex) 'azd env set %s <passphrase>'.",
+				passphraseEnvVarName,
+				passphraseFileEnvVarName,
+				passphraseEnvVarName,
+			),
+		}
+	}
+
+	passphrase, err := password.FromAlphabet(password.LettersAndDigits, 32)
+	if err != nil {
+		return "", fmt.Errorf("generating pulumi passphrase: %w", err)
+	}
+
+	p.env.DotenvSet(passphraseEnvVarName, passphrase)
+	if err := p.envManager.Save(ctx, p.env); err != nil {
+		return "", fmt.Errorf("saving pulumi passphrase: %w", err)
+	}
+
+	return passphrase, nil
+}
+
+// pulumiBackend describes where the state of the pulumi stacks are stored
+type pulumiBackend struct {
+	url string
+	// Additional environment variables required by the backend
+	env []string
+	// Self-managed backends require a passphrase to encrypt secrets
+	selfManaged bool
+	// Shared backends store the stacks outside of the azd environment, where they can be used by other users
+	shared bool
+}
+
+// backend resolves the pulumi backend in the following precedence:
+// 1. PULUMI_BACKEND_URL set within the azd environment or the current process
+// 2. The azd remote state configuration, when configured for Azure blob storage
+// 3. A local file backend within the .azure environment folder
+func (p *PulumiProvider) backend() (*pulumiBackend, error) {
+	backendUrl, has := p.env.LookupEnv(backendUrlEnvVarName)
+	if !has {
+		backendUrl = os.Getenv(backendUrlEnvVarName)
+	}
+
+	if backendUrl != "" {
+		return &pulumiBackend{
+			url:         backendUrl,
+			selfManaged: !strings.HasPrefix(backendUrl, "https://") && !strings.HasPrefix(backendUrl, "http://"),
+			shared:      true,
+		}, nil
+	}
+
+	if p.remoteStateConfig != nil &&
+		p.remoteStateConfig.Backend == string(environment.RemoteKindAzureBlobStorage) &&
+		p.storageAccountConfig != nil {
+		if p.storageAccountConfig.AccountName == "" {
+			return nil, errors.New("remote state configuration is missing the storage account name")
+		}
+
+		return &pulumiBackend{
+			url:         fmt.Sprintf("azblob://%s", p.storageAccountConfig.ContainerName),
+			env:         []string{fmt.Sprintf("AZURE_STORAGE_ACCOUNT=%s", p.storageAccountConfig.AccountName)},
+			selfManaged: true,
+			shared:      true,
+		}, nil
+	}
+
+	stateDirPath := p.localStateDirPath()
+	if err := os.MkdirAll(stateDirPath, osutil.PermissionDirectory); err != nil {
+		return nil, fmt.Errorf("creating pulumi state directory: %w", err)
+	}
+
+	return &pulumiBackend{
+		url:         "file://" + filepath.ToSlash(stateDirPath),
+		selfManaged: true,
+	}, nil
+}
+
+// Gets the stack name for the current environment
+func (p *PulumiProvider) stackName() string {
+	return p.env.Name()
+}
+
+// Gets the folder path to the pulumi project
+func (p *PulumiProvider) modulePath() string {
+	infraPath := p.options.Path
+	if strings.TrimSpace(infraPath) == "" {
+		infraPath = defaultPath
+	}
+
+	if filepath.IsAbs(infraPath) {
+		return infraPath
+	}
+
+	return filepath.Join(p.projectPath, infraPath)
+}
+
+// Gets the path to the optional stack parameters file
+func (p *PulumiProvider) parametersFilePath() string {
+	return filepath.Join(p.modulePath(), fmt.Sprintf("%s.parameters.json", p.options.Module))
+}
+
+// Gets the path to the local file backend within the .azure environment folder
+func (p *PulumiProvider) localStateDirPath() string {
+	return filepath.Join(p.projectPath, azdcontext.EnvironmentDirectoryName, p.env.Name(), p.options.Path, ".pulumi")
+}
+
+// convertOutputs converts the pulumi stack outputs to the canonical format shared by all provider implementations.
+func convertOutputs(outputMap map[string]any) map[string]provisioning.OutputParameter {
+	outputParameters := make(map[string]provisioning.OutputParameter, len(outputMap))
+	for key, value := range outputMap {
+		if value == nil {
+			// omit null
+			continue
+		}
+
+		outputParameters[key] = provisioning.OutputParameter{
+			Type:  parameterType(value),
+			Value: value,
+		}
+	}
+
+	return outputParameters
+}
+
+func parameterType(value any) provisioning.ParameterType {
+	switch value.(type) {
+	case bool:
+		return provisioning.ParameterTypeBoolean
+	case float64, int, int64:
+		return provisioning.ParameterTypeNumber
+	case []any:
+		return provisioning.ParameterTypeArray
+	case map[string]any:
+		return provisioning.ParameterTypeObject
+	default:
+		return provisioning.ParameterTypeString
+	}
+}
+
+// collectAzureResources collects the Azure resources managed by the stack. Any resource with an Azure resource id
+// is considered, regardless of the pulumi provider (azure-native, azure classic, ...) that manages it.
+func collectAzureResources(resources []pulumiResourceState) []provisioning.Resource {
+	azureResources := []provisioning.Resource{}
+	seen := map[string]struct{}{}
+
+	for _, resource := range resources {
+		if !resource.Custom || !isAzureResourceId(resource.Id) {
+			continue
+		}
+
+		if _, has := seen[resource.Id]; has {
+			continue
+		}
+
+		seen[resource.Id] = struct{}{}
+		azureResources = append(azureResources, provisioning.Resource{Id: resource.Id})
+	}
+
+	return azureResources
+}
+
+func isAzureResourceId(id string) bool {
+	return strings.HasPrefix(strings.ToLower(id), "/subscriptions/")
+}
+
+// convertPreviewSteps converts the steps of a pulumi preview into the changes of a deployment preview
+func convertPreviewSteps(steps []pulumiPreviewStep) []*provisioning.DeploymentPreviewChange {
+	changes := []*provisioning.DeploymentPreviewChange{}
+
+	for _, step := range steps {
+		changeType, ok := previewChangeTypes[step.Op]
+		if !ok {
+			// Other operations (ex: create-replacement, delete-replaced, read, refresh) are part of another change
+			// or do not modify resources.
+			continue
+		}
+
+		resourceState := step.NewState
+		if resourceState == nil {
+			resourceState = step.OldState
+		}
+
+		if resourceState == nil || !resourceState.Custom || strings.HasPrefix(resourceState.Type, "pulumi:") {
+			// Skip component resources, the stack and the provider resources
+			continue
+		}
+
+		change := &provisioning.DeploymentPreviewChange{
+			ChangeType:   changeType,
+			ResourceType: armResourceType(resourceState),
+			Name:         resourceName(step.Urn),
+		}
+
+		if step.OldState != nil {
+			change.ResourceId = provisioning.Resource{Id: step.OldState.Id}
+			change.Before = step.OldState.Inputs
+		}
+
+		if step.NewState != nil {
+			change.After = step.NewState.Inputs
+		}
+
+		change.Delta = propertyChanges(step)
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+var previewChangeTypes = map[string]provisioning.ChangeType{
+	"create":  provisioning.ChangeTypeCreate,
+	"update":  provisioning.ChangeTypeModify,
+	"replace": provisioning.ChangeTypeReplace,
+	"delete":  provisioning.ChangeTypeDelete,
+	"same":    provisioning.ChangeTypeNoChange,
+}
+
+// propertyChanges converts the detailed diff of a pulumi step into the property changes of a deployment preview
+func propertyChanges(step pulumiPreviewStep) []provisioning.DeploymentPreviewPropertyChange {
+	if len(step.DetailedDiff) == 0 {
+		return nil
+	}
+
+	var before, after map[string]any
+	if step.OldState != nil {
+		before = step.OldState.Inputs
+	}
+	if step.NewState != nil {
+		after = step.NewState.Inputs
+	}
+
+	delta := []provisioning.DeploymentPreviewPropertyChange{}
+	for _, path := range slices.Sorted(maps.Keys(step.DetailedDiff)) {
+		var changeType provisioning.PropertyChangeType
+		switch strings.TrimSuffix(step.DetailedDiff[path].Kind, "-replace") {
+		case "add":
+			changeType = provisioning.PropertyChangeTypeCreate
+		case "delete":
+			changeType = provisioning.PropertyChangeTypeDelete
+		default:
+			changeType = provisioning.PropertyChangeTypeModify
+		}
+
+		delta = append(delta, provisioning.DeploymentPreviewPropertyChange{
+			ChangeType: changeType,
+			Path:       path,
+			Before:     before[path],
+			After:      after[path],
+		})
+	}
+
+	return delta
+}
+
+// resourceName gets the logical name of the resource, which is the last segment of the pulumi URN
+func resourceName(urn string) string {
+	segments := strings.Split(urn, "::")
+	return segments[len(segments)-1]
+}
+
+// armResourceType gets the Azure resource type of the resource. The type is resolved from the Azure resource id when
+// the resource already exists, otherwise from the well-known azure-native type tokens.
+func armResourceType(resource *pulumiResourceState) string {
+	if isAzureResourceId(resource.Id) {
+		if resourceId, err := arm.ParseResourceID(resource.Id); err == nil {
+			return resourceId.ResourceType.String()
+		}
+	}
+
+	if resourceType, has := azureNativeResourceTypes[resource.Type]; has {
+		return resourceType
+	}
+
+	return resource.Type
+}
+
+// azureNativeResourceTypes maps the azure-native pulumi type tokens to the Azure resource type
+var azureNativeResourceTypes = map[string]string{
+	"azure-native:resources:ResourceGroup":                   "Microsoft.Resources/resourceGroups",
+	"azure-native:web:WebApp":                                "Microsoft.Web/sites",
+	"azure-native:web:AppServicePlan":                        "Microsoft.Web/serverfarms",
+	"azure-native:web:StaticSite":                            "Microsoft.Web/staticSites",
+	"azure-native:app:ContainerApp":                          "Microsoft.App/containerApps",
+	"azure-native:app:ManagedEnvironment":                    "Microsoft.App/managedEnvironments",
+	"azure-native:containerregistry:Registry":                "Microsoft.ContainerRegistry/registries",
+	"azure-native:containerservice:ManagedCluster":           "Microsoft.ContainerService/managedClusters",
+	"azure-native:storage:StorageAccount":                    "Microsoft.Storage/storageAccounts",
+	"azure-native:keyvault:Vault":                            "Microsoft.KeyVault/vaults",
+	"azure-native:operationalinsights:Workspace":             "Microsoft.OperationalInsights/workspaces",
+	"azure-native:insights:Component":                        "Microsoft.Insights/components",
+	"azure-native:documentdb:DatabaseAccount":                "Microsoft.DocumentDB/databaseAccounts",
+	"azure-native:cache:Redis":                               "Microsoft.Cache/redis",
+	"azure-native:dbforpostgresql:Server":                    "Microsoft.DBforPostgreSQL/flexibleServers",
+	"azure-native:dbformysql:Server":                         "Microsoft.DBforMySQL/flexibleServers",
+	"azure-native:sql:Server":                                "Microsoft.Sql/servers",
+	"azure-native:servicebus:Namespace":                      "Microsoft.ServiceBus/namespaces",
+	"azure-native:cognitiveservices:Account":                 "Microsoft.CognitiveServices/accounts",
+	"azure-native:search:Service":                            "Microsoft.Search/searchServices",
+	"azure-native:network:VirtualNetwork":                    "Microsoft.Network/virtualNetworks",
+	"azure-native:appconfiguration:ConfigurationStore":       "Microsoft.AppConfiguration/configurationStores",
+	"azure-native:apimanagement:ApiManagementService":        "Microsoft.ApiManagement/service",
+	"azure-native:machinelearningservices:Workspace":         "Microsoft.MachineLearningServices/workspaces",
+	"azure-native:cognitiveservices:Deployment":              "Microsoft.CognitiveServices/accounts/deployments",
+	"azure-native:containerservice:AgentPool":                "Microsoft.ContainerService/managedClusters/agentPools",
+	"azure-native:appplatform:Service":                       "Microsoft.AppPlatform/Spring",
+	"azure-native:network:PrivateEndpoint":                   "Microsoft.Network/privateEndpoints",
+	"azure-native:portal:Dashboard":                          "Microsoft.Portal/dashboards",
+	"azure-native:loadtestservice:LoadTest":                  "Microsoft.LoadTestService/loadTests",
+	"azure-native:cdn:Profile":                               "Microsoft.Cdn/profiles",
+	"azure-native:managedidentity:UserAssignedIdentity":      "Microsoft.ManagedIdentity/userAssignedIdentities",
+	"azure-native:authorization:RoleAssignment":              "Microsoft.Authorization/roleAssignments",
+	"azure-native:keyvault:Secret":                           "Microsoft.KeyVault/vaults/secrets",
+	"azure-native:storage:BlobContainer":                     "Microsoft.Storage/storageAccounts/blobServices/containers",
+	"azure-native:web:WebAppApplicationSettings":             "Microsoft.Web/sites/config",
+	"azure-native:dbforpostgresql:Database":                  "Microsoft.DBforPostgreSQL/flexibleServers/databases",
+	"azure-native:documentdb:SqlResourceSqlDatabase":         "Microsoft.DocumentDB/databaseAccounts/sqlDatabases",
+	"azure-native:documentdb:MongoDBResourceMongoDBDatabase": "Microsoft.DocumentDB/databaseAccounts/mongodbDatabases",
+}
+
+// pulumiPreviewOutput is a model type for the output of `pulumi preview --json`
+type pulumiPreviewOutput struct {
+	Steps         []pulumiPreviewStep `json:"steps"`
+	ChangeSummary map[string]int      `json:"changeSummary"`
+}
+
+// pulumiPreviewStep is a model type for a single step of a pulumi preview
+type pulumiPreviewStep struct {
+	Op           string                        `json:"op"`
+	Urn          string                        `json:"urn"`
+	OldState     *pulumiResourceState          `json:"oldState"`
+	NewState     *pulumiResourceState          `json:"newState"`
+	DiffReasons  []string                      `json:"diffReasons"`
+	DetailedDiff map[string]pulumiPropertyDiff `json:"detailedDiff"`
+}
+
+// pulumiPropertyDiff describes the change of a single property within a pulumi preview step
+type pulumiPropertyDiff struct {
+	// add, update, delete, add-replace, update-replace or delete-replace
+	Kind      string `json:"kind"`
+	InputDiff bool   `json:"inputDiff"`
+}
+
+// pulumiResourceState is a model type for the state of a resource in pulumi previews and stack exports
+type pulumiResourceState struct {
+	Urn     string         `json:"urn"`
+	Custom  bool           `json:"custom"`
+	Id      string         `json:"id"`
+	Type    string         `json:"type"`
+	Inputs  map[string]any `json:"inputs"`
+	Outputs map[string]any `json:"outputs"`
+}
+
+// pulumiStackExport is a model type for the output of `pulumi stack export`
+type pulumiStackExport struct {
+	Version    int `json:"version"`
+	Deployment struct {
+		Resources []pulumiResourceState `json:"resources"`
+	} `json:"deployment"`
+}
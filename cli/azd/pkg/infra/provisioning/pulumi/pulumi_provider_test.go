@@ -0,0 +1,347 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pulumi
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk/storage"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/state"
+	pulumiTools "github.com/azure/azure-dev/cli/azd/pkg/tools/pulumi"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockexec"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/pulumi_preview_mock.json
+var pulumiPreviewMockOutput string
+
+//go:embed testdata/pulumi_export_mock.json
+var pulumiExportMockOutput string
+
+const pulumiOutputMock = `{"AZURE_LOCATION": "westus2", "RG_NAME": "rg-test-env", "PORT": 8080, "EMPTY": null}`
+
+func TestPulumiPreview(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareStackMocks(mockContext.CommandRunner)
+
+	var configArgs []string
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "config set-all")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		configArgs = args.Args
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	secrets := map[string]string{}
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "config set --secret")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		value, err := io.ReadAll(args.StdIn)
+		require.NoError(t, err)
+		secrets[args.Args[5]] = string(value)
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "preview --json")
+	}).Respond(exec.NewRunResult(0, pulumiPreviewMockOutput, ""))
+
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+	provider := createPulumiProvider(t, mockContext, nil)
+	require.NoError(t, provider.env.DotenvSetSecret("DB_PASSWORD", "db-p@ssw0rd"))
+	provider.env.DotenvSet("DB_HOST", "db.example.com")
+	require.NoError(t, os.WriteFile(provider.parametersFilePath(), []byte(`{
+		"appName": "${AZURE_ENV_NAME}",
+		"principalId": "${AZURE_PRINCIPAL_ID}",
+		"adminPassword": { "value": "${ADMIN_PASSWORD}", "secret": true },
+		"connectionString": "Server=${DB_HOST};Password=${DB_PASSWORD}",
+		"tags": { "azd-env-name": "${AZURE_ENV_NAME}" }
+	}`), 0600))
+
+	previewResult, err := provider.Preview(*mockContext.Context)
+	require.NoError(t, err)
+
+	require.Contains(t, configArgs, "azure-native:location=westus2")
+	require.Contains(t, configArgs, "appName=test-env")
+	require.Contains(t, configArgs, "principalId=11111111-1111-1111-1111-111111111111")
+	require.Contains(t, configArgs, `tags={"azd-env-name":"test-env"}`)
+
+	// Secrets, declared as such or including secrets of the environment, are never set in plain text
+	require.Equal(t, map[string]string{
+		"adminPassword":    "admin-p@ssw0rd",
+		"connectionString": "Server=db.example.com;Password=db-p@ssw0rd",
+	}, secrets)
+	for _, arg := range configArgs {
+		require.NotContains(t, arg, "p@ssw0rd")
+	}
+
+	changes := previewResult.Preview.Properties.Changes
+	require.Len(t, changes, 2)
+
+	require.Equal(t, provisioning.ChangeTypeModify, changes[0].ChangeType)
+	require.Equal(t, "Microsoft.Resources/resourceGroups", changes[0].ResourceType)
+	require.Equal(t, "rg", changes[0].Name)
+	require.Equal(t, []provisioning.DeploymentPreviewPropertyChange{
+		{
+			ChangeType: provisioning.PropertyChangeTypeCreate,
+			Path:       "tags",
+			After:      map[string]any{"azd-env-name": "test-env"},
+		},
+	}, changes[0].Delta)
+
+	require.Equal(t, provisioning.ChangeTypeCreate, changes[1].ChangeType)
+	require.Equal(t, "Microsoft.Storage/storageAccounts", changes[1].ResourceType)
+	require.Equal(t, "storage", changes[1].Name)
+}
+
+func TestPulumiDeploy(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareStackMocks(mockContext.CommandRunner)
+	prepareOutputMocks(mockContext.CommandRunner)
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "config set-all")
+	}).Respond(exec.NewRunResult(0, "", ""))
+
+	ranUp := false
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.HasPrefix(strings.Join(args.Args, " "), "up")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		ranUp = true
+		require.Contains(t, args.Args, "--yes")
+		require.Contains(t, args.Args, "test-env")
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	provider := createPulumiProvider(t, mockContext, nil)
+	deployResult, err := provider.Deploy(*mockContext.Context)
+	require.NoError(t, err)
+	require.True(t, ranUp)
+
+	outputs := deployResult.Deployment.Outputs
+	require.Len(t, outputs, 3)
+	require.Equal(t, provisioning.OutputParameter{
+		Type:  provisioning.ParameterTypeString,
+		Value: "rg-test-env",
+	}, outputs["RG_NAME"])
+	require.Equal(t, provisioning.ParameterTypeNumber, outputs["PORT"].Type)
+	require.Equal(t, "test-env", deployResult.Deployment.Parameters["appName"].Value)
+
+	// The values of the secrets are not reported
+	require.Equal(t, provisioning.InputParameter{Type: "securestring"}, deployResult.Deployment.Parameters["adminPassword"])
+}
+
+func TestPulumiDestroy(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareStackMocks(mockContext.CommandRunner)
+	prepareOutputMocks(mockContext.CommandRunner)
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "destroy")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		require.Contains(t, args.Args, "--yes")
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	provider := createPulumiProvider(t, mockContext, nil)
+	destroyResult, err := provider.Destroy(*mockContext.Context, provisioning.NewDestroyOptions(true, false))
+	require.NoError(t, err)
+
+	slices.Sort(destroyResult.InvalidatedEnvKeys)
+	require.Equal(t, []string{"AZURE_LOCATION", "PORT", "RG_NAME"}, destroyResult.InvalidatedEnvKeys)
+}
+
+func TestPulumiState(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareStackMocks(mockContext.CommandRunner)
+	prepareOutputMocks(mockContext.CommandRunner)
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "stack export")
+	}).Respond(exec.NewRunResult(0, pulumiExportMockOutput, ""))
+
+	provider := createPulumiProvider(t, mockContext, nil)
+	stateResult, err := provider.State(*mockContext.Context, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, "westus2", stateResult.State.Outputs["AZURE_LOCATION"].Value)
+	require.Equal(t, []provisioning.Resource{
+		{Id: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-test-env"},
+	}, stateResult.State.Resources)
+}
+
+func TestPulumiBackend(t *testing.T) {
+	t.Run("LocalFileBackend", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		provider := createPulumiProvider(t, mockContext, nil)
+
+		envVars := cliEnv(t, mockContext, provider)
+		expectedPath := filepath.ToSlash(filepath.Join(provider.projectPath, ".azure", "test-env", "infra", ".pulumi"))
+		require.Equal(t, "file://"+expectedPath, envVars[backendUrlEnvVarName])
+		require.DirExists(t, filepath.FromSlash(expectedPath))
+
+		// A passphrase is generated and stored within the environment
+		passphrase := envVars[passphraseEnvVarName]
+		require.Len(t, passphrase, 32)
+		require.Equal(t, passphrase, provider.env.Getenv(passphraseEnvVarName))
+	})
+
+	t.Run("AzureBlobStorageBackend", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		provider := createPulumiProvider(t, mockContext, &state.RemoteConfig{
+			Backend: string(environment.RemoteKindAzureBlobStorage),
+		})
+		provider.storageAccountConfig = &storage.AccountConfig{
+			AccountName:   "azdstate",
+			ContainerName: "myproject",
+		}
+		provider.env.DotenvSet(passphraseEnvVarName, "my-passphrase")
+
+		envVars := cliEnv(t, mockContext, provider)
+		require.Equal(t, "azblob://myproject", envVars[backendUrlEnvVarName])
+		require.Equal(t, "azdstate", envVars["AZURE_STORAGE_ACCOUNT"])
+		require.Equal(t, "my-passphrase", envVars[passphraseEnvVarName])
+	})
+
+	t.Run("SharedBackendRequiresPassphrase", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		provider := createPulumiProvider(t, mockContext, &state.RemoteConfig{
+			Backend: string(environment.RemoteKindAzureBlobStorage),
+		})
+		provider.storageAccountConfig = &storage.AccountConfig{
+			AccountName:   "azdstate",
+			ContainerName: "myproject",
+		}
+
+		// A passphrase generated locally could not be used by the other users of the backend
+		err := provider.configureCli(*mockContext.Context)
+		require.ErrorContains(t, err, passphraseEnvVarName)
+		_, hasPassphrase := provider.env.LookupEnv(passphraseEnvVarName)
+		require.False(t, hasPassphrase)
+	})
+
+	t.Run("ExplicitBackendUrl", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		provider := createPulumiProvider(t, mockContext, nil)
+		provider.env.DotenvSet(backendUrlEnvVarName, "https://api.pulumi.com")
+
+		envVars := cliEnv(t, mockContext, provider)
+		require.Equal(t, "https://api.pulumi.com", envVars[backendUrlEnvVarName])
+		_, hasPassphrase := envVars[passphraseEnvVarName]
+		require.False(t, hasPassphrase)
+	})
+}
+
+func Test_convertOutputs_Types(t *testing.T) {
+	var outputMap map[string]any
+	require.NoError(t, json.Unmarshal(
+		[]byte(`{"str": "a", "num": 1, "bool": true, "arr": [1], "obj": {"a": 1}}`), &outputMap),
+	)
+
+	outputs := convertOutputs(outputMap)
+	require.Equal(t, provisioning.ParameterTypeString, outputs["str"].Type)
+	require.Equal(t, provisioning.ParameterTypeNumber, outputs["num"].Type)
+	require.Equal(t, provisioning.ParameterTypeBoolean, outputs["bool"].Type)
+	require.Equal(t, provisioning.ParameterTypeArray, outputs["arr"].Type)
+	require.Equal(t, provisioning.ParameterTypeObject, outputs["obj"].Type)
+}
+
+// cliEnv configures the pulumi CLI of the provider and returns the environment variables set on pulumi commands
+func cliEnv(t *testing.T, mockContext *mocks.MockContext, provider *PulumiProvider) map[string]string {
+	require.NoError(t, provider.configureCli(*mockContext.Context))
+
+	envVars := map[string]string{}
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi"
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		for _, envVar := range args.Env {
+			key, value, _ := strings.Cut(envVar, "=")
+			envVars[key] = value
+		}
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	require.NoError(t, provider.cli.SelectStack(*mockContext.Context, provider.modulePath(), "test-env", false))
+	return envVars
+}
+
+func createPulumiProvider(
+	t *testing.T,
+	mockContext *mocks.MockContext,
+	remoteStateConfig *state.RemoteConfig,
+) *PulumiProvider {
+	// Ensure the tests are not affected by the pulumi configuration of the current process
+	for _, envVar := range []string{backendUrlEnvVarName, passphraseEnvVarName, passphraseFileEnvVarName} {
+		t.Setenv(envVar, "")
+		os.Unsetenv(envVar)
+	}
+
+	projectDir := t.TempDir()
+	require.NoError(t, os.CopyFS(filepath.Join(projectDir, "infra"), os.DirFS(filepath.Join("testdata", "infra"))))
+
+	env := environment.NewWithValues("test-env", map[string]string{
+		"ADMIN_PASSWORD":        "admin-p@ssw0rd",
+		"AZURE_ENV_NAME":        "test-env",
+		"AZURE_LOCATION":        "westus2",
+		"AZURE_SUBSCRIPTION_ID": "00000000-0000-0000-0000-000000000000",
+	})
+
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	provider := NewPulumiProvider(
+		pulumiTools.NewCli(mockContext.CommandRunner),
+		envManager,
+		env,
+		mockContext.Console,
+		&mockCurrentPrincipal{},
+		nil,
+		remoteStateConfig,
+		nil,
+	).(*PulumiProvider)
+
+	provider.projectPath = projectDir
+	provider.options = provisioning.Options{
+		Module: "main",
+		Path:   "infra",
+	}
+
+	return provider
+}
+
+func prepareStackMocks(commandRunner *mockexec.MockCommandRunner) {
+	commandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "stack select")
+	}).Respond(exec.NewRunResult(0, "", ""))
+
+	commandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "config set --secret")
+	}).Respond(exec.NewRunResult(0, "", ""))
+}
+
+func prepareOutputMocks(commandRunner *mockexec.MockCommandRunner) {
+	commandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi" && strings.Contains(command, "stack output")
+	}).Respond(exec.NewRunResult(0, pulumiOutputMock, ""))
+}
+
+type mockCurrentPrincipal struct{}
+
+func (m *mockCurrentPrincipal) CurrentPrincipalId(_ context.Context) (string, error) {
+	return "11111111-1111-1111-1111-111111111111", nil
+}
@@ -0,0 +1,296 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package terraform
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+)
+
+const (
+	// unknownValue is displayed in place of the values that are only known once the plan is applied
+	unknownValue = "(known after apply)"
+	// sensitiveValue is displayed in place of the values marked as sensitive
+	sensitiveValue = "(sensitive value)"
+)
+
+// terraformPlan is a model type for the JSON output of `terraform show -json <planfile>`.
+// see https://developer.hashicorp.com/terraform/internals/json-format#plan-representation for more information on the
+// shape of the JSON data.
+type terraformPlan struct {
+	FormatVersion   string                    `json:"format_version"`
+	ResourceChanges []terraformResourceChange `json:"resource_changes"`
+}
+
+// terraformResourceChange is a model type for a planned change to a single resource instance.
+type terraformResourceChange struct {
+	Address      string          `json:"address"`
+	Mode         string          `json:"mode"`
+	Type         string          `json:"type"`
+	Name         string          `json:"name"`
+	ProviderName string          `json:"provider_name"`
+	Change       terraformChange `json:"change"`
+}
+
+// terraformChange is a model type for the "change" object of a resource change. Before and After are the values of the
+// resource attributes. AfterUnknown, BeforeSensitive and AfterSensitive mirror the structure of the values, with `true`
+// for the attributes that are unknown until applied or sensitive. They are `false` when no attribute is concerned.
+type terraformChange struct {
+	// The actions to apply to the resource, ex) ["create"], ["update"] or ["delete", "create"] for a replacement
+	Actions         []string       `json:"actions"`
+	Before          map[string]any `json:"before"`
+	After           map[string]any `json:"after"`
+	AfterUnknown    any            `json:"after_unknown"`
+	BeforeSensitive any            `json:"before_sensitive"`
+	AfterSensitive  any            `json:"after_sensitive"`
+}
+
+// convertResourceChanges converts the resource changes of a terraform plan into the changes of a deployment preview
+func convertResourceChanges(resourceChanges []terraformResourceChange) []*provisioning.DeploymentPreviewChange {
+	changes := []*provisioning.DeploymentPreviewChange{}
+
+	for _, resourceChange := range resourceChanges {
+		if resourceChange.Mode != terraformModeManaged {
+			// Data sources are only read
+			continue
+		}
+
+		changeType, ok := planChangeType(resourceChange.Change.Actions)
+		if !ok {
+			continue
+		}
+
+		change := &provisioning.DeploymentPreviewChange{
+			ChangeType:   changeType,
+			ResourceType: armResourceType(resourceChange),
+			Name:         resourceName(resourceChange),
+		}
+
+		if before := resourceChange.Change.Before; before != nil {
+			if id, isString := before["id"].(string); isString {
+				change.ResourceId = provisioning.Resource{Id: id}
+			}
+
+			change.Before = maskedValues(before, resourceChange.Change.BeforeSensitive, nil)
+		}
+
+		if after := resourceChange.Change.After; after != nil {
+			change.After = maskedValues(after, resourceChange.Change.AfterSensitive, resourceChange.Change.AfterUnknown)
+		}
+
+		if changeType == provisioning.ChangeTypeModify || changeType == provisioning.ChangeTypeReplace {
+			change.Delta = propertyChanges(resourceChange.Change)
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// planChangeType maps the actions of a terraform resource change to a change type. Returns false for the actions that
+// do not change the resource, like reading a data source.
+func planChangeType(actions []string) (provisioning.ChangeType, bool) {
+	switch strings.Join(actions, ",") {
+	case "create":
+		return provisioning.ChangeTypeCreate, true
+	case "update":
+		return provisioning.ChangeTypeModify, true
+	case "delete":
+		return provisioning.ChangeTypeDelete, true
+	case "no-op":
+		return provisioning.ChangeTypeNoChange, true
+	// A replacement either deletes the resource first, or creates the new resource before deleting the existing one
+	// when `create_before_destroy` is set.
+	case "delete,create", "create,delete":
+		return provisioning.ChangeTypeReplace, true
+	default:
+		return "", false
+	}
+}
+
+// propertyChanges compares the top level attributes of the resource before and after the change
+func propertyChanges(change terraformChange) []provisioning.DeploymentPreviewPropertyChange {
+	before := maskedValues(change.Before, change.BeforeSensitive, nil)
+	after := maskedValues(change.After, change.AfterSensitive, change.AfterUnknown)
+
+	paths := slices.Collect(maps.Keys(before))
+	for path := range after {
+		if _, has := before[path]; !has {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+
+	delta := []provisioning.DeploymentPreviewPropertyChange{}
+	for _, path := range paths {
+		beforeValue, inBefore := before[path]
+		afterValue, inAfter := after[path]
+		inBefore = inBefore && beforeValue != nil
+		inAfter = inAfter && afterValue != nil
+
+		var changeType provisioning.PropertyChangeType
+		switch {
+		case !inBefore && !inAfter:
+			continue
+		case !inBefore:
+			changeType = provisioning.PropertyChangeTypeCreate
+		case !inAfter:
+			changeType = provisioning.PropertyChangeTypeDelete
+		case reflect.DeepEqual(beforeValue, afterValue) && afterValue != unknownValue:
+			continue
+		default:
+			changeType = provisioning.PropertyChangeTypeModify
+		}
+
+		delta = append(delta, provisioning.DeploymentPreviewPropertyChange{
+			ChangeType: changeType,
+			Path:       path,
+			Before:     beforeValue,
+			After:      afterValue,
+		})
+	}
+
+	return delta
+}
+
+// maskedValues returns a copy of the top level attributes where the sensitive values are masked and the values unknown
+// until the plan is applied are replaced with a placeholder.
+func maskedValues(values map[string]any, sensitive any, unknown any) map[string]any {
+	sensitiveAttributes, _ := sensitive.(map[string]any)
+	unknownAttributes, _ := unknown.(map[string]any)
+
+	masked := make(map[string]any, len(values))
+	for key, value := range values {
+		masked[key] = value
+	}
+
+	for key, value := range unknownAttributes {
+		if value == true {
+			masked[key] = unknownValue
+		}
+	}
+
+	for key, value := range sensitiveAttributes {
+		if isMarked(value) {
+			masked[key] = sensitiveValue
+		}
+	}
+
+	return masked
+}
+
+// isMarked returns true when the value of a sensitivity marker flags the attribute, or any of its nested attributes
+func isMarked(marker any) bool {
+	switch marker := marker.(type) {
+	case bool:
+		return marker
+	case map[string]any:
+		for _, value := range marker {
+			if isMarked(value) {
+				return true
+			}
+		}
+	case []any:
+		for _, value := range marker {
+			if isMarked(value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// resourceName gets the name of the Azure resource when known, otherwise the terraform address of the resource
+func resourceName(resourceChange terraformResourceChange) string {
+	for _, values := range []map[string]any{resourceChange.Change.After, resourceChange.Change.Before} {
+		if name, isString := values["name"].(string); isString && name != "" {
+			return name
+		}
+	}
+
+	return resourceChange.Address
+}
+
+// armResourceType gets the Azure resource type of the resource. The type is resolved from the Azure resource id when
+// the resource already exists, from the type of azapi resources, otherwise from the well-known azurerm resource types.
+func armResourceType(resourceChange terraformResourceChange) string {
+	if id, isString := resourceChange.Change.Before["id"].(string); isString {
+		if resourceId, err := arm.ParseResourceID(id); err == nil {
+			return resourceId.ResourceType.String()
+		}
+	}
+
+	if strings.HasPrefix(resourceChange.Type, "azapi_") {
+		for _, values := range []map[string]any{resourceChange.Change.After, resourceChange.Change.Before} {
+			// The type of azapi resources includes the api version, ex) Microsoft.App/containerApps@2023-05-01
+			if azapiType, isString := values["type"].(string); isString && azapiType != "" {
+				resourceType, _, _ := strings.Cut(azapiType, "@")
+				return resourceType
+			}
+		}
+	}
+
+	if resourceType, has := azurermResourceTypes[resourceChange.Type]; has {
+		return resourceType
+	}
+
+	return resourceChange.Type
+}
+
+// azurermResourceTypes maps the azurerm terraform resource types to the Azure resource type
+var azurermResourceTypes = map[string]string{
+	"azurerm_resource_group":                             "Microsoft.Resources/resourceGroups",
+	"azurerm_linux_web_app":                              "Microsoft.Web/sites",
+	"azurerm_windows_web_app":                            "Microsoft.Web/sites",
+	"azurerm_linux_function_app":                         "Microsoft.Web/sites",
+	"azurerm_windows_function_app":                       "Microsoft.Web/sites",
+	"azurerm_app_service":                                "Microsoft.Web/sites",
+	"azurerm_function_app":                               "Microsoft.Web/sites",
+	"azurerm_service_plan":                               "Microsoft.Web/serverfarms",
+	"azurerm_app_service_plan":                           "Microsoft.Web/serverfarms",
+	"azurerm_static_site":                                "Microsoft.Web/staticSites",
+	"azurerm_static_web_app":                             "Microsoft.Web/staticSites",
+	"azurerm_container_app":                              "Microsoft.App/containerApps",
+	"azurerm_container_app_environment":                  "Microsoft.App/managedEnvironments",
+	"azurerm_container_registry":                         "Microsoft.ContainerRegistry/registries",
+	"azurerm_kubernetes_cluster":                         "Microsoft.ContainerService/managedClusters",
+	"azurerm_kubernetes_cluster_node_pool":               "Microsoft.ContainerService/managedClusters/agentPools",
+	"azurerm_storage_account":                            "Microsoft.Storage/storageAccounts",
+	"azurerm_key_vault":                                  "Microsoft.KeyVault/vaults",
+	"azurerm_key_vault_managed_hardware_security_module": "Microsoft.KeyVault/managedHSMs",
+	"azurerm_log_analytics_workspace":                    "Microsoft.OperationalInsights/workspaces",
+	"azurerm_application_insights":                       "Microsoft.Insights/components",
+	"azurerm_portal_dashboard":                           "Microsoft.Portal/dashboards",
+	"azurerm_cosmosdb_account":                           "Microsoft.DocumentDB/databaseAccounts",
+	"azurerm_redis_cache":                                "Microsoft.Cache/redis",
+	"azurerm_postgresql_flexible_server":                 "Microsoft.DBforPostgreSQL/flexibleServers",
+	"azurerm_mysql_flexible_server":                      "Microsoft.DBforMySQL/flexibleServers",
+	"azurerm_mssql_server":                               "Microsoft.Sql/servers",
+	"azurerm_servicebus_namespace":                       "Microsoft.ServiceBus/namespaces",
+	"azurerm_cognitive_account":                          "Microsoft.CognitiveServices/accounts",
+	"azurerm_cognitive_deployment":                       "Microsoft.CognitiveServices/accounts/deployments",
+	"azurerm_search_service":                             "Microsoft.Search/searchServices",
+	"azurerm_virtual_network":                            "Microsoft.Network/virtualNetworks",
+	"azurerm_private_endpoint":                           "Microsoft.Network/privateEndpoints",
+	"azurerm_app_configuration":                          "Microsoft.AppConfiguration/configurationStores",
+	"azurerm_api_management":                             "Microsoft.ApiManagement/service",
+	"azurerm_spring_cloud_service":                       "Microsoft.AppPlatform/Spring",
+	"azurerm_cdn_profile":                                "Microsoft.Cdn/profiles",
+	"azurerm_cdn_frontdoor_profile":                      "Microsoft.Cdn/profiles",
+	"azurerm_load_test":                                  "Microsoft.LoadTestService/loadTests",
+	"azurerm_dev_center":                                 "Microsoft.DevCenter/devcenters",
+	"azurerm_dev_center_project":                         "Microsoft.DevCenter/projects",
+	"azurerm_machine_learning_workspace":                 "Microsoft.MachineLearningServices/workspaces",
+	"azurerm_user_assigned_identity":                     "Microsoft.ManagedIdentity/userAssignedIdentities",
+	"azurerm_role_assignment":                            "Microsoft.Authorization/roleAssignments",
+	"azurerm_key_vault_secret":                           "Microsoft.KeyVault/vaults/secrets",
+	"azurerm_storage_container":                          "Microsoft.Storage/storageAccounts/blobServices/containers",
+}
@@ -215,18 +215,25 @@ func (t *TerraformProvider) Deploy(ctx context.Context) (*provisioning.DeployRes
 	}, nil
 }
 
+// Previews the infrastructure changes by creating a plan and converting the changes of the plan file, as reported by
+// `terraform show -json`, into a deployment preview
 func (t *TerraformProvider) Preview(ctx context.Context) (*provisioning.DeployPreviewResult, error) {
-	// terraform uses plan() to display the what-if output
-	// no changes are added to the properties
-	_, _, err := t.plan(ctx)
+	_, deploymentDetails, err := t.plan(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	plan, err := t.showPlan(ctx, t.modulePath(), deploymentDetails.PlanFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading terraform plan: %w", err)
+	}
+
 	return &provisioning.DeployPreviewResult{
 		Preview: &provisioning.DeploymentPreview{
-			Status:     "done",
-			Properties: &provisioning.DeploymentPreviewProperties{},
+			Status: "done",
+			Properties: &provisioning.DeploymentPreviewProperties{
+				Changes: convertResourceChanges(plan.ResourceChanges),
+			},
 		},
 	}, nil
 }
@@ -476,6 +483,21 @@ func (t *TerraformProvider) showCurrentState(
 	return &showOutput, nil
 }
 
+// showPlan reads the changes of the specified plan file
+func (t *TerraformProvider) showPlan(ctx context.Context, modulePath string, planFilePath string) (*terraformPlan, error) {
+	runResult, err := t.cli.Show(ctx, modulePath, planFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("showing plan failed: %s, err:%w", runResult, err)
+	}
+
+	var plan terraformPlan
+	if err := json.Unmarshal([]byte(runResult), &plan); err != nil {
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
 // Creates the deployment object from the specified module path
 func (t *TerraformProvider) createDeployment(ctx context.Context) (*provisioning.Deployment, error) {
 	templateParameters := make(map[string]provisioning.InputParameter)
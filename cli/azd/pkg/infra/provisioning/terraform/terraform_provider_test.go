@@ -59,6 +59,65 @@ func TestTerraformPlan(t *testing.T) {
 	require.NotEmpty(t, deploymentPlan.localStateFilePath)
 }
 
+func TestTerraformPreview(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareGenericMocks(mockContext.CommandRunner)
+	preparePlanningMocks(mockContext.CommandRunner)
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "terraform" && strings.Contains(command, "show -json") && strings.HasSuffix(command, ".tfplan")
+	}).Respond(exec.NewRunResult(0, terraformPlanMockOutput, ""))
+
+	infraProvider := createTerraformProvider(t, mockContext)
+	previewResult, err := infraProvider.Preview(*mockContext.Context)
+	require.NoError(t, err)
+
+	changes := previewResult.Preview.Properties.Changes
+	require.Len(t, changes, 4)
+
+	require.Equal(t, provisioning.ChangeTypeModify, changes[0].ChangeType)
+	require.Equal(t, "Microsoft.Resources/resourceGroups", changes[0].ResourceType)
+	require.Equal(t, "rg-test-env", changes[0].Name)
+	require.Equal(t, []provisioning.DeploymentPreviewPropertyChange{
+		{
+			ChangeType: provisioning.PropertyChangeTypeModify,
+			Path:       "tags",
+			Before:     map[string]any{"azd-env-name": "test-env"},
+			After:      map[string]any{"azd-env-name": "test-env", "owner": "team"},
+		},
+	}, changes[0].Delta)
+
+	require.Equal(t, provisioning.ChangeTypeCreate, changes[1].ChangeType)
+	require.Equal(t, "Microsoft.Storage/storageAccounts", changes[1].ResourceType)
+	require.Equal(t, "sttestenv", changes[1].Name)
+	require.Empty(t, changes[1].Delta)
+	after := changes[1].After.(map[string]any)
+	require.Equal(t, unknownValue, after["id"])
+	require.Equal(t, sensitiveValue, after["primary_access_key"])
+
+	require.Equal(t, provisioning.ChangeTypeReplace, changes[2].ChangeType)
+	require.Equal(t, "Microsoft.ContainerRegistry/registries", changes[2].ResourceType)
+	require.Equal(t, []provisioning.DeploymentPreviewPropertyChange{
+		{
+			ChangeType: provisioning.PropertyChangeTypeModify,
+			Path:       "id",
+			Before:     changes[2].ResourceId.Id,
+			After:      unknownValue,
+		},
+		{
+			ChangeType: provisioning.PropertyChangeTypeModify,
+			Path:       "location",
+			Before:     "westus2",
+			After:      "eastus2",
+		},
+	}, changes[2].Delta)
+	require.Equal(t, sensitiveValue, changes[2].Before.(map[string]any)["admin_password"])
+
+	require.Equal(t, provisioning.ChangeTypeNoChange, changes[3].ChangeType)
+	require.Equal(t, "random_string", changes[3].ResourceType)
+	require.Equal(t, "random_string.suffix", changes[3].Name)
+}
+
 func TestTerraformDestroy(t *testing.T) {
 	mockContext := mocks.NewMockContext(context.Background())
 	prepareGenericMocks(mockContext.CommandRunner)
@@ -179,6 +238,9 @@ func preparePlanningMocks(commandRunner *mockexec.MockCommandRunner) {
 //go:embed testdata/terraform_show_mock.json
 var terraformShowMockOutput string
 
+//go:embed testdata/terraform_plan_mock.json
+var terraformPlanMockOutput string
+
 func prepareShowMocks(commandRunner *mockexec.MockCommandRunner) {
 	commandRunner.When(func(args exec.RunArgs, command string) bool {
 		return args.Cmd == "terraform" && strings.Contains(command, "show")
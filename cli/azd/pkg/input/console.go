@@ -28,6 +28,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/internal/tracing"
 	"github.com/azure/azure-dev/cli/azd/internal/tracing/resource"
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
 	tm "github.com/buger/goterm"
@@ -163,6 +164,11 @@ type AskerConsole struct {
 	spinnerCurrentTitle string
 
 	previewer *progressLog
+	// the writer of the previewer, when writing the jsonl event stream
+	previewerLogLines *logLineWriter
+
+	// serializes the lines of the jsonl event stream
+	eventMu sync.Mutex
 
 	currentIndent *atomic.String
 	// consoleWidth is the width of the underlying console window. The value is updated as the window resized. Nil when
@@ -213,7 +219,9 @@ func (c *AskerConsole) IsUnformatted() bool {
 // Prints out a message to the underlying console write
 func (c *AskerConsole) Message(ctx context.Context, message string) {
 	// Disable output when formatting is enabled
-	if c.formatter != nil && c.formatter.Kind() == output.JsonFormat {
+	if c.isJsonLines() {
+		c.writeLogLine(message, "")
+	} else if c.formatter != nil && c.formatter.Kind() == output.JsonFormat {
 		// we call json.Marshal directly, because the formatter marshalls using indentation, and we would prefer
 		// these objects be written on a single line.
 		jsonMessage, err := json.Marshal(output.EventForMessage(message))
@@ -266,6 +274,15 @@ func shouldWarn() bool {
 }
 
 func (c *AskerConsole) MessageUxItem(ctx context.Context, item ux.UxItem) {
+	if c.isJsonLines() {
+		if eventItem, ok := item.(ux.EventItem); ok {
+			c.writeEvent(eventItem.Event())
+		} else {
+			c.writeLogLine(item.ToString(""), "")
+		}
+		return
+	}
+
 	if c.formatter != nil && c.formatter.Kind() == output.JsonFormat {
 		// no need to check the spinner for json format, as the spinner won't start when using json format
 		// instead, there would be a message about starting spinner
@@ -301,6 +318,17 @@ func (c *AskerConsole) ShowPreviewer(ctx context.Context, options *ShowPreviewer
 	c.showProgressMu.Lock()
 	defer c.showProgressMu.Unlock()
 
+	if c.isJsonLines() {
+		// Each line written to the previewer is a log line of the event stream
+		source := ""
+		if options != nil {
+			source = options.Title
+		}
+
+		c.previewerLogLines = &logLineWriter{console: c, source: source}
+		return c.previewerLogLines
+	}
+
 	// Pause any active spinner
 	currentMsg := c.spinnerCurrentTitle
 	_ = c.spinner.Pause()
@@ -318,6 +346,12 @@ func (c *AskerConsole) ShowPreviewer(ctx context.Context, options *ShowPreviewer
 }
 
 func (c *AskerConsole) StopPreviewer(ctx context.Context, keepLogs bool) {
+	if c.previewerLogLines != nil {
+		c.previewerLogLines.flush()
+		c.previewerLogLines = nil
+		return
+	}
+
 	c.previewer.Stop(keepLogs)
 	c.previewer = nil
 	c.writer = c.defaultWriter
@@ -380,6 +414,17 @@ func (c *AskerConsole) ShowSpinner(ctx context.Context, title string, format Spi
 	c.showProgressMu.Lock()
 	defer c.showProgressMu.Unlock()
 
+	if c.isJsonLines() {
+		// A step is started for each new title of the spinner
+		c.spinnerLineMu.Lock()
+		if title != c.spinnerCurrentTitle {
+			c.spinnerCurrentTitle = title
+			c.writeEvent(output.NewEvent(contracts.StepStartedEventDataType, contracts.StepStarted{Title: title}))
+		}
+		c.spinnerLineMu.Unlock()
+		return
+	}
+
 	if c.formatter != nil && c.formatter.Kind() == output.JsonFormat {
 		// Spinner is disabled when using json format.
 		return
@@ -452,6 +497,28 @@ func (c *AskerConsole) getIndent() string {
 }
 
 func (c *AskerConsole) StopSpinner(ctx context.Context, lastMessage string, format SpinnerUxType) {
+	if c.isJsonLines() {
+		c.spinnerLineMu.Lock()
+		defer c.spinnerLineMu.Unlock()
+
+		// Do nothing when no step is running
+		if c.spinnerCurrentTitle == "" && lastMessage == "" {
+			return
+		}
+
+		title := lastMessage
+		if title == "" {
+			title = c.spinnerCurrentTitle
+		}
+
+		c.spinnerCurrentTitle = ""
+		c.writeEvent(output.NewEvent(contracts.StepFinishedEventDataType, contracts.StepFinished{
+			Title:  title,
+			Status: stepStatus(format),
+		}))
+		return
+	}
+
 	if c.formatter != nil && c.formatter.Kind() == output.JsonFormat {
 		// Spinner is disabled when using json format.
 		return
@@ -503,14 +570,16 @@ func (c *AskerConsole) getStopChar(format SpinnerUxType) string {
 	return fmt.Sprintf("%s%s", c.getIndent(), stopChar)
 }
 
-func promptFromOptions(options ConsoleOptions) survey.Prompt {
+func promptFromOptions(options ConsoleOptions, resetLine bool) survey.Prompt {
 	if options.IsPassword {
 		// different than survey.Input, survey.Password doest not reset the line before rendering the question
 		// see password implementation: https://github.com/AlecAivazis/survey/blob/master/password.go#L51
 		// and input: https://github.com/AlecAivazis/survey/blob/master/input.go#L141
 		// by calling .Render(), the line is reset, cleaning any current message or spinner.
-		tm.Print(tm.ResetLine(""))
-		tm.Flush()
+		if resetLine {
+			tm.Print(tm.ResetLine(""))
+			tm.Flush()
+		}
 		return &survey.Password{
 			Message: options.Message,
 			Help:    options.Help,
@@ -611,8 +680,15 @@ func (c *AskerConsole) Prompt(ctx context.Context, options ConsoleOptions) (stri
 		return response, nil
 	}
 
+	promptKind := contracts.PromptKindString
+	if options.IsPassword {
+		promptKind = contracts.PromptKindPassword
+	}
+	c.writePromptNeeded(promptKind, options)
+
 	err := c.doInteraction(func(c *AskerConsole) error {
-		return c.asker(promptFromOptions(options), &response)
+		// The line is not reset in the event stream, which is written to stdout
+		return c.asker(promptFromOptions(options, !c.isJsonLines()), &response)
 	})
 	if err != nil {
 		return response, err
@@ -652,6 +728,8 @@ func (c *AskerConsole) PromptDir(ctx context.Context, options ConsoleOptions) (s
 		return response, nil
 	}
 
+	c.writePromptNeeded(contracts.PromptKindDirectory, options)
+
 	err := c.doInteraction(func(c *AskerConsole) error {
 		prompt := &survey.Input{
 			Message: options.Message,
@@ -751,6 +829,8 @@ func (c *AskerConsole) Select(ctx context.Context, options ConsoleOptions) (int,
 
 	var response int
 
+	c.writePromptNeeded(contracts.PromptKindSelect, options)
+
 	err := c.doInteraction(func(c *AskerConsole) error {
 		return c.asker(survey, &response)
 	})
@@ -821,6 +901,8 @@ func (c *AskerConsole) MultiSelect(ctx context.Context, options ConsoleOptions)
 		Help:    options.Help,
 	}
 
+	c.writePromptNeeded(contracts.PromptKindMultiSelect, options)
+
 	err := c.doInteraction(func(c *AskerConsole) error {
 		return c.asker(survey, &response)
 	})
@@ -883,6 +965,8 @@ func (c *AskerConsole) Confirm(ctx context.Context, options ConsoleOptions) (boo
 
 	var response bool
 
+	c.writePromptNeeded(contracts.PromptKindConfirm, options)
+
 	err := c.doInteraction(func(c *AskerConsole) error {
 		return c.asker(survey, &response)
 	})
@@ -1024,7 +1108,15 @@ func NewConsole(
 	handles ConsoleHandles,
 	formatter output.Formatter,
 	externalPromptCfg *ExternalPromptConfiguration) Console {
-	asker := NewAsker(noPrompt, isTerminal, handles.Stdout, handles.Stdin)
+	askerWriter := handles.Stdout
+	if formatter != nil && formatter.Kind() == output.JsonLinesFormat {
+		// The event stream is machine-readable: prompts are announced by promptNeeded events rather than rendered, and
+		// their responses are read from stdin as plain lines.
+		isTerminal = false
+		askerWriter = io.Discard
+	}
+
+	asker := NewAsker(noPrompt, isTerminal, askerWriter, handles.Stdin)
 
 	c := &AskerConsole{
 		asker:         asker,
@@ -0,0 +1,110 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package input
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+)
+
+// isJsonLines returns true when the console writes the jsonl event stream instead of rendering its output.
+func (c *AskerConsole) isJsonLines() bool {
+	return c.formatter != nil && c.formatter.Kind() == output.JsonLinesFormat
+}
+
+// writeEvent writes an event of the jsonl event stream to the console writer.
+func (c *AskerConsole) writeEvent(event contracts.EventEnvelope) {
+	c.eventMu.Lock()
+	defer c.eventMu.Unlock()
+
+	if err := output.WriteEvent(c.writer, event); err != nil {
+		panic(fmt.Sprintf("writeEvent: unexpected error during marshaling for a valid object: %v", err))
+	}
+}
+
+// writeLogLine writes a logLine event for each line of the message. Blank lines are not written.
+func (c *AskerConsole) writeLogLine(message string, source string) {
+	for _, line := range strings.Split(message, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		c.writeEvent(output.EventForLogLine(strings.TrimRight(line, "\r"), source))
+	}
+}
+
+// writePromptNeeded writes a promptNeeded event before reading the response of a prompt from stdin. Nothing is written
+// when azd doesn't read the response, because prompting is disabled or delegated to an external prompt service.
+func (c *AskerConsole) writePromptNeeded(kind contracts.PromptKind, options ConsoleOptions) {
+	if !c.isJsonLines() || c.noPrompt || c.promptClient != nil {
+		return
+	}
+
+	c.writeEvent(output.NewEvent(contracts.PromptNeededEventDataType, contracts.PromptNeeded{
+		Kind:         kind,
+		Message:      options.Message,
+		Help:         options.Help,
+		Options:      options.Options,
+		DefaultValue: options.DefaultValue,
+	}))
+}
+
+// stepStatus is the status of the stepFinished event for the format of the last message of a spinner.
+func stepStatus(format SpinnerUxType) contracts.StepStatus {
+	switch format {
+	case StepDone:
+		return contracts.StepStatusDone
+	case StepFailed:
+		return contracts.StepStatusFailed
+	case StepWarning:
+		return contracts.StepStatusWarning
+	case StepSkipped:
+		return contracts.StepStatusSkipped
+	default:
+		return contracts.StepStatusStopped
+	}
+}
+
+// logLineWriter is the io.Writer of the console previewer for the jsonl event stream. Each line written is written as
+// a logLine event of the source.
+type logLineWriter struct {
+	console *AskerConsole
+	source  string
+
+	mu      sync.Mutex
+	pending bytes.Buffer
+}
+
+func (w *logLineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.pending.Write(p)
+	for {
+		line, err := w.pending.ReadString('\n')
+		if err != nil {
+			// Keep the incomplete line until the rest of it is written
+			w.pending.WriteString(line)
+			break
+		}
+
+		w.console.writeLogLine(line, w.source)
+	}
+
+	return len(p), nil
+}
+
+// flush writes the last line, when it is not terminated by a new line.
+func (w *logLineWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.console.writeLogLine(w.pending.String(), w.source)
+	w.pending.Reset()
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
 	"github.com/stretchr/testify/require"
 )
 
@@ -96,6 +97,77 @@ func TestAskerConsole_Spinner_NonTty(t *testing.T) {
 	require.Len(t, lines.captured, 5)
 }
 
+// Verifies the console writes the jsonl event stream, one event per line.
+func TestAskerConsole_JsonLines(t *testing.T) {
+	formatter, err := output.NewFormatter(string(output.JsonLinesFormat))
+	require.NoError(t, err)
+
+	lines := &lineCapturer{}
+	c := NewConsole(
+		false,
+		false,
+		Writers{Output: lines},
+		ConsoleHandles{
+			Stderr: os.Stderr,
+			Stdin:  strings.NewReader("web\n"),
+			Stdout: lines,
+		},
+		formatter,
+		nil,
+	)
+
+	ctx := context.Background()
+	c.ShowSpinner(ctx, "Deploying service web", Step)
+	// Progress of the same title doesn't start a new step
+	c.ShowSpinner(ctx, "Deploying service web", Step)
+	c.Message(ctx, output.WithHighLightFormat("Some message."))
+	c.MessageUxItem(ctx, &ux.DisplayedResource{Type: "Container App", Name: "web", State: ux.SucceededState})
+	previewer := c.ShowPreviewer(ctx, &ShowPreviewerOptions{Title: "predeploy Hook Output"})
+	_, _ = previewer.Write([]byte("first\nsec"))
+	_, _ = previewer.Write([]byte("ond\nlast"))
+	c.StopPreviewer(ctx, false)
+	c.StopSpinner(ctx, "Deploying service web", StepDone)
+	// No step is running
+	c.StopSpinner(ctx, "", Step)
+
+	response, err := c.Select(ctx, ConsoleOptions{Message: "Pick a service:", Options: []string{"api", "web"}})
+	require.NoError(t, err)
+	require.Equal(t, 1, response)
+
+	events := make([]map[string]any, len(lines.captured))
+	for i, line := range lines.captured {
+		require.NoError(t, json.Unmarshal([]byte(line), &events[i]))
+	}
+
+	types := make([]string, len(events))
+	for i, event := range events {
+		types[i] = event["type"].(string)
+	}
+
+	require.Equal(t, []string{
+		"stepStarted",
+		"logLine",
+		"resourceCreated",
+		"logLine",
+		"logLine",
+		"logLine",
+		"stepFinished",
+		"promptNeeded",
+	}, types)
+
+	require.Equal(t, map[string]any{"title": "Deploying service web"}, events[0]["data"])
+	require.Equal(t, map[string]any{"message": "Some message."}, events[1]["data"])
+	require.Equal(t, map[string]any{"type": "Container App", "name": "web", "state": "Succeeded"}, events[2]["data"])
+	require.Equal(t, map[string]any{"message": "second", "source": "predeploy Hook Output"}, events[4]["data"])
+	require.Equal(t, map[string]any{"message": "last", "source": "predeploy Hook Output"}, events[5]["data"])
+	require.Equal(t, map[string]any{"title": "Deploying service web", "status": "done"}, events[6]["data"])
+	require.Equal(t, map[string]any{
+		"kind":    "select",
+		"message": "Pick a service:",
+		"options": []any{"api", "web"},
+	}, events[7]["data"])
+}
+
 func TestAskerConsoleExternalPrompt(t *testing.T) {
 	t.Skip("Need to be updated to use the new external prompt mechanism.")
 
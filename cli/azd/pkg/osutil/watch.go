@@ -0,0 +1,91 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package osutil
+
+import (
+	"context"
+	"io/fs"
+	"log"
+	"maps"
+	"path/filepath"
+	"time"
+)
+
+// WatchDir polls the files of the directory every interval, and sends to the returned channel when files were created,
+// changed or removed since the previous poll, until the context is cancelled. The directories for which skipDir returns
+// true, like the directories of dependencies or of build outputs, aren't watched.
+//
+// Changes are coalesced: when the receiver hasn't received the previous change yet, the next one isn't sent.
+func WatchDir(
+	ctx context.Context,
+	dir string,
+	interval time.Duration,
+	skipDir func(path string, d fs.DirEntry) bool,
+) <-chan struct{} {
+	changes := make(chan struct{}, 1)
+	previous := dirSnapshot(dir, skipDir)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			current := dirSnapshot(dir, skipDir)
+			if maps.Equal(previous, current) {
+				continue
+			}
+
+			previous = current
+			select {
+			case changes <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	return changes
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// dirSnapshot returns the modification time and the size of each file of the directory.
+func dirSnapshot(dir string, skipDir func(path string, d fs.DirEntry) bool) map[string]fileState {
+	snapshot := map[string]fileState{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Files may be removed while walking the directory
+			return nil
+		}
+
+		if d.IsDir() {
+			if path != dir && skipDir != nil && skipDir(path, d) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		snapshot[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	})
+	if err != nil {
+		log.Printf("watching directory %s: %v", dir, err)
+	}
+
+	return snapshot
+}
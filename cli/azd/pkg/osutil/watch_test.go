@@ -0,0 +1,61 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package osutil_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "node_modules"), osutil.PermissionDirectory))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.js"), []byte("1"), osutil.PermissionFile))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := osutil.WatchDir(ctx, dir, 10*time.Millisecond, func(path string, d fs.DirEntry) bool {
+		return d.Name() == "node_modules"
+	})
+
+	noChange := func() {
+		select {
+		case <-changes:
+			require.Fail(t, "unexpected change")
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	change := func() {
+		select {
+		case <-changes:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "change not detected")
+		}
+	}
+
+	noChange()
+
+	// The files of the skipped directories aren't watched
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "node_modules", "lib.js"), []byte("1"), osutil.PermissionFile))
+	noChange()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.js"), []byte("22"), osutil.PermissionFile))
+	change()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new.js"), []byte("1"), osutil.PermissionFile))
+	change()
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "new.js")))
+	change()
+	noChange()
+}
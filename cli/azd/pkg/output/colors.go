@@ -51,3 +51,21 @@ func WithBackticks(text string) string {
 func WithHyperlink(url string, text string) string {
 	return WithLinkFormat(fmt.Sprintf("\033]8;;%s\007%s\033]8;;\007", url, text))
 }
+
+// sourceColors are the colors telling apart the lines of several sources printed together
+var sourceColors = []color.Attribute{
+	color.FgCyan,
+	color.FgMagenta,
+	color.FgGreen,
+	color.FgBlue,
+	color.FgYellow,
+	color.FgHiCyan,
+	color.FgHiMagenta,
+	color.FgHiGreen,
+}
+
+// WithSourceColor formats text with the color of the i-th source of lines printed together, like the services of
+// 'azd run'. The colors are reused when there are more sources than colors.
+func WithSourceColor(i int, text string) string {
+	return color.New(sourceColors[i%len(sourceColors)]).Sprint(text)
+}
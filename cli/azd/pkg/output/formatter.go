@@ -11,10 +11,11 @@ import (
 type Format string
 
 const (
-	EnvVarsFormat Format = "dotenv"
-	JsonFormat    Format = "json"
-	TableFormat   Format = "table"
-	NoneFormat    Format = "none"
+	EnvVarsFormat   Format = "dotenv"
+	JsonFormat      Format = "json"
+	JsonLinesFormat Format = "jsonl"
+	TableFormat     Format = "table"
+	NoneFormat      Format = "none"
 )
 
 type Formatter interface {
@@ -22,10 +23,18 @@ type Formatter interface {
 	Format(obj interface{}, writer io.Writer, opts interface{}) error
 }
 
+// IsJsonFormat returns true when the format renders the output of commands as JSON, which is the case of the json format
+// and of the result events of the jsonl event stream.
+func IsJsonFormat(format Format) bool {
+	return format == JsonFormat || format == JsonLinesFormat
+}
+
 func NewFormatter(format string) (Formatter, error) {
 	switch format {
 	case string(JsonFormat):
 		return &JsonFormatter{}, nil
+	case string(JsonLinesFormat):
+		return &JsonLinesFormatter{}, nil
 	case string(EnvVarsFormat):
 		return &EnvVarsFormatter{}, nil
 	case string(TableFormat):
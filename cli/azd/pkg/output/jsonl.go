@@ -0,0 +1,55 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+)
+
+// JsonLinesFormatter renders the output of a command as a stream of events, one JSON object per line. The formatted
+// object is written as the data of a result event, while the console writes the other events of the stream.
+type JsonLinesFormatter struct {
+}
+
+func (f *JsonLinesFormatter) Kind() Format {
+	return JsonLinesFormat
+}
+
+func (f *JsonLinesFormatter) Format(obj interface{}, writer io.Writer, _ interface{}) error {
+	return WriteEvent(writer, NewEvent(contracts.ResultEventDataType, obj))
+}
+
+var _ Formatter = (*JsonLinesFormatter)(nil)
+
+// NewEvent creates an event of the jsonl event stream, timestamped with the current time.
+func NewEvent(eventType contracts.EventDataType, data any) contracts.EventEnvelope {
+	return contracts.EventEnvelope{
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Data:      data,
+	}
+}
+
+// EventForLogLine creates a logLine event for a message. Any ANSI control sequences from the message are removed.
+func EventForLogLine(message string, source string) contracts.EventEnvelope {
+	return NewEvent(contracts.LogLineEventDataType, contracts.LogLine{
+		Message: withoutAnsiSequences(message),
+		Source:  source,
+	})
+}
+
+// WriteEvent writes the event as a single line of JSON.
+func WriteEvent(writer io.Writer, event contracts.EventEnvelope) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(append(b, '\n'))
+	return err
+}
@@ -0,0 +1,49 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJsonLinesFormatter(t *testing.T) {
+	formatter := &JsonLinesFormatter{}
+	require.Equal(t, JsonLinesFormat, formatter.Kind())
+
+	buffer := &bytes.Buffer{}
+	err := formatter.Format(jsonInput{Size: "mega", IsCool: true}, buffer, nil)
+	require.NoError(t, err)
+
+	// The result is a single line
+	require.True(t, strings.HasSuffix(buffer.String(), "}\n"))
+	require.Equal(t, 1, strings.Count(buffer.String(), "\n"))
+
+	var event struct {
+		Type contracts.EventDataType `json:"type"`
+		Data jsonInput               `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &event))
+	require.Equal(t, contracts.ResultEventDataType, event.Type)
+	require.Equal(t, jsonInput{Size: "mega", IsCool: true}, event.Data)
+}
+
+func TestEventForLogLine(t *testing.T) {
+	event := EventForLogLine(WithSuccessFormat("SUCCESS: done"), "prepackage")
+
+	require.Equal(t, contracts.LogLineEventDataType, event.Type)
+	require.Equal(t, contracts.LogLine{Message: "SUCCESS: done", Source: "prepackage"}, event.Data)
+}
+
+func TestIsJsonFormat(t *testing.T) {
+	require.True(t, IsJsonFormat(JsonFormat))
+	require.True(t, IsJsonFormat(JsonLinesFormat))
+	require.False(t, IsJsonFormat(TableFormat))
+	require.False(t, IsJsonFormat(NoneFormat))
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package output
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// PrefixWriter is an io.Writer that prefixes each line with a fixed prefix. Lines are buffered until complete and written
+// to the underlying writer in a single call, so the output of several PrefixWriter sharing a writer is not interleaved
+// within a line.
+type PrefixWriter struct {
+	next   io.Writer
+	prefix []byte
+
+	buf bytes.Buffer
+	// mu protects access to buf
+	mu sync.Mutex
+}
+
+// NewPrefixWriter creates a PrefixWriter writing the lines to next, prefixed by prefix.
+func NewPrefixWriter(next io.Writer, prefix string) *PrefixWriter {
+	return &PrefixWriter{
+		next:   next,
+		prefix: []byte(prefix),
+	}
+}
+
+// Write implements io.Writer.
+func (pw *PrefixWriter) Write(p []byte) (int, error) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	written := 0
+	for len(p) > 0 {
+		if pw.buf.Len() == 0 {
+			pw.buf.Write(pw.prefix)
+		}
+
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			pw.buf.Write(p)
+			return written + len(p), nil
+		}
+
+		pw.buf.Write(p[:i+1])
+		if _, err := pw.next.Write(pw.buf.Bytes()); err != nil {
+			return written, err
+		}
+
+		pw.buf.Reset()
+		written += i + 1
+		p = p[i+1:]
+	}
+
+	return written, nil
+}
+
+// Flush writes the last line, when it does not end with a new line.
+func (pw *PrefixWriter) Flush() error {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	if pw.buf.Len() == 0 {
+		return nil
+	}
+
+	pw.buf.WriteByte('\n')
+	_, err := pw.next.Write(pw.buf.Bytes())
+	pw.buf.Reset()
+
+	return err
+}
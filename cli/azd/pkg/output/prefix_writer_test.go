@@ -0,0 +1,33 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writer := NewPrefixWriter(buffer, "api | ")
+
+	n, err := writer.Write([]byte("first line\nsecond "))
+	require.NoError(t, err)
+	require.Equal(t, 18, n)
+	// The incomplete line is buffered
+	require.Equal(t, "api | first line\n", buffer.String())
+
+	_, err = writer.Write([]byte("line\n\nlast"))
+	require.NoError(t, err)
+	require.Equal(t, "api | first line\napi | second line\napi | \n", buffer.String())
+
+	require.NoError(t, writer.Flush())
+	require.Equal(t, "api | first line\napi | second line\napi | \napi | last\n", buffer.String())
+
+	// Nothing left to flush
+	require.NoError(t, writer.Flush())
+	require.Equal(t, "api | first line\napi | second line\napi | \napi | last\n", buffer.String())
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 )
 
@@ -50,3 +51,12 @@ func (cr *DisplayedResource) MarshalJSON() ([]byte, error) {
 	return json.Marshal(output.EventForMessage(
 		fmt.Sprintf("%s: Creating %s: %s", cr.State, cr.Type, cr.Name)))
 }
+
+func (cr *DisplayedResource) Event() contracts.EventEnvelope {
+	return output.NewEvent(contracts.ResourceCreatedEventDataType, contracts.ResourceCreated{
+		Type:       cr.Type,
+		Name:       cr.Name,
+		State:      string(cr.State),
+		DurationMs: cr.Duration.Milliseconds(),
+	})
+}
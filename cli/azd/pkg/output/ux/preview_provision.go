@@ -29,6 +29,7 @@ const (
 	OperationTypeIgnore      OperationType = "Ignore"
 	OperationTypeModify      OperationType = "Modify"
 	OperationTypeNoChange    OperationType = "NoChange"
+	OperationTypeReplace     OperationType = "Replace"
 	OperationTypeUnsupported OperationType = "Unsupported"
 )
 
@@ -49,6 +50,28 @@ type Resource struct {
 	Operation OperationType
 	Name      string
 	Type      string
+	// The changes to the properties of the resource, when known
+	Properties []*PropertyChange `json:",omitempty"`
+}
+
+// PropertyChange describes the change to a single property of a resource.
+type PropertyChange struct {
+	Operation OperationType
+	Path      string
+	Before    any `json:",omitempty"`
+	After     any `json:",omitempty"`
+}
+
+// symbol is displayed before the path of the property in the preview
+func (pc *PropertyChange) symbol() string {
+	switch pc.Operation {
+	case OperationTypeCreate:
+		return "+"
+	case OperationTypeDelete:
+		return "-"
+	default:
+		return "~"
+	}
 }
 
 func colorType(opType OperationType) func(string, ...interface{}) string {
@@ -60,6 +83,8 @@ func colorType(opType OperationType) func(string, ...interface{}) string {
 		final = output.WithGrayFormat
 	case OperationTypeDelete:
 		final = color.RedString
+	case OperationTypeReplace:
+		final = color.MagentaString
 	case OperationTypeModify:
 		final = color.YellowString
 	default:
@@ -76,7 +101,7 @@ func (pp *PreviewProvision) ToString(currentIndentation string) string {
 
 	title := currentIndentation + "Resources:"
 
-	changes := make([]string, len(pp.Operations))
+	changes := make([]string, 0, len(pp.Operations))
 	actions := make([]string, len(pp.Operations))
 	resources := make([]string, len(pp.Operations))
 
@@ -101,13 +126,23 @@ func (pp *PreviewProvision) ToString(currentIndentation string) string {
 		resources[index] = op.Type + typeGapToFill + " :"
 	}
 
+	// Property changes are listed below their resource, aligned with the resource type
+	propertyIndentation := currentIndentation + strings.Repeat(" ", maxActionLen+3)
 	for index, op := range pp.Operations {
-		changes[index] = fmt.Sprintf("%s%s %s %s",
+		changes = append(changes, fmt.Sprintf("%s%s %s %s",
 			currentIndentation,
 			colorType(op.Operation)(actions[index]),
 			resources[index],
 			op.Name,
-		)
+		))
+
+		for _, property := range op.Properties {
+			changes = append(changes, fmt.Sprintf("%s%s %s",
+				propertyIndentation,
+				colorType(property.Operation)(property.symbol()),
+				property.Path,
+			))
+		}
 	}
 
 	return fmt.Sprintf("%s\n\n%s", title, strings.Join(changes, "\n"))
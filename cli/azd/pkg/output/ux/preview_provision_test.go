@@ -33,6 +33,23 @@ func TestPreviewProvision(t *testing.T) {
 				Name:      "resource name 3",
 				Operation: OperationTypeDelete,
 			},
+			{
+				Type:      "Web App",
+				Name:      "resource name 4",
+				Operation: OperationTypeModify,
+				Properties: []*PropertyChange{
+					{Operation: OperationTypeCreate, Path: "tags"},
+					{Operation: OperationTypeModify, Path: "site_config.always_on"},
+				},
+			},
+			{
+				Type:      "Container Registry",
+				Name:      "resource name 5",
+				Operation: OperationTypeReplace,
+				Properties: []*PropertyChange{
+					{Operation: OperationTypeDelete, Path: "location"},
+				},
+			},
 		},
 	}
 
@@ -6,6 +6,7 @@ package ux
 import (
 	"encoding/json"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 )
 
@@ -16,5 +17,11 @@ type UxItem interface {
 	json.Marshaler
 }
 
+// EventItem is implemented by the UxItems rendered as a typed event of the jsonl event stream. The other items are
+// rendered as log lines.
+type EventItem interface {
+	Event() contracts.EventEnvelope
+}
+
 var donePrefix string = output.WithSuccessFormat("(✓) Done:")
 var failedPrefix string = output.WithErrorFormat("(x) Failed:")
@@ -0,0 +1,709 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/azure/azure-dev/cli/azd/pkg/entraid"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/gitlab"
+	"github.com/azure/azure-dev/cli/azd/pkg/graphsdk"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+)
+
+// GitLabRepositoryDetails provides extra state needed for the GitLab provider.
+// this is stored as the details property in repoDetails
+type GitLabRepositoryDetails struct {
+	// baseUrl is the URL of the GitLab instance, e.g. https://gitlab.com
+	baseUrl   string
+	projectId int
+}
+
+// GitLabScmProvider implements ScmProvider using GitLab, either gitlab.com or a self-hosted instance, as the provider
+// for source control manager.
+type GitLabScmProvider struct {
+	newProjectCreated bool
+	envManager        environment.Manager
+	env               *environment.Environment
+	console           input.Console
+	gitCli            *git.Cli
+	transport         policy.Transporter
+}
+
+func NewGitLabScmProvider(
+	envManager environment.Manager,
+	env *environment.Environment,
+	console input.Console,
+	gitCli *git.Cli,
+	transport policy.Transporter,
+) ScmProvider {
+	return &GitLabScmProvider{
+		envManager: envManager,
+		env:        env,
+		console:    console,
+		gitCli:     gitCli,
+		transport:  transport,
+	}
+}
+
+// ***  subareaProvider implementation ******
+
+// requiredTools return the list of external tools required by
+// GitLab provider during its execution.
+func (p *GitLabScmProvider) requiredTools(_ context.Context) ([]tools.ExternalTool, error) {
+	return []tools.ExternalTool{}, nil
+}
+
+// preConfigureCheck check the current state of external tools and any
+// other dependency to be as expected for execution.
+func (p *GitLabScmProvider) preConfigureCheck(
+	ctx context.Context,
+	pipelineManagerArgs PipelineManagerArgs,
+	infraOptions provisioning.Options,
+	projectPath string,
+) (bool, error) {
+	_, updatedToken, err := gitlab.EnsureTokenExists(ctx, p.env, p.console)
+	return updatedToken, err
+}
+
+// name returns the name of the provider
+func (p *GitLabScmProvider) Name() string {
+	return gitLabDisplayName
+}
+
+// ***  scmProvider implementation ******
+
+// configureGitRemote guides the user on setting a remote url for the local git project, from an existing or a new
+// GitLab project.
+func (p *GitLabScmProvider) configureGitRemote(
+	ctx context.Context,
+	repoPath string,
+	remoteName string,
+) (string, error) {
+	// used to detect when a new project was created
+	p.newProjectCreated = false
+
+	// There are a few ways to configure the remote so offer a choice to the user.
+	idx, err := p.console.Select(ctx, input.ConsoleOptions{
+		Message: "How would you like to configure your git remote to GitLab?",
+		Options: []string{
+			"Select an existing GitLab project",
+			"Create a new private GitLab project",
+			"Enter a remote URL directly",
+		},
+		DefaultValue: "Create a new private GitLab project",
+	})
+	if err != nil {
+		return "", fmt.Errorf("prompting for remote configuration type: %w", err)
+	}
+
+	// The URL of the instance is only needed to look for projects, the remote URL has the instance of the project
+	if idx == 2 {
+		remoteUrl, err := p.getRemoteUrlFromPrompt(ctx, remoteName)
+		if err != nil {
+			return "", fmt.Errorf("getting remote from prompt: %w", err)
+		}
+
+		return remoteUrl, nil
+	}
+
+	baseUrl, err := gitlab.EnsureBaseUrlExists(ctx, p.envManager, p.env, p.console)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := newGitLabClient(p.env, baseUrl, p.transport)
+	if err != nil {
+		return "", err
+	}
+
+	switch idx {
+	// Select from an existing GitLab project
+	case 0:
+		remoteUrl, err := p.getRemoteUrlFromExisting(ctx, client)
+		if err != nil {
+			return "", fmt.Errorf("getting remote from existing project: %w", err)
+		}
+
+		return remoteUrl, nil
+	// Create a new project
+	case 1:
+		remoteUrl, err := p.getRemoteUrlFromNewProject(ctx, client, repoPath)
+		if err != nil {
+			return "", fmt.Errorf("getting remote from new project: %w", err)
+		}
+		p.newProjectCreated = true
+
+		return remoteUrl, nil
+	default:
+		panic(fmt.Sprintf("unexpected selection index %d", idx))
+	}
+}
+
+// getRemoteUrlFromExisting let user to select an existing project which the user can configure the CI/CD of, and
+// returns the remote url for that project.
+func (p *GitLabScmProvider) getRemoteUrlFromExisting(ctx context.Context, client *gitlab.Client) (string, error) {
+	projects, err := client.ListProjects(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if len(projects) == 0 {
+		return "", fmt.Errorf("no existing GitLab projects found on %s", client.BaseUrl())
+	}
+
+	options := make([]string, 0, len(projects))
+	for _, project := range projects {
+		options = append(options, project.PathWithNamespace)
+	}
+
+	projectIdx, err := p.console.Select(ctx, input.ConsoleOptions{
+		Message: "Choose an existing GitLab project",
+		Options: options,
+	})
+	if err != nil {
+		return "", fmt.Errorf("prompting for project: %w", err)
+	}
+
+	return projects[projectIdx].HttpUrlToRepo, nil
+}
+
+// getRemoteUrlFromNewProject creates a new private project on GitLab and returns its remote url
+func (p *GitLabScmProvider) getRemoteUrlFromNewProject(
+	ctx context.Context,
+	client *gitlab.Client,
+	currentPathName string,
+) (string, error) {
+	currentDirectoryName := filepath.Base(currentPathName)
+
+	for {
+		name, err := p.console.Prompt(ctx, input.ConsoleOptions{
+			Message:      "Enter the name for your new project OR Hit enter to use this name:",
+			DefaultValue: currentDirectoryName,
+		})
+		if err != nil {
+			return "", fmt.Errorf("asking for new project name: %w", err)
+		}
+
+		project, err := client.CreateProject(ctx, name)
+		if errors.Is(err, gitlab.ErrProjectNameInUse) {
+			p.console.Message(ctx, fmt.Sprintf("error: the project name '%s' is already in use\n", name))
+			continue // try again
+		} else if err != nil {
+			return "", err
+		}
+
+		return project.HttpUrlToRepo, nil
+	}
+}
+
+// getRemoteUrlFromPrompt interactively prompts the user for a URL for a GitLab project. It validates
+// that the URL is well formed and is in the correct format for a GitLab project.
+func (p *GitLabScmProvider) getRemoteUrlFromPrompt(ctx context.Context, remoteName string) (string, error) {
+	for {
+		remoteUrl, err := p.console.Prompt(ctx, input.ConsoleOptions{
+			Message: fmt.Sprintf("Enter the url to use for remote %s:", remoteName),
+		})
+		if err != nil {
+			return "", fmt.Errorf("prompting for remote url: %w", err)
+		}
+
+		if _, err := p.parseRemote(remoteUrl); err != nil {
+			fmt.Fprintf(p.console.Handles().Stdout, "error: \"%s\" is not a valid GitLab URL.\n", remoteUrl)
+			continue // try again
+		}
+
+		return remoteUrl, nil
+	}
+}
+
+// parseRemote extracts the GitLab instance and the project from the remote url, using the URL of the instance from the
+// environment, if any, for the instances served under a relative path.
+func (p *GitLabScmProvider) parseRemote(remoteUrl string) (*gitlab.Remote, error) {
+	return gitlab.ParseRemote(remoteUrl, p.env.Getenv(gitlab.GitLabEnvironmentHostName))
+}
+
+// gitRepoDetails extracts the information from a GitLab remote url into general scm concepts
+// like owner, name and path, and validates that the project exists on the GitLab instance.
+func (p *GitLabScmProvider) gitRepoDetails(ctx context.Context, remoteUrl string) (*gitRepositoryDetails, error) {
+	remote, err := p.parseRemote(remoteUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newGitLabClient(p.env, remote.BaseUrl, p.transport)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := client.GetProject(ctx, remote.ProjectPath)
+	if err != nil {
+		return nil, fmt.Errorf("validating remote %s: %w", remoteUrl, err)
+	}
+
+	// The owner is the group, including its parent groups, or the user of the project
+	separator := strings.LastIndex(project.PathWithNamespace, "/")
+	return &gitRepositoryDetails{
+		owner:    project.PathWithNamespace[:separator],
+		repoName: project.PathWithNamespace[separator+1:],
+		remote:   remoteUrl,
+		url:      project.WebUrl,
+		details: &GitLabRepositoryDetails{
+			baseUrl:   remote.BaseUrl,
+			projectId: project.Id,
+		},
+	}, nil
+}
+
+// preventGitPush validates that the CI/CD of the project isn't disabled before pushing changes to upstream.
+func (p *GitLabScmProvider) preventGitPush(
+	ctx context.Context,
+	gitRepo *gitRepositoryDetails,
+	remoteName string,
+	branchName string) (bool, error) {
+	// The CI/CD is enabled for new projects
+	if p.newProjectCreated {
+		return false, nil
+	}
+
+	details := gitRepo.details.(*GitLabRepositoryDetails)
+	client, err := newGitLabClient(p.env, details.baseUrl, p.transport)
+	if err != nil {
+		return false, err
+	}
+
+	project, err := client.GetProject(ctx, gitRepo.owner+"/"+gitRepo.repoName)
+	if err != nil {
+		return false, err
+	}
+
+	if project.BuildsAccessLevel != "disabled" {
+		return false, nil
+	}
+
+	p.console.Message(ctx, fmt.Sprintf("\n%s\n"+
+		" - Enable CI/CD in the visibility settings of the project here: %s.\n",
+		output.WithHighLightFormat("CI/CD is currently disabled for your project."),
+		output.WithHighLightFormat("%s/edit", project.WebUrl)))
+
+	enabled, err := p.console.Confirm(ctx, input.ConsoleOptions{
+		Message:      "Have you enabled CI/CD? Continue with pushing your changes?",
+		DefaultValue: false,
+	})
+	if err != nil {
+		return false, fmt.Errorf("prompting to enable gitlab ci/cd: %w", err)
+	}
+
+	return !enabled, nil
+}
+
+func (p *GitLabScmProvider) GitPush(
+	ctx context.Context,
+	gitRepo *gitRepositoryDetails,
+	remoteName string,
+	branchName string) error {
+	return p.gitCli.PushUpstream(ctx, gitRepo.gitProjectPath, remoteName, branchName)
+}
+
+const (
+	gitLabFederatedIdentityAudience = "api://AzureADTokenExchange"
+)
+
+// GitLabCiProvider implements a CiProvider using GitLab CI/CD, with the pipeline defined in .gitlab-ci.yml and the
+// configuration stored as CI/CD variables of the project.
+type GitLabCiProvider struct {
+	env       *environment.Environment
+	console   input.Console
+	transport policy.Transporter
+}
+
+func NewGitLabCiProvider(
+	env *environment.Environment,
+	console input.Console,
+	transport policy.Transporter,
+) CiProvider {
+	return &GitLabCiProvider{
+		env:       env,
+		console:   console,
+		transport: transport,
+	}
+}
+
+// ***  subareaProvider implementation ******
+
+// requiredTools defines the requires tools for GitLab to be used as CI manager
+func (p *GitLabCiProvider) requiredTools(_ context.Context) ([]tools.ExternalTool, error) {
+	return []tools.ExternalTool{}, nil
+}
+
+// preConfigureCheck validates that current state of tools and GitLab is as expected to
+// execute.
+func (p *GitLabCiProvider) preConfigureCheck(
+	ctx context.Context,
+	pipelineManagerArgs PipelineManagerArgs,
+	infraOptions provisioning.Options,
+	projectPath string,
+) (bool, error) {
+	_, updated, err := gitlab.EnsureTokenExists(ctx, p.env, p.console)
+	if err != nil {
+		return updated, err
+	}
+
+	authType := PipelineAuthType(pipelineManagerArgs.PipelineAuthTypeName)
+
+	// Federated Auth + Terraform is not a supported combination
+	if infraOptions.Provider == provisioning.Terraform {
+		// Throw error if Federated auth is explicitly requested
+		if authType == AuthTypeFederated {
+			return false, fmt.Errorf(
+				//nolint:lll
+				"Terraform does not support federated authentication. To explicitly use client credentials set the %s flag. %w",
+				output.WithBackticks("--auth-type client-credentials"),
+				ErrAuthNotSupported,
+			)
+		} else if authType == "" {
+			// If not explicitly set, show warning
+			p.console.MessageUxItem(
+				ctx,
+				&ux.WarningMessage{
+					//nolint:lll
+					Description: "Terraform provisioning does not support federated authentication, defaulting to Service Principal with client ID and client secret.\n",
+				},
+			)
+		}
+	}
+
+	return updated, nil
+}
+
+// name returns the name of the provider.
+func (p *GitLabCiProvider) Name() string {
+	return gitLabDisplayName
+}
+
+// credentialOptions returns federated credentials for the ID tokens GitLab issues to the jobs of the current and main
+// branches, unless client credentials are requested.
+func (p *GitLabCiProvider) credentialOptions(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	infraOptions provisioning.Options,
+	authType PipelineAuthType,
+	credentials *entraid.AzureCredentials,
+) (*CredentialOptions, error) {
+	// Default auth type to client-credentials for terraform
+	if infraOptions.Provider == provisioning.Terraform && authType == "" {
+		authType = AuthTypeClientCredentials
+	}
+
+	if authType == AuthTypeClientCredentials {
+		return &CredentialOptions{
+			EnableClientCredentials: true,
+		}, nil
+	}
+
+	// If not specified default to federated credentials
+	if authType == "" || authType == AuthTypeFederated {
+		// Configure federated auth for both main branch and current branch
+		branches := []string{repoDetails.branch}
+		if !slices.Contains(branches, "main") {
+			branches = append(branches, "main")
+		}
+
+		// GitLab is the issuer of the ID tokens of its jobs, self-hosted instances included
+		issuer := repoDetails.details.(*GitLabRepositoryDetails).baseUrl
+		projectPath := repoDetails.owner + "/" + repoDetails.repoName
+		credentialSafeName := strings.ReplaceAll(projectPath, "/", "-")
+
+		federatedCredentials := []*graphsdk.FederatedIdentityCredential{}
+		for _, branch := range branches {
+			federatedCredentials = append(federatedCredentials, &graphsdk.FederatedIdentityCredential{
+				Name:        gitLabFederatedCredentialName(credentialSafeName, branch),
+				Issuer:      issuer,
+				Subject:     fmt.Sprintf("project_path:%s:ref_type:branch:ref:%s", projectPath, branch),
+				Description: to.Ptr("Created by Azure Developer CLI"),
+				Audiences:   []string{gitLabFederatedIdentityAudience},
+			})
+		}
+
+		return &CredentialOptions{
+			EnableFederatedCredentials: true,
+			FederatedCredentialOptions: federatedCredentials,
+		}, nil
+	}
+
+	return &CredentialOptions{
+		EnableClientCredentials:    false,
+		EnableFederatedCredentials: false,
+	}, nil
+}
+
+// gitLabFederatedCredentialName returns the name of the federated credential of the branch, which only has letters,
+// digits, '-' and '_', as required by Entra ID.
+func gitLabFederatedCredentialName(credentialSafeName string, branch string) string {
+	name := []rune(fmt.Sprintf("gitlab-%s-%s", credentialSafeName, branch))
+	for i, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			name[i] = '-'
+		}
+	}
+
+	// Names are limited to 120 characters
+	return string(name[:min(len(name), 120)])
+}
+
+// ***  ciProvider implementation ******
+
+// configureConnection sets the CI/CD variables of the GitLab project for the jobs to log in to Azure with the service
+// principal and make changes on behalf of a user.
+func (p *GitLabCiProvider) configureConnection(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	infraOptions provisioning.Options,
+	servicePrincipal *graphsdk.ServicePrincipal,
+	credentialOptions *CredentialOptions,
+	credentials *entraid.AzureCredentials,
+) error {
+	client, projectId, err := p.projectClient(repoDetails)
+	if err != nil {
+		return err
+	}
+
+	variables := map[string]string{
+		environment.EnvNameEnvVarName:        p.env.Name(),
+		environment.LocationEnvVarName:       p.env.GetLocation(),
+		environment.SubscriptionIdEnvVarName: p.env.GetSubscriptionId(),
+		environment.TenantIdEnvVarName:       *servicePrincipal.AppOwnerOrganizationId,
+		"AZURE_CLIENT_ID":                    servicePrincipal.AppId,
+	}
+	secrets := map[string]string{}
+
+	if credentialOptions.EnableClientCredentials {
+		/* #nosec G101 - Potential hardcoded credentials - false positive */
+		secrets["AZURE_CLIENT_SECRET"] = credentials.ClientSecret
+
+		if infraOptions.Provider == provisioning.Terraform {
+			variables["ARM_TENANT_ID"] = credentials.TenantId
+			variables["ARM_CLIENT_ID"] = credentials.ClientId
+			secrets["ARM_CLIENT_SECRET"] = credentials.ClientSecret
+		}
+	}
+
+	if infraOptions.Provider == provisioning.Terraform {
+		remoteStateKeys := []string{"RS_RESOURCE_GROUP", "RS_STORAGE_ACCOUNT", "RS_CONTAINER_NAME"}
+		for _, key := range remoteStateKeys {
+			value, ok := p.env.LookupEnv(key)
+			if !ok || strings.TrimSpace(value) == "" {
+				p.console.StopSpinner(ctx, "Configuring terraform", input.StepWarning)
+				p.console.MessageUxItem(ctx, &ux.WarningMessage{
+					Description: "Terraform Remote State configuration is invalid",
+					HidePrefix:  true,
+				})
+				p.console.Message(
+					ctx,
+					fmt.Sprintf(
+						"Visit %s for more information on configuring Terraform remote state",
+						output.WithLinkFormat("https://aka.ms/azure-dev/terraform"),
+					),
+				)
+				p.console.Message(ctx, "")
+				return errors.New("terraform remote state is not correctly configured")
+			}
+
+			variables[key] = value
+		}
+	}
+
+	if infraOptions.Provider == provisioning.Bicep {
+		if rgName, has := p.env.LookupEnv(environment.ResourceGroupEnvVarName); has {
+			variables[environment.ResourceGroupEnvVarName] = rgName
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(variables)) {
+		if err := p.setVariable(ctx, client, projectId, name, variables[name], false); err != nil {
+			return fmt.Errorf("failed setting %s variable: %w", name, err)
+		}
+		p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
+			Name: name,
+			Kind: ux.GitHubVariable,
+		})
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(secrets)) {
+		if err := p.setVariable(ctx, client, projectId, name, secrets[name], true); err != nil {
+			return fmt.Errorf("failed setting %s secret: %w", name, err)
+		}
+		p.console.MessageUxItem(ctx, &ux.CreatedRepoValue{
+			Name: name,
+			Kind: ux.GitHubSecret,
+		})
+	}
+
+	return nil
+}
+
+// setVariable sets the CI/CD variable of the project. The values are masked in the job logs, when GitLab supports
+// masking them. Secrets GitLab can't mask, e.g. JSON values, are stored without masking, with a warning.
+func (p *GitLabCiProvider) setVariable(
+	ctx context.Context,
+	client *gitlab.Client,
+	projectId int,
+	name string,
+	value string,
+	secret bool,
+) error {
+	masked := gitlab.CanMask(value)
+	if secret && !masked {
+		p.console.MessageUxItem(ctx, &ux.WarningMessage{
+			Description: fmt.Sprintf(
+				"The value of the secret %s can't be masked by GitLab in the job logs. Avoid printing it in the jobs.",
+				name,
+			),
+		})
+	}
+
+	return client.SetVariable(ctx, projectId, gitlab.Variable{
+		Key:    name,
+		Value:  value,
+		Masked: masked,
+		Raw:    true,
+	})
+}
+
+// configurePipeline sets the variables and the secrets of the project as CI/CD variables. The pipeline itself is
+// created by GitLab from the .gitlab-ci.yml file of the repository.
+func (p *GitLabCiProvider) configurePipeline(
+	ctx context.Context,
+	repoDetails *gitRepositoryDetails,
+	options *configurePipelineOptions,
+) (CiPipeline, error) {
+	client, projectId, err := p.projectClient(repoDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// The CI/CD variables are independent from the pipeline, they are set on the project level.
+	// The previous values of the project's variables and secrets, which aren't set anymore, are removed, so a previous
+	// value isn't leaked when a secret is moved to be a variable, or when a variable is unset from .env.
+	msg := ""
+	var procErr error
+	ciVariables := []gitlab.Variable{}
+	if len(options.projectVariables) > 0 || len(options.projectSecrets) > 0 {
+		msg = "Setting up project's variables to be used in the pipeline"
+		ciVariables, err = client.ListVariables(ctx, projectId)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get list of project variables: %w", err)
+		}
+		p.console.ShowSpinner(ctx, msg, input.Step)
+	}
+
+	defer func() {
+		if msg != "" {
+			p.console.StopSpinner(ctx, msg, input.GetStepResultFormat(procErr))
+		}
+		if procErr == nil {
+			p.console.MessageUxItem(ctx, &ux.MultilineMessage{
+				Lines: []string{
+					"",
+					"GitLab CI/CD variables are now configured. You can view the CI/CD variables that were " +
+						"created at this link:",
+					output.WithLinkFormat("%s/-/settings/ci_cd", repoDetails.url),
+					""},
+			})
+		}
+	}()
+
+	for _, existing := range ciVariables {
+		_, isVariable := options.variables[existing.Key]
+		_, isSecret := options.secrets[existing.Key]
+		if isVariable || isSecret {
+			// the variable will be updated, we don't need to delete it
+			continue
+		}
+
+		// only delete if the variable is defined in the project's secrets or variables (azure.yaml)
+		if slices.Contains(options.projectVariables, existing.Key) || slices.Contains(options.projectSecrets, existing.Key) {
+			if err := client.DeleteVariable(ctx, projectId, existing.Key); err != nil {
+				procErr = fmt.Errorf("failed deleting %s variable: %w", existing.Key, err)
+				return nil, procErr
+			}
+		}
+	}
+
+	// set the new variables and secrets
+	for _, key := range slices.Sorted(maps.Keys(options.secrets)) {
+		if err := p.setVariable(ctx, client, projectId, key, options.secrets[key], true); err != nil {
+			procErr = fmt.Errorf("failed setting %s secret: %w", key, err)
+			return nil, procErr
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(options.variables)) {
+		if err := p.setVariable(ctx, client, projectId, key, options.variables[key], false); err != nil {
+			procErr = fmt.Errorf("failed setting %s variable: %w", key, err)
+			return nil, procErr
+		}
+	}
+
+	return &gitLabPipeline{
+		repoDetails: repoDetails,
+	}, nil
+}
+
+// projectClient returns the client of the GitLab instance of the repository, and the id of its project.
+func (p *GitLabCiProvider) projectClient(repoDetails *gitRepositoryDetails) (*gitlab.Client, int, error) {
+	details := repoDetails.details.(*GitLabRepositoryDetails)
+	client, err := newGitLabClient(p.env, details.baseUrl, p.transport)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return client, details.projectId, nil
+}
+
+// gitLabPipeline is the implementation for a CiPipeline for GitLab
+type gitLabPipeline struct {
+	repoDetails *gitRepositoryDetails
+}
+
+func (pipeline *gitLabPipeline) name() string {
+	return "pipelines"
+}
+
+func (pipeline *gitLabPipeline) url() string {
+	return pipeline.repoDetails.url + "/-/pipelines"
+}
+
+// newGitLabClient creates a client of the GitLab instance with the token from the environment. The instance must be the
+// configured one, as the token isn't sent to other hosts.
+func newGitLabClient(
+	env *environment.Environment,
+	baseUrl string,
+	transport policy.Transporter,
+) (*gitlab.Client, error) {
+	if err := gitlab.CheckTokenHost(baseUrl, env.Getenv(gitlab.GitLabEnvironmentHostName)); err != nil {
+		return nil, err
+	}
+
+	token, has := env.LookupEnv(gitlab.GitLabTokenName)
+	if !has || token == "" {
+		return nil, fmt.Errorf("gitlab access token not found in environment variable %s", gitlab.GitLabTokenName)
+	}
+
+	return gitlab.NewClient(baseUrl, token, transport), nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pipeline
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/gitlab"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockgitlab"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockinput"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_gitLab_provider_getRepoDetails(t *testing.T) {
+	gitLab := mockgitlab.NewMockGitLab(t)
+	project := gitLab.AddProject("group/subgroup/app")
+	env := environment.NewWithValues("test", map[string]string{
+		gitlab.GitLabTokenName:           mockgitlab.Token,
+		gitlab.GitLabEnvironmentHostName: gitLab.BaseUrl,
+	})
+	provider := NewGitLabScmProvider(nil, env, mockinput.NewMockConsole(), nil, gitLab.Transport)
+
+	t.Run("self-hosted", func(t *testing.T) {
+		details, err := provider.gitRepoDetails(context.Background(), gitLab.BaseUrl+"/group/subgroup/app.git")
+		require.NoError(t, err)
+		require.Equal(t, "group/subgroup", details.owner)
+		require.Equal(t, "app", details.repoName)
+		require.Equal(t, gitLab.BaseUrl+"/group/subgroup/app", details.url)
+		require.Equal(t, &GitLabRepositoryDetails{baseUrl: gitLab.BaseUrl, projectId: project.Id}, details.details)
+	})
+
+	t.Run("project not found", func(t *testing.T) {
+		_, err := provider.gitRepoDetails(context.Background(), gitLab.BaseUrl+"/group/other.git")
+		require.ErrorIs(t, err, gitlab.ErrProjectNotFound)
+	})
+
+	t.Run("token not sent to other hosts", func(t *testing.T) {
+		_, err := provider.gitRepoDetails(context.Background(), "https://gitlab.attacker.example.com/group/app.git")
+		require.ErrorIs(t, err, gitlab.ErrUntrustedHost)
+	})
+
+	t.Run("token not sent over http", func(t *testing.T) {
+		remoteUrl := strings.Replace(gitLab.BaseUrl, "https://", "http://", 1) + "/group/subgroup/app.git"
+		_, err := provider.gitRepoDetails(context.Background(), remoteUrl)
+		require.ErrorIs(t, err, gitlab.ErrUntrustedHost)
+	})
+
+	t.Run("not gitlab", func(t *testing.T) {
+		_, err := provider.gitRepoDetails(context.Background(), "https://github.com/Azure/azure-dev.git")
+		require.ErrorIs(t, err, gitlab.ErrRemoteHostIsNotGitLab)
+	})
+}
+
+func Test_gitLab_provider_credentialOptions(t *testing.T) {
+	provider := NewGitLabCiProvider(environment.New("test"), mockinput.NewMockConsole(), http.DefaultClient)
+	repoDetails := &gitRepositoryDetails{
+		owner:    "group/subgroup",
+		repoName: "app",
+		branch:   "feature/login",
+		details:  &GitLabRepositoryDetails{baseUrl: "https://gitlab.example.com", projectId: 7},
+	}
+
+	t.Run("federated by default", func(t *testing.T) {
+		options, err := provider.credentialOptions(context.Background(), repoDetails, provisioning.Options{}, "", nil)
+		require.NoError(t, err)
+		require.True(t, options.EnableFederatedCredentials)
+		require.False(t, options.EnableClientCredentials)
+		require.Len(t, options.FederatedCredentialOptions, 2)
+
+		current := options.FederatedCredentialOptions[0]
+		require.Equal(t, "gitlab-group-subgroup-app-feature-login", current.Name)
+		require.Equal(t, "https://gitlab.example.com", current.Issuer)
+		require.Equal(t, "project_path:group/subgroup/app:ref_type:branch:ref:feature/login", current.Subject)
+		require.Equal(t, []string{gitLabFederatedIdentityAudience}, current.Audiences)
+
+		mainBranch := options.FederatedCredentialOptions[1]
+		require.Equal(t, "project_path:group/subgroup/app:ref_type:branch:ref:main", mainBranch.Subject)
+	})
+
+	t.Run("client credentials for terraform", func(t *testing.T) {
+		options, err := provider.credentialOptions(
+			context.Background(), repoDetails, provisioning.Options{Provider: provisioning.Terraform}, "", nil)
+		require.NoError(t, err)
+		require.True(t, options.EnableClientCredentials)
+		require.False(t, options.EnableFederatedCredentials)
+	})
+}
+
+func Test_gitLab_provider_configurePipeline(t *testing.T) {
+	gitLab := mockgitlab.NewMockGitLab(t)
+	project := gitLab.AddProject("group/subgroup/app")
+	gitLab.SetVariable(project.Id, gitlab.Variable{Key: "STALE", Value: "previous"})
+	gitLab.SetVariable(project.Id, gitlab.Variable{Key: "UNRELATED", Value: "kept"})
+
+	env := environment.NewWithValues("test", map[string]string{
+		gitlab.GitLabTokenName:           mockgitlab.Token,
+		gitlab.GitLabEnvironmentHostName: gitLab.BaseUrl,
+	})
+	console := mockinput.NewMockConsole()
+	provider := NewGitLabCiProvider(env, console, gitLab.Transport)
+	repoDetails := &gitRepositoryDetails{
+		url:     project.WebUrl,
+		details: &GitLabRepositoryDetails{baseUrl: gitLab.BaseUrl, projectId: project.Id},
+	}
+
+	ciPipeline, err := provider.configurePipeline(context.Background(), repoDetails, &configurePipelineOptions{
+		provisioningProvider: &provisioning.Options{},
+		variables:            map[string]string{"LOCATION": "eastus"},
+		secrets:              map[string]string{"API_KEY": "a-long-secret-value", "CONFIG": `{"key": "value"}`},
+		projectVariables:     []string{"LOCATION", "STALE"},
+		projectSecrets:       []string{"API_KEY", "CONFIG"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, project.WebUrl+"/-/pipelines", ciPipeline.url())
+
+	// Stale values of the project are removed, others are kept
+	variables := gitLab.Variables(project.Id)
+	require.NotContains(t, variables, "STALE")
+	require.Contains(t, variables, "UNRELATED")
+
+	// Values are masked when GitLab supports it
+	require.Equal(t, "eastus", variables["LOCATION"].Value)
+	require.False(t, variables["LOCATION"].Masked)
+	require.True(t, variables["API_KEY"].Masked)
+	require.True(t, variables["API_KEY"].Raw)
+	require.False(t, variables["CONFIG"].Masked)
+	require.Contains(t, strings.Join(console.Output(), "\n"), "The value of the secret CONFIG can't be masked")
+}
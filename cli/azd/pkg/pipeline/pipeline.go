@@ -169,6 +169,9 @@ const (
 	azdoRoot          string = ".azdo"
 	azdoRootAlt       string = ".azuredevops"
 	azdoPipelines     string = "pipelines"
+	gitLabDisplayName string = "GitLab"
+	gitLabCode               = "gitlab"
+	gitLabCiFile      string = ".gitlab-ci.yml"
 	envPersistedKey   string = "AZD_PIPELINE_PROVIDER"
 )
 
@@ -200,6 +203,14 @@ var (
 			DefaultFile: pipelineFileNames[0],
 			DisplayName: azdoDisplayName,
 		},
+		// GitLab only runs the pipeline defined at the root of the repository
+		ciProviderGitLab: {
+			RootDirectories:     []string{""},
+			PipelineDirectories: []string{""},
+			Files:               []string{gitLabCiFile},
+			DefaultFile:         gitLabCiFile,
+			DisplayName:         gitLabDisplayName,
+		},
 	}
 )
 
@@ -218,11 +229,12 @@ type ciProviderType string
 const (
 	ciProviderGitHubActions ciProviderType = gitHubCode
 	ciProviderAzureDevOps   ciProviderType = azdoCode
+	ciProviderGitLab        ciProviderType = gitLabCode
 )
 
 func toCiProviderType(provider string) (ciProviderType, error) {
 	result := ciProviderType(provider)
-	if result == ciProviderGitHubActions || result == ciProviderAzureDevOps {
+	if result == ciProviderGitHubActions || result == ciProviderAzureDevOps || result == ciProviderGitLab {
 		return result, nil
 	}
 	return "", fmt.Errorf("invalid ci provider type %s", provider)
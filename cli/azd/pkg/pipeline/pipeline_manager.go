@@ -688,9 +688,10 @@ func (pm *PipelineManager) resolveProviderAndDetermine(
 // Logic:
 //   - If the user specifies a provider through the arguments, that provider is used.
 //   - If no provider is specified:
-//   - If both GitHub and Azure DevOps configurations are detected, prompt the user to choose which one to use.
+//   - If configurations of several providers are detected, prompt the user to choose which one to use.
 //   - If only GitHub configuration is found, use GitHub Actions.
 //   - If only Azure DevOps configuration is found, use Azure DevOps.
+//   - If only GitLab configuration is found, use GitLab CI/CD.
 //   - If no configuration is found, prompt the user to select which one to set up.
 //   - Default to GitHub Actions if no provider is specified or selected.
 //   - Prompt the user to confirm adding the azure-dev file if it’s missing, and inform them where the file is created.
@@ -776,11 +777,16 @@ func (pm *PipelineManager) initialize(ctx context.Context, override string) erro
 	}
 
 	var scmProviderName, ciProviderName, displayName string
-	if pipelineProvider == ciProviderAzureDevOps {
+	switch pipelineProvider {
+	case ciProviderAzureDevOps:
 		scmProviderName = string(ciProviderAzureDevOps)
 		ciProviderName = scmProviderName
 		displayName = azdoDisplayName
-	} else {
+	case ciProviderGitLab:
+		scmProviderName = string(ciProviderGitLab)
+		ciProviderName = scmProviderName
+		displayName = gitLabDisplayName
+	default:
 		scmProviderName = string(ciProviderGitHubActions)
 		ciProviderName = scmProviderName
 		displayName = gitHubDisplayName
@@ -901,7 +907,7 @@ func (pm *PipelineManager) promptForCiFiles(ctx context.Context, props projectPr
 		ctx,
 		fmt.Sprintf(
 			"The default %s file, which contains a basic workflow to help you get started, is missing from your project.",
-			output.WithHighLightFormat("azure-dev.yml"),
+			output.WithHighLightFormat(pipelineProviderFiles[props.CiProvider].DefaultFile),
 		),
 	)
 	pm.console.Message(ctx, "")
@@ -1009,30 +1015,32 @@ func (pm *PipelineManager) determineProvider(ctx context.Context, repoRoot strin
 	// Check for existence of official YAML files in the repo root
 	hasGitHubYml := hasPipelineFile(ciProviderGitHubActions, repoRoot)
 	hasAzDevOpsYml := hasPipelineFile(ciProviderAzureDevOps, repoRoot)
+	hasGitLabYml := hasPipelineFile(ciProviderGitLab, repoRoot)
 
 	log.Printf("GitHub Actions YAML exists: %v", hasGitHubYml)
 	log.Printf("Azure DevOps YAML exists: %v", hasAzDevOpsYml)
+	log.Printf("GitLab CI/CD YAML exists: %v", hasGitLabYml)
 
 	switch {
-	case (!hasGitHubYml && !hasAzDevOpsYml) || (hasGitHubYml && hasAzDevOpsYml):
-		// No official YAML files found for either provider or both are found
-		log.Printf("Neither or both YAML files found. Prompting user for provider selection.")
-		return pm.promptForProvider(ctx)
-
-	case hasGitHubYml && !hasAzDevOpsYml:
-		// GitHub Actions YAML found, Azure DevOps YAML not found
+	case hasGitHubYml && !hasAzDevOpsYml && !hasGitLabYml:
+		// Only GitHub Actions YAML found
 		log.Printf("Only GitHub Actions YAML found. Selecting GitHub Actions as the provider.")
 		return ciProviderGitHubActions, nil
 
-	case hasAzDevOpsYml && !hasGitHubYml:
-		// Azure DevOps YAML found, GitHub Actions YAML not found
+	case hasAzDevOpsYml && !hasGitHubYml && !hasGitLabYml:
+		// Only Azure DevOps YAML found
 		log.Printf("Only Azure DevOps YAML found. Selecting Azure DevOps as the provider.")
 		return ciProviderAzureDevOps, nil
 
+	case hasGitLabYml && !hasGitHubYml && !hasAzDevOpsYml:
+		// Only GitLab CI/CD YAML found
+		log.Printf("Only GitLab CI/CD YAML found. Selecting GitLab as the provider.")
+		return ciProviderGitLab, nil
+
 	default:
-		// Default to GitHub Actions if no provider is specified
-		log.Printf("Defaulting to GitHub Actions as the provider.")
-		return ciProviderGitHubActions, nil
+		// No official YAML files found for any provider or several are found
+		log.Printf("No YAML files or YAML files of several providers found. Prompting user for provider selection.")
+		return pm.promptForProvider(ctx)
 	}
 }
 
@@ -1042,7 +1050,7 @@ func (pm *PipelineManager) promptForProvider(ctx context.Context) (ciProviderTyp
 	pm.console.Message(ctx, "")
 	choice, err := pm.console.Select(ctx, input.ConsoleOptions{
 		Message: "Select a provider:",
-		Options: []string{gitHubDisplayName, azdoDisplayName},
+		Options: []string{gitHubDisplayName, azdoDisplayName, gitLabDisplayName},
 	})
 	if err != nil {
 		return "", fmt.Errorf("prompting for CI/CD provider: %w", err)
@@ -1054,6 +1062,8 @@ func (pm *PipelineManager) promptForProvider(ctx context.Context) (ciProviderTyp
 		return ciProviderGitHubActions, nil
 	} else if choice == 1 {
 		return ciProviderAzureDevOps, nil
+	} else if choice == 2 {
+		return ciProviderGitLab, nil
 	}
 
 	return "", nil // This case should never occur with the current options.
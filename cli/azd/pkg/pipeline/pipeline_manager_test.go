@@ -309,6 +309,33 @@ func Test_PipelineManager_Initialize(t *testing.T) {
 
 		deleteYamlFiles(t, tempDir)
 	})
+	t.Run("gitlab file only", func(t *testing.T) {
+		mockContext = resetContext(tempDir, ctx)
+
+		createYamlFiles(t, tempDir, ciProviderGitLab)
+
+		// The provider is detected from the .gitlab-ci.yml file, without prompting
+		manager, err := createPipelineManager(mockContext, azdContext, nil, nil)
+
+		verifyProvider(t, manager, ciProviderGitLab, err)
+
+		deleteYamlFiles(t, tempDir)
+	})
+	t.Run("no files - gitlab selected", func(t *testing.T) {
+		mockContext = resetContext(tempDir, ctx)
+
+		deleteYamlFiles(t, tempDir)
+
+		simulateUserInteraction(mockContext, ciProviderGitLab, true)
+
+		manager, err := createPipelineManager(mockContext, azdContext, nil, nil)
+		verifyProvider(t, manager, ciProviderGitLab, err)
+
+		// The .gitlab-ci.yml file is created at the root of the repository
+		assert.FileExists(t, filepath.Join(tempDir, ".gitlab-ci.yml"))
+
+		deleteYamlFiles(t, tempDir)
+	})
 	t.Run("both files - user selects GitHub", func(t *testing.T) {
 		mockContext = resetContext(tempDir, ctx)
 
@@ -665,6 +692,63 @@ func Test_promptForCiFiles(t *testing.T) {
 		assert.NoError(t, err)
 		snapshot.SnapshotT(t, normalizeEOL(content))
 	})
+	t.Run("no files - gitlab selected - no app host - fed Cred", func(t *testing.T) {
+		tempDir := t.TempDir()
+		expectedPath := filepath.Join(tempDir, pipelineProviderFiles[ciProviderGitLab].Files[0])
+		err := generatePipelineDefinition(expectedPath, projectProperties{
+			CiProvider:    ciProviderGitLab,
+			InfraProvider: infraProviderBicep,
+			RepoRoot:      tempDir,
+			HasAppHost:    false,
+			BranchName:    "main",
+			AuthType:      AuthTypeFederated,
+		})
+		assert.NoError(t, err)
+		// should've created the pipeline
+		assert.FileExists(t, expectedPath)
+		// open the file and check the content
+		content, err := os.ReadFile(expectedPath)
+		assert.NoError(t, err)
+		snapshot.SnapshotT(t, normalizeEOL(content))
+	})
+	t.Run("no files - gitlab selected - App host - fed Cred", func(t *testing.T) {
+		tempDir := t.TempDir()
+		expectedPath := filepath.Join(tempDir, pipelineProviderFiles[ciProviderGitLab].Files[0])
+		err := generatePipelineDefinition(expectedPath, projectProperties{
+			CiProvider:    ciProviderGitLab,
+			InfraProvider: infraProviderBicep,
+			RepoRoot:      tempDir,
+			HasAppHost:    true,
+			BranchName:    "main",
+			AuthType:      AuthTypeFederated,
+		})
+		assert.NoError(t, err)
+		// should've created the pipeline
+		assert.FileExists(t, expectedPath)
+		// open the file and check the content
+		content, err := os.ReadFile(expectedPath)
+		assert.NoError(t, err)
+		snapshot.SnapshotT(t, normalizeEOL(content))
+	})
+	t.Run("no files - gitlab selected - no app host - client cred", func(t *testing.T) {
+		tempDir := t.TempDir()
+		expectedPath := filepath.Join(tempDir, pipelineProviderFiles[ciProviderGitLab].Files[0])
+		err := generatePipelineDefinition(expectedPath, projectProperties{
+			CiProvider:    ciProviderGitLab,
+			InfraProvider: infraProviderBicep,
+			RepoRoot:      tempDir,
+			HasAppHost:    false,
+			BranchName:    "main",
+			AuthType:      AuthTypeClientCredentials,
+		})
+		assert.NoError(t, err)
+		// should've created the pipeline
+		assert.FileExists(t, expectedPath)
+		// open the file and check the content
+		content, err := os.ReadFile(expectedPath)
+		assert.NoError(t, err)
+		snapshot.SnapshotT(t, normalizeEOL(content))
+	})
 }
 
 func Test_promptForCiFiles_azureDevOpsDirectory(t *testing.T) {
@@ -757,6 +841,8 @@ func createPipelineManager(
 		"github-scm": NewGitHubScmProvider,
 		"azdo-ci":    NewAzdoCiProvider,
 		"azdo-scm":   NewAzdoScmProvider,
+		"gitlab-ci":  NewGitLabCiProvider,
+		"gitlab-scm": NewGitLabScmProvider,
 	}
 
 	for provider, constructor := range pipelineProviderMap {
@@ -773,7 +859,7 @@ func createPipelineManager(
 		mockContext.Console,
 		args,
 		mockContext.Container,
-		project.NewImportManager(nil),
+		project.NewImportManager(nil, nil),
 		&mockUserConfigManager{},
 	)
 }
@@ -855,6 +941,7 @@ func resetContext(tempDir string, ctx context.Context) *mocks.MockContext {
 func createYamlFiles(t *testing.T, tempDir string, createOptionsAndFileIndex ...interface{}) {
 	shouldCreateGitHub := true
 	shouldCreateAzdo := true
+	shouldCreateGitLab := false
 	fileIndex := 0
 
 	// Determine which providers to create files for and if a fileIndex is provided
@@ -868,6 +955,10 @@ func createYamlFiles(t *testing.T, tempDir string, createOptionsAndFileIndex ...
 			case ciProviderAzureDevOps:
 				shouldCreateAzdo = true
 				shouldCreateGitHub = false
+			case ciProviderGitLab:
+				shouldCreateGitLab = true
+				shouldCreateGitHub = false
+				shouldCreateAzdo = false
 			}
 		case int:
 			fileIndex = v
@@ -881,6 +972,10 @@ func createYamlFiles(t *testing.T, tempDir string, createOptionsAndFileIndex ...
 	if shouldCreateAzdo {
 		createPipelineFiles(t, tempDir, ciProviderAzureDevOps, fileIndex)
 	}
+
+	if shouldCreateGitLab {
+		createPipelineFiles(t, tempDir, ciProviderGitLab, fileIndex)
+	}
 }
 
 // Helper function to create pipeline files
@@ -908,16 +1003,20 @@ func createPipelineFiles(t *testing.T, baseDir string, provider ciProviderType,
 func deleteYamlFiles(t *testing.T, tempDir string, deleteOptions ...ciProviderType) {
 	shouldDeleteGitHub := true
 	shouldDeleteAzdo := true
+	shouldDeleteGitLab := true
 
 	if len(deleteOptions) > 0 {
 		shouldDeleteGitHub = false
 		shouldDeleteAzdo = false
+		shouldDeleteGitLab = false
 		for _, option := range deleteOptions {
 			switch option {
 			case ciProviderGitHubActions:
 				shouldDeleteGitHub = true
 			case ciProviderAzureDevOps:
 				shouldDeleteAzdo = true
+			case ciProviderGitLab:
+				shouldDeleteGitLab = true
 			}
 		}
 	}
@@ -929,6 +1028,10 @@ func deleteYamlFiles(t *testing.T, tempDir string, deleteOptions ...ciProviderTy
 	if shouldDeleteAzdo {
 		deletePipelineFiles(t, tempDir, ciProviderAzureDevOps)
 	}
+
+	if shouldDeleteGitLab {
+		deletePipelineFiles(t, tempDir, ciProviderGitLab)
+	}
 }
 
 // Helper function to delete pipeline files and directories
@@ -948,6 +1051,8 @@ func simulateUserInteraction(mockContext *mocks.MockContext, providerLabel ciPro
 		providerIndex = 0
 	case ciProviderAzureDevOps:
 		providerIndex = 1
+	case ciProviderGitLab:
+		providerIndex = 2
 	default:
 		providerIndex = 0
 	}
@@ -975,6 +1080,9 @@ func verifyProvider(t *testing.T, manager *PipelineManager, providerLabel ciProv
 	case ciProviderAzureDevOps:
 		assert.IsType(t, &AzdoScmProvider{}, manager.scmProvider)
 		assert.IsType(t, &AzdoCiProvider{}, manager.ciProvider)
+	case ciProviderGitLab:
+		assert.IsType(t, &GitLabScmProvider{}, manager.scmProvider)
+		assert.IsType(t, &GitLabCiProvider{}, manager.ciProvider)
 	default:
 		t.Fatalf("%s is not a known pipeline provider", providerLabel)
 	}
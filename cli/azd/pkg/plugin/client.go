@@ -0,0 +1,202 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	osexec "os/exec"
+	"time"
+
+	"go.lsp.dev/jsonrpc2"
+)
+
+// shutdownTimeout is how long the plugin has to exit after the standard input is closed, before being killed
+const shutdownTimeout = 10 * time.Second
+
+// exitErrorTimeout is how long to wait for the plugin to exit after it closed its standard output, to report its exit code
+const exitErrorTimeout = time.Second
+
+// StartOptions are the options used to start a plugin
+type StartOptions struct {
+	// The working directory of the plugin
+	Dir string
+	// The environment variables of the plugin, in the form "key=value"
+	Env []string
+	// Invoked for each progress message reported by the plugin
+	OnProgress func(message string)
+}
+
+// Client is a JSON-RPC connection to a running plugin
+type Client struct {
+	path   string
+	cmd    *osexec.Cmd
+	conn   jsonrpc2.Conn
+	exited chan struct{}
+	// The error returned when waiting for the plugin process, only valid once exited is closed
+	waitErr error
+}
+
+// Start starts the plugin at the specified path and connects to its standard input and output.
+// [Client.Close] must be called to stop the plugin.
+func Start(ctx context.Context, path string, options StartOptions) (*Client, error) {
+	cmd := osexec.Command(path)
+	cmd.Dir = options.Dir
+	cmd.Env = options.Env
+	cmd.Stderr = log.Writer()
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("creating standard input of plugin: %w", err)
+	}
+
+	// Unlike cmd.StdoutPipe, the read end of the pipe is not closed when the plugin exits, so the messages written right
+	// before exiting are not lost.
+	stdout, stdoutWriter, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("creating standard output of plugin: %w", err)
+	}
+	cmd.Stdout = stdoutWriter
+
+	log.Printf("starting plugin '%s'", path)
+	err = cmd.Start()
+	// The plugin process holds its own copy of the write end of the pipe
+	stdoutWriter.Close()
+	if err != nil {
+		stdout.Close()
+		return nil, fmt.Errorf("starting plugin '%s': %w", path, err)
+	}
+
+	client := &Client{
+		path:   path,
+		cmd:    cmd,
+		conn:   jsonrpc2.NewConn(jsonrpc2.NewStream(&stdioPipe{ReadCloser: stdout, WriteCloser: stdin})),
+		exited: make(chan struct{}),
+	}
+
+	go func() {
+		client.waitErr = cmd.Wait()
+		close(client.exited)
+	}()
+
+	client.conn.Go(ctx, func(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
+		if req.Method() != MethodProgress {
+			return jsonrpc2.MethodNotFoundHandler(ctx, reply, req)
+		}
+
+		var params ProgressParams
+		if err := json.Unmarshal(req.Params(), &params); err != nil {
+			log.Printf("ignoring invalid progress notification from plugin '%s': %v", path, err)
+			return reply(ctx, nil, nil)
+		}
+
+		if options.OnProgress != nil {
+			options.OnProgress(params.Message)
+		}
+
+		return reply(ctx, nil, nil)
+	})
+
+	return client, nil
+}
+
+// Call sends a request to the plugin and waits for the response, which is unmarshalled into result.
+// An error is returned when the plugin returns an error or exits before responding.
+func (c *Client) Call(ctx context.Context, method string, params any, result any) error {
+	callCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The pending calls are not completed when the connection fails, stop waiting once the plugin closed its standard
+	// output and all the messages were read.
+	go func() {
+		select {
+		case <-c.conn.Done():
+			cancel()
+		case <-callCtx.Done():
+		}
+	}()
+
+	_, err := c.conn.Call(callCtx, method, params, result)
+	if err == nil {
+		return nil
+	}
+
+	var rpcErr *jsonrpc2.Error
+	switch {
+	case errors.As(err, &rpcErr):
+		return fmt.Errorf("plugin '%s' failed to complete '%s': %s", c.path, method, rpcErr.Message)
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case callCtx.Err() != nil:
+		return fmt.Errorf("plugin '%s' exited before completing '%s': %w", c.path, method, c.exitError())
+	default:
+		return fmt.Errorf("calling '%s' on plugin '%s': %w", method, c.path, err)
+	}
+}
+
+// Close notifies the plugin to shut down, closes its standard input and waits for it to exit.
+// The plugin is killed when it does not exit in a timely manner.
+func (c *Client) Close() error {
+	select {
+	case <-c.conn.Done():
+		// The connection was already closed when the plugin closed its standard output
+	default:
+		notifyCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err := c.conn.Notify(notifyCtx, MethodShutdown, nil); err != nil {
+			log.Printf("failed to notify plugin '%s' to shutdown: %v", c.path, err)
+		}
+
+		if err := c.conn.Close(); err != nil {
+			log.Printf("failed to close connection to plugin '%s': %v", c.path, err)
+		}
+	}
+
+	select {
+	case <-c.exited:
+	case <-time.After(shutdownTimeout):
+		log.Printf("plugin '%s' did not exit after %s, killing it", c.path, shutdownTimeout)
+		if err := c.cmd.Process.Kill(); err != nil {
+			return fmt.Errorf("killing plugin '%s': %w", c.path, err)
+		}
+		<-c.exited
+	}
+
+	return nil
+}
+
+// exitError describes why the connection to the plugin was lost
+func (c *Client) exitError() error {
+	select {
+	case <-c.exited:
+		if c.waitErr != nil {
+			return c.waitErr
+		}
+
+		return errors.New("the plugin exited with code 0")
+	case <-time.After(exitErrorTimeout):
+		if err := c.conn.Err(); err != nil {
+			return err
+		}
+
+		return io.ErrUnexpectedEOF
+	}
+}
+
+// stdioPipe combines the standard output and standard input of the plugin into the stream of the connection
+type stdioPipe struct {
+	io.ReadCloser
+	io.WriteCloser
+}
+
+// Close closes the standard input of the plugin, then the standard output.
+func (p *stdioPipe) Close() error {
+	return errors.Join(p.WriteCloser.Close(), p.ReadCloser.Close())
+}
@@ -0,0 +1,138 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/jsonrpc2"
+)
+
+// testPluginEnvVar makes the test binary act as a plugin, so the client is tested against a real process
+const testPluginEnvVar = "AZD_TEST_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(testPluginEnvVar) == "1" {
+		runTestPlugin()
+		return
+	}
+
+	os.Exit(m.Run())
+}
+
+// runTestPlugin serves the requests sent to the test plugin until its standard input is closed
+func runTestPlugin() {
+	conn := jsonrpc2.NewConn(jsonrpc2.NewStream(&stdioPipe{ReadCloser: os.Stdin, WriteCloser: os.Stdout}))
+	conn.Go(context.Background(), func(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
+		switch req.Method() {
+		case MethodPackage:
+			var params PackageParams
+			if err := json.Unmarshal(req.Params(), &params); err != nil {
+				return reply(ctx, nil, err)
+			}
+
+			for _, message := range []string{"Compressing", "Uploading"} {
+				if err := conn.Notify(ctx, MethodProgress, ProgressParams{Message: message}); err != nil {
+					return reply(ctx, nil, err)
+				}
+			}
+
+			return reply(ctx, PackageResult{
+				PackagePath: params.FrameworkPackage.PackagePath + ".zip",
+				Details:     map[string]any{"service": params.Service.Name},
+			}, nil)
+		case MethodDeploy:
+			return reply(ctx, nil, errors.New("deployment quota exceeded"))
+		case MethodEndpoints:
+			// Crash while handling the request
+			os.Exit(3)
+			return nil
+		case MethodShutdown:
+			return reply(ctx, nil, nil)
+		default:
+			return jsonrpc2.MethodNotFoundHandler(ctx, reply, req)
+		}
+	})
+
+	<-conn.Done()
+	os.Exit(0)
+}
+
+func startTestPlugin(t *testing.T, onProgress func(string)) *Client {
+	testBinary, err := os.Executable()
+	require.NoError(t, err)
+
+	client, err := Start(context.Background(), testBinary, StartOptions{
+		Env:        append(os.Environ(), testPluginEnvVar+"=1"),
+		OnProgress: onProgress,
+	})
+	require.NoError(t, err)
+
+	return client
+}
+
+func Test_Client_Call(t *testing.T) {
+	progress := []string{}
+	client := startTestPlugin(t, func(message string) {
+		progress = append(progress, message)
+	})
+
+	var result PackageResult
+	err := client.Call(context.Background(), MethodPackage, PackageParams{
+		Service:          ServiceConfig{Name: "api"},
+		FrameworkPackage: PackageResult{PackagePath: "dist"},
+	}, &result)
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+
+	require.Equal(t, "dist.zip", result.PackagePath)
+	require.Equal(t, map[string]any{"service": "api"}, result.Details)
+	require.Equal(t, []string{"Compressing", "Uploading"}, progress)
+}
+
+func Test_Client_CallError(t *testing.T) {
+	t.Run("PluginError", func(t *testing.T) {
+		client := startTestPlugin(t, nil)
+		defer client.Close()
+
+		err := client.Call(context.Background(), MethodDeploy, DeployParams{}, nil)
+		require.ErrorContains(t, err, "deployment quota exceeded")
+	})
+
+	t.Run("MethodNotFound", func(t *testing.T) {
+		client := startTestPlugin(t, nil)
+		defer client.Close()
+
+		err := client.Call(context.Background(), MethodInitialize, ServiceParams{}, nil)
+		require.ErrorContains(t, err, MethodInitialize)
+	})
+
+	t.Run("PluginExited", func(t *testing.T) {
+		client := startTestPlugin(t, nil)
+		defer client.Close()
+
+		var endpoints []string
+		err := client.Call(context.Background(), MethodEndpoints, EndpointsParams{}, &endpoints)
+		require.ErrorContains(t, err, "exited before completing")
+		require.ErrorContains(t, err, "exit status 3")
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		client := startTestPlugin(t, nil)
+		defer client.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := client.Call(ctx, MethodPackage, PackageParams{}, nil)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func Test_Start_NotFound(t *testing.T) {
+	_, err := Start(context.Background(), "azd-host-does-not-exist", StartOptions{})
+	require.ErrorContains(t, err, "starting plugin 'azd-host-does-not-exist'")
+}
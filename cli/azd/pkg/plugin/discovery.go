@@ -0,0 +1,51 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	osexec "os/exec"
+	"path/filepath"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+)
+
+// ServiceTargetPrefix is the prefix of the name of the executables implementing a service target
+const ServiceTargetPrefix = "azd-host-"
+
+// ErrNotFound is returned when no plugin is installed for a given name
+var ErrNotFound = errors.New("plugin not found")
+
+// Dir returns the directory where plugins are installed, `plugins` within the user config directory.
+func Dir() (string, error) {
+	configDir, err := config.GetUserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("getting user config directory: %w", err)
+	}
+
+	return filepath.Join(configDir, "plugins"), nil
+}
+
+// FindServiceTarget returns the path to the plugin implementing the specified host, `azd-host-<host>`. The plugins
+// directory is searched first, then the PATH.
+func FindServiceTarget(host string) (string, error) {
+	name := ServiceTargetPrefix + host
+
+	pluginsDir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	// LookPath resolves the executable extensions on Windows, like .exe
+	if path, err := osexec.LookPath(filepath.Join(pluginsDir, name)); err == nil {
+		return path, nil
+	}
+
+	if path, err := osexec.LookPath(name); err == nil {
+		return path, nil
+	}
+
+	return "", fmt.Errorf("%w: '%s' was not found in '%s' or on the PATH", ErrNotFound, name, pluginsDir)
+}
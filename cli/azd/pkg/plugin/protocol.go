@@ -0,0 +1,110 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Package plugin implements the protocol used by azd to delegate work to out-of-process plugins.
+//
+// A service target plugin is an executable named `azd-host-<host>`, started by azd for services whose `host` is not
+// implemented by azd itself. azd and the plugin exchange JSON-RPC 2.0 messages over the standard input and output of
+// the plugin, each message being prefixed by a `Content-Length` header like the Language Server Protocol. The standard
+// error of the plugin is written to the azd debug log.
+//
+// The plugin is started for every operation. azd sends a single request, mirroring a method of the service target
+// interface, then the [MethodShutdown] notification and closes the standard input of the plugin, which is expected to
+// exit. While handling a request, the plugin can send [MethodProgress] notifications to report its progress.
+//
+// The plugin is started in the directory of the service, and the values of the azd environment are available to the
+// plugin as environment variables.
+package plugin
+
+// The methods of the service target protocol
+const (
+	// Initializes the service target for a service. The params are [ServiceParams] and the result is ignored.
+	MethodInitialize = "Initialize"
+	// Gets the tools required to package & deploy a service. The params are [ServiceParams] and the result is a list of
+	// [ExternalTool].
+	MethodRequiredExternalTools = "RequiredExternalTools"
+	// Prepares the artifacts to deploy. The params are [PackageParams] and the result is a [PackageResult].
+	MethodPackage = "Package"
+	// Deploys the artifacts to the target resource. The params are [DeployParams] and the result is a [DeployResult].
+	MethodDeploy = "Deploy"
+	// Gets the endpoints exposed by a service. The params are [EndpointsParams] and the result is a list of URLs.
+	MethodEndpoints = "Endpoints"
+	// Notification sent by azd once the request completed, before closing the standard input of the plugin.
+	MethodShutdown = "Shutdown"
+	// Notification sent by the plugin to report the progress of the current request. The params are [ProgressParams].
+	MethodProgress = "Progress"
+)
+
+// ServiceConfig is the configuration of the service, from azure.yaml
+type ServiceConfig struct {
+	Name     string `json:"name"`
+	Host     string `json:"host"`
+	Language string `json:"language,omitempty"`
+	// The full path to the directory of the azd project
+	ProjectPath string `json:"projectPath"`
+	// The full path to the directory of the service
+	Path string `json:"path"`
+	// The path of the build output, relative to the directory of the service
+	OutputPath string `json:"outputPath,omitempty"`
+	// The custom configuration of the service, from the `config` section of the service
+	Config map[string]any `json:"config,omitempty"`
+}
+
+// TargetResource is the Azure resource a service is deployed to
+type TargetResource struct {
+	SubscriptionId    string `json:"subscriptionId"`
+	ResourceGroupName string `json:"resourceGroupName"`
+	ResourceName      string `json:"resourceName"`
+	ResourceType      string `json:"resourceType"`
+}
+
+// ExternalTool is a tool the plugin requires to be installed
+type ExternalTool struct {
+	Name       string `json:"name"`
+	InstallUrl string `json:"installUrl"`
+	// The command that is looked up on the PATH to check if the tool is installed. Defaults to the name of the tool.
+	Command string `json:"command,omitempty"`
+}
+
+// PackageResult is the result of packaging a service
+type PackageResult struct {
+	PackagePath string `json:"packagePath"`
+	Details     any    `json:"details,omitempty"`
+}
+
+// DeployResult is the result of deploying a service
+type DeployResult struct {
+	TargetResourceId string   `json:"targetResourceId"`
+	Endpoints        []string `json:"endpoints"`
+	Details          any      `json:"details,omitempty"`
+}
+
+// ServiceParams are the params of the requests that only need the service configuration
+type ServiceParams struct {
+	Service ServiceConfig `json:"service"`
+}
+
+// PackageParams are the params of [MethodPackage]
+type PackageParams struct {
+	Service ServiceConfig `json:"service"`
+	// The package produced by the framework service of the service language, ex) the build output of a Node.js app
+	FrameworkPackage PackageResult `json:"frameworkPackage"`
+}
+
+// DeployParams are the params of [MethodDeploy]
+type DeployParams struct {
+	Service        ServiceConfig  `json:"service"`
+	Package        PackageResult  `json:"package"`
+	TargetResource TargetResource `json:"targetResource"`
+}
+
+// EndpointsParams are the params of [MethodEndpoints]
+type EndpointsParams struct {
+	Service        ServiceConfig  `json:"service"`
+	TargetResource TargetResource `json:"targetResource"`
+}
+
+// ProgressParams are the params of [MethodProgress]
+type ProgressParams struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,551 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/internal/scaffold"
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/braydonk/yaml"
+	"github.com/psanford/memfs"
+)
+
+// composeImageResources maps the well-known images of compose services to the resources provisioned in their place.
+var composeImageResources = map[string]ResourceType{
+	"postgres": ResourceTypeDbPostgres,
+	"redis":    ResourceTypeDbRedis,
+	"mongo":    ResourceTypeDbMongo,
+}
+
+// ComposeImporter imports the services and the infrastructure of a Docker Compose file.
+//
+// Each compose service which builds an image, or runs an image which isn't a well-known database, becomes a service of
+// the project hosted in a container app. The compose services running a well-known database image become resources.
+type ComposeImporter struct {
+}
+
+func NewComposeImporter() *ComposeImporter {
+	return &ComposeImporter{}
+}
+
+// CanImport returns true when the path is a Docker Compose file, e.g. compose.yaml or docker-compose.yml.
+func (ci *ComposeImporter) CanImport(ctx context.Context, path string) (bool, error) {
+	name := filepath.Base(path)
+	ext := filepath.Ext(name)
+	if ext != ".yaml" && ext != ".yml" {
+		return false, nil
+	}
+
+	if !strings.HasPrefix(name, "compose") && !strings.HasPrefix(name, "docker-compose") {
+		return false, nil
+	}
+
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return !info.IsDir(), nil
+}
+
+// Services returns the services of the project imported from the compose file of the service.
+func (ci *ComposeImporter) Services(
+	ctx context.Context, p *ProjectConfig, svcConfig *ServiceConfig,
+) (map[string]*ServiceConfig, error) {
+	imported, err := importCompose(p, svcConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return imported.services, nil
+}
+
+// ProjectInfrastructure returns the infrastructure of the compose file of the service, generated in a temporary
+// directory.
+func (ci *ComposeImporter) ProjectInfrastructure(ctx context.Context, svcConfig *ServiceConfig) (*Infra, error) {
+	tmpDir, err := os.MkdirTemp("", "azd-infra")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary directory: %w", err)
+	}
+
+	if err := execComposeInfra(svcConfig.Project, svcConfig, tmpDir); err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return nil, err
+	}
+
+	return &Infra{
+		Options: provisioning.Options{
+			Provider: provisioning.Bicep,
+			Path:     tmpDir,
+			Module:   DefaultModule,
+		},
+		cleanupDir: tmpDir,
+	}, nil
+}
+
+// SynthAllInfrastructure returns the infrastructure of the compose file of the service, in the infra/ folder of the
+// project.
+func (ci *ComposeImporter) SynthAllInfrastructure(
+	ctx context.Context, p *ProjectConfig, svcConfig *ServiceConfig,
+) (fs.FS, error) {
+	tmpDir, err := os.MkdirTemp("", "azd-infra")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := execComposeInfra(p, svcConfig, tmpDir); err != nil {
+		return nil, err
+	}
+
+	rootModuleName := DefaultModule
+	if p.Infra.Module != "" {
+		rootModuleName = p.Infra.Module
+	}
+
+	infraPathPrefix := DefaultPath
+	if p.Infra.Path != "" {
+		infraPathPrefix = p.Infra.Path
+	}
+
+	generatedFS := memfs.New()
+	infraFS := os.DirFS(tmpDir)
+	err = fs.WalkDir(infraFS, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		// The root module is scaffolded as main.bicep, with main.parameters.json
+		target := path
+		if rest, has := strings.CutPrefix(path, DefaultModule+"."); has && filepath.Dir(path) == "." {
+			target = rootModuleName + "." + rest
+		}
+
+		err = generatedFS.MkdirAll(filepath.Join(infraPathPrefix, filepath.Dir(target)), osutil.PermissionDirectoryOwnerOnly)
+		if err != nil {
+			return err
+		}
+
+		contents, err := fs.ReadFile(infraFS, path)
+		if err != nil {
+			return err
+		}
+
+		return generatedFS.WriteFile(filepath.Join(infraPathPrefix, target), contents, osutil.PermissionFile)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generating infra/ folder: %w", err)
+	}
+
+	return generatedFS, nil
+}
+
+// execComposeInfra scaffolds the infrastructure of the compose file of the service in the target directory.
+func execComposeInfra(p *ProjectConfig, svcConfig *ServiceConfig, target string) error {
+	imported, err := importCompose(p, svcConfig)
+	if err != nil {
+		return err
+	}
+
+	spec, err := imported.infraSpec()
+	if err != nil {
+		return err
+	}
+
+	t, err := scaffold.Load()
+	if err != nil {
+		return fmt.Errorf("loading scaffold templates: %w", err)
+	}
+
+	if err := scaffold.ExecInfra(t, spec, target); err != nil {
+		return fmt.Errorf("generating infrastructure: %w", err)
+	}
+
+	return nil
+}
+
+// composeImport holds the services and the resources imported from a compose file.
+type composeImport struct {
+	services  map[string]*ServiceConfig
+	resources map[string]*ResourceConfig
+}
+
+// importCompose maps the services of the compose file of the service to services and resources of the project.
+func importCompose(p *ProjectConfig, svcConfig *ServiceConfig) (*composeImport, error) {
+	composePath := svcConfig.Path()
+	compose, err := readComposeFile(composePath)
+	if err != nil {
+		return nil, err
+	}
+
+	composeDir := filepath.Dir(composePath)
+	imported := &composeImport{
+		services:  map[string]*ServiceConfig{},
+		resources: map[string]*ResourceConfig{},
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(compose.Services)) {
+		service := compose.Services[name]
+
+		if service.Build == nil {
+			if service.Image == "" {
+				return nil, fmt.Errorf("importing %s: service %s must specify build or image", composePath, name)
+			}
+
+			if resourceType, has := composeImageResources[composeImageName(service.Image)]; has {
+				imported.resources[name] = &ResourceConfig{
+					Project: p,
+					Type:    resourceType,
+					Name:    name,
+				}
+				continue
+			}
+		}
+
+		svc := &ServiceConfig{
+			Host: ContainerAppTarget,
+		}
+
+		if service.Build != nil {
+			contextDir := service.Build.Context
+			if contextDir == "" {
+				contextDir = "."
+			}
+			if !filepath.IsAbs(contextDir) {
+				contextDir = filepath.Join(composeDir, contextDir)
+			}
+
+			dockerfile := service.Build.Dockerfile
+			if dockerfile == "" {
+				dockerfile = "Dockerfile"
+			}
+			if !filepath.IsAbs(dockerfile) {
+				dockerfile = filepath.Join(contextDir, dockerfile)
+			}
+
+			relPath, err := filepath.Rel(p.Path, contextDir)
+			if err != nil {
+				return nil, err
+			}
+
+			svc.RelativePath = relPath
+			svc.Language = ServiceLanguageDocker
+			svc.Docker = DockerProjectOptions{
+				Path:      dockerfile,
+				Context:   contextDir,
+				Target:    service.Build.Target,
+				BuildArgs: mapToExpandableStringSlice(service.Build.Args, "="),
+			}
+		} else {
+			relPath, err := filepath.Rel(p.Path, composeDir)
+			if err != nil {
+				return nil, err
+			}
+
+			svc.RelativePath = relPath
+			svc.Image = osutil.NewExpandableString(service.Image)
+		}
+
+		// TODO: Some of this code is duplicated from project.Parse, we should centralize this logic long term.
+		svc.Name = name
+		svc.Project = p
+		svc.EventDispatcher = ext.NewEventDispatcher[ServiceLifecycleEventArgs]()
+
+		svc.Infra.Provider, err = provisioning.ParseProvider(svc.Infra.Provider)
+		if err != nil {
+			return nil, fmt.Errorf("parsing service %s: %w", svc.Name, err)
+		}
+
+		props := ContainerAppProps{}
+		if len(service.Ports) > 0 {
+			props.Port = int(service.Ports[0])
+		} else if len(service.Expose) > 0 {
+			props.Port = int(service.Expose[0])
+		}
+
+		for _, envName := range slices.Sorted(maps.Keys(service.Environment)) {
+			props.Env = append(props.Env, ServiceEnvVar{
+				Name:  envName,
+				Value: service.Environment[envName],
+			})
+		}
+
+		imported.services[name] = svc
+		imported.resources[name] = &ResourceConfig{
+			Project: p,
+			Type:    ResourceTypeHostContainerApp,
+			Name:    name,
+			Props:   props,
+		}
+	}
+
+	// The services which depend on other services are deployed after them, and use the resources they depend on
+	for name, svc := range imported.services {
+		for _, dependency := range compose.Services[name].DependsOn {
+			if _, has := imported.services[dependency]; has {
+				svc.DependsOn = append(svc.DependsOn, dependency)
+			} else if _, has := imported.resources[dependency]; has {
+				svc.Uses = append(svc.Uses, dependency)
+			} else {
+				return nil, fmt.Errorf(
+					"importing %s: service %s depends on unknown service '%s'", composePath, name, dependency)
+			}
+
+			imported.resources[name].Uses = append(imported.resources[name].Uses, dependency)
+		}
+	}
+
+	return imported, nil
+}
+
+// infraSpec returns the infrastructure of the imported resources.
+func (ci *composeImport) infraSpec() (scaffold.InfraSpec, error) {
+	spec := scaffold.InfraSpec{}
+	databases := map[ResourceType]string{}
+
+	for _, name := range slices.Sorted(maps.Keys(ci.resources)) {
+		resource := ci.resources[name]
+		if resource.Type == ResourceTypeHostContainerApp {
+			continue
+		}
+
+		if existing, has := databases[resource.Type]; has {
+			return scaffold.InfraSpec{}, fmt.Errorf(
+				"services %s and %s both run a %s database, only one database of each kind is supported",
+				existing, name, resource.Type)
+		}
+		databases[resource.Type] = name
+
+		switch resource.Type {
+		case ResourceTypeDbPostgres:
+			spec.DbPostgres = &scaffold.DatabasePostgres{DatabaseName: name}
+		case ResourceTypeDbMongo:
+			spec.DbCosmosMongo = &scaffold.DatabaseCosmosMongo{DatabaseName: name}
+		case ResourceTypeDbRedis:
+			spec.DbRedis = &scaffold.DatabaseRedis{}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(ci.resources)) {
+		resource := ci.resources[name]
+		if resource.Type != ResourceTypeHostContainerApp {
+			continue
+		}
+
+		props := resource.Props.(ContainerAppProps)
+		serviceSpec := scaffold.ServiceSpec{
+			Name: name,
+			Port: props.Port,
+		}
+
+		if len(props.Env) > 0 {
+			serviceSpec.Env = map[string]string{}
+			for _, env := range props.Env {
+				serviceSpec.Env[env.Name] = env.Value
+			}
+		}
+
+		for _, use := range resource.Uses {
+			switch ci.resources[use].Type {
+			case ResourceTypeDbPostgres:
+				serviceSpec.DbPostgres = &scaffold.DatabaseReference{DatabaseName: use}
+			case ResourceTypeDbMongo:
+				serviceSpec.DbCosmosMongo = &scaffold.DatabaseReference{DatabaseName: use}
+			case ResourceTypeDbRedis:
+				serviceSpec.DbRedis = &scaffold.DatabaseReference{DatabaseName: use}
+			case ResourceTypeHostContainerApp:
+				if serviceSpec.Frontend == nil {
+					serviceSpec.Frontend = &scaffold.Frontend{}
+				}
+				serviceSpec.Frontend.Backends = append(serviceSpec.Frontend.Backends, scaffold.ServiceReference{Name: use})
+			}
+		}
+
+		// The services used by other services are their backends
+		for _, other := range slices.Sorted(maps.Keys(ci.resources)) {
+			if ci.resources[other].Type == ResourceTypeHostContainerApp && slices.Contains(ci.resources[other].Uses, name) {
+				if serviceSpec.Backend == nil {
+					serviceSpec.Backend = &scaffold.Backend{}
+				}
+				serviceSpec.Backend.Frontends = append(serviceSpec.Backend.Frontends, scaffold.ServiceReference{Name: other})
+			}
+		}
+
+		spec.Services = append(spec.Services, serviceSpec)
+	}
+
+	return spec, nil
+}
+
+// composeImageName returns the name of an image without its registry, namespace, tag and digest, e.g. 'postgres' for
+// 'docker.io/library/postgres:16-alpine'.
+func composeImageName(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	if slash := strings.LastIndex(image, "/"); slash >= 0 {
+		image = image[slash+1:]
+	}
+	image, _, _ = strings.Cut(image, ":")
+
+	return image
+}
+
+// composeFile is the subset of the Compose specification imported by azd.
+type composeFile struct {
+	Services map[string]composeService `yaml:"services"`
+}
+
+type composeService struct {
+	Image       string         `yaml:"image"`
+	Build       *composeBuild  `yaml:"build"`
+	Ports       []composePort  `yaml:"ports"`
+	Expose      []composePort  `yaml:"expose"`
+	Environment composeMapping `yaml:"environment"`
+	DependsOn   composeList    `yaml:"depends_on"`
+}
+
+// composeBuild is the build of a compose service, either the path of its context or the build options.
+type composeBuild struct {
+	Context    string         `yaml:"context"`
+	Dockerfile string         `yaml:"dockerfile"`
+	Target     string         `yaml:"target"`
+	Args       composeMapping `yaml:"args"`
+}
+
+func (b *composeBuild) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		return value.Decode(&b.Context)
+	}
+
+	type rawComposeBuild composeBuild
+	return value.Decode((*rawComposeBuild)(b))
+}
+
+// composePort is the port of the container of a port mapping, e.g. 80 for '127.0.0.1:8080:80/tcp'.
+type composePort int
+
+func (p *composePort) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.MappingNode {
+		var port struct {
+			Target int `yaml:"target"`
+		}
+		if err := value.Decode(&port); err != nil {
+			return err
+		}
+
+		*p = composePort(port.Target)
+		return nil
+	}
+
+	mapping, _, _ := strings.Cut(value.Value, "/")
+	if colon := strings.LastIndex(mapping, ":"); colon >= 0 {
+		mapping = mapping[colon+1:]
+	}
+	// The first port of a range
+	mapping, _, _ = strings.Cut(mapping, "-")
+
+	port, err := strconv.Atoi(mapping)
+	if err != nil {
+		return fmt.Errorf("invalid port '%s'", value.Value)
+	}
+
+	*p = composePort(port)
+	return nil
+}
+
+// composeMapping is a mapping of a compose file, either a map or a list of 'KEY=VALUE' items. Keys without a value take
+// their value from the environment, as an expression like ${KEY} expanded by azd.
+type composeMapping map[string]string
+
+func (m *composeMapping) UnmarshalYAML(value *yaml.Node) error {
+	mapping := composeMapping{}
+
+	if value.Kind == yaml.SequenceNode {
+		var items []string
+		if err := value.Decode(&items); err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			key, val, has := strings.Cut(item, "=")
+			if !has {
+				val = fmt.Sprintf("${%s}", key)
+			}
+			mapping[key] = val
+		}
+
+		*m = mapping
+		return nil
+	}
+
+	var items map[string]*string
+	if err := value.Decode(&items); err != nil {
+		return err
+	}
+
+	for key, val := range items {
+		if val == nil {
+			mapping[key] = fmt.Sprintf("${%s}", key)
+		} else {
+			mapping[key] = *val
+		}
+	}
+
+	*m = mapping
+	return nil
+}
+
+// composeList is a list of names of a compose file, either a list or the keys of a map, like depends_on.
+type composeList []string
+
+func (l *composeList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.SequenceNode {
+		var items []string
+		if err := value.Decode(&items); err != nil {
+			return err
+		}
+
+		*l = items
+		return nil
+	}
+
+	var items map[string]yaml.Node
+	if err := value.Decode(&items); err != nil {
+		return err
+	}
+
+	*l = slices.Sorted(maps.Keys(items))
+	return nil
+}
+
+func readComposeFile(path string) (*composeFile, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading compose file: %w", err)
+	}
+
+	var compose composeFile
+	if err := yaml.Unmarshal(contents, &compose); err != nil {
+		return nil, fmt.Errorf("parsing compose file %s: %w", path, err)
+	}
+
+	return &compose, nil
+}
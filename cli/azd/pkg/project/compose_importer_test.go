@@ -0,0 +1,227 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/stretchr/testify/require"
+)
+
+const testComposeFile = `
+services:
+  web:
+    build: ./web
+    ports:
+      - "8080:3000"
+    environment:
+      - API_URL=http://api:5000
+    depends_on:
+      - api
+  api:
+    build:
+      context: ./api
+      dockerfile: Dockerfile.dev
+      target: runtime
+      args:
+        VERSION: "1.0"
+    expose:
+      - 5000
+    environment:
+      LOG_LEVEL: debug
+      API_KEY:
+    depends_on:
+      db:
+        condition: service_healthy
+      cache:
+        condition: service_started
+  db:
+    image: postgres:16-alpine
+  cache:
+    image: docker.io/library/redis:7
+  proxy:
+    image: nginx:latest
+    ports:
+      - target: 80
+        published: 80
+`
+
+func writeTestComposeProject(t *testing.T) *ProjectConfig {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte(testComposeFile), osutil.PermissionFile)
+	require.NoError(t, err)
+
+	projectConfig := &ProjectConfig{
+		Name: "compose",
+		Path: dir,
+	}
+	projectConfig.Services = map[string]*ServiceConfig{
+		"app": {
+			Name:         "app",
+			Project:      projectConfig,
+			RelativePath: "compose.yaml",
+			Language:     ServiceLanguageDocker,
+			Host:         ContainerAppTarget,
+		},
+	}
+
+	return projectConfig
+}
+
+func Test_ComposeImporter_CanImport(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"compose.yaml", "docker-compose.yml", "Dockerfile", "other.yaml"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte{}, osutil.PermissionFile))
+	}
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"compose.yaml", true},
+		{"docker-compose.yml", true},
+		{"compose.yml", false},
+		{"Dockerfile", false},
+		{"other.yaml", false},
+		{".", false},
+	}
+
+	importer := NewComposeImporter()
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			canImport, err := importer.CanImport(context.Background(), filepath.Join(dir, test.path))
+			require.NoError(t, err)
+			require.Equal(t, test.expected, canImport)
+		})
+	}
+}
+
+func Test_ComposeImporter_Services(t *testing.T) {
+	projectConfig := writeTestComposeProject(t)
+
+	services, err := NewComposeImporter().Services(
+		context.Background(), projectConfig, projectConfig.Services["app"])
+	require.NoError(t, err)
+
+	// The databases are resources rather than services
+	require.Len(t, services, 3)
+	require.NotContains(t, services, "db")
+	require.NotContains(t, services, "cache")
+
+	web := services["web"]
+	require.Equal(t, "web", web.RelativePath)
+	require.Equal(t, ServiceLanguageDocker, web.Language)
+	require.Equal(t, ContainerAppTarget, web.Host)
+	require.Equal(t, filepath.Join(projectConfig.Path, "web", "Dockerfile"), web.Docker.Path)
+	require.Equal(t, []string{"api"}, web.DependsOn)
+	require.Empty(t, web.Uses)
+
+	api := services["api"]
+	require.Equal(t, "api", api.RelativePath)
+	require.Equal(t, filepath.Join(projectConfig.Path, "api", "Dockerfile.dev"), api.Docker.Path)
+	require.Equal(t, filepath.Join(projectConfig.Path, "api"), api.Docker.Context)
+	require.Equal(t, "runtime", api.Docker.Target)
+	require.Equal(t, []osutil.ExpandableString{osutil.NewExpandableString("VERSION=1.0")}, api.Docker.BuildArgs)
+	require.Empty(t, api.DependsOn)
+	require.Equal(t, []string{"cache", "db"}, api.Uses)
+
+	proxy := services["proxy"]
+	require.Equal(t, ".", proxy.RelativePath)
+	require.Equal(t, ServiceLanguageNone, proxy.Language)
+	require.Equal(t, osutil.NewExpandableString("nginx:latest"), proxy.Image)
+}
+
+func Test_ComposeImporter_Resources(t *testing.T) {
+	projectConfig := writeTestComposeProject(t)
+
+	imported, err := importCompose(projectConfig, projectConfig.Services["app"])
+	require.NoError(t, err)
+
+	require.Equal(t, ResourceTypeDbPostgres, imported.resources["db"].Type)
+	require.Equal(t, ResourceTypeDbRedis, imported.resources["cache"].Type)
+
+	web := imported.resources["web"]
+	require.Equal(t, ResourceTypeHostContainerApp, web.Type)
+	require.Equal(t, ContainerAppProps{
+		Port: 3000,
+		Env:  []ServiceEnvVar{{Name: "API_URL", Value: "http://api:5000"}},
+	}, web.Props)
+
+	api := imported.resources["api"]
+	require.Equal(t, ContainerAppProps{
+		Port: 5000,
+		Env: []ServiceEnvVar{
+			{Name: "API_KEY", Value: "${API_KEY}"},
+			{Name: "LOG_LEVEL", Value: "debug"},
+		},
+	}, api.Props)
+	require.Equal(t, []string{"cache", "db"}, api.Uses)
+
+	require.Equal(t, 80, imported.resources["proxy"].Props.(ContainerAppProps).Port)
+
+	spec, err := imported.infraSpec()
+	require.NoError(t, err)
+	require.NotNil(t, spec.DbPostgres)
+	require.Equal(t, "db", spec.DbPostgres.DatabaseName)
+	require.NotNil(t, spec.DbRedis)
+	require.Nil(t, spec.DbCosmosMongo)
+	require.Len(t, spec.Services, 3)
+
+	apiSpec := spec.Services[0]
+	require.Equal(t, "api", apiSpec.Name)
+	require.NotNil(t, apiSpec.DbPostgres)
+	require.NotNil(t, apiSpec.DbRedis)
+	require.Equal(t, "web", apiSpec.Backend.Frontends[0].Name)
+
+	webSpec := spec.Services[2]
+	require.Equal(t, "web", webSpec.Name)
+	require.Equal(t, "api", webSpec.Frontend.Backends[0].Name)
+}
+
+func Test_ImportManager_Compose(t *testing.T) {
+	projectConfig := writeTestComposeProject(t)
+	manager := NewImportManager(nil, NewComposeImporter())
+
+	services, err := manager.ServiceStable(context.Background(), projectConfig)
+	require.NoError(t, err)
+	require.Len(t, services, 3)
+	require.Equal(t, "api", services[0].Name)
+	require.Equal(t, "proxy", services[1].Name)
+	require.Equal(t, "web", services[2].Name)
+
+	synthFS, err := manager.SynthAllInfrastructure(context.Background(), projectConfig)
+	require.NoError(t, err)
+
+	for _, path := range []string{"infra/main.bicep", "infra/main.parameters.json", "infra/resources.bicep"} {
+		_, err := fs.Stat(synthFS, path)
+		require.NoError(t, err, path)
+	}
+
+	parameters, err := fs.ReadFile(synthFS, "infra/main.parameters.json")
+	require.NoError(t, err)
+	require.Contains(t, string(parameters), "LOG_LEVEL")
+
+	infra, err := manager.ProjectInfrastructure(context.Background(), projectConfig)
+	require.NoError(t, err)
+	defer infra.Cleanup()
+	require.FileExists(t, filepath.Join(infra.Options.Path, "main.bicep"))
+}
+
+func Test_ImportManager_Compose_MultipleServices(t *testing.T) {
+	projectConfig := writeTestComposeProject(t)
+	projectConfig.Services["other"] = &ServiceConfig{
+		Name:     "other",
+		Project:  projectConfig,
+		Language: ServiceLanguageJavaScript,
+		Host:     ContainerAppTarget,
+	}
+
+	_, err := NewImportManager(nil, NewComposeImporter()).ServiceStable(context.Background(), projectConfig)
+	require.ErrorIs(t, err, errNoMultipleServicesWithCompose)
+}
@@ -30,6 +30,7 @@ type ContainerHelper struct {
 	env                      *environment.Environment
 	envManager               environment.Manager
 	remoteBuildManager       *containerregistry.RemoteBuildManager
+	ociBuilder               *containerregistry.OciBuilder
 	containerRegistryService azcli.ContainerRegistryService
 	docker                   *docker.Cli
 	clock                    clock.Clock
@@ -43,6 +44,7 @@ func NewContainerHelper(
 	clock clock.Clock,
 	containerRegistryService azcli.ContainerRegistryService,
 	remoteBuildManager *containerregistry.RemoteBuildManager,
+	ociBuilder *containerregistry.OciBuilder,
 	docker *docker.Cli,
 	console input.Console,
 	cloud *cloud.Cloud,
@@ -51,6 +53,7 @@ func NewContainerHelper(
 		env:                      env,
 		envManager:               envManager,
 		remoteBuildManager:       remoteBuildManager,
+		ociBuilder:               ociBuilder,
 		containerRegistryService: containerRegistryService,
 		docker:                   docker,
 		clock:                    clock,
@@ -190,7 +193,7 @@ func (ch *ContainerHelper) LocalImageTag(ctx context.Context, serviceConfig *Ser
 }
 
 func (ch *ContainerHelper) RequiredExternalTools(ctx context.Context, serviceConfig *ServiceConfig) []tools.ExternalTool {
-	if serviceConfig.Docker.RemoteBuild {
+	if serviceConfig.Docker.RemoteBuild || serviceConfig.Docker.Builder == DockerBuilderOci {
 		return []tools.ExternalTool{}
 	}
 
@@ -270,6 +273,8 @@ func (ch *ContainerHelper) Deploy(
 
 	if serviceConfig.Docker.RemoteBuild {
 		remoteImage, err = ch.runRemoteBuild(ctx, serviceConfig, targetResource, progress)
+	} else if serviceConfig.Docker.Builder == DockerBuilderOci {
+		remoteImage, err = ch.runOciBuild(ctx, serviceConfig, packageOutput, targetResource, progress)
 	} else {
 		remoteImage, err = ch.runLocalBuild(ctx, serviceConfig, packageOutput, progress)
 	}
@@ -371,7 +376,7 @@ func (ch *ContainerHelper) runLocalBuild(
 				errSuggestion := &internal.ErrorWithSuggestion{
 					Err: err,
 					//nolint:lll
-					Suggestion: "When pushing to an external registry, ensure you have successfully authenticated by calling 'docker login' and run 'azd deploy' again",
+					Suggestion: fmt.Sprintf("When pushing to an external registry, ensure you have successfully authenticated by calling '%s login' and run 'azd deploy' again", ch.docker.Command()),
 				}
 
 				return "", errSuggestion
@@ -473,6 +478,60 @@ func (ch *ContainerHelper) runRemoteBuild(
 	return imageName, nil
 }
 
+// runOciBuild builds the image without a container engine, layering the package output of the service on the base image,
+// and pushes it to the remote registry. It returns the full remote image name.
+func (ch *ContainerHelper) runOciBuild(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	packageOutput *ServicePackageResult,
+	target *environment.TargetResource,
+	progress *async.Progress[ServiceProgress],
+) (string, error) {
+	if packageOutput == nil || packageOutput.PackagePath == "" {
+		return "", errors.New("missing package output")
+	}
+
+	dockerOptions := getDockerOptionsWithDefaults(serviceConfig.Docker)
+
+	baseImage, err := dockerOptions.BaseImage.Envsubst(ch.env.Getenv)
+	if err != nil {
+		return "", fmt.Errorf("substituting environment variables in base image: %w", err)
+	}
+
+	localImageTag, err := ch.LocalImageTag(ctx, serviceConfig)
+	if err != nil {
+		return "", err
+	}
+
+	imageName, err := ch.RemoteImageTag(ctx, serviceConfig, localImageTag)
+	if err != nil {
+		return "", err
+	}
+
+	progress.SetProgress(NewServiceProgress("Getting container registry credentials"))
+	credentials, err := ch.Credentials(ctx, serviceConfig, target)
+	if err != nil {
+		return "", fmt.Errorf("getting container registry credentials: %w", err)
+	}
+
+	progress.SetProgress(NewServiceProgress("Building and pushing container image"))
+	digest, err := ch.ociBuilder.Build(ctx, containerregistry.OciBuildOptions{
+		BaseImage:  baseImage,
+		Source:     packageOutput.PackagePath,
+		Entrypoint: dockerOptions.Entrypoint,
+		Platform:   dockerOptions.Platform,
+		Image:      imageName,
+		Username:   credentials.Username,
+		Password:   credentials.Password,
+	})
+	if err != nil {
+		return "", fmt.Errorf("building container image of %s: %w", serviceConfig.Name, err)
+	}
+
+	log.Printf("pushed image %s@%s for %s", imageName, digest, serviceConfig.Name)
+	return imageName, nil
+}
+
 type dockerDeployResult struct {
 	RemoteImageTag string
 }
@@ -6,6 +6,7 @@ package project
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
@@ -23,6 +24,7 @@ const (
 	ServiceLanguageTypeScript ServiceLanguageKind = "ts"
 	ServiceLanguagePython     ServiceLanguageKind = "python"
 	ServiceLanguageJava       ServiceLanguageKind = "java"
+	ServiceLanguageGo         ServiceLanguageKind = "go"
 	ServiceLanguageDocker     ServiceLanguageKind = "docker"
 	ServiceLanguageSwa        ServiceLanguageKind = "swa"
 )
@@ -32,6 +34,9 @@ func parseServiceLanguage(kind ServiceLanguageKind) (ServiceLanguageKind, error)
 	if string(kind) == "py" {
 		return ServiceLanguagePython, nil
 	}
+	if string(kind) == "golang" {
+		return ServiceLanguageGo, nil
+	}
 
 	switch kind {
 	case ServiceLanguageNone,
@@ -41,7 +46,8 @@ func parseServiceLanguage(kind ServiceLanguageKind) (ServiceLanguageKind, error)
 		ServiceLanguageJavaScript,
 		ServiceLanguageTypeScript,
 		ServiceLanguagePython,
-		ServiceLanguageJava:
+		ServiceLanguageJava,
+		ServiceLanguageGo:
 		// Excluding ServiceLanguageDocker and ServiceLanguageSwa since it is implicitly derived currently,
 		// and not an actual language
 		return kind, nil
@@ -109,6 +115,40 @@ type CompositeFrameworkService interface {
 	SetSource(inner FrameworkService)
 }
 
+// LocalRunOptions are the options of a service run locally.
+type LocalRunOptions struct {
+	// The variables added to the environment of the service, 'KEY=VALUE' items
+	Env []string
+}
+
+// LocalRunner is implemented by the framework services that can run a service locally, from its source.
+// This is an optional capability of a FrameworkService.
+type LocalRunner interface {
+	// Runs the service, writing its output to the writer, until the service exits or the context is cancelled.
+	RunLocal(ctx context.Context, serviceConfig *ServiceConfig, options LocalRunOptions, writer io.Writer) error
+}
+
+// GetLocalRunner returns the LocalRunner of the framework service, if it has one. The services of composite framework
+// services, like the services built into containers, are run by the framework service of their source.
+func GetLocalRunner(frameworkService FrameworkService) (LocalRunner, bool) {
+	for frameworkService != nil {
+		if runner, ok := frameworkService.(LocalRunner); ok {
+			return runner, true
+		}
+
+		switch composite := frameworkService.(type) {
+		case *dockerProject:
+			frameworkService = composite.framework
+		case *swaProject:
+			frameworkService = composite.framework
+		default:
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
 func validatePackageOutput(packagePath string) error {
 	entries, err := os.ReadDir(packagePath)
 	if err != nil && os.IsNotExist(err) {
@@ -33,6 +33,17 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+// DockerBuilder is the kind of builder building the container image of a service.
+type DockerBuilder string
+
+const (
+	// The image is built by the container engine from a Dockerfile. This is the default.
+	DockerBuilderEngine DockerBuilder = ""
+	// The image is built in-process, without a container engine, by layering the build output of the service on a base
+	// image.
+	DockerBuilderOci DockerBuilder = "oci"
+)
+
 type DockerProjectOptions struct {
 	Path        string                    `yaml:"path,omitempty"        json:"path,omitempty"`
 	Context     string                    `yaml:"context,omitempty"     json:"context,omitempty"`
@@ -43,6 +54,11 @@ type DockerProjectOptions struct {
 	Tag         osutil.ExpandableString   `yaml:"tag,omitempty"         json:"tag,omitempty"`
 	RemoteBuild bool                      `yaml:"remoteBuild,omitempty" json:"remoteBuild,omitempty"`
 	BuildArgs   []osutil.ExpandableString `yaml:"buildArgs,omitempty"   json:"buildArgs,omitempty"`
+	Builder     DockerBuilder             `yaml:"builder,omitempty"     json:"builder,omitempty"`
+	// The base image of the images built by the oci builder
+	BaseImage osutil.ExpandableString `yaml:"baseImage,omitempty"  json:"baseImage,omitempty"`
+	// The entrypoint of the images built by the oci builder. The entrypoint of the base image is kept when empty.
+	Entrypoint []string `yaml:"entrypoint,omitempty" json:"entrypoint,omitempty"`
 	// not supported from azure.yaml directly yet. Adding it for Aspire to use it, initially.
 	// Aspire would pass the secret keys, which are env vars that azd will set just to run docker build.
 	BuildSecrets []string `yaml:"-"                     json:"-"`
@@ -164,7 +180,7 @@ func (p *dockerProject) Requirements() FrameworkRequirements {
 
 // Gets the required external tools for the project
 func (p *dockerProject) RequiredExternalTools(_ context.Context, sc *ServiceConfig) []tools.ExternalTool {
-	if sc.Docker.RemoteBuild {
+	if sc.Docker.RemoteBuild || sc.Docker.Builder == DockerBuilderOci {
 		return []tools.ExternalTool{}
 	}
 
@@ -203,6 +219,11 @@ func (p *dockerProject) Build(
 		return &ServiceBuildResult{Restore: restoreOutput}, nil
 	}
 
+	// The oci builder layers the build output of the service on the base image when the image is pushed
+	if serviceConfig.Docker.Builder == DockerBuilderOci {
+		return p.framework.Build(ctx, serviceConfig, restoreOutput, progress)
+	}
+
 	dockerOptions := getDockerOptionsWithDefaults(serviceConfig.Docker)
 
 	resolveParameters := func(source []string) ([]string, error) {
@@ -299,6 +320,10 @@ func (p *dockerProject) Build(
 	// 1. Environment variables from the host
 	// 2. Environment variables from the service configuration
 	// 3. Environment variables from the docker configuration
+	if err := p.env.CheckSecrets(); err != nil {
+		return nil, err
+	}
+
 	dockerEnv := []string{}
 	dockerEnv = append(dockerEnv, os.Environ()...)
 	dockerEnv = append(dockerEnv, p.env.Environ()...)
@@ -351,6 +376,10 @@ func (p *dockerProject) Package(
 		return &ServicePackageResult{Build: buildOutput}, nil
 	}
 
+	if serviceConfig.Docker.Builder == DockerBuilderOci {
+		return p.ociPackage(ctx, serviceConfig, buildOutput)
+	}
+
 	var imageId string
 
 	if buildOutput != nil {
@@ -406,6 +435,35 @@ func (p *dockerProject) Package(
 	}, nil
 }
 
+// ociPackage packages the build output of the service, which the oci builder copies to the image when it is pushed.
+func (p *dockerProject) ociPackage(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	buildOutput *ServiceBuildResult,
+) (*ServicePackageResult, error) {
+	packagePath := serviceConfig.Path()
+	if buildOutput != nil && buildOutput.BuildOutputPath != "" {
+		packagePath = buildOutput.BuildOutputPath
+	}
+
+	if !filepath.IsAbs(packagePath) {
+		packagePath = filepath.Join(serviceConfig.Path(), packagePath)
+	}
+
+	imageWithTag, err := p.containerHelper.LocalImageTag(ctx, serviceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("generating local image tag: %w", err)
+	}
+
+	return &ServicePackageResult{
+		Build:       buildOutput,
+		PackagePath: packagePath,
+		Details: &dockerPackageResult{
+			TargetImage: imageWithTag,
+		},
+	}, nil
+}
+
 // Default builder image to produce container images from source, needn't java jdk storage, use the standard bp
 const DefaultBuilderImage = "mcr.microsoft.com/oryx/builder:debian-bullseye-20240424.1"
 
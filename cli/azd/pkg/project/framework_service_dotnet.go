@@ -6,6 +6,7 @@ package project
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -186,6 +187,21 @@ func (dp *dotnetProject) Package(
 	}, nil
 }
 
+// Runs the .NET project
+func (dp *dotnetProject) RunLocal(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	options LocalRunOptions,
+	writer io.Writer,
+) error {
+	projFile, err := findProjectFile(serviceConfig.Name, serviceConfig.Path())
+	if err != nil {
+		return err
+	}
+
+	return dp.dotnetCli.Run(ctx, projFile, options.Env, writer)
+}
+
 func (dp *dotnetProject) setUserSecretsFromOutputs(
 	ctx context.Context,
 	serviceConfig *ServiceConfig,
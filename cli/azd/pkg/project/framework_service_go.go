@@ -0,0 +1,250 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/docker"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/golang"
+	"github.com/otiai10/copy"
+)
+
+// The default, conventional name of the executable of a Go service.
+// On App Service, the startup command of the web app runs this executable, i.e. `./app`.
+const GoExecutableName = "app"
+
+type goProject struct {
+	env *environment.Environment
+	cli *golang.Cli
+}
+
+// NewGoProject creates a new instance of a Go project
+func NewGoProject(cli *golang.Cli, env *environment.Environment) FrameworkService {
+	return &goProject{
+		env: env,
+		cli: cli,
+	}
+}
+
+func (gp *goProject) Requirements() FrameworkRequirements {
+	return FrameworkRequirements{
+		// The executable is compiled during build, which downloads the missing modules
+		Package: FrameworkPackageRequirements{
+			RequireRestore: false,
+			RequireBuild:   true,
+		},
+	}
+}
+
+// Gets the required external tools for the project
+func (gp *goProject) RequiredExternalTools(_ context.Context, _ *ServiceConfig) []tools.ExternalTool {
+	return []tools.ExternalTool{gp.cli}
+}
+
+// Initializes the Go project
+func (gp *goProject) Initialize(ctx context.Context, serviceConfig *ServiceConfig) error {
+	return nil
+}
+
+// Restores the project dependencies by downloading the modules listed in go.mod
+func (gp *goProject) Restore(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceRestoreResult, error) {
+	progress.SetProgress(NewServiceProgress("Downloading Go modules"))
+	if err := gp.cli.ModDownload(ctx, serviceConfig.Path()); err != nil {
+		return nil, err
+	}
+
+	return &ServiceRestoreResult{}, nil
+}
+
+// Builds the executable of the project, cross-compiled for the OS and architecture of the service host.
+// The build output path is a directory containing only the executable.
+func (gp *goProject) Build(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	restoreOutput *ServiceRestoreResult,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceBuildResult, error) {
+	goos, goarch := goTargetPlatform(serviceConfig)
+
+	executableRelPath, err := goExecutablePath(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if goos == "windows" && filepath.Ext(executableRelPath) == "" {
+		executableRelPath += ".exe"
+	}
+
+	buildDest, err := os.MkdirTemp("", "azd")
+	if err != nil {
+		return nil, fmt.Errorf("creating build directory for %s: %w", serviceConfig.Name, err)
+	}
+
+	executablePath := filepath.Join(buildDest, executableRelPath)
+	if err := os.MkdirAll(filepath.Dir(executablePath), osutil.PermissionDirectory); err != nil {
+		return nil, fmt.Errorf("creating build directory for %s: %w", serviceConfig.Name, err)
+	}
+
+	progress.SetProgress(NewServiceProgress(fmt.Sprintf("Building Go executable for %s/%s", goos, goarch)))
+	err = gp.cli.Build(ctx, serviceConfig.Path(), golang.BuildOptions{
+		OS:     goos,
+		Arch:   goarch,
+		Output: executablePath,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServiceBuildResult{
+		Restore:         restoreOutput,
+		BuildOutputPath: buildDest,
+	}, nil
+}
+
+// Packages the executable with the other files of the project, like static assets or the host.json and function.json
+// files of a Functions custom handler. The Go source files are not packaged.
+func (gp *goProject) Package(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	buildOutput *ServiceBuildResult,
+	progress *async.Progress[ServiceProgress],
+) (*ServicePackageResult, error) {
+	if buildOutput == nil || buildOutput.BuildOutputPath == "" {
+		return nil, fmt.Errorf("service '%s' must be built before it is packaged", serviceConfig.Name)
+	}
+
+	packageDest, err := os.MkdirTemp("", "azd")
+	if err != nil {
+		return nil, fmt.Errorf("creating package directory for %s: %w", serviceConfig.Name, err)
+	}
+
+	packageSource := serviceConfig.Path()
+	if serviceConfig.OutputPath != "" {
+		packageSource = filepath.Join(packageSource, serviceConfig.OutputPath)
+	}
+
+	// The executable is usually listed in .gitignore
+	ignoreMatcher, err := newIgnoreMatcher(serviceConfig, packageSource, false)
+	if err != nil {
+		return nil, err
+	}
+
+	progress.SetProgress(NewServiceProgress("Copying deployment package"))
+	excluded, err := buildForZip(
+		packageSource,
+		packageDest,
+		buildForZipOptions{
+			excludeConditions: []excludeDirEntryCondition{
+				excludeGoSource,
+				excludeGoVendor,
+			},
+			ignoreMatcher: ignoreMatcher,
+		})
+	if err != nil {
+		return nil, fmt.Errorf("packaging for %s: %w", serviceConfig.Name, err)
+	}
+
+	// The executable is copied after the files of the project so it is never replaced by a file with the same name
+	if err := copy.Copy(buildOutput.BuildOutputPath, packageDest); err != nil {
+		return nil, fmt.Errorf("copying executable to package directory: %w", err)
+	}
+
+	if err := validatePackageOutput(packageDest); err != nil {
+		return nil, err
+	}
+
+	return &ServicePackageResult{
+		Build:         buildOutput,
+		PackagePath:   packageDest,
+		ExcludedFiles: excluded,
+	}, nil
+}
+
+// goTargetPlatform gets the OS and architecture the executable is compiled for.
+// App Service and Azure Functions run the executable on Linux x64 workers. Container hosts use the platform of the image.
+func goTargetPlatform(serviceConfig *ServiceConfig) (goos string, goarch string) {
+	platform := docker.DefaultPlatform
+	if serviceConfig.Host.RequiresContainer() && serviceConfig.Docker.Platform != "" {
+		platform = serviceConfig.Docker.Platform
+	}
+
+	// The platform may specify a variant, like linux/arm64/v8, which doesn't apply to the Go toolchain
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 {
+		return "linux", "amd64"
+	}
+
+	return parts[0], parts[1]
+}
+
+// goExecutablePath gets the path of the executable of the service, relative to the package. For Azure Functions, the
+// executable is the custom handler declared in host.json.
+func goExecutablePath(serviceConfig *ServiceConfig) (string, error) {
+	if serviceConfig.Host != AzureFunctionTarget {
+		return GoExecutableName, nil
+	}
+
+	hostJsonPath := filepath.Join(serviceConfig.Path(), "host.json")
+	hostJson, err := os.ReadFile(hostJsonPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return GoExecutableName, nil
+	} else if err != nil {
+		return "", fmt.Errorf("reading %s: %w", hostJsonPath, err)
+	}
+
+	var functionHost struct {
+		CustomHandler struct {
+			Description struct {
+				DefaultExecutablePath string `json:"defaultExecutablePath"`
+			} `json:"description"`
+		} `json:"customHandler"`
+	}
+
+	if err := json.Unmarshal(hostJson, &functionHost); err != nil {
+		return "", fmt.Errorf("parsing %s: %w", hostJsonPath, err)
+	}
+
+	executablePath := functionHost.CustomHandler.Description.DefaultExecutablePath
+	if executablePath == "" {
+		return "", fmt.Errorf(
+			"%s does not declare the executable of the custom handler in 'customHandler.description.defaultExecutablePath'",
+			hostJsonPath,
+		)
+	}
+
+	executablePath = filepath.FromSlash(executablePath)
+	if !filepath.IsLocal(executablePath) {
+		return "", fmt.Errorf("the custom handler executable '%s' must be within the function app directory", executablePath)
+	}
+
+	return executablePath, nil
+}
+
+func excludeGoSource(path string, file os.FileInfo) bool {
+	if file.IsDir() {
+		return false
+	}
+
+	name := file.Name()
+	return filepath.Ext(name) == ".go" || name == "go.mod" || name == "go.sum" || name == "go.work" || name == "go.work.sum"
+}
+
+func excludeGoVendor(path string, file os.FileInfo) bool {
+	return file.IsDir() && file.Name() == "vendor"
+}
@@ -0,0 +1,130 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/golang"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/ostest"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GoProject_Build(t *testing.T) {
+	tests := []struct {
+		name           string
+		host           ServiceTargetKind
+		platform       string
+		hostJson       string
+		wantExecutable string
+		wantEnv        []string
+	}{
+		{
+			name:           "AppService",
+			host:           AppServiceTarget,
+			wantExecutable: "app",
+			wantEnv:        []string{"CGO_ENABLED=0", "GOOS=linux", "GOARCH=amd64"},
+		},
+		{
+			name:           "ContainerAppPlatform",
+			host:           ContainerAppTarget,
+			platform:       "linux/arm64/v8",
+			wantExecutable: "app",
+			wantEnv:        []string{"CGO_ENABLED=0", "GOOS=linux", "GOARCH=arm64"},
+		},
+		{
+			name:           "FunctionCustomHandler",
+			host:           AzureFunctionTarget,
+			hostJson:       `{"customHandler": {"description": {"defaultExecutablePath": "bin/handler"}}}`,
+			wantExecutable: filepath.Join("bin", "handler"),
+			wantEnv:        []string{"CGO_ENABLED=0", "GOOS=linux", "GOARCH=amd64"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ostest.Chdir(t, t.TempDir())
+
+			var buildArgs exec.RunArgs
+			mockContext := mocks.NewMockContext(context.Background())
+			mockContext.CommandRunner.
+				When(func(args exec.RunArgs, command string) bool {
+					return strings.Contains(command, "go build")
+				}).
+				RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+					buildArgs = args
+					return exec.NewRunResult(0, "", ""), nil
+				})
+
+			serviceConfig := createTestServiceConfig("./src/api", tt.host, ServiceLanguageGo)
+			serviceConfig.Docker.Platform = tt.platform
+			require.NoError(t, os.MkdirAll(serviceConfig.Path(), osutil.PermissionDirectory))
+			if tt.hostJson != "" {
+				err := os.WriteFile(
+					filepath.Join(serviceConfig.Path(), "host.json"), []byte(tt.hostJson), osutil.PermissionFile)
+				require.NoError(t, err)
+			}
+
+			goProject := NewGoProject(golang.NewCli(mockContext.CommandRunner), environment.New("test"))
+			result, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServiceBuildResult, error) {
+				return goProject.Build(*mockContext.Context, serviceConfig, nil, progress)
+			})
+			require.NoError(t, err)
+			require.NotEmpty(t, result.BuildOutputPath)
+
+			require.Equal(t, serviceConfig.Path(), buildArgs.Cwd)
+			require.Equal(t,
+				[]string{"build", "-o", filepath.Join(result.BuildOutputPath, tt.wantExecutable), "."},
+				buildArgs.Args,
+			)
+			require.Equal(t, tt.wantEnv, buildArgs.Env)
+		})
+	}
+}
+
+func Test_GoProject_Package(t *testing.T) {
+	ostest.Chdir(t, t.TempDir())
+	mockContext := mocks.NewMockContext(context.Background())
+
+	serviceConfig := createTestServiceConfig("./src/api", AzureFunctionTarget, ServiceLanguageGo)
+	require.NoError(t, os.MkdirAll(filepath.Join(serviceConfig.Path(), "hello"), osutil.PermissionDirectory))
+	for _, file := range []string{"host.json", "go.mod", "go.sum", "main.go", "hello/function.json"} {
+		err := os.WriteFile(filepath.Join(serviceConfig.Path(), file), []byte("{}"), osutil.PermissionFile)
+		require.NoError(t, err)
+	}
+
+	buildOutputPath := t.TempDir()
+	err := os.WriteFile(filepath.Join(buildOutputPath, "handler"), []byte("executable"), osutil.PermissionExecutableFile)
+	require.NoError(t, err)
+
+	goProject := NewGoProject(golang.NewCli(mockContext.CommandRunner), environment.New("test"))
+	result, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServicePackageResult, error) {
+		return goProject.Package(
+			*mockContext.Context,
+			serviceConfig,
+			&ServiceBuildResult{
+				BuildOutputPath: buildOutputPath,
+			},
+			progress,
+		)
+	})
+	require.NoError(t, err)
+
+	for _, file := range []string{"handler", "host.json", "hello/function.json"} {
+		require.FileExists(t, filepath.Join(result.PackagePath, file))
+	}
+
+	for _, file := range []string{"go.mod", "go.sum", "main.go"} {
+		require.NoFileExists(t, filepath.Join(result.PackagePath, file))
+	}
+}
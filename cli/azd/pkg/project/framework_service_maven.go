@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -177,6 +178,16 @@ func (m *mavenProject) Package(
 // funcAppDir returns the directory of the function app packaged by azure-functions-maven-plugin for the given service.
 //
 // The app is typically packaged under target/azure-functions.
+// Runs the Spring Boot application of the maven project
+func (m *mavenProject) RunLocal(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	options LocalRunOptions,
+	writer io.Writer,
+) error {
+	return m.mavenCli.SpringBootRun(ctx, serviceConfig.Path(), options.Env, writer)
+}
+
 func (m *mavenProject) funcAppDir(ctx context.Context, svc *ServiceConfig) (string, error) {
 	svcPath := svc.Path()
 	// The staging directory for azure-functions-maven-plugin is target/azure-functions.
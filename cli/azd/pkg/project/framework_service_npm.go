@@ -6,6 +6,7 @@ package project
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -125,16 +126,23 @@ func (np *npmProject) Package(
 		)
 	}
 
-	progress.SetProgress(NewServiceProgress("Copying deployment package"))
+	// The package source is the build output, or the project itself including its build output when there is no dist
+	ignoreMatcher, err := newIgnoreMatcher(serviceConfig, packageSource, false)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := buildForZip(
+	progress.SetProgress(NewServiceProgress("Copying deployment package"))
+	excluded, err := buildForZip(
 		packageSource,
 		packageDest,
 		buildForZipOptions{
 			excludeConditions: []excludeDirEntryCondition{
 				excludeNodeModules,
 			},
-		}); err != nil {
+			ignoreMatcher: ignoreMatcher,
+		})
+	if err != nil {
 		return nil, fmt.Errorf("packaging for %s: %w", serviceConfig.Name, err)
 	}
 
@@ -143,11 +151,22 @@ func (np *npmProject) Package(
 	}
 
 	return &ServicePackageResult{
-		Build:       buildOutput,
-		PackagePath: packageDest,
+		Build:         buildOutput,
+		PackagePath:   packageDest,
+		ExcludedFiles: excluded,
 	}, nil
 }
 
+// Runs the start script of the NPM project
+func (np *npmProject) RunLocal(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	options LocalRunOptions,
+	writer io.Writer,
+) error {
+	return np.cli.Start(ctx, serviceConfig.Path(), options.Env, writer)
+}
+
 func excludeNodeModules(path string, file os.FileInfo) bool {
 	return file.IsDir() && file.Name() == "node_modules"
 }
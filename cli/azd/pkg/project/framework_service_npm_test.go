@@ -127,3 +127,47 @@ func Test_NpmProject_Package(t *testing.T) {
 		runArgs.Args,
 	)
 }
+
+func Test_NpmProject_Package_GitIgnoredBuildOutput(t *testing.T) {
+	tempDir := t.TempDir()
+	ostest.Chdir(t, tempDir)
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.
+		When(func(args exec.RunArgs, command string) bool {
+			return strings.Contains(command, "npm run build")
+		}).
+		Respond(exec.NewRunResult(0, "", ""))
+
+	env := environment.New("test")
+	npmCli := npm.NewCli(mockContext.CommandRunner)
+	serviceConfig := createTestServiceConfig("./src/web", AppServiceTarget, ServiceLanguageTypeScript)
+
+	// Without dist, the project is packaged with its build output, which is listed in .gitignore
+	files := map[string]string{
+		"package.json":          "{}",
+		".gitignore":            "node_modules/\nbuild/\n.next/\n",
+		".azdignore":            "*.test.js\n",
+		"build/index.js":        "",
+		".next/server/app.js":   "",
+		"node_modules/lib/a.js": "",
+		"src/index.test.js":     "",
+	}
+	for path, contents := range files {
+		fullPath := filepath.Join(serviceConfig.Path(), filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), osutil.PermissionDirectory))
+		require.NoError(t, os.WriteFile(fullPath, []byte(contents), osutil.PermissionFile))
+	}
+
+	npmProject := NewNpmProject(npmCli, env)
+	result, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServicePackageResult, error) {
+		return npmProject.Package(*mockContext.Context, serviceConfig, &ServiceBuildResult{}, progress)
+	})
+	require.NoError(t, err)
+	defer os.RemoveAll(result.PackagePath)
+
+	require.FileExists(t, filepath.Join(result.PackagePath, "build", "index.js"))
+	require.FileExists(t, filepath.Join(result.PackagePath, ".next", "server", "app.js"))
+	require.NoFileExists(t, filepath.Join(result.PackagePath, "src", "index.test.js"))
+	require.NoDirExists(t, filepath.Join(result.PackagePath, "node_modules"))
+}
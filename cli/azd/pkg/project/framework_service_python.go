@@ -5,7 +5,9 @@ package project
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -126,8 +128,13 @@ func (pp *pythonProject) Package(
 		return nil, fmt.Errorf("package source '%s' is empty or does not exist", packageSource)
 	}
 
+	ignoreMatcher, err := newIgnoreMatcher(serviceConfig, packageSource, true)
+	if err != nil {
+		return nil, err
+	}
+
 	progress.SetProgress(NewServiceProgress("Copying deployment package"))
-	if err := buildForZip(
+	excluded, err := buildForZip(
 		packageSource,
 		packageDest,
 		buildForZipOptions{
@@ -135,8 +142,9 @@ func (pp *pythonProject) Package(
 				excludeVirtualEnv,
 				excludePyCache,
 			},
-		}); err != nil {
-
+			ignoreMatcher: ignoreMatcher,
+		})
+	if err != nil {
 		return nil, fmt.Errorf("packaging for %s: %w", serviceConfig.Name, err)
 	}
 
@@ -145,11 +153,40 @@ func (pp *pythonProject) Package(
 	}
 
 	return &ServicePackageResult{
-		Build:       buildOutput,
-		PackagePath: packageDest,
+		Build:         buildOutput,
+		PackagePath:   packageDest,
+		ExcludedFiles: excluded,
 	}, nil
 }
 
+// pythonEntryPoints are the arguments running the conventional entry points of Python apps, by order of precedence
+var pythonEntryPoints = [][]string{
+	{"main.py"},
+	{"app.py"},
+	{"manage.py", "runserver"},
+}
+
+// Runs the entry point of the Python project with the Python of its virtual environment
+func (pp *pythonProject) RunLocal(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	options LocalRunOptions,
+	writer io.Writer,
+) error {
+	vEnvName := pp.getVenvName(serviceConfig)
+	if !isPythonVirtualEnv(filepath.Join(serviceConfig.Path(), vEnvName)) {
+		return fmt.Errorf("the virtual environment of the service was not found, run `azd restore %s`", serviceConfig.Name)
+	}
+
+	for _, args := range pythonEntryPoints {
+		if _, err := os.Stat(filepath.Join(serviceConfig.Path(), args[0])); err == nil {
+			return pp.cli.RunApp(ctx, serviceConfig.Path(), vEnvName, args, options.Env, writer)
+		}
+	}
+
+	return errors.New("no entry point was found in the service directory: expected main.py, app.py or manage.py")
+}
+
 func isPythonVirtualEnv(path string) bool {
 	// check if `pyvenv.cfg` is within the folder
 	if _, err := os.Stat(filepath.Join(path, "pyvenv.cfg")); err == nil {
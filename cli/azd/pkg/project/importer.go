@@ -17,12 +17,14 @@ import (
 )
 
 type ImportManager struct {
-	dotNetImporter *DotNetImporter
+	dotNetImporter  *DotNetImporter
+	composeImporter *ComposeImporter
 }
 
-func NewImportManager(dotNetImporter *DotNetImporter) *ImportManager {
+func NewImportManager(dotNetImporter *DotNetImporter, composeImporter *ComposeImporter) *ImportManager {
 	return &ImportManager{
-		dotNetImporter: dotNetImporter,
+		dotNetImporter:  dotNetImporter,
+		composeImporter: composeImporter,
 	}
 }
 
@@ -47,13 +49,62 @@ var (
 
 	errAppHostMustTargetContainerApp = fmt.Errorf(
 		"Aspire services must be configured to target the container app host at this time.")
+
+	errNoMultipleServicesWithCompose = fmt.Errorf(
+		"a project may only contain a single Docker Compose service and no other services at this time.")
+
+	errComposeMustTargetContainerApp = fmt.Errorf(
+		"Docker Compose services must be configured to target the container app host at this time.")
 )
 
+// composeService returns the service of the project importing a Docker Compose file, if any.
+func (im *ImportManager) composeService(ctx context.Context, projectConfig *ProjectConfig) (*ServiceConfig, error) {
+	for _, svcConfig := range projectConfig.Services {
+		if svcConfig.Language != ServiceLanguageDocker {
+			continue
+		}
+
+		if canImport, err := im.composeImporter.CanImport(ctx, svcConfig.Path()); canImport {
+			if len(projectConfig.Services) != 1 {
+				return nil, errNoMultipleServicesWithCompose
+			}
+
+			if svcConfig.Host != ContainerAppTarget {
+				return nil, errComposeMustTargetContainerApp
+			}
+
+			return svcConfig, nil
+		} else if err != nil {
+			log.Printf("error checking if %s is a compose file: %v", svcConfig.Path(), err)
+		}
+	}
+
+	return nil, nil
+}
+
 // Retrieves the list of services in the project, in a stable ordering that is deterministic.
 func (im *ImportManager) ServiceStable(ctx context.Context, projectConfig *ProjectConfig) ([]*ServiceConfig, error) {
 	allServices := make(map[string]*ServiceConfig)
 
+	composeSvc, err := im.composeService(ctx, projectConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if composeSvc != nil {
+		services, err := im.composeImporter.Services(ctx, projectConfig, composeSvc)
+		if err != nil {
+			return nil, fmt.Errorf("importing services: %w", err)
+		}
+
+		allServices = services
+	}
+
 	for name, svcConfig := range projectConfig.Services {
+		if svcConfig == composeSvc {
+			continue
+		}
+
 		if svcConfig.Language == ServiceLanguageDotNet {
 			if canImport, err := im.dotNetImporter.CanImport(ctx, svcConfig.Path()); canImport {
 				if len(projectConfig.Services) != 1 {
@@ -144,6 +195,15 @@ func (im *ImportManager) ProjectInfrastructure(ctx context.Context, projectConfi
 		}, nil
 	}
 
+	composeSvc, err := im.composeService(ctx, projectConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if composeSvc != nil {
+		return im.composeImporter.ProjectInfrastructure(ctx, composeSvc)
+	}
+
 	for _, svcConfig := range projectConfig.Services {
 		if svcConfig.Language == ServiceLanguageDotNet {
 			if canImport, err := im.dotNetImporter.CanImport(ctx, svcConfig.Path()); canImport {
@@ -181,6 +241,15 @@ func pathHasModule(path, module string) (bool, error) {
 }
 
 func (im *ImportManager) SynthAllInfrastructure(ctx context.Context, projectConfig *ProjectConfig) (fs.FS, error) {
+	composeSvc, err := im.composeService(ctx, projectConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if composeSvc != nil {
+		return im.composeImporter.SynthAllInfrastructure(ctx, projectConfig, composeSvc)
+	}
+
 	for _, svcConfig := range projectConfig.Services {
 		if svcConfig.Language == ServiceLanguageDotNet {
 			if len(projectConfig.Services) != 1 {
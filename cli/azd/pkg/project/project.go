@@ -3,6 +3,7 @@ package project
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -122,6 +123,16 @@ func Parse(ctx context.Context, yamlContent string) (*ProjectConfig, error) {
 			return nil, fmt.Errorf("parsing service %s: must specify language or image", svc.Name)
 		}
 
+		if err := validateDockerBuilder(svc.Docker); err != nil {
+			return nil, fmt.Errorf("parsing service %s: %w", svc.Name, err)
+		}
+
+		// Only container apps route the traffic to their new revisions
+		if svc.Deploy.Strategy != nil && svc.Host != ContainerAppTarget {
+			return nil, fmt.Errorf(
+				"parsing service %s: deploy.strategy is only supported by the '%s' host", svc.Name, ContainerAppTarget)
+		}
+
 		if strings.ContainsRune(svc.RelativePath, '\\') && !strings.ContainsRune(svc.RelativePath, '/') {
 			svc.RelativePath = strings.ReplaceAll(svc.RelativePath, "\\", "/")
 		}
@@ -140,9 +151,32 @@ func Parse(ctx context.Context, yamlContent string) (*ProjectConfig, error) {
 		svc.Project = &projectConfig
 	}
 
+	if err := validateServiceDependencies(&projectConfig); err != nil {
+		return nil, err
+	}
+
 	return &projectConfig, nil
 }
 
+func validateDockerBuilder(options DockerProjectOptions) error {
+	switch options.Builder {
+	case DockerBuilderEngine:
+		return nil
+	case DockerBuilderOci:
+		if options.RemoteBuild {
+			return errors.New("docker.remoteBuild isn't supported with the oci builder")
+		}
+
+		if options.BaseImage.Empty() {
+			return errors.New("docker.baseImage must be specified with the oci builder")
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unsupported docker.builder '%s'. The supported builder is 'oci'", options.Builder)
+	}
+}
+
 // Load hydrates the azure.yaml configuring into an viewable structure
 // This does not evaluate any tooling
 func Load(ctx context.Context, projectFilePath string) (*ProjectConfig, error) {
@@ -62,7 +62,14 @@ type ProjectMetadata struct {
 	// Template is a slug that identifies the template and a version. This attribute should be
 	// in every template that we ship.
 	// ex: todo-python-mongo@version
-	Template string
+	Template string `yaml:"template,omitempty"`
+	// TemplateRepository is the URL of the repository of the template the project was initialized or last upgraded
+	// from, with TemplateRef and TemplateCommit the base of `azd template upgrade`.
+	TemplateRepository string `yaml:"templateRepository,omitempty"`
+	// TemplateRef is the git ref the template was fetched at, empty for the default branch.
+	TemplateRef string `yaml:"templateRef,omitempty"`
+	// TemplateCommit is the commit of the files of the template copied into the project.
+	TemplateCommit string `yaml:"templateCommit,omitempty"`
 }
 
 // HooksConfig is an alias for map of hook names to slice of hook configurations
@@ -41,7 +41,7 @@ func TestProjectConfigParse_Invalid(t *testing.T) {
 				services:
 					web:
 						language: csharp
-						host: appservice-containerapp-hybrid-edge-cloud
+						host: appservice/containerapp hybrid
 			`),
 		},
 		{
@@ -62,6 +62,24 @@ func TestProjectConfigParse_Invalid(t *testing.T) {
 	}
 }
 
+func TestProjectConfigPluginHost(t *testing.T) {
+	projectConfig := heredoc.Doc(`
+		name: proj-plugin-host
+		services:
+			web:
+				language: js
+				host: cloudflare.workers
+	`)
+
+	project, err := Parse(context.Background(), projectConfig)
+	require.NoError(t, err)
+	require.Equal(t, ServiceTargetKind("cloudflare.workers"), project.Services["web"].Host)
+	require.True(t, project.Services["web"].Host.IsPlugin())
+
+	require.False(t, AppServiceTarget.IsPlugin())
+	require.False(t, DotNetContainerAppTarget.IsPlugin())
+}
+
 func TestProjectConfigDefaults(t *testing.T) {
 	const testProj = `
 name: test-proj
@@ -5,6 +5,7 @@ package project
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"testing"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/azure"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockarmresources"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockazcli"
@@ -342,3 +344,67 @@ services:
 	assert.Equal(t, filepath.FromSlash("src/api"), projectConfig.Services["api"].RelativePath)
 	assert.Equal(t, filepath.FromSlash("bin/api"), projectConfig.Services["api"].OutputPath)
 }
+
+func Test_DockerBuilderFromYaml(t *testing.T) {
+	const testProj = `
+name: test-proj
+services:
+  api:
+    host: containerapp
+    language: js
+    project: src/api
+    docker:
+%s
+`
+
+	projectConfig, err := Parse(context.Background(), fmt.Sprintf(testProj, `
+      builder: oci
+      baseImage: node:20-alpine
+      entrypoint: ["node", "server.js"]`))
+	require.NoError(t, err)
+
+	docker := projectConfig.Services["api"].Docker
+	assert.Equal(t, DockerBuilderOci, docker.Builder)
+	assert.Equal(t, osutil.NewExpandableString("node:20-alpine"), docker.BaseImage)
+	assert.Equal(t, []string{"node", "server.js"}, docker.Entrypoint)
+
+	tests := map[string]struct {
+		docker   string
+		expected string
+	}{
+		"UnknownBuilder": {"      builder: ko", "unsupported docker.builder 'ko'"},
+		"NoBaseImage":    {"      builder: oci", "docker.baseImage must be specified"},
+		"RemoteBuild": {
+			"      builder: oci\n      baseImage: node:20-alpine\n      remoteBuild: true",
+			"docker.remoteBuild isn't supported",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(context.Background(), fmt.Sprintf(testProj, test.docker))
+			require.ErrorContains(t, err, test.expected)
+		})
+	}
+}
+
+func Test_DeployStrategyFromYaml(t *testing.T) {
+	const testProj = `
+name: test-proj
+services:
+  api:
+    host: %s
+    language: js
+    project: src/api
+    deploy:
+      strategy:
+        type: canary
+`
+
+	projectConfig, err := Parse(context.Background(), fmt.Sprintf(testProj, "containerapp"))
+	require.NoError(t, err)
+	require.Equal(t, CanaryDeployStrategy, projectConfig.Services["api"].Deploy.Strategy.Type)
+
+	_, err = Parse(context.Background(), fmt.Sprintf(testProj, "appservice"))
+	require.ErrorContains(t, err, "deploy.strategy is only supported by the 'containerapp' host")
+}
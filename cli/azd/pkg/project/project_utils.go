@@ -9,34 +9,73 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/ignore"
 	"github.com/azure/azure-dev/cli/azd/pkg/rzip"
 	"github.com/otiai10/copy"
 )
 
 // CreateDeployableZip creates a zip file of a folder, recursively.
-// Returns the path to the created zip file or an error if it fails.
-func createDeployableZip(projectName string, appName string, path string) (string, error) {
-	// TODO: should probably avoid picking up files that weren't meant to be deployed (ie, local .env files, etc..)
-	filePath := filepath.Join(os.TempDir(), fmt.Sprintf("%s-%s-azddeploy-%d.zip", projectName, appName, time.Now().Unix()))
+// Files matching the ignore files of the folder or the service `ignore` patterns are not included. The folder is a build
+// output or a package, so .gitignore files are not honored.
+// Returns the path to the created zip file and the excluded paths, or an error if it fails.
+func createDeployableZip(serviceConfig *ServiceConfig, path string) (string, []string, error) {
+	appName := serviceConfig.Name
+	filePath := filepath.Join(
+		os.TempDir(),
+		fmt.Sprintf("%s-%s-azddeploy-%d.zip", serviceConfig.Project.Name, appName, time.Now().Unix()),
+	)
 	zipFile, err := os.Create(filePath)
 	if err != nil {
-		return "", fmt.Errorf("failed when creating zip package to deploy %s: %w", appName, err)
+		return "", nil, fmt.Errorf("failed when creating zip package to deploy %s: %w", appName, err)
 	}
 
-	if err := rzip.CreateFromDirectory(path, zipFile); err != nil {
+	ignoreMatcher, err := newIgnoreMatcher(serviceConfig, path, false)
+	if err != nil {
+		zipFile.Close()
+		os.Remove(zipFile.Name())
+		return "", nil, err
+	}
+
+	excluded, err := rzip.CreateFromDirectory(path, zipFile, ignoreMatcher)
+	if err != nil {
 		// if we fail here just do our best to close things out and cleanup
 		zipFile.Close()
 		os.Remove(zipFile.Name())
-		return "", err
+		return "", nil, err
 	}
 
 	if err := zipFile.Close(); err != nil {
 		// may fail but, again, we'll do our best to cleanup here.
 		os.Remove(zipFile.Name())
-		return "", err
+		return "", nil, err
+	}
+
+	return zipFile.Name(), excluded, nil
+}
+
+// newIgnoreMatcher creates the ignore matcher used when packaging the service from the specified root directory.
+// Besides .azdignore, the ignore file used by the tooling of the hosting target is also honored. The .gitignore files are
+// only honored when gitIgnore is true, for a root that is the source of the service: they usually list the build output,
+// like dist/ or .next/, which is what gets deployed.
+func newIgnoreMatcher(serviceConfig *ServiceConfig, root string, gitIgnore bool) (*ignore.Matcher, error) {
+	fileNames := []string{ignore.AzdIgnoreFileName}
+	if gitIgnore {
+		fileNames = append(fileNames, ignore.GitIgnoreFileName)
+	}
+
+	switch serviceConfig.Host {
+	case AzureFunctionTarget:
+		fileNames = append(fileNames, ignore.FuncIgnoreFileName)
+	case AppServiceTarget:
+		fileNames = append(fileNames, ignore.WebAppIgnoreFileName)
+	}
+
+	matcher, err := ignore.NewMatcher(root, fileNames, serviceConfig.Ignore)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ignore patterns for service '%s': %w", serviceConfig.Name, err)
 	}
 
-	return zipFile.Name(), nil
+	return matcher, nil
 }
 
 // excludeDirEntryCondition resolves when a file or directory should be considered or not as part of build, when build is a
@@ -46,26 +85,58 @@ type excludeDirEntryCondition func(path string, file os.FileInfo) bool
 // buildForZipOptions provides a set of options for doing build for zip
 type buildForZipOptions struct {
 	excludeConditions []excludeDirEntryCondition
+	// Optional matcher evaluating the ignore files found in the source directory
+	ignoreMatcher *ignore.Matcher
 }
 
 // buildForZip is use by projects which build strategy is to only copy the source code into a folder which is later
 // zipped for packaging. For example Python and Node framework languages. buildForZipOptions provides the specific
 // details for each language which should not be ever copied.
-func buildForZip(src, dst string, options buildForZipOptions) error {
+// Returns the excluded paths, relative to src.
+func buildForZip(src, dst string, options buildForZipOptions) ([]string, error) {
+	excluded := []string{}
 
 	// these exclude conditions applies to all projects
 	options.excludeConditions = append(options.excludeConditions, globalExcludeAzdFolder)
 
-	return copy.Copy(src, dst, copy.Options{
-		Skip: func(srcInfo os.FileInfo, src, dest string) (bool, error) {
+	err := copy.Copy(src, dst, copy.Options{
+		Skip: func(srcInfo os.FileInfo, path, dest string) (bool, error) {
+			relPath, err := filepath.Rel(src, path)
+			if err != nil {
+				return false, err
+			}
+
+			if relPath == "." {
+				return false, nil
+			}
+
 			for _, checkExclude := range options.excludeConditions {
-				if checkExclude(src, srcInfo) {
+				if checkExclude(path, srcInfo) {
+					excluded = append(excluded, ignore.FormatExcluded(relPath, srcInfo.IsDir()))
+					return true, nil
+				}
+			}
+
+			if options.ignoreMatcher != nil {
+				isExcluded, err := options.ignoreMatcher.Match(relPath, srcInfo.IsDir())
+				if err != nil {
+					return false, err
+				}
+
+				if isExcluded {
+					excluded = append(excluded, ignore.FormatExcluded(relPath, srcInfo.IsDir()))
 					return true, nil
 				}
 			}
+
 			return false, nil
 		},
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return excluded, nil
 }
 
 func globalExcludeAzdFolder(path string, file os.FileInfo) bool {
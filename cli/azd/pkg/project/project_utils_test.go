@@ -0,0 +1,60 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_createDeployableZip_HonorsIgnoreFiles(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".gitignore":        "dist/\n",
+		".webappignore":     "tests/\n",
+		".funcignore":       "app.py\n",
+		"dist/index.js":     "",
+		"app.py":            "print('hello')",
+		"tests/test_app.py": "",
+		"data/local.db":     "",
+	}
+
+	for path, contents := range files {
+		fullPath := filepath.Join(root, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(contents), 0600))
+	}
+
+	serviceConfig := &ServiceConfig{
+		Project: &ProjectConfig{Name: "test"},
+		Name:    "api",
+		Host:    AppServiceTarget,
+		Ignore:  []string{"*.db"},
+	}
+
+	zipFilePath, excluded, err := createDeployableZip(serviceConfig, root)
+	require.NoError(t, err)
+	defer os.Remove(zipFilePath)
+
+	sort.Strings(excluded)
+	require.Equal(t, []string{"data/local.db", "tests/"}, excluded)
+
+	reader, err := zip.OpenReader(zipFilePath)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	entries := []string{}
+	for _, file := range reader.File {
+		entries = append(entries, file.Name)
+	}
+
+	sort.Strings(entries)
+	// .funcignore is not honored for app service targets, and .gitignore is not honored for the packaged build output
+	require.Equal(t, []string{".funcignore", ".gitignore", ".webappignore", "app.py", "dist/index.js"}, entries)
+}
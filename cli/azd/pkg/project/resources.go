@@ -12,11 +12,15 @@ import (
 type ResourceType string
 
 const (
-	ResourceTypeDbRedis          ResourceType = "db.redis"
-	ResourceTypeDbPostgres       ResourceType = "db.postgres"
-	ResourceTypeDbMongo          ResourceType = "db.mongo"
-	ResourceTypeHostContainerApp ResourceType = "host.containerapp"
-	ResourceTypeOpenAiModel      ResourceType = "ai.openai.model"
+	ResourceTypeDbRedis             ResourceType = "db.redis"
+	ResourceTypeDbPostgres          ResourceType = "db.postgres"
+	ResourceTypeDbMySql             ResourceType = "db.mysql"
+	ResourceTypeDbSqlServer         ResourceType = "db.sqlserver"
+	ResourceTypeDbMongo             ResourceType = "db.mongo"
+	ResourceTypeMessagingServiceBus ResourceType = "messaging.servicebus"
+	ResourceTypeStorage             ResourceType = "storage"
+	ResourceTypeHostContainerApp    ResourceType = "host.containerapp"
+	ResourceTypeOpenAiModel         ResourceType = "ai.openai.model"
 )
 
 func (r ResourceType) String() string {
@@ -25,8 +29,16 @@ func (r ResourceType) String() string {
 		return "Redis"
 	case ResourceTypeDbPostgres:
 		return "PostgreSQL"
+	case ResourceTypeDbMySql:
+		return "MySQL"
+	case ResourceTypeDbSqlServer:
+		return "SQL Server"
 	case ResourceTypeDbMongo:
 		return "MongoDB"
+	case ResourceTypeMessagingServiceBus:
+		return "Service Bus"
+	case ResourceTypeStorage:
+		return "Storage Account"
 	case ResourceTypeHostContainerApp:
 		return "Container App"
 	case ResourceTypeOpenAiModel:
@@ -28,6 +28,10 @@ type ServiceConfig struct {
 	Language ServiceLanguageKind `yaml:"language"`
 	// The output path for build artifacts
 	OutputPath string `yaml:"dist,omitempty"`
+	// Glob patterns, in .gitignore syntax, of files to exclude from the deployment package
+	Ignore []string `yaml:"ignore,omitempty"`
+	// The deployment slot of an App Service or a Function App to deploy to, instead of the production slot
+	Slot osutil.ExpandableString `yaml:"slot,omitempty"`
 	// The source image to use for container based applications
 	Image osutil.ExpandableString `yaml:"image,omitempty"`
 	// The optional docker options for configuring the output image
@@ -36,8 +40,14 @@ type ServiceConfig struct {
 	K8s AksOptions `yaml:"k8s,omitempty"`
 	// The optional Azure Spring Apps options
 	Spring SpringOptions `yaml:"spring,omitempty"`
+	// The optional deployment options, like the strategy routing the traffic to a new container app revision
+	Deploy DeployOptions `yaml:"deploy,omitempty"`
 	// The infrastructure provisioning configuration
 	Infra provisioning.Options `yaml:"infra,omitempty"`
+	// The services or resources used by this service. Referenced services are deployed before this service.
+	Uses []string `yaml:"uses,omitempty"`
+	// The services that must be deployed before this service
+	DependsOn []string `yaml:"dependsOn,omitempty"`
 	// Hook configuration for service
 	Hooks HooksConfig `yaml:"hooks,omitempty"`
 	// Options specific to the DotNetContainerApp target. These are set by the importer and
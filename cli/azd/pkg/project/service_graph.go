@@ -0,0 +1,151 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// DefaultMaxParallelDeployments is the default number of services that are packaged & deployed concurrently
+const DefaultMaxParallelDeployments = 4
+
+// ErrDependencyFailed is returned for services that are not deployed because a service they depend on failed
+var ErrDependencyFailed = errors.New("dependency failed")
+
+// ServiceDeployState is the state of a service within a multi-service deployment
+type ServiceDeployState string
+
+const (
+	// The service is waiting for the services it depends on, or for a free deployment slot
+	ServiceDeployStatePending ServiceDeployState = "pending"
+	// The service is being packaged or deployed
+	ServiceDeployStateRunning ServiceDeployState = "running"
+	// The service was deployed successfully
+	ServiceDeployStateSucceeded ServiceDeployState = "succeeded"
+	// The service failed to package or deploy
+	ServiceDeployStateFailed ServiceDeployState = "failed"
+	// The service was skipped because a service it depends on failed
+	ServiceDeployStateSkipped ServiceDeployState = "skipped"
+)
+
+// ServiceDeployProgress reports the state of a single service within a multi-service deployment
+type ServiceDeployProgress struct {
+	State ServiceDeployState
+	// The latest progress message reported by the service while running
+	Message string
+	// The result of the deployment, when the service succeeded
+	Result *ServiceDeployResult
+	// The error of the deployment, when the service failed or was skipped
+	Error error
+}
+
+// DeployServicesOptions configures how multiple services are packaged & deployed
+type DeployServicesOptions struct {
+	// The maximum number of services packaged & deployed concurrently.
+	// When unset, DefaultMaxParallelDeployments is used.
+	MaxParallel int
+	// When set, the existing package deployed instead of packaging the service.
+	// Only supported when deploying a single service.
+	FromPackage string
+	// When set, the services deployed to a deployment slot have their slot swapped with the production slot once
+	// deployed. The services that are not deployed to a slot are only deployed.
+	SwapSlots bool
+	// Optional callback invoked every time the state or progress of a service changes.
+	// The callback is never invoked concurrently.
+	OnProgress func(serviceConfig *ServiceConfig, progress ServiceDeployProgress)
+}
+
+// ServiceDependencies returns the names of the services that must be deployed before the specified service.
+// Dependencies are declared with `dependsOn`, or with `uses` when the entry references another service of the project.
+func ServiceDependencies(serviceConfig *ServiceConfig) []string {
+	dependencies := []string{}
+	for _, name := range serviceConfig.DependsOn {
+		if !slices.Contains(dependencies, name) {
+			dependencies = append(dependencies, name)
+		}
+	}
+
+	for _, name := range serviceConfig.Uses {
+		if serviceConfig.Project != nil {
+			if _, isService := serviceConfig.Project.Services[name]; !isService {
+				continue
+			}
+		}
+
+		if !slices.Contains(dependencies, name) {
+			dependencies = append(dependencies, name)
+		}
+	}
+
+	return dependencies
+}
+
+// validateServiceDependencies ensures the dependencies of all the services reference existing services or resources,
+// and that the services do not depend on each other in a cycle.
+func validateServiceDependencies(projectConfig *ProjectConfig) error {
+	for _, svc := range projectConfig.Services {
+		for _, name := range svc.DependsOn {
+			if _, has := projectConfig.Services[name]; !has {
+				return fmt.Errorf("parsing service %s: dependsOn references unknown service '%s'", svc.Name, name)
+			}
+		}
+
+		for _, name := range svc.Uses {
+			_, isService := projectConfig.Services[name]
+			_, isResource := projectConfig.Resources[name]
+			if !isService && !isResource {
+				return fmt.Errorf("parsing service %s: uses references unknown service or resource '%s'", svc.Name, name)
+			}
+		}
+
+		if slices.Contains(ServiceDependencies(svc), svc.Name) {
+			return fmt.Errorf("parsing service %s: a service cannot depend on itself", svc.Name)
+		}
+	}
+
+	visited := map[string]bool{}
+	for _, name := range slices.Sorted(maps.Keys(projectConfig.Services)) {
+		if cycle := findDependencyCycle(projectConfig, name, visited, []string{}); cycle != nil {
+			return fmt.Errorf("services have a circular dependency: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	return nil
+}
+
+// findDependencyCycle walks the dependencies of the service depth first, returning the path of the first cycle found.
+// visited tracks the services which dependencies have already been fully walked.
+func findDependencyCycle(
+	projectConfig *ProjectConfig,
+	name string,
+	visited map[string]bool,
+	path []string,
+) []string {
+	if index := slices.Index(path, name); index >= 0 {
+		return append(slices.Clone(path[index:]), name)
+	}
+
+	if visited[name] {
+		return nil
+	}
+
+	svc, has := projectConfig.Services[name]
+	if !has {
+		return nil
+	}
+
+	path = append(path, name)
+	for _, dependency := range ServiceDependencies(svc) {
+		if cycle := findDependencyCycle(projectConfig, dependency, visited, path); cycle != nil {
+			return cycle
+		}
+	}
+
+	visited[name] = true
+	return nil
+}
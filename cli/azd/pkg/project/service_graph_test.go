@@ -0,0 +1,127 @@
+package project
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ServiceDependencies(t *testing.T) {
+	projectConfig, err := Parse(context.Background(), heredoc.Doc(`
+		name: test-proj
+		services:
+		  api:
+		    project: src/api
+		    language: js
+		    host: containerapp
+		    uses:
+		      - db
+		      - worker
+		  web:
+		    project: src/web
+		    language: js
+		    host: appservice
+		    dependsOn:
+		      - api
+		    uses:
+		      - api
+		  worker:
+		    project: src/worker
+		    language: js
+		    host: containerapp
+		resources:
+		  db:
+		    type: db.postgres
+	`))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"worker"}, ServiceDependencies(projectConfig.Services["api"]))
+	require.Equal(t, []string{"api"}, ServiceDependencies(projectConfig.Services["web"]))
+	require.Empty(t, ServiceDependencies(projectConfig.Services["worker"]))
+}
+
+func Test_ServiceDependencies_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		projectConfig string
+		expectedError string
+	}{
+		{
+			name: "UnknownDependsOn",
+			projectConfig: heredoc.Doc(`
+				name: test-proj
+				services:
+				  web:
+				    project: src/web
+				    language: js
+				    host: appservice
+				    dependsOn:
+				      - api
+			`),
+			expectedError: "dependsOn references unknown service 'api'",
+		},
+		{
+			name: "UnknownUses",
+			projectConfig: heredoc.Doc(`
+				name: test-proj
+				services:
+				  web:
+				    project: src/web
+				    language: js
+				    host: appservice
+				    uses:
+				      - db
+			`),
+			expectedError: "uses references unknown service or resource 'db'",
+		},
+		{
+			name: "Self",
+			projectConfig: heredoc.Doc(`
+				name: test-proj
+				services:
+				  web:
+				    project: src/web
+				    language: js
+				    host: appservice
+				    dependsOn:
+				      - web
+			`),
+			expectedError: "a service cannot depend on itself",
+		},
+		{
+			name: "Cycle",
+			projectConfig: heredoc.Doc(`
+				name: test-proj
+				services:
+				  api:
+				    project: src/api
+				    language: js
+				    host: appservice
+				    dependsOn:
+				      - worker
+				  web:
+				    project: src/web
+				    language: js
+				    host: appservice
+				    uses:
+				      - api
+				  worker:
+				    project: src/worker
+				    language: js
+				    host: appservice
+				    dependsOn:
+				      - web
+			`),
+			expectedError: "services have a circular dependency: api -> worker -> web -> api",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse(context.Background(), test.projectConfig)
+			require.ErrorContains(t, err, test.expectedError)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
@@ -18,6 +19,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/ext"
 	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/plugin"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/swa"
 )
@@ -93,6 +95,31 @@ type ServiceManager interface {
 		progress *async.Progress[ServiceProgress],
 	) (*ServiceDeployResult, error)
 
+	// Rolls back the specified service to the deployment that preceded its current deployment, when supported by the
+	// service target. No artifact is packaged, the previous deployment is restored from the Azure resource or the
+	// environment.
+	Rollback(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		progress *async.Progress[ServiceProgress],
+	) (*ServiceDeployResult, error)
+
+	// Swaps the deployment slot of the specified service with its production slot, when supported by the service target.
+	SwapSlot(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		progress *async.Progress[ServiceProgress],
+	) (*ServiceDeployResult, error)
+
+	// Packages & deploys the specified services honoring the dependencies declared between them.
+	// Services that don't depend on each other are processed concurrently, and a service is only deployed once all the
+	// services it depends on have been deployed. When a service fails, only the services depending on it are skipped.
+	DeployServices(
+		ctx context.Context,
+		serviceConfigs []*ServiceConfig,
+		options *DeployServicesOptions,
+	) (map[string]*ServiceDeployResult, error)
+
 	// Gets the framework service for the specified service config
 	// The framework service performs the restoration and building of the service app code
 	GetFrameworkService(ctx context.Context, serviceConfig *ServiceConfig) (FrameworkService, error)
@@ -115,6 +142,11 @@ type serviceManager struct {
 	operationCache      ServiceOperationCache
 	alphaFeatureManager *alpha.FeatureManager
 	initialized         map[*ServiceConfig]map[any]bool
+	// cacheMu guards the operation cache since services can be packaged & deployed concurrently
+	cacheMu sync.RWMutex
+	// pluginTargets are the service targets implemented by plugins, by host, so they are only initialized once
+	pluginTargets   map[ServiceTargetKind]ServiceTarget
+	pluginTargetsMu sync.Mutex
 }
 
 // NewServiceManager creates a new instance of the ServiceManager component
@@ -132,6 +164,7 @@ func NewServiceManager(
 		operationCache:      operationCache,
 		alphaFeatureManager: alphaFeatureManager,
 		initialized:         map[*ServiceConfig]map[any]bool{},
+		pluginTargets:       map[ServiceTargetKind]ServiceTarget{},
 	}
 }
 
@@ -497,6 +530,273 @@ func (sm *serviceManager) Deploy(
 	return deployResult, nil
 }
 
+// Rolls back the specified service to the deployment that preceded its current deployment
+func (sm *serviceManager) Rollback(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
+	serviceTarget, err := sm.GetServiceTarget(ctx, serviceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("getting service target: %w", err)
+	}
+
+	rollbacker, ok := serviceTarget.(ServiceRollbacker)
+	if !ok {
+		return nil, fmt.Errorf("%w for service host '%s'", ErrRollbackNotSupported, serviceConfig.Host)
+	}
+
+	targetResource, err := sm.resourceManager.GetTargetResource(ctx, sm.env.GetSubscriptionId(), serviceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("getting target resource: %w", err)
+	}
+
+	deployResult, err := rollbacker.Rollback(ctx, serviceConfig, targetResource, progress)
+	if err != nil {
+		return nil, fmt.Errorf("failed rolling back service '%s': %w", serviceConfig.Name, err)
+	}
+
+	overriddenEndpoints := OverriddenEndpoints(ctx, serviceConfig, sm.env)
+	if len(overriddenEndpoints) > 0 {
+		deployResult.Endpoints = overriddenEndpoints
+	}
+
+	return deployResult, nil
+}
+
+// Swaps the deployment slot of the specified service with its production slot
+func (sm *serviceManager) SwapSlot(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
+	serviceTarget, err := sm.GetServiceTarget(ctx, serviceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("getting service target: %w", err)
+	}
+
+	swapper, ok := serviceTarget.(ServiceSlotSwapper)
+	if !ok {
+		return nil, fmt.Errorf("%w for service host '%s'", ErrSlotSwapNotSupported, serviceConfig.Host)
+	}
+
+	targetResource, err := sm.resourceManager.GetTargetResource(ctx, sm.env.GetSubscriptionId(), serviceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("getting target resource: %w", err)
+	}
+
+	deployResult, err := swapper.SwapSlot(ctx, serviceConfig, targetResource, progress)
+	if err != nil {
+		return nil, fmt.Errorf("failed swapping slot of service '%s': %w", serviceConfig.Name, err)
+	}
+
+	overriddenEndpoints := OverriddenEndpoints(ctx, serviceConfig, sm.env)
+	if len(overriddenEndpoints) > 0 {
+		deployResult.Endpoints = overriddenEndpoints
+	}
+
+	return deployResult, nil
+}
+
+// Packages & deploys the specified services honoring the dependencies declared between them.
+// Each service waits for the services it depends on, and then for a free slot within the parallelism limit.
+func (sm *serviceManager) DeployServices(
+	ctx context.Context,
+	serviceConfigs []*ServiceConfig,
+	options *DeployServicesOptions,
+) (map[string]*ServiceDeployResult, error) {
+	if options == nil {
+		options = &DeployServicesOptions{}
+	}
+
+	if options.FromPackage != "" && len(serviceConfigs) > 1 {
+		return nil, errors.New("deploying from an existing package is only supported for a single service")
+	}
+
+	maxParallel := options.MaxParallel
+	if maxParallel <= 0 {
+		maxParallel = DefaultMaxParallelDeployments
+	}
+
+	// Calls to the progress callback are serialized, so callers don't need to synchronize the console
+	var progressMu sync.Mutex
+	reportProgress := func(serviceConfig *ServiceConfig, progress ServiceDeployProgress) {
+		if options.OnProgress == nil {
+			return
+		}
+
+		progressMu.Lock()
+		defer progressMu.Unlock()
+		options.OnProgress(serviceConfig, progress)
+	}
+
+	// Only dependencies on the services being deployed are considered, any other service is expected to be deployed
+	done := map[string]chan struct{}{}
+	for _, serviceConfig := range serviceConfigs {
+		done[serviceConfig.Name] = make(chan struct{})
+	}
+
+	var resultsMu sync.Mutex
+	results := map[string]*ServiceDeployResult{}
+	failures := map[string]error{}
+
+	slots := make(chan struct{}, maxParallel)
+	var wg sync.WaitGroup
+
+	for _, serviceConfig := range serviceConfigs {
+		reportProgress(serviceConfig, ServiceDeployProgress{State: ServiceDeployStatePending})
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[serviceConfig.Name])
+
+			for _, dependency := range ServiceDependencies(serviceConfig) {
+				dependencyDone, has := done[dependency]
+				if !has {
+					continue
+				}
+
+				select {
+				case <-dependencyDone:
+				case <-ctx.Done():
+					return
+				}
+
+				resultsMu.Lock()
+				_, failed := failures[dependency]
+				resultsMu.Unlock()
+
+				if failed {
+					err := fmt.Errorf(
+						"skipped service '%s', depends on service '%s': %w", serviceConfig.Name, dependency, ErrDependencyFailed,
+					)
+
+					resultsMu.Lock()
+					failures[serviceConfig.Name] = err
+					resultsMu.Unlock()
+
+					reportProgress(serviceConfig, ServiceDeployProgress{State: ServiceDeployStateSkipped, Error: err})
+					return
+				}
+			}
+
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-slots }()
+
+			reportProgress(serviceConfig, ServiceDeployProgress{State: ServiceDeployStateRunning})
+			deployResult, err := sm.packageAndDeploy(ctx, serviceConfig, options, func(message string) {
+				reportProgress(serviceConfig, ServiceDeployProgress{
+					State:   ServiceDeployStateRunning,
+					Message: message,
+				})
+			})
+
+			resultsMu.Lock()
+			if err != nil {
+				failures[serviceConfig.Name] = err
+			} else {
+				results[serviceConfig.Name] = deployResult
+			}
+			resultsMu.Unlock()
+
+			if err != nil {
+				reportProgress(serviceConfig, ServiceDeployProgress{State: ServiceDeployStateFailed, Error: err})
+				return
+			}
+
+			reportProgress(serviceConfig, ServiceDeployProgress{State: ServiceDeployStateSucceeded, Result: deployResult})
+		}()
+	}
+
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+
+	// Report the errors in the order of the services, skipping the services that were not deployed because of a failed
+	// dependency since the root cause is already reported.
+	errs := []error{}
+	for _, serviceConfig := range serviceConfigs {
+		if err, has := failures[serviceConfig.Name]; has && !errors.Is(err, ErrDependencyFailed) {
+			errs = append(errs, err)
+		}
+	}
+
+	switch len(errs) {
+	case 0:
+		return results, nil
+	case 1:
+		return results, errs[0]
+	default:
+		return results, errors.Join(errs...)
+	}
+}
+
+// packageAndDeploy packages the service, unless an existing package is provided, and deploys it. When requested, the
+// deployment slot of the service is then swapped with the production slot.
+func (sm *serviceManager) packageAndDeploy(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	options *DeployServicesOptions,
+	onProgress func(message string),
+) (*ServiceDeployResult, error) {
+	reportProgress := func(progress ServiceProgress) {
+		onProgress(progress.Message)
+	}
+
+	var packageResult *ServicePackageResult
+	if options.FromPackage != "" {
+		packageResult = &ServicePackageResult{
+			PackagePath: options.FromPackage,
+		}
+	} else {
+		result, err := async.RunWithProgress(
+			reportProgress,
+			func(progress *async.Progress[ServiceProgress]) (*ServicePackageResult, error) {
+				return sm.Package(ctx, serviceConfig, nil, progress, nil)
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		packageResult = result
+	}
+
+	deployResult, err := async.RunWithProgress(
+		reportProgress,
+		func(progress *async.Progress[ServiceProgress]) (*ServiceDeployResult, error) {
+			return sm.Deploy(ctx, serviceConfig, packageResult, progress)
+		},
+	)
+	if err != nil || !options.SwapSlots {
+		return deployResult, err
+	}
+
+	if slot, err := DeploymentSlot(sm.env, serviceConfig); err != nil || slot == "" {
+		return deployResult, err
+	}
+
+	swapResult, err := async.RunWithProgress(
+		reportProgress,
+		func(progress *async.Progress[ServiceProgress]) (*ServiceDeployResult, error) {
+			return sm.SwapSlot(ctx, serviceConfig, progress)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	swapResult.Package = deployResult.Package
+	return swapResult, nil
+}
+
 // GetServiceTarget constructs a ServiceTarget from the underlying service configuration
 func (sm *serviceManager) GetServiceTarget(ctx context.Context, serviceConfig *ServiceConfig) (ServiceTarget, error) {
 	var target ServiceTarget
@@ -513,6 +813,10 @@ func (sm *serviceManager) GetServiceTarget(ctx context.Context, serviceConfig *S
 		}
 	}
 
+	if serviceConfig.Host.IsPlugin() {
+		return sm.getPluginServiceTarget(serviceConfig)
+	}
+
 	if err := sm.serviceLocator.ResolveNamed(host, &target); err != nil {
 		return nil, fmt.Errorf(
 			"failed to resolve service host '%s' for service '%s', %w",
@@ -525,6 +829,37 @@ func (sm *serviceManager) GetServiceTarget(ctx context.Context, serviceConfig *S
 	return target, nil
 }
 
+// getPluginServiceTarget gets the service target implemented by the `azd-host-<host>` plugin
+func (sm *serviceManager) getPluginServiceTarget(serviceConfig *ServiceConfig) (ServiceTarget, error) {
+	sm.pluginTargetsMu.Lock()
+	defer sm.pluginTargetsMu.Unlock()
+
+	if target, has := sm.pluginTargets[serviceConfig.Host]; has {
+		return target, nil
+	}
+
+	pluginPath, err := plugin.FindServiceTarget(string(serviceConfig.Host))
+	if errors.Is(err, plugin.ErrNotFound) {
+		return nil, fmt.Errorf(
+			"service host '%s' for service '%s' is not supported by azd and no plugin implements it, %w. "+
+				"Install a plugin named '%s%s' in the azd plugins directory or on the PATH.",
+			serviceConfig.Host,
+			serviceConfig.Name,
+			err,
+			plugin.ServiceTargetPrefix,
+			serviceConfig.Host,
+		)
+	} else if err != nil {
+		return nil, fmt.Errorf("finding plugin for service host '%s': %w", serviceConfig.Host, err)
+	}
+
+	log.Printf("using plugin '%s' for service host '%s'", pluginPath, serviceConfig.Host)
+	target := NewPluginServiceTarget(serviceConfig.Host, pluginPath, sm.env)
+	sm.pluginTargets[serviceConfig.Host] = target
+
+	return target, nil
+}
+
 // GetFrameworkService constructs a framework service from the underlying service configuration
 func (sm *serviceManager) GetFrameworkService(ctx context.Context, serviceConfig *ServiceConfig) (FrameworkService, error) {
 	var frameworkService FrameworkService
@@ -603,6 +938,9 @@ func OverriddenEndpoints(ctx context.Context, serviceConfig *ServiceConfig, env
 
 // Attempts to retrieve the result of a previous operation from the cache
 func (sm *serviceManager) getOperationResult(serviceConfig *ServiceConfig, operationName string) (any, bool) {
+	sm.cacheMu.RLock()
+	defer sm.cacheMu.RUnlock()
+
 	key := fmt.Sprintf("%s:%s:%s", sm.env.Name(), serviceConfig.Name, operationName)
 	value, ok := sm.operationCache[key]
 
@@ -611,6 +949,9 @@ func (sm *serviceManager) getOperationResult(serviceConfig *ServiceConfig, opera
 
 // Sets the result of an operation in the cache
 func (sm *serviceManager) setOperationResult(serviceConfig *ServiceConfig, operationName string, result any) {
+	sm.cacheMu.Lock()
+	defer sm.cacheMu.Unlock()
+
 	key := fmt.Sprintf("%s:%s:%s", sm.env.Name(), serviceConfig.Name, operationName)
 	sm.operationCache[key] = result
 }
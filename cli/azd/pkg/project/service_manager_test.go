@@ -3,8 +3,14 @@ package project
 import (
 	"context"
 	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
@@ -414,6 +420,160 @@ func Test_ServiceManager_Events_With_Errors(t *testing.T) {
 	}
 }
 
+func Test_ServiceManager_DeployServices(t *testing.T) {
+	t.Run("DependenciesDeployFirst", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		setupMocksForServiceManager(mockContext)
+		env := environment.NewWithValues("test", map[string]string{
+			environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+		})
+		sm := createServiceManager(mockContext, env, ServiceOperationCache{})
+		services := createTestServiceGraph(map[string][]string{
+			"api":    {"worker"},
+			"web":    {"api"},
+			"worker": nil,
+		})
+
+		var mu sync.Mutex
+		events := []string{}
+		for _, svc := range services {
+			for _, event := range []ext.Event{"predeploy", "postdeploy"} {
+				_ = svc.AddHandler(event, func(ctx context.Context, args ServiceLifecycleEventArgs) error {
+					mu.Lock()
+					defer mu.Unlock()
+					events = append(events, fmt.Sprintf("%s:%s", event, args.Service.Name))
+					return nil
+				})
+			}
+		}
+
+		results, err := sm.DeployServices(*mockContext.Context, services, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		require.Equal(t, []string{
+			"predeploy:worker", "postdeploy:worker",
+			"predeploy:api", "postdeploy:api",
+			"predeploy:web", "postdeploy:web",
+		}, events)
+	})
+
+	t.Run("IndependentServicesOverlap", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		setupMocksForServiceManager(mockContext)
+		env := environment.NewWithValues("test", map[string]string{
+			environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+		})
+		sm := createServiceManager(mockContext, env, ServiceOperationCache{})
+		services := createTestServiceGraph(map[string][]string{
+			"api":    nil,
+			"web":    nil,
+			"worker": {"api", "web"},
+		})
+
+		// api & web only complete their deployment once both are being deployed at the same time
+		var arrived sync.WaitGroup
+		arrived.Add(2)
+		for _, svc := range services[:2] {
+			_ = svc.AddHandler("predeploy", func(ctx context.Context, args ServiceLifecycleEventArgs) error {
+				arrived.Done()
+
+				overlapping := make(chan struct{})
+				go func() {
+					arrived.Wait()
+					close(overlapping)
+				}()
+
+				select {
+				case <-overlapping:
+					return nil
+				case <-time.After(10 * time.Second):
+					return fmt.Errorf("service '%s' was not deployed concurrently", args.Service.Name)
+				}
+			})
+		}
+
+		results, err := sm.DeployServices(*mockContext.Context, services, &DeployServicesOptions{
+			MaxParallel: 2,
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+	})
+
+	t.Run("FailureSkipsDependents", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		setupMocksForServiceManager(mockContext)
+		env := environment.NewWithValues("test", map[string]string{
+			environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+		})
+		sm := createServiceManager(mockContext, env, ServiceOperationCache{})
+		services := createTestServiceGraph(map[string][]string{
+			"api":    nil,
+			"web":    {"api"},
+			"worker": nil,
+		})
+
+		_ = services[0].AddHandler("predeploy", func(ctx context.Context, args ServiceLifecycleEventArgs) error {
+			return errors.New("api deployment failed")
+		})
+
+		states := map[string]ServiceDeployState{}
+		results, err := sm.DeployServices(*mockContext.Context, services, &DeployServicesOptions{
+			MaxParallel: 1,
+			OnProgress: func(serviceConfig *ServiceConfig, progress ServiceDeployProgress) {
+				states[serviceConfig.Name] = progress.State
+			},
+		})
+
+		require.ErrorContains(t, err, "api deployment failed")
+		require.False(t, errors.Is(err, ErrDependencyFailed))
+		require.Len(t, results, 1)
+		require.Contains(t, results, "worker")
+		require.Equal(t, map[string]ServiceDeployState{
+			"api":    ServiceDeployStateFailed,
+			"web":    ServiceDeployStateSkipped,
+			"worker": ServiceDeployStateSucceeded,
+		}, states)
+	})
+
+	t.Run("FromPackageRequiresSingleService", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		env := environment.NewWithValues("test", nil)
+		sm := createServiceManager(mockContext, env, ServiceOperationCache{})
+		services := createTestServiceGraph(map[string][]string{
+			"api": nil,
+			"web": nil,
+		})
+
+		_, err := sm.DeployServices(*mockContext.Context, services, &DeployServicesOptions{
+			FromPackage: "package.zip",
+		})
+		require.Error(t, err)
+	})
+}
+
+// createTestServiceGraph creates fake services of a single project, sorted by name, with the specified dependencies
+func createTestServiceGraph(dependencies map[string][]string) []*ServiceConfig {
+	projectConfig := &ProjectConfig{
+		Name:            "Test-App",
+		Path:            ".",
+		Services:        map[string]*ServiceConfig{},
+		EventDispatcher: ext.NewEventDispatcher[ProjectLifecycleEventArgs](),
+	}
+
+	services := []*ServiceConfig{}
+	for _, name := range slices.Sorted(maps.Keys(dependencies)) {
+		serviceConfig := createTestServiceConfig(filepath.Join("./src", name), ServiceTargetFake, ServiceLanguageFake)
+		serviceConfig.Name = name
+		serviceConfig.Project = projectConfig
+		serviceConfig.DependsOn = dependencies[name]
+
+		projectConfig.Services[name] = serviceConfig
+		services = append(services, serviceConfig)
+	}
+
+	return services
+}
+
 func setupMocksForServiceManager(mockContext *mocks.MockContext) {
 	mockContext.Container.MustRegisterNamedSingleton(string(ServiceLanguageFake), newFakeFramework)
 	mockContext.Container.MustRegisterNamedSingleton(string(ServiceTargetFake), newFakeServiceTarget)
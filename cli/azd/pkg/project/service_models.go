@@ -73,6 +73,8 @@ type ServicePackageResult struct {
 	Build       *ServiceBuildResult `json:"build"`
 	PackagePath string              `json:"packagePath"`
 	Details     interface{}         `json:"details"`
+	// Paths, relative to the packaged directory, that were excluded from the package
+	ExcludedFiles []string `json:"excludedFiles,omitempty"`
 }
 
 // Supports rendering messages for UX items
@@ -6,7 +6,10 @@ package project
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"regexp"
 	"strings"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
@@ -40,6 +43,28 @@ func (stk ServiceTargetKind) RequiresContainer() bool {
 	return false
 }
 
+// IsPlugin returns true if the service target is not implemented by azd, but by an out-of-process plugin named
+// `azd-host-<host>`.
+func (stk ServiceTargetKind) IsPlugin() bool {
+	switch stk {
+	case NonSpecifiedTarget,
+		AppServiceTarget,
+		ContainerAppTarget,
+		AzureFunctionTarget,
+		StaticWebAppTarget,
+		SpringAppTarget,
+		AksTarget,
+		DotNetContainerAppTarget,
+		AiEndpointTarget:
+		return false
+	}
+
+	return true
+}
+
+// pluginHostRegex matches the hosts that can be implemented by a plugin, the host being part of the plugin file name
+var pluginHostRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
 func parseServiceHost(kind ServiceTargetKind) (ServiceTargetKind, error) {
 	switch kind {
 
@@ -57,6 +82,11 @@ func parseServiceHost(kind ServiceTargetKind) (ServiceTargetKind, error) {
 		return kind, nil
 	}
 
+	// Any other host is delegated to a plugin, which is only looked up when the service is packaged or deployed
+	if kind.IsPlugin() && pluginHostRegex.MatchString(string(kind)) {
+		return kind, nil
+	}
+
 	return ServiceTargetKind(""), fmt.Errorf("unsupported host '%s'", kind)
 }
 
@@ -94,6 +124,141 @@ type ServiceTarget interface {
 	) ([]string, error)
 }
 
+// logStreamTailLines is the number of recent log lines streamed before the new entries, when supported by the host
+const logStreamTailLines = 100
+
+// LogStreamOptions are the options used to stream the logs of a deployed service
+type LogStreamOptions struct {
+	// Keeps streaming the new log entries until the context is cancelled
+	Follow bool
+	// Streams the logs of the hosting platform instead of the application logs, when supported by the host
+	System bool
+}
+
+// ServiceLogStreamer is implemented by the service targets that can stream the logs of a deployed service.
+// This is an optional capability of a ServiceTarget.
+type ServiceLogStreamer interface {
+	// Writes the logs of the service to the writer, until the recent logs are exhausted or, when following, the context
+	// is cancelled.
+	StreamLogs(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		targetResource *environment.TargetResource,
+		options LogStreamOptions,
+		writer io.Writer,
+	) error
+}
+
+// ServiceRollbacker is implemented by the service targets that can roll back a deployed service to the deployment that
+// preceded the current one. This is an optional capability of a ServiceTarget.
+type ServiceRollbacker interface {
+	// Reverts the service to its previous deployment, as recorded in the environment by the last deployments
+	Rollback(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		targetResource *environment.TargetResource,
+		progress *async.Progress[ServiceProgress],
+	) (*ServiceDeployResult, error)
+}
+
+// ErrRollbackNotSupported is returned when rolling back a service whose service target is not a ServiceRollbacker
+var ErrRollbackNotSupported = errors.New("rolling back is not supported")
+
+// ErrNoPreviousDeployment is returned when rolling back a service that has no previous deployment recorded
+var ErrNoPreviousDeployment = errors.New("no previous deployment")
+
+// ServiceSlotSwapper is implemented by the service targets that can deploy a service to a deployment slot, and then swap
+// the slot with the production slot. This is an optional capability of a ServiceTarget.
+type ServiceSlotSwapper interface {
+	// Swaps the deployment slot of the service with the production slot, so the deployment validated in the slot serves
+	// the production traffic.
+	SwapSlot(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		targetResource *environment.TargetResource,
+		progress *async.Progress[ServiceProgress],
+	) (*ServiceDeployResult, error)
+}
+
+// ErrSlotSwapNotSupported is returned when swapping the slot of a service whose service target is not a
+// ServiceSlotSwapper
+var ErrSlotSwapNotSupported = errors.New("swapping deployment slots is not supported")
+
+// The service properties recording the identity of the current and previous deployments of a service, like the name of
+// a container app revision. A rollback swaps them, so rolling back twice restores the current deployment.
+const (
+	deploymentIdProperty         = "DEPLOYMENT_ID"
+	previousDeploymentIdProperty = "PREVIOUS_DEPLOYMENT_ID"
+)
+
+// recordDeployment records the identity of the new deployment of a service in the environment, keeping the identity of
+// the deployment it replaces so the service can be rolled back to it.
+func recordDeployment(env *environment.Environment, serviceName string, deploymentId string) {
+	recordSlotDeployment(env, serviceName, "", deploymentId)
+}
+
+// recordSlotDeployment records the new deployment of a service to a deployment slot, like recordDeployment does. Each slot
+// has its own deployments, the production slot being the empty slot.
+func recordSlotDeployment(env *environment.Environment, serviceName string, slot string, deploymentId string) {
+	currentId := env.GetServiceProperty(serviceName, slotProperty(deploymentIdProperty, slot))
+	if currentId == deploymentId {
+		return
+	}
+
+	if currentId != "" {
+		env.SetServiceProperty(serviceName, slotProperty(previousDeploymentIdProperty, slot), currentId)
+	}
+
+	env.SetServiceProperty(serviceName, slotProperty(deploymentIdProperty, slot), deploymentId)
+}
+
+// slotProperty gets the name of the service property recording a deployment to the deployment slot. The properties of
+// the production slot are not qualified by the slot.
+func slotProperty(propertyName string, slot string) string {
+	if slot == "" {
+		return propertyName
+	}
+
+	return fmt.Sprintf("SLOT_%s_%s", strings.ReplaceAll(strings.ToUpper(slot), "-", "_"), propertyName)
+}
+
+// swappedSlotProperty is the service property recording the deployment slot swapped with production by the last
+// deployment of a service, which a rollback swaps back.
+const swappedSlotProperty = "SWAPPED_SLOT"
+
+// recordSlotSwap records the deployment slot swapped with production by the last deployment of a service, or that the
+// last deployment didn't swap slots when the slot is empty.
+func recordSlotSwap(env *environment.Environment, serviceName string, slot string) {
+	if env.GetServiceProperty(serviceName, swappedSlotProperty) != slot {
+		env.SetServiceProperty(serviceName, swappedSlotProperty, slot)
+	}
+}
+
+// previousDeployment gets the identity of the deployment that preceded the current deployment of a service
+func previousDeployment(env *environment.Environment, serviceName string) (string, error) {
+	return previousSlotDeployment(env, serviceName, "")
+}
+
+// previousSlotDeployment gets the identity of the deployment that preceded the current deployment of a service to the
+// deployment slot
+func previousSlotDeployment(env *environment.Environment, serviceName string, slot string) (string, error) {
+	deploymentId := env.GetServiceProperty(serviceName, slotProperty(previousDeploymentIdProperty, slot))
+	if deploymentId == "" && slot != "" {
+		return "", fmt.Errorf(
+			"%w of service '%s' to slot '%s' is recorded in environment '%s'",
+			ErrNoPreviousDeployment,
+			serviceName,
+			slot,
+			env.Name(),
+		)
+	} else if deploymentId == "" {
+		return "", fmt.Errorf(
+			"%w of service '%s' is recorded in environment '%s'", ErrNoPreviousDeployment, serviceName, env.Name())
+	}
+
+	return deploymentId, nil
+}
+
 // NewServiceDeployResult is a helper function to create a new ServiceDeployResult
 func NewServiceDeployResult(
 	relatedResourceId string,
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -223,13 +225,21 @@ func (t *aksTarget) Deploy(
 	deployed := false
 
 	// Helm Support
-	helmDeployed, err := t.deployHelmCharts(ctx, serviceConfig, progress)
+	helmDeployed, helmRevisions, err := t.deployHelmCharts(ctx, serviceConfig, progress)
 	if err != nil {
 		return nil, fmt.Errorf("helm deployment failed: %w", err)
 	}
 
 	deployed = deployed || helmDeployed
 
+	// Helm keeps the history of the releases, which allows rolling back the service to the recorded revisions
+	if len(helmRevisions) > 0 {
+		recordDeployment(t.env, serviceConfig.Name, strings.Join(helmRevisions, ","))
+		if err := t.envManager.Save(ctx, t.env); err != nil {
+			return nil, fmt.Errorf("saving deployment of service %s: %w", serviceConfig.Name, err)
+		}
+	}
+
 	// Kustomize Support
 	kustomizeDeployed, err := t.deployKustomize(ctx, serviceConfig, progress)
 	if err != nil {
@@ -407,71 +417,165 @@ func (t *aksTarget) deployKustomize(
 	return true, nil
 }
 
-// deployHelmCharts deploys helm charts to the k8s cluster
+// deployHelmCharts deploys helm charts to the k8s cluster, and returns the deployed revisions of the releases
 func (t *aksTarget) deployHelmCharts(
 	ctx context.Context, serviceConfig *ServiceConfig,
 	task *async.Progress[ServiceProgress],
-) (bool, error) {
+) (bool, []string, error) {
 	if serviceConfig.K8s.Helm == nil {
-		return false, nil
+		return false, nil, nil
 	}
 
 	if !t.featureManager.IsEnabled(featureHelm) {
-		return false, fmt.Errorf("Helm support is not enabled. Run '%s' to enable it.", alpha.GetEnableCommand(featureHelm))
+		return false, nil, fmt.Errorf(
+			"Helm support is not enabled. Run '%s' to enable it.", alpha.GetEnableCommand(featureHelm))
 	}
 
 	for _, repo := range serviceConfig.K8s.Helm.Repositories {
 		task.SetProgress(NewServiceProgress(fmt.Sprintf("Configuring helm repo: %s", repo.Name)))
 		if err := t.helmCli.AddRepo(ctx, repo); err != nil {
-			return false, err
+			return false, nil, err
 		}
 
 		if err := t.helmCli.UpdateRepo(ctx, repo.Name); err != nil {
-			return false, err
+			return false, nil, err
 		}
 	}
 
+	revisions := []string{}
 	for _, release := range serviceConfig.K8s.Helm.Releases {
 		if release.Namespace == "" {
 			release.Namespace = t.getK8sNamespace(serviceConfig)
 		}
 
 		if err := t.ensureNamespace(ctx, release.Namespace); err != nil {
-			return false, err
+			return false, nil, err
 		}
 
 		task.SetProgress(NewServiceProgress(fmt.Sprintf("Installing helm release: %s", release.Name)))
 		if err := t.helmCli.Upgrade(ctx, release); err != nil {
-			return false, err
+			return false, nil, err
 		}
 
 		task.SetProgress(NewServiceProgress(fmt.Sprintf("Checking helm release status: %s", release.Name)))
-		err := retry.Do(
-			ctx,
-			retry.WithMaxDuration(10*time.Minute, retry.NewConstant(5*time.Second)),
-			func(ctx context.Context) error {
-				status, err := t.helmCli.Status(ctx, release)
-				if err != nil {
-					return err
-				}
+		revision, err := t.waitForHelmRelease(ctx, release)
+		if err != nil {
+			return false, nil, err
+		}
 
-				if status.Info.Status != helm.StatusKindDeployed {
-					fmt.Printf("Status: %s\n", status.Info.Status)
-					return retry.RetryableError(
-						fmt.Errorf("helm release '%s' is not ready, %w", release.Name, err),
-					)
-				}
+		revisions = append(revisions, helmReleaseRevision(release.Name, revision))
+	}
 
-				return nil
-			},
-		)
+	return true, revisions, nil
+}
+
+// waitForHelmRelease waits for the helm release to be deployed, and returns its current revision
+func (t *aksTarget) waitForHelmRelease(ctx context.Context, release *helm.Release) (int, error) {
+	var revision int
+	err := retry.Do(
+		ctx,
+		retry.WithMaxDuration(10*time.Minute, retry.NewConstant(5*time.Second)),
+		func(ctx context.Context) error {
+			status, err := t.helmCli.Status(ctx, release)
+			if err != nil {
+				return err
+			}
+
+			if status.Info.Status != helm.StatusKindDeployed {
+				fmt.Printf("Status: %s\n", status.Info.Status)
+				return retry.RetryableError(
+					fmt.Errorf("helm release '%s' is not ready, %w", release.Name, err),
+				)
+			}
+
+			revision = int(status.Version)
+			return nil
+		},
+	)
+
+	return revision, err
+}
+
+// Rolls back the helm releases of the service to the revisions of the previous deployment
+func (t *aksTarget) Rollback(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
+	if err := t.validateTargetResource(targetResource); err != nil {
+		return nil, fmt.Errorf("validating target resource: %w", err)
+	}
+
+	if serviceConfig.K8s.Helm == nil || len(serviceConfig.K8s.Helm.Releases) == 0 {
+		return nil, fmt.Errorf(
+			"rolling back service '%s' is only supported for services deployed with helm releases", serviceConfig.Name)
+	}
+
+	if !t.featureManager.IsEnabled(featureHelm) {
+		return nil, fmt.Errorf("Helm support is not enabled. Run '%s' to enable it.", alpha.GetEnableCommand(featureHelm))
+	}
+
+	previousDeploymentId, err := previousDeployment(t.env, serviceConfig.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	previousRevisions, err := parseHelmReleaseRevisions(previousDeploymentId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.setK8sContext(ctx, serviceConfig, "rollback"); err != nil {
+		return nil, err
+	}
+
+	revisions := []string{}
+	for _, release := range serviceConfig.K8s.Helm.Releases {
+		previousRevision, has := previousRevisions[release.Name]
+		if !has {
+			return nil, fmt.Errorf("%w of helm release '%s' is recorded", ErrNoPreviousDeployment, release.Name)
+		}
+
+		if release.Namespace == "" {
+			release.Namespace = t.getK8sNamespace(serviceConfig)
+		}
+
+		progress.SetProgress(NewServiceProgress(
+			fmt.Sprintf("Rolling back helm release %s to revision %d", release.Name, previousRevision)))
+		if err := t.helmCli.Rollback(ctx, release, previousRevision); err != nil {
+			return nil, err
+		}
 
+		// Rolling back creates a new revision of the release
+		revision, err := t.waitForHelmRelease(ctx, release)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
+
+		revisions = append(revisions, helmReleaseRevision(release.Name, revision))
 	}
 
-	return true, nil
+	recordDeployment(t.env, serviceConfig.Name, strings.Join(revisions, ","))
+	if err := t.envManager.Save(ctx, t.env); err != nil {
+		return nil, fmt.Errorf("saving deployment of service %s: %w", serviceConfig.Name, err)
+	}
+
+	progress.SetProgress(NewServiceProgress("Fetching endpoints for AKS service"))
+	endpoints, err := t.Endpoints(ctx, serviceConfig, targetResource)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServiceDeployResult{
+		TargetResourceId: azure.KubernetesServiceRID(
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceName(),
+		),
+		Kind:      AksTarget,
+		Endpoints: endpoints,
+	}, nil
 }
 
 // Gets the service endpoints for the AKS service target
@@ -509,6 +613,42 @@ func (t *aksTarget) Endpoints(
 	return endpoints, nil
 }
 
+// Streams the logs of the pods of the k8s deployment of the service
+func (t *aksTarget) StreamLogs(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	options LogStreamOptions,
+	writer io.Writer,
+) error {
+	if err := t.validateTargetResource(targetResource); err != nil {
+		return fmt.Errorf("validating target resource: %w", err)
+	}
+
+	// Reading logs never creates the namespace of the service
+	if err := t.useK8sContext(ctx, serviceConfig); err != nil {
+		return err
+	}
+
+	deploymentName := serviceConfig.K8s.Deployment.Name
+	if deploymentName == "" {
+		deploymentName = serviceConfig.Name
+	}
+
+	return t.kubectl.Logs(
+		ctx,
+		fmt.Sprintf("deployment/%s", deploymentName),
+		&kubectl.LogsOptions{
+			Follow: options.Follow,
+			Tail:   logStreamTailLines,
+		},
+		writer,
+		&kubectl.KubeCliFlags{
+			Namespace: t.getK8sNamespace(serviceConfig),
+		},
+	)
+}
+
 func (t *aksTarget) validateTargetResource(
 	targetResource *environment.TargetResource,
 ) error {
@@ -814,6 +954,27 @@ func (t *aksTarget) getIngressEndpoints(
 	return endpoints, nil
 }
 
+// helmReleaseRevision formats the revision of a helm release recorded for a deployment, like `api:3`
+func helmReleaseRevision(releaseName string, revision int) string {
+	return fmt.Sprintf("%s:%d", releaseName, revision)
+}
+
+// parseHelmReleaseRevisions parses the revisions of the helm releases recorded for a deployment, by release name
+func parseHelmReleaseRevisions(deploymentId string) (map[string]int, error) {
+	revisions := map[string]int{}
+	for _, releaseRevision := range strings.Split(deploymentId, ",") {
+		releaseName, revision, _ := strings.Cut(releaseRevision, ":")
+		value, err := strconv.Atoi(revision)
+		if err != nil {
+			return nil, fmt.Errorf("invalid helm release revision '%s': %w", releaseRevision, err)
+		}
+
+		revisions[releaseName] = value
+	}
+
+	return revisions, nil
+}
+
 func (t *aksTarget) getK8sNamespace(serviceConfig *ServiceConfig) string {
 	namespace := serviceConfig.K8s.Namespace
 	if namespace == "" {
@@ -824,41 +985,42 @@ func (t *aksTarget) getK8sNamespace(serviceConfig *ServiceConfig) string {
 }
 
 func (t *aksTarget) setK8sContext(ctx context.Context, serviceConfig *ServiceConfig, eventName ext.Event) error {
-	t.kubectl.SetEnv(t.env.Dotenv())
-	hasCustomKubeConfig := false
-
-	// If a KUBECONFIG env var is set, use it.
-	kubeConfigPath := t.env.Getenv(kubectl.KubeConfigEnvVarName)
-	if kubeConfigPath != "" {
-		t.kubectl.SetKubeConfig(kubeConfigPath)
-		hasCustomKubeConfig = true
-	}
-
-	targetResource, err := t.resourceManager.GetTargetResource(ctx, t.env.GetSubscriptionId(), serviceConfig)
-	if err != nil {
-		return err
-	}
-
-	defaultNamespace := t.getK8sNamespace(serviceConfig)
-	_, err = t.ensureClusterContext(ctx, serviceConfig, targetResource, defaultNamespace)
-	if err != nil {
+	if err := t.useK8sContext(ctx, serviceConfig); err != nil {
 		return err
 	}
 
-	err = t.ensureNamespace(ctx, defaultNamespace)
-	if err != nil {
+	if err := t.ensureNamespace(ctx, t.getK8sNamespace(serviceConfig)); err != nil {
 		return err
 	}
 
 	// Display message to the user when we detect they are using a non-default KUBECONFIG configuration
 	// In standard AZD AKS deployment users should not typically need to set a custom KUBECONFIG
-	if hasCustomKubeConfig && eventName == "predeploy" {
+	kubeConfigPath := t.env.Getenv(kubectl.KubeConfigEnvVarName)
+	if kubeConfigPath != "" && eventName == "predeploy" {
 		t.console.Message(ctx, output.WithWarningFormat("Using KUBECONFIG @ %s\n", kubeConfigPath))
 	}
 
 	return nil
 }
 
+// useK8sContext sets the kube context to the AKS cluster of the service, without creating any resource in the cluster.
+func (t *aksTarget) useK8sContext(ctx context.Context, serviceConfig *ServiceConfig) error {
+	t.kubectl.SetEnv(t.env.Dotenv())
+
+	// If a KUBECONFIG env var is set, use it.
+	if kubeConfigPath := t.env.Getenv(kubectl.KubeConfigEnvVarName); kubeConfigPath != "" {
+		t.kubectl.SetKubeConfig(kubeConfigPath)
+	}
+
+	targetResource, err := t.resourceManager.GetTargetResource(ctx, t.env.GetSubscriptionId(), serviceConfig)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.ensureClusterContext(ctx, serviceConfig, targetResource, t.getK8sNamespace(serviceConfig))
+	return err
+}
+
 // resolveClusterName attempts to resolve the cluster name from the following sources:
 // 1. The 'AZD_AKS_CLUSTER' environment variable
 // 2. The 'resourceName' property in the azure.yaml (Can use expandable string as well)
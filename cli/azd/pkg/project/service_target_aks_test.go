@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -315,6 +316,97 @@ func Test_Deploy_Helm(t *testing.T) {
 	helmStatus, helmStatusCalled := mockResults["helm-status"]
 	require.True(t, helmStatusCalled)
 	require.Contains(t, strings.Join(helmStatus.Args, " "), "status argocd")
+
+	require.Equal(t, "argocd:4", env.GetServiceProperty(serviceConfig.Name, deploymentIdProperty))
+}
+
+func Test_Rollback_Helm(t *testing.T) {
+	tempDir := t.TempDir()
+	ostest.Chdir(t, tempDir)
+
+	mockContext := mocks.NewMockContext(context.Background())
+	err := setupMocksForAksTarget(mockContext)
+	require.NoError(t, err)
+
+	mockResults, err := setupMocksForHelm(mockContext)
+	require.NoError(t, err)
+
+	serviceConfig := *createTestServiceConfig(tempDir, AksTarget, ServiceLanguageTypeScript)
+	serviceConfig.RelativePath = ""
+	serviceConfig.K8s.Helm = &helm.Config{
+		Releases: []*helm.Release{
+			{
+				Name:  "argocd",
+				Chart: "argo/argo-cd",
+			},
+		},
+	}
+
+	env := createEnv()
+	env.SetServiceProperty(serviceConfig.Name, deploymentIdProperty, "argocd:3")
+	env.SetServiceProperty(serviceConfig.Name, previousDeploymentIdProperty, "argocd:2")
+	userConfig := config.NewConfig(nil)
+	_ = userConfig.Set("alpha.aks.helm", "on")
+
+	serviceTarget := createAksServiceTarget(mockContext, &serviceConfig, env, userConfig)
+	err = simulateInitliaze(*mockContext.Context, serviceTarget, &serviceConfig)
+	require.NoError(t, err)
+
+	scope := environment.NewTargetResource("SUB_ID", "RG_ID", "", string(azapi.AzureResourceTypeManagedCluster))
+	deployResult, err := logProgress(
+		t, func(progress *async.Progress[ServiceProgress]) (*ServiceDeployResult, error) {
+			return serviceTarget.(ServiceRollbacker).Rollback(*mockContext.Context, &serviceConfig, scope, progress)
+		},
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, deployResult)
+
+	helmRollback, helmRollbackCalled := mockResults["helm-rollback"]
+	require.True(t, helmRollbackCalled)
+	require.Contains(t, strings.Join(helmRollback.Args, " "), "rollback argocd 2")
+
+	// The release is rolled back to a new revision, and the rolled back deployment becomes the previous one
+	require.Equal(t, "argocd:4", env.GetServiceProperty(serviceConfig.Name, deploymentIdProperty))
+	require.Equal(t, "argocd:3", env.GetServiceProperty(serviceConfig.Name, previousDeploymentIdProperty))
+}
+
+func Test_StreamLogs_NoNamespaceCreated(t *testing.T) {
+	tempDir := t.TempDir()
+	ostest.Chdir(t, tempDir)
+
+	mockContext := mocks.NewMockContext(context.Background())
+	err := setupMocksForAksTarget(mockContext)
+	require.NoError(t, err)
+
+	namespaceCreated := false
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "kubectl create namespace") || strings.Contains(command, "kubectl apply")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		namespaceCreated = true
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	var logsArgs exec.RunArgs
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "kubectl logs")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		logsArgs = args
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	serviceConfig := createTestServiceConfig(tempDir, AksTarget, ServiceLanguageTypeScript)
+	env := createEnv()
+
+	serviceTarget := createAksServiceTarget(mockContext, serviceConfig, env, nil)
+	scope := environment.NewTargetResource("SUB_ID", "RG_ID", "", string(azapi.AzureResourceTypeManagedCluster))
+	err = serviceTarget.(ServiceLogStreamer).StreamLogs(
+		*mockContext.Context, serviceConfig, scope, LogStreamOptions{}, io.Discard)
+	require.NoError(t, err)
+
+	// Reading the logs only uses the kube context of the cluster
+	require.False(t, namespaceCreated)
+	require.Contains(t, strings.Join(logsArgs.Args, " "), "logs deployment/api")
 }
 
 func Test_Deploy_Kustomize(t *testing.T) {
@@ -417,6 +509,13 @@ func setupMocksForHelm(mockContext *mocks.MockContext) (map[string]exec.RunArgs,
 		return exec.NewRunResult(0, "", ""), nil
 	})
 
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "helm rollback")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		result["helm-rollback"] = args
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
 	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
 		return strings.Contains(command, "helm status")
 	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
@@ -424,7 +523,8 @@ func setupMocksForHelm(mockContext *mocks.MockContext) (map[string]exec.RunArgs,
 		statusResult := `{
 			"info": {
 				"status": "deployed"
-			}
+			},
+			"version": 4
 		}`
 		return exec.NewRunResult(0, statusResult, ""), nil
 	})
@@ -848,6 +948,7 @@ func createAksServiceTarget(
 		clock.NewMock(),
 		containerRegistryService,
 		remoteBuildManager,
+		nil,
 		dockerCli,
 		mockContext.Console,
 		cloud.AzurePublic(),
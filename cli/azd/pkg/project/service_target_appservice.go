@@ -5,31 +5,45 @@ package project
 
 import (
 	"context"
+	"crypto/sha256"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/azure"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
 )
 
 type appServiceTarget struct {
-	env *environment.Environment
-	cli azcli.AzCli
+	env        *environment.Environment
+	envManager environment.Manager
+	azdCtx     *azdcontext.AzdContext
+	cli        azcli.AzCli
 }
 
 // NewAppServiceTarget creates a new instance of the AppServiceTarget
 func NewAppServiceTarget(
 	env *environment.Environment,
+	envManager environment.Manager,
+	azdCtx *azdcontext.AzdContext,
 	azCli azcli.AzCli,
 ) ServiceTarget {
 	return &appServiceTarget{
-		env: env,
-		cli: azCli,
+		env:        env,
+		envManager: envManager,
+		azdCtx:     azdCtx,
+		cli:        azCli,
 	}
 }
 
@@ -51,18 +65,15 @@ func (st *appServiceTarget) Package(
 	progress *async.Progress[ServiceProgress],
 ) (*ServicePackageResult, error) {
 	progress.SetProgress(NewServiceProgress("Compressing deployment artifacts"))
-	zipFilePath, err := createDeployableZip(
-		serviceConfig.Project.Name,
-		serviceConfig.Name,
-		packageOutput.PackagePath,
-	)
+	zipFilePath, excluded, err := createDeployableZip(serviceConfig, packageOutput.PackagePath)
 	if err != nil {
 		return nil, err
 	}
 
 	return &ServicePackageResult{
-		Build:       packageOutput.Build,
-		PackagePath: zipFilePath,
+		Build:         packageOutput.Build,
+		PackagePath:   zipFilePath,
+		ExcludedFiles: append(packageOutput.ExcludedFiles, excluded...),
 	}, nil
 }
 
@@ -78,6 +89,11 @@ func (st *appServiceTarget) Deploy(
 		return nil, fmt.Errorf("validating target resource: %w", err)
 	}
 
+	slot, err := DeploymentSlot(st.env, serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	zipFile, err := os.Open(packageOutput.PackagePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed reading deployment zip file: %w", err)
@@ -92,6 +108,7 @@ func (st *appServiceTarget) Deploy(
 		targetResource.SubscriptionId(),
 		targetResource.ResourceGroupName(),
 		targetResource.ResourceName(),
+		slot,
 		zipFile,
 		func(logProgress string) { progress.SetProgress(NewServiceProgress(logProgress)) },
 	)
@@ -99,8 +116,122 @@ func (st *appServiceTarget) Deploy(
 		return nil, fmt.Errorf("deploying service %s: %w", serviceConfig.Name, err)
 	}
 
+	// The package is kept so the service can be rolled back to it once it's replaced by a new deployment
+	progress.SetProgress(NewServiceProgress("Saving deployment package"))
+	recordSlotSwap(st.env, serviceConfig.Name, "")
+	if err := st.keepPackage(ctx, serviceConfig, slot, packageOutput.PackagePath); err != nil {
+		return nil, err
+	}
+
+	return st.deployResult(ctx, packageOutput, targetResource, slot, *res, progress)
+}
+
+// Rolls back the App Service. When the last deployment was swapped from a deployment slot into production, the slot is
+// swapped back. Otherwise the package of the previous deployment to the deployment slot of the service, or to production
+// when the service isn't deployed to a slot, is redeployed to it.
+func (st *appServiceTarget) Rollback(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
+	if err := st.validateTargetResource(targetResource); err != nil {
+		return nil, fmt.Errorf("validating target resource: %w", err)
+	}
+
+	slot, err := DeploymentSlot(st.env, serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if swapped, err := swapSlotBack(ctx, st.env, st.cli, serviceConfig, targetResource, slot, progress); err != nil {
+		return nil, err
+	} else if swapped {
+		return st.deployResult(ctx, nil, targetResource, "", "OK", progress)
+	}
+
+	previousPackage, err := previousSlotDeployment(st.env, serviceConfig.Name, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	zipFile, err := os.Open(filepath.Join(st.packagesDirectory(serviceConfig, slot), previousPackage))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf(
+			"%w of service '%s', the package '%s' is not found", ErrNoPreviousDeployment, serviceConfig.Name, previousPackage)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed reading deployment zip file: %w", err)
+	}
+	defer zipFile.Close()
+
+	progress.SetProgress(NewServiceProgress(fmt.Sprintf("Redeploying package %s", previousPackage)))
+	res, err := st.cli.DeployAppServiceZip(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		slot,
+		zipFile,
+		func(logProgress string) { progress.SetProgress(NewServiceProgress(logProgress)) },
+	)
+	if err != nil {
+		return nil, fmt.Errorf("rolling back service %s: %w", serviceConfig.Name, err)
+	}
+
+	recordSlotDeployment(st.env, serviceConfig.Name, slot, previousPackage)
+	if err := st.envManager.Save(ctx, st.env); err != nil {
+		return nil, fmt.Errorf("saving deployment of service %s: %w", serviceConfig.Name, err)
+	}
+
+	return st.deployResult(ctx, nil, targetResource, slot, *res, progress)
+}
+
+// Swaps the deployment slot of the App Service with its production slot
+func (st *appServiceTarget) SwapSlot(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
+	if err := st.validateTargetResource(targetResource); err != nil {
+		return nil, fmt.Errorf("validating target resource: %w", err)
+	}
+
+	slot, err := requiredDeploymentSlot(st.env, serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	progress.SetProgress(NewServiceProgress(fmt.Sprintf("Swapping slot %s with production", slot)))
+	err = st.cli.SwapAppServiceSlot(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		slot,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("swapping slot of service %s: %w", serviceConfig.Name, err)
+	}
+
+	recordSlotSwap(st.env, serviceConfig.Name, slot)
+	if err := st.envManager.Save(ctx, st.env); err != nil {
+		return nil, fmt.Errorf("saving deployment of service %s: %w", serviceConfig.Name, err)
+	}
+
+	return st.deployResult(ctx, nil, targetResource, "", "OK", progress)
+}
+
+func (st *appServiceTarget) deployResult(
+	ctx context.Context,
+	packageOutput *ServicePackageResult,
+	targetResource *environment.TargetResource,
+	slot string,
+	rawResult string,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
 	progress.SetProgress(NewServiceProgress("Fetching endpoints for app service"))
-	endpoints, err := st.Endpoints(ctx, serviceConfig, targetResource)
+	endpoints, err := st.endpoints(ctx, targetResource, slot)
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +243,7 @@ func (st *appServiceTarget) Deploy(
 			targetResource.ResourceName(),
 		),
 		AppServiceTarget,
-		*res,
+		rawResult,
 		endpoints,
 	)
 	sdr.Package = packageOutput
@@ -120,17 +251,31 @@ func (st *appServiceTarget) Deploy(
 	return sdr, nil
 }
 
-// Gets the exposed endpoints for the App Service
+// Gets the exposed endpoints for the App Service, or for its deployment slot when the service is deployed to a slot
 func (st *appServiceTarget) Endpoints(
 	ctx context.Context,
 	serviceConfig *ServiceConfig,
 	targetResource *environment.TargetResource,
+) ([]string, error) {
+	slot, err := DeploymentSlot(st.env, serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return st.endpoints(ctx, targetResource, slot)
+}
+
+func (st *appServiceTarget) endpoints(
+	ctx context.Context,
+	targetResource *environment.TargetResource,
+	slot string,
 ) ([]string, error) {
 	appServiceProperties, err := st.cli.GetAppServiceProperties(
 		ctx,
 		targetResource.SubscriptionId(),
 		targetResource.ResourceGroupName(),
 		targetResource.ResourceName(),
+		slot,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("fetching service properties: %w", err)
@@ -144,6 +289,179 @@ func (st *appServiceTarget) Endpoints(
 	return endpoints, nil
 }
 
+// Streams the application logs of the App Service, or of its deployment slot, from its log stream
+func (st *appServiceTarget) StreamLogs(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	options LogStreamOptions,
+	writer io.Writer,
+) error {
+	if err := st.validateTargetResource(targetResource); err != nil {
+		return fmt.Errorf("validating target resource: %w", err)
+	}
+
+	slot, err := DeploymentSlot(st.env, serviceConfig)
+	if err != nil {
+		return err
+	}
+
+	return st.cli.StreamAppServiceLogs(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		slot,
+		options.Follow,
+		writer,
+	)
+}
+
+// packagesDirectory gets the directory of the environment where the packages deployed to the deployment slot of the service
+// are kept, the production slot being the empty slot
+func (st *appServiceTarget) packagesDirectory(serviceConfig *ServiceConfig, slot string) string {
+	packagesDir := filepath.Join(st.azdCtx.EnvironmentRoot(st.env.Name()), "packages", serviceConfig.Name)
+	if slot != "" {
+		return filepath.Join(packagesDir, "slots", slot)
+	}
+
+	return packagesDir
+}
+
+// keepPackage moves the deployed package to the packages directory of the environment and records it as the current
+// deployment of the service to the deployment slot. Only the packages of the current and previous deployments are kept.
+func (st *appServiceTarget) keepPackage(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	slot string,
+	packagePath string,
+) error {
+	packagesDir := st.packagesDirectory(serviceConfig, slot)
+	if err := os.MkdirAll(packagesDir, osutil.PermissionDirectory); err != nil {
+		return fmt.Errorf("creating packages directory: %w", err)
+	}
+
+	// The package is named after its contents, so deploying the same package again is not recorded as a new deployment
+	packageName, err := packageFileName(packagePath)
+	if err != nil {
+		return err
+	}
+
+	if err := moveFile(packagePath, filepath.Join(packagesDir, packageName)); err != nil {
+		return fmt.Errorf("saving deployment package: %w", err)
+	}
+
+	recordSlotDeployment(st.env, serviceConfig.Name, slot, packageName)
+	if err := st.envManager.Save(ctx, st.env); err != nil {
+		return fmt.Errorf("saving deployment of service %s: %w", serviceConfig.Name, err)
+	}
+
+	entries, err := os.ReadDir(packagesDir)
+	if err != nil {
+		return fmt.Errorf("reading packages directory: %w", err)
+	}
+
+	keep := []string{
+		st.env.GetServiceProperty(serviceConfig.Name, slotProperty(deploymentIdProperty, slot)),
+		st.env.GetServiceProperty(serviceConfig.Name, slotProperty(previousDeploymentIdProperty, slot)),
+	}
+
+	for _, entry := range entries {
+		// The packages of the deployment slots are kept in their own directories
+		if entry.IsDir() {
+			continue
+		}
+
+		if !slices.Contains(keep, entry.Name()) {
+			if err := os.Remove(filepath.Join(packagesDir, entry.Name())); err != nil {
+				log.Printf("failed removing deployment package '%s': %v", entry.Name(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// packageFileName gets the file name of a kept package, from the hash of its contents
+func packageFileName(packagePath string) (string, error) {
+	packageFile, err := os.Open(packagePath)
+	if err != nil {
+		return "", fmt.Errorf("failed reading deployment zip file: %w", err)
+	}
+	defer packageFile.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, packageFile); err != nil {
+		return "", fmt.Errorf("failed reading deployment zip file: %w", err)
+	}
+
+	return fmt.Sprintf("%x.zip", hash.Sum(nil)[:8]), nil
+}
+
+// DeploymentSlot gets the deployment slot the service is deployed to, expanded from the environment. The production slot
+// is returned as an empty slot name.
+func DeploymentSlot(env *environment.Environment, serviceConfig *ServiceConfig) (string, error) {
+	slot, err := serviceConfig.Slot.Envsubst(env.Getenv)
+	if err != nil {
+		return "", fmt.Errorf("expanding deployment slot of service %s: %w", serviceConfig.Name, err)
+	}
+
+	if strings.EqualFold(slot, "production") {
+		return "", nil
+	}
+
+	return slot, nil
+}
+
+// requiredDeploymentSlot gets the deployment slot of the service, which must be deployed to a slot other than production
+func requiredDeploymentSlot(env *environment.Environment, serviceConfig *ServiceConfig) (string, error) {
+	slot, err := DeploymentSlot(env, serviceConfig)
+	if err != nil {
+		return "", err
+	}
+
+	if slot == "" {
+		return "", fmt.Errorf(
+			"service '%s' is not deployed to a deployment slot, set 'slot' in %s",
+			serviceConfig.Name,
+			azdcontext.ProjectFileName,
+		)
+	}
+
+	return slot, nil
+}
+
+// swapSlotBack rolls back a deployment that was swapped from the deployment slot into production, by swapping the slot
+// with production again. It returns false when the last deployment of the service didn't swap the slot. The swap stays
+// recorded, so rolling back twice restores the current deployment.
+func swapSlotBack(
+	ctx context.Context,
+	env *environment.Environment,
+	cli azcli.AzCli,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	slot string,
+	progress *async.Progress[ServiceProgress],
+) (bool, error) {
+	if slot == "" || env.GetServiceProperty(serviceConfig.Name, swappedSlotProperty) != slot {
+		return false, nil
+	}
+
+	progress.SetProgress(NewServiceProgress(fmt.Sprintf("Swapping slot %s back with production", slot)))
+	err := cli.SwapAppServiceSlot(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		slot,
+	)
+	if err != nil {
+		return false, fmt.Errorf("rolling back service %s: %w", serviceConfig.Name, err)
+	}
+
+	return true, nil
+}
+
 func (st *appServiceTarget) validateTargetResource(
 	targetResource *environment.TargetResource,
 ) error {
@@ -4,11 +4,21 @@
 package project
 
 import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/azcli"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
 	"github.com/stretchr/testify/require"
 )
 
@@ -53,3 +63,234 @@ func TestNewAppServiceTargetTypeValidation(t *testing.T) {
 		})
 	}
 }
+
+func Test_AppService_KeepPackage(t *testing.T) {
+	tempDir := t.TempDir()
+	mockContext := mocks.NewMockContext(context.Background())
+
+	env := environment.New("test")
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Save", *mockContext.Context, env).Return(nil)
+
+	serviceTarget := &appServiceTarget{
+		env:        env,
+		envManager: envManager,
+		azdCtx:     azdcontext.NewAzdContextWithDirectory(tempDir),
+	}
+	serviceConfig := createTestServiceConfig(tempDir, AppServiceTarget, ServiceLanguagePython)
+
+	packageNames := []string{}
+	for _, contents := range []string{"first", "second", "third"} {
+		packagePath := filepath.Join(tempDir, "package.zip")
+		require.NoError(t, os.WriteFile(packagePath, []byte(contents), osutil.PermissionFile))
+		require.NoError(t, serviceTarget.keepPackage(*mockContext.Context, serviceConfig, "", packagePath))
+		require.NoFileExists(t, packagePath)
+
+		packageNames = append(packageNames, env.GetServiceProperty(serviceConfig.Name, deploymentIdProperty))
+	}
+
+	// Only the packages of the current and previous deployments are kept
+	require.Equal(t, packageNames[1], env.GetServiceProperty(serviceConfig.Name, previousDeploymentIdProperty))
+
+	entries, err := os.ReadDir(serviceTarget.packagesDirectory(serviceConfig, ""))
+	require.NoError(t, err)
+	kept := []string{}
+	for _, entry := range entries {
+		kept = append(kept, entry.Name())
+	}
+	require.ElementsMatch(t, packageNames[1:], kept)
+}
+
+func Test_DeploymentSlot(t *testing.T) {
+	env := environment.NewWithValues("test", map[string]string{
+		"SLOT_NAME": "staging",
+	})
+
+	tests := []struct {
+		name     string
+		slot     string
+		wantSlot string
+	}{
+		{name: "Production", slot: "", wantSlot: ""},
+		{name: "ProductionByName", slot: "Production", wantSlot: ""},
+		{name: "Slot", slot: "preview", wantSlot: "preview"},
+		{name: "Expanded", slot: "${SLOT_NAME}", wantSlot: "staging"},
+		{name: "ExpandedEmpty", slot: "${MISSING_SLOT_NAME}", wantSlot: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguagePython)
+			serviceConfig.Slot = osutil.NewExpandableString(tt.slot)
+
+			slot, err := DeploymentSlot(env, serviceConfig)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSlot, slot)
+
+			_, err = requiredDeploymentSlot(env, serviceConfig)
+			if tt.wantSlot == "" {
+				require.ErrorContains(t, err, "is not deployed to a deployment slot")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// slotSwappingAzCli records the slots swapped with production
+type slotSwappingAzCli struct {
+	azcli.AzCli
+	swaps []string
+}
+
+func (cli *slotSwappingAzCli) SwapAppServiceSlot(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	slotName string,
+) error {
+	cli.swaps = append(cli.swaps, slotName)
+	return nil
+}
+
+func (cli *slotSwappingAzCli) GetAppServiceProperties(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	applicationName string,
+	slotName string,
+) (*azcli.AzCliAppServiceProperties, error) {
+	return &azcli.AzCliAppServiceProperties{}, nil
+}
+
+func (cli *slotSwappingAzCli) GetFunctionAppProperties(
+	ctx context.Context,
+	subscriptionID string,
+	resourceGroup string,
+	funcName string,
+	slotName string,
+) (*azcli.AzCliFunctionAppProperties, error) {
+	return &azcli.AzCliFunctionAppProperties{}, nil
+}
+
+func Test_RollbackSwapsSlotBack(t *testing.T) {
+	targets := map[string]func(*environment.Environment, environment.Manager, azcli.AzCli) ServiceTarget{
+		"AppService": func(env *environment.Environment, envManager environment.Manager, cli azcli.AzCli) ServiceTarget {
+			return NewAppServiceTarget(env, envManager, nil, cli)
+		},
+		"FunctionApp": NewFunctionAppTarget,
+	}
+
+	for name, newTarget := range targets {
+		t.Run(name, func(t *testing.T) {
+			mockContext := mocks.NewMockContext(context.Background())
+			env := environment.New("test")
+			envManager := &mockenv.MockEnvManager{}
+			envManager.On("Save", *mockContext.Context, env).Return(nil)
+
+			cli := &slotSwappingAzCli{}
+			serviceTarget := newTarget(env, envManager, cli)
+			serviceConfig := createTestServiceConfig("./src/api", AppServiceTarget, ServiceLanguagePython)
+			serviceConfig.Slot = osutil.NewExpandableString("staging")
+			targetResource := environment.NewTargetResource(
+				"SUB_ID", "RG_ID", "res", string(azapi.AzureResourceTypeWebSite))
+
+			_, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServiceDeployResult, error) {
+				return serviceTarget.(ServiceSlotSwapper).SwapSlot(
+					*mockContext.Context, serviceConfig, targetResource, progress)
+			})
+			require.NoError(t, err)
+			require.Equal(t, "staging", env.GetServiceProperty(serviceConfig.Name, swappedSlotProperty))
+
+			// The previous production deployment is in the slot, so it's swapped back into production
+			_, err = logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServiceDeployResult, error) {
+				return serviceTarget.(ServiceRollbacker).Rollback(
+					*mockContext.Context, serviceConfig, targetResource, progress)
+			})
+			require.NoError(t, err)
+			require.Equal(t, []string{"staging", "staging"}, cli.swaps)
+
+			// Deployments that weren't swapped aren't rolled back by swapping
+			recordSlotSwap(env, serviceConfig.Name, "")
+			_, err = logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServiceDeployResult, error) {
+				return serviceTarget.(ServiceRollbacker).Rollback(
+					*mockContext.Context, serviceConfig, targetResource, progress)
+			})
+			require.ErrorIs(t, err, ErrNoPreviousDeployment)
+			require.Len(t, cli.swaps, 2)
+		})
+	}
+}
+
+// zipDeployingAzCli records the contents of the packages deployed to each slot
+type zipDeployingAzCli struct {
+	slotSwappingAzCli
+	deployments map[string][]string
+}
+
+func (cli *zipDeployingAzCli) DeployAppServiceZip(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	slotName string,
+	deployZipFile io.ReadSeeker,
+	logProgress func(string),
+) (*string, error) {
+	contents, err := io.ReadAll(deployZipFile)
+	if err != nil {
+		return nil, err
+	}
+
+	cli.deployments[slotName] = append(cli.deployments[slotName], string(contents))
+	result := "OK"
+	return &result, nil
+}
+
+func Test_AppService_RollbackRedeploysToSlot(t *testing.T) {
+	tempDir := t.TempDir()
+	mockContext := mocks.NewMockContext(context.Background())
+	env := environment.New("test")
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Save", *mockContext.Context, env).Return(nil)
+
+	cli := &zipDeployingAzCli{deployments: map[string][]string{}}
+	serviceTarget := NewAppServiceTarget(env, envManager, azdcontext.NewAzdContextWithDirectory(tempDir), cli)
+	serviceConfig := createTestServiceConfig(tempDir, AppServiceTarget, ServiceLanguagePython)
+	serviceConfig.Slot = osutil.NewExpandableString("staging")
+	targetResource := environment.NewTargetResource("SUB_ID", "RG_ID", "res", string(azapi.AzureResourceTypeWebSite))
+
+	// Production and the slot both have a previous deployment
+	deployments := map[string][]string{
+		"":        {"production-1", "production-2"},
+		"staging": {"staging-1", "staging-2"},
+	}
+	for slot, contents := range deployments {
+		for _, content := range contents {
+			packagePath := filepath.Join(tempDir, "package.zip")
+			require.NoError(t, os.WriteFile(packagePath, []byte(content), osutil.PermissionFile))
+			require.NoError(t, serviceTarget.(*appServiceTarget).keepPackage(
+				*mockContext.Context, serviceConfig, slot, packagePath))
+		}
+	}
+	productionDeployment := env.GetServiceProperty(serviceConfig.Name, deploymentIdProperty)
+	slotPackage := env.GetServiceProperty(serviceConfig.Name, slotProperty(deploymentIdProperty, "staging"))
+	previousSlotPackage := env.GetServiceProperty(
+		serviceConfig.Name, slotProperty(previousDeploymentIdProperty, "staging"))
+
+	// The last deployment to the slot wasn't swapped into production, the previous package of the slot is redeployed to it
+	_, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (*ServiceDeployResult, error) {
+		return serviceTarget.(ServiceRollbacker).Rollback(*mockContext.Context, serviceConfig, targetResource, progress)
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"staging": {"staging-1"}}, cli.deployments)
+	require.Empty(t, cli.swaps)
+
+	// The deployments of production are left untouched
+	require.Equal(t, productionDeployment, env.GetServiceProperty(serviceConfig.Name, deploymentIdProperty))
+	require.Equal(t,
+		previousSlotPackage, env.GetServiceProperty(serviceConfig.Name, slotProperty(deploymentIdProperty, "staging")))
+	require.Equal(t,
+		slotPackage, env.GetServiceProperty(serviceConfig.Name, slotProperty(previousDeploymentIdProperty, "staging")))
+}
@@ -6,6 +6,7 @@ package project
 import (
 	"context"
 	"fmt"
+	"io"
 	"strconv"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
@@ -13,6 +14,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/azure"
 	"github.com/azure/azure-dev/cli/azd/pkg/containerapps"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
 )
 
@@ -22,6 +24,7 @@ type containerAppTarget struct {
 	containerHelper     *ContainerHelper
 	containerAppService containerapps.ContainerAppService
 	resourceManager     ResourceManager
+	console             input.Console
 }
 
 // NewContainerAppTarget creates the container app service target.
@@ -34,6 +37,7 @@ func NewContainerAppTarget(
 	containerHelper *ContainerHelper,
 	containerAppService containerapps.ContainerAppService,
 	resourceManager ResourceManager,
+	console input.Console,
 ) ServiceTarget {
 	return &containerAppTarget{
 		env:                 env,
@@ -41,6 +45,7 @@ func NewContainerAppTarget(
 		containerHelper:     containerHelper,
 		containerAppService: containerAppService,
 		resourceManager:     resourceManager,
+		console:             console,
 	}
 }
 
@@ -91,19 +96,84 @@ func (at *containerAppTarget) Deploy(
 	}
 
 	imageName := at.env.GetServiceProperty(serviceConfig.Name, "IMAGE_NAME")
-	progress.SetProgress(NewServiceProgress("Updating container app revision"))
-	err = at.containerAppService.AddRevision(
+	var revisionName string
+	if serviceConfig.Deploy.Strategy != nil {
+		revisionName, err = at.addRevisionWithStrategy(
+			ctx, serviceConfig, targetResource, imageName, &containerAppOptions, progress)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		progress.SetProgress(NewServiceProgress("Updating container app revision"))
+		revisionName, err = at.containerAppService.AddRevision(
+			ctx,
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceName(),
+			imageName,
+			&containerAppOptions,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("updating container app service: %w", err)
+		}
+	}
+
+	recordDeployment(at.env, serviceConfig.Name, revisionName)
+	if err := at.envManager.Save(ctx, at.env); err != nil {
+		return nil, fmt.Errorf("saving deployment of service %s: %w", serviceConfig.Name, err)
+	}
+
+	return at.deployResult(ctx, serviceConfig, packageOutput, targetResource, progress)
+}
+
+// Rolls back the container app to the revision of the previous deployment
+func (at *containerAppTarget) Rollback(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
+	if err := at.validateTargetResource(targetResource); err != nil {
+		return nil, fmt.Errorf("validating target resource: %w", err)
+	}
+
+	previousRevisionName, err := previousDeployment(at.env, serviceConfig.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	containerAppOptions := containerapps.ContainerAppOptions{
+		ApiVersion: serviceConfig.ApiVersion,
+	}
+
+	progress.SetProgress(NewServiceProgress(fmt.Sprintf("Rolling back to revision %s", previousRevisionName)))
+	revisionName, err := at.containerAppService.RollbackRevision(
 		ctx,
 		targetResource.SubscriptionId(),
 		targetResource.ResourceGroupName(),
 		targetResource.ResourceName(),
-		imageName,
+		previousRevisionName,
 		&containerAppOptions,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("updating container app service: %w", err)
+		return nil, fmt.Errorf("rolling back container app service: %w", err)
 	}
 
+	recordDeployment(at.env, serviceConfig.Name, revisionName)
+	if err := at.envManager.Save(ctx, at.env); err != nil {
+		return nil, fmt.Errorf("saving deployment of service %s: %w", serviceConfig.Name, err)
+	}
+
+	return at.deployResult(ctx, serviceConfig, nil, targetResource, progress)
+}
+
+func (at *containerAppTarget) deployResult(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	packageOutput *ServicePackageResult,
+	targetResource *environment.TargetResource,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
 	progress.SetProgress(NewServiceProgress("Fetching endpoints for container app service"))
 	endpoints, err := at.Endpoints(ctx, serviceConfig, targetResource)
 	if err != nil {
@@ -150,6 +220,37 @@ func (at *containerAppTarget) Endpoints(
 	}
 }
 
+// Streams the console logs of the containers, or the system logs, of the container app
+func (at *containerAppTarget) StreamLogs(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	options LogStreamOptions,
+	writer io.Writer,
+) error {
+	if err := at.validateTargetResource(targetResource); err != nil {
+		return fmt.Errorf("validating target resource: %w", err)
+	}
+
+	containerAppOptions := containerapps.ContainerAppOptions{
+		ApiVersion: serviceConfig.ApiVersion,
+	}
+
+	return at.containerAppService.StreamLogs(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		&containerapps.LogStreamOptions{
+			Follow:    options.Follow,
+			TailLines: logStreamTailLines,
+			System:    options.System,
+		},
+		&containerAppOptions,
+		writer,
+	)
+}
+
 func (at *containerAppTarget) validateTargetResource(
 	targetResource *environment.TargetResource,
 ) error {
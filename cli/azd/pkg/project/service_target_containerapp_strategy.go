@@ -0,0 +1,369 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appcontainers/armappcontainers/v3"
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/containerapps"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/sethvargo/go-retry"
+)
+
+// The deployment options of a service
+type DeployOptions struct {
+	// The strategy used to route the traffic of a container app to its new revision.
+	// By default, the new revision receives all the traffic as soon as it's created.
+	Strategy *DeployStrategy `yaml:"strategy,omitempty"`
+}
+
+type DeployStrategyKind string
+
+const (
+	// The new revision receives an increasing share of the traffic, step by step, before it receives all the traffic
+	CanaryDeployStrategy DeployStrategyKind = "canary"
+	// The new revision is reachable on the URL of a label, to verify it before it receives all the traffic
+	BlueGreenDeployStrategy DeployStrategyKind = "blueGreen"
+)
+
+// DeployStrategy configures how the traffic is routed to the new revision of a container app. The health of the new
+// revision, as reported by the health probes of its containers, is checked after each step, and the traffic is routed
+// back to the previous revisions when the new revision is unhealthy.
+type DeployStrategy struct {
+	// The kind of strategy, canary or blueGreen
+	Type DeployStrategyKind `yaml:"type"`
+	// The percentages of the traffic routed to the new revision by the canary steps, in increasing order.
+	// Defaults to 10 and 50.
+	Steps []int32 `yaml:"steps,omitempty"`
+	// The duration to wait after each canary step, like 5m
+	Pause string `yaml:"pause,omitempty"`
+	// Prompts for confirmation before each canary step, or before promoting the blue/green revision
+	Confirm bool `yaml:"confirm,omitempty"`
+	// The label of the blue/green revision. Defaults to green.
+	Label string `yaml:"label,omitempty"`
+	// The maximum duration to wait for the new revision to be healthy after each step. Defaults to 5m.
+	HealthTimeout string `yaml:"healthTimeout,omitempty"`
+}
+
+var (
+	defaultCanarySteps           = []int32{10, 50}
+	defaultBlueGreenLabel        = "green"
+	defaultRevisionHealthTimeout = 5 * time.Minute
+	// The maximum duration to route the traffic back to the previous revisions, which outlives the cancellation of the
+	// deployment
+	trafficRollbackTimeout = 2 * time.Minute
+)
+
+// deployStrategyPlan is the validated configuration of a deploy strategy
+type deployStrategyPlan struct {
+	kind          DeployStrategyKind
+	steps         []int32
+	pause         time.Duration
+	confirm       bool
+	label         string
+	healthTimeout time.Duration
+}
+
+func newDeployStrategyPlan(strategy *DeployStrategy) (*deployStrategyPlan, error) {
+	plan := &deployStrategyPlan{
+		kind:          strategy.Type,
+		confirm:       strategy.Confirm,
+		healthTimeout: defaultRevisionHealthTimeout,
+	}
+
+	switch strategy.Type {
+	case CanaryDeployStrategy:
+		plan.steps = defaultCanarySteps
+		if len(strategy.Steps) > 0 {
+			plan.steps = strategy.Steps
+		}
+
+		for i, step := range plan.steps {
+			if step <= 0 || step >= 100 || (i > 0 && step <= plan.steps[i-1]) {
+				return nil, fmt.Errorf(
+					"invalid canary steps %v, the steps must be increasing percentages between 1 and 99", plan.steps)
+			}
+		}
+	case BlueGreenDeployStrategy:
+		plan.label = defaultBlueGreenLabel
+		if strategy.Label != "" {
+			plan.label = strategy.Label
+		}
+	default:
+		return nil, fmt.Errorf(
+			"unsupported deploy strategy '%s', the supported strategies are '%s' and '%s'",
+			strategy.Type,
+			CanaryDeployStrategy,
+			BlueGreenDeployStrategy,
+		)
+	}
+
+	if strategy.Pause != "" {
+		pause, err := time.ParseDuration(strategy.Pause)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deploy strategy pause '%s': %w", strategy.Pause, err)
+		}
+
+		plan.pause = pause
+	}
+
+	if strategy.HealthTimeout != "" {
+		healthTimeout, err := time.ParseDuration(strategy.HealthTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deploy strategy health timeout '%s': %w", strategy.HealthTimeout, err)
+		}
+
+		plan.healthTimeout = healthTimeout
+	}
+
+	return plan, nil
+}
+
+// addRevisionWithStrategy adds a new revision to the container app without traffic, and routes the traffic to it
+// following the deploy strategy of the service. When the new revision is unhealthy, or a step is declined, the traffic is
+// routed back to the previous revisions. Returns the name of the new revision.
+func (at *containerAppTarget) addRevisionWithStrategy(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	imageName string,
+	options *containerapps.ContainerAppOptions,
+	progress *async.Progress[ServiceProgress],
+) (string, error) {
+	plan, err := newDeployStrategyPlan(serviceConfig.Deploy.Strategy)
+	if err != nil {
+		return "", err
+	}
+
+	subscriptionId := targetResource.SubscriptionId()
+	resourceGroupName := targetResource.ResourceGroupName()
+	appName := targetResource.ResourceName()
+
+	previousTrafficWeights, err := at.containerAppService.GetTrafficWeights(
+		ctx, subscriptionId, resourceGroupName, appName, options)
+	if err != nil {
+		return "", fmt.Errorf("getting traffic weights: %w", err)
+	}
+
+	progress.SetProgress(NewServiceProgress("Creating container app revision without traffic"))
+	revisionName, err := at.containerAppService.StageRevision(
+		ctx, subscriptionId, resourceGroupName, appName, imageName, plan.label, options)
+	if err != nil {
+		return "", fmt.Errorf("updating container app service: %w", err)
+	}
+
+	// rollback routes the traffic back to the previous revisions, since the new revision can't be promoted
+	// The traffic is routed back even when the deployment is cancelled, like when the pause of a canary step is interrupted
+	rollback := func(cause error) error {
+		rollbackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), trafficRollbackTimeout)
+		defer cancel()
+
+		progress.SetProgress(NewServiceProgress("Routing traffic back to the previous revisions"))
+		err := at.containerAppService.SetTrafficWeights(
+			rollbackCtx, subscriptionId, resourceGroupName, appName, previousTrafficWeights, options)
+		if err != nil {
+			return fmt.Errorf("%w, and routing traffic back to the previous revisions failed: %w", cause, err)
+		}
+
+		return fmt.Errorf("traffic routed back from revision '%s' to the previous revisions: %w", revisionName, cause)
+	}
+
+	if err := at.waitForHealthyRevision(ctx, targetResource, revisionName, plan, options, progress); err != nil {
+		return "", rollback(err)
+	}
+
+	switch plan.kind {
+	case CanaryDeployStrategy:
+		for _, weight := range plan.steps {
+			if plan.confirm {
+				confirmed, err := at.console.Confirm(ctx, input.ConsoleOptions{
+					Message: fmt.Sprintf(
+						"Route %d%% of the traffic of service %s to revision %s?", weight, serviceConfig.Name, revisionName),
+					DefaultValue: true,
+				})
+				if err != nil {
+					return "", rollback(err)
+				} else if !confirmed {
+					return "", rollback(fmt.Errorf("routing %d%% of the traffic was declined", weight))
+				}
+			}
+
+			progress.SetProgress(NewServiceProgress(fmt.Sprintf("Routing %d%% of the traffic to the new revision", weight)))
+			err := at.containerAppService.SetTrafficWeights(
+				ctx,
+				subscriptionId,
+				resourceGroupName,
+				appName,
+				canaryTrafficWeights(previousTrafficWeights, revisionName, weight),
+				options,
+			)
+			if err != nil {
+				return "", rollback(err)
+			}
+
+			if plan.pause > 0 {
+				progress.SetProgress(NewServiceProgress(
+					fmt.Sprintf("Pausing for %s with %d%% of the traffic on the new revision", plan.pause, weight)))
+				select {
+				case <-time.After(plan.pause):
+				case <-ctx.Done():
+					return "", rollback(ctx.Err())
+				}
+			}
+
+			if err := at.waitForHealthyRevision(ctx, targetResource, revisionName, plan, options, progress); err != nil {
+				return "", rollback(err)
+			}
+		}
+	case BlueGreenDeployStrategy:
+		if plan.confirm {
+			labelUrl, err := at.labelUrl(ctx, serviceConfig, targetResource, plan.label)
+			if err != nil {
+				return "", rollback(err)
+			}
+
+			confirmed, err := at.console.Confirm(ctx, input.ConsoleOptions{
+				Message: fmt.Sprintf(
+					"Promote revision %s of service %s, available at %s, to receive all the traffic?",
+					revisionName,
+					serviceConfig.Name,
+					labelUrl,
+				),
+				DefaultValue: true,
+			})
+			if err != nil {
+				return "", rollback(err)
+			} else if !confirmed {
+				return "", rollback(errors.New("promoting the revision was declined"))
+			}
+		}
+	}
+
+	progress.SetProgress(NewServiceProgress("Routing all the traffic to the new revision"))
+	err = at.containerAppService.SetTrafficWeights(
+		ctx,
+		subscriptionId,
+		resourceGroupName,
+		appName,
+		[]containerapps.TrafficWeight{
+			{
+				RevisionName: revisionName,
+				Weight:       100,
+			},
+		},
+		options,
+	)
+	if err != nil {
+		return "", rollback(err)
+	}
+
+	return revisionName, nil
+}
+
+// waitForHealthyRevision waits for the revision to run and to be reported healthy by the health probes of its containers
+func (at *containerAppTarget) waitForHealthyRevision(
+	ctx context.Context,
+	targetResource *environment.TargetResource,
+	revisionName string,
+	plan *deployStrategyPlan,
+	options *containerapps.ContainerAppOptions,
+	progress *async.Progress[ServiceProgress],
+) error {
+	progress.SetProgress(NewServiceProgress("Checking the health of the new revision"))
+	return retry.Do(
+		ctx,
+		retry.WithMaxDuration(plan.healthTimeout, retry.NewConstant(5*time.Second)),
+		func(ctx context.Context) error {
+			status, err := at.containerAppService.GetRevisionStatus(
+				ctx,
+				targetResource.SubscriptionId(),
+				targetResource.ResourceGroupName(),
+				targetResource.ResourceName(),
+				revisionName,
+				options,
+			)
+			if err != nil {
+				return err
+			}
+
+			running := status.RunningState == armappcontainers.RevisionRunningStateRunning
+			switch {
+			case status.RunningState == armappcontainers.RevisionRunningStateFailed:
+				return fmt.Errorf("revision '%s' failed to run", revisionName)
+			case running && status.HealthState == armappcontainers.RevisionHealthStateHealthy,
+				running && status.HealthState == armappcontainers.RevisionHealthStateNone:
+				return nil
+			}
+
+			return retry.RetryableError(fmt.Errorf(
+				"revision '%s' is not healthy, running state: '%s', health state: '%s'",
+				revisionName,
+				status.RunningState,
+				status.HealthState,
+			))
+		},
+	)
+}
+
+// labelUrl gets the URL of the revisions with the label. The host name of a label is the host name of the container
+// app, where the name of the app is suffixed with the label, like https://app---green.example.azurecontainerapps.io/
+func (at *containerAppTarget) labelUrl(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	label string,
+) (string, error) {
+	endpoints, err := at.Endpoints(ctx, serviceConfig, targetResource)
+	if err != nil {
+		return "", err
+	}
+
+	if len(endpoints) == 0 {
+		return "", fmt.Errorf("container app '%s' has no ingress", targetResource.ResourceName())
+	}
+
+	endpoint := strings.TrimPrefix(endpoints[0], "https://")
+	appName, domain, _ := strings.Cut(endpoint, ".")
+	return fmt.Sprintf("https://%s---%s.%s", appName, label, domain), nil
+}
+
+// canaryTrafficWeights routes the weight of the traffic to the new revision, and the rest of the traffic to the previous
+// revisions, in proportion of their previous weights.
+func canaryTrafficWeights(
+	previousTrafficWeights []containerapps.TrafficWeight,
+	revisionName string,
+	weight int32,
+) []containerapps.TrafficWeight {
+	trafficWeights := []containerapps.TrafficWeight{}
+	remaining := 100 - weight
+	for _, previous := range previousTrafficWeights {
+		previous.Weight = previous.Weight * (100 - weight) / 100
+		remaining -= previous.Weight
+		trafficWeights = append(trafficWeights, previous)
+	}
+
+	// The rounding remainder goes to the revision that had the most traffic
+	if remaining > 0 && len(trafficWeights) > 0 {
+		most := 0
+		for i, trafficWeight := range trafficWeights {
+			if trafficWeight.Weight > trafficWeights[most].Weight {
+				most = i
+			}
+		}
+
+		trafficWeights[most].Weight += remaining
+	}
+
+	return append(trafficWeights, containerapps.TrafficWeight{
+		RevisionName: revisionName,
+		Weight:       weight,
+	})
+}
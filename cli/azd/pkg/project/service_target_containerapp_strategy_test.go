@@ -0,0 +1,160 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appcontainers/armappcontainers/v3"
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/containerapps"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewDeployStrategyPlan(t *testing.T) {
+	t.Run("CanaryDefaults", func(t *testing.T) {
+		plan, err := newDeployStrategyPlan(&DeployStrategy{Type: CanaryDeployStrategy})
+		require.NoError(t, err)
+		require.Equal(t, []int32{10, 50}, plan.steps)
+		require.Equal(t, 5*time.Minute, plan.healthTimeout)
+		require.Zero(t, plan.pause)
+	})
+
+	t.Run("BlueGreenDefaults", func(t *testing.T) {
+		plan, err := newDeployStrategyPlan(&DeployStrategy{Type: BlueGreenDeployStrategy, HealthTimeout: "90s"})
+		require.NoError(t, err)
+		require.Equal(t, "green", plan.label)
+		require.Equal(t, 90*time.Second, plan.healthTimeout)
+	})
+
+	invalid := map[string]*DeployStrategy{
+		"UnknownType":        {Type: "rolling"},
+		"DecreasingSteps":    {Type: CanaryDeployStrategy, Steps: []int32{50, 10}},
+		"OutOfRangeStep":     {Type: CanaryDeployStrategy, Steps: []int32{10, 100}},
+		"InvalidPause":       {Type: CanaryDeployStrategy, Pause: "5 minutes"},
+		"InvalidHealthCheck": {Type: BlueGreenDeployStrategy, HealthTimeout: "soon"},
+	}
+
+	for name, strategy := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := newDeployStrategyPlan(strategy)
+			require.Error(t, err)
+		})
+	}
+}
+
+func Test_CanaryTrafficWeights(t *testing.T) {
+	previous := []containerapps.TrafficWeight{
+		{RevisionName: "app--v1", Weight: 75},
+		{RevisionName: "app--v2", Weight: 25, Label: "blue"},
+	}
+
+	weights := canaryTrafficWeights(previous, "app--v3", 10)
+	require.Equal(t, []containerapps.TrafficWeight{
+		{RevisionName: "app--v1", Weight: 68},
+		{RevisionName: "app--v2", Weight: 22, Label: "blue"},
+		{RevisionName: "app--v3", Weight: 10},
+	}, weights)
+
+	// The previous weights are not modified
+	require.Equal(t, int32(75), previous[0].Weight)
+}
+
+// stagingContainerAppService stages the revision 'app--v2' of a container app whose traffic is routed to 'app--v1', and
+// records the traffic weights set, with the error of their context when they were set.
+type stagingContainerAppService struct {
+	containerapps.ContainerAppService
+	// Called after each traffic weights are set
+	onSetTrafficWeights func()
+	trafficWeights      [][]containerapps.TrafficWeight
+	contextErrs         []error
+}
+
+func (s *stagingContainerAppService) GetTrafficWeights(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	options *containerapps.ContainerAppOptions,
+) ([]containerapps.TrafficWeight, error) {
+	return []containerapps.TrafficWeight{{RevisionName: "app--v1", Weight: 100}}, nil
+}
+
+func (s *stagingContainerAppService) StageRevision(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	imageName string,
+	label string,
+	options *containerapps.ContainerAppOptions,
+) (string, error) {
+	return "app--v2", nil
+}
+
+func (s *stagingContainerAppService) GetRevisionStatus(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	revisionName string,
+	options *containerapps.ContainerAppOptions,
+) (*containerapps.RevisionStatus, error) {
+	return &containerapps.RevisionStatus{
+		Name:         revisionName,
+		RunningState: armappcontainers.RevisionRunningStateRunning,
+		HealthState:  armappcontainers.RevisionHealthStateHealthy,
+	}, nil
+}
+
+func (s *stagingContainerAppService) SetTrafficWeights(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	trafficWeights []containerapps.TrafficWeight,
+	options *containerapps.ContainerAppOptions,
+) error {
+	s.trafficWeights = append(s.trafficWeights, trafficWeights)
+	s.contextErrs = append(s.contextErrs, ctx.Err())
+	if s.onSetTrafficWeights != nil {
+		s.onSetTrafficWeights()
+	}
+
+	return nil
+}
+
+func Test_AddRevisionWithStrategy_CancelledRollback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The deployment is cancelled during the pause of the first canary step
+	containerAppService := &stagingContainerAppService{onSetTrafficWeights: cancel}
+	target := &containerAppTarget{containerAppService: containerAppService}
+
+	serviceConfig := &ServiceConfig{
+		Name: "api",
+		Deploy: DeployOptions{
+			Strategy: &DeployStrategy{Type: CanaryDeployStrategy, Steps: []int32{10}, Pause: "1h"},
+		},
+	}
+	targetResource := environment.NewTargetResource(
+		"SUB_ID", "RG_ID", "app", string(azapi.AzureResourceTypeContainerApp))
+
+	_, err := logProgress(t, func(progress *async.Progress[ServiceProgress]) (string, error) {
+		return target.addRevisionWithStrategy(ctx, serviceConfig, targetResource, "image", nil, progress)
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorContains(t, err, "traffic routed back from revision 'app--v2'")
+
+	// The traffic is routed back to the previous revision with a context that isn't cancelled
+	require.Len(t, containerAppService.trafficWeights, 2)
+	require.Equal(t,
+		[]containerapps.TrafficWeight{{RevisionName: "app--v1", Weight: 100}}, containerAppService.trafficWeights[1])
+	require.NoError(t, containerAppService.contextErrs[1])
+}
@@ -125,6 +125,8 @@ func Test_ContainerApp_Deploy(t *testing.T) {
 	require.Greater(t, len(deployResult.Endpoints), 0)
 	// New env variable is created
 	require.Equal(t, "REGISTRY.azurecr.io/test-app/api-test:azd-deploy-0", env.Dotenv()["SERVICE_API_IMAGE_NAME"])
+	// The new revision is recorded as the current deployment
+	require.Equal(t, "CONTAINER_APP--azd-0", env.Dotenv()["SERVICE_API_DEPLOYMENT_ID"])
 }
 
 func createContainerAppServiceTarget(
@@ -162,6 +164,7 @@ func createContainerAppServiceTarget(
 		clock.NewMock(),
 		containerRegistryService,
 		remoteBuildManager,
+		nil,
 		dockerCli,
 		mockContext.Console,
 		cloud.AzurePublic(),
@@ -177,6 +180,7 @@ func createContainerAppServiceTarget(
 		containerHelper,
 		containerAppService,
 		resourceManager,
+		mockContext.Console,
 	)
 }
 
@@ -352,6 +352,10 @@ func (at *dotnetContainerAppTarget) Deploy(
 				return azure.RawArmTemplate{}, nil, fmt.Errorf("closing bicep file: %w", err)
 			}
 
+			if err := at.env.CheckSecrets(); err != nil {
+				return azure.RawArmTemplate{}, nil, err
+			}
+
 			res, err := at.bicepCli.BuildBicepParam(ctx, f.Name(), at.env.Environ())
 			if err != nil {
 				return azure.RawArmTemplate{}, nil, fmt.Errorf("building container app bicep: %w", err)
@@ -6,6 +6,7 @@ package project
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -20,18 +21,21 @@ import (
 // functionAppTarget specifies an Azure Function to deploy to.
 // Implements `project.ServiceTarget`
 type functionAppTarget struct {
-	env *environment.Environment
-	cli azcli.AzCli
+	env        *environment.Environment
+	envManager environment.Manager
+	cli        azcli.AzCli
 }
 
 // NewFunctionAppTarget creates a new instance of the Function App target
 func NewFunctionAppTarget(
 	env *environment.Environment,
+	envManager environment.Manager,
 	azCli azcli.AzCli,
 ) ServiceTarget {
 	return &functionAppTarget{
-		env: env,
-		cli: azCli,
+		env:        env,
+		envManager: envManager,
+		cli:        azCli,
 	}
 }
 
@@ -53,18 +57,15 @@ func (f *functionAppTarget) Package(
 	progress *async.Progress[ServiceProgress],
 ) (*ServicePackageResult, error) {
 	progress.SetProgress(NewServiceProgress("Compressing deployment artifacts"))
-	zipFilePath, err := createDeployableZip(
-		serviceConfig.Project.Name,
-		serviceConfig.Name,
-		packageOutput.PackagePath,
-	)
+	zipFilePath, excluded, err := createDeployableZip(serviceConfig, packageOutput.PackagePath)
 	if err != nil {
 		return nil, err
 	}
 
 	return &ServicePackageResult{
-		Build:       packageOutput.Build,
-		PackagePath: zipFilePath,
+		Build:         packageOutput.Build,
+		PackagePath:   zipFilePath,
+		ExcludedFiles: append(packageOutput.ExcludedFiles, excluded...),
 	}, nil
 }
 
@@ -80,6 +81,11 @@ func (f *functionAppTarget) Deploy(
 		return nil, fmt.Errorf("validating target resource: %w", err)
 	}
 
+	slot, err := DeploymentSlot(f.env, serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	zipFile, err := os.Open(packageOutput.PackagePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed reading deployment zip file: %w", err)
@@ -97,6 +103,7 @@ func (f *functionAppTarget) Deploy(
 		targetResource.SubscriptionId(),
 		targetResource.ResourceGroupName(),
 		targetResource.ResourceName(),
+		slot,
 		zipFile,
 		remoteBuild,
 	)
@@ -104,8 +111,91 @@ func (f *functionAppTarget) Deploy(
 		return nil, err
 	}
 
+	recordSlotSwap(f.env, serviceConfig.Name, "")
+	if err := f.envManager.Save(ctx, f.env); err != nil {
+		return nil, fmt.Errorf("saving deployment of service %s: %w", serviceConfig.Name, err)
+	}
+
+	return f.deployResult(ctx, packageOutput, targetResource, slot, *res, progress)
+}
+
+// Rolls back the Function App by swapping its deployment slot back with production, when the last deployment was swapped
+// into production. Deployments that didn't swap slots can't be rolled back.
+func (f *functionAppTarget) Rollback(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
+	if err := f.validateTargetResource(targetResource); err != nil {
+		return nil, fmt.Errorf("validating target resource: %w", err)
+	}
+
+	slot, err := DeploymentSlot(f.env, serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	swapped, err := swapSlotBack(ctx, f.env, f.cli, serviceConfig, targetResource, slot, progress)
+	if err != nil {
+		return nil, err
+	} else if !swapped {
+		return nil, fmt.Errorf(
+			"%w of service '%s' to swap back, only deployments swapped from a deployment slot with '--swap' can be rolled back",
+			ErrNoPreviousDeployment,
+			serviceConfig.Name,
+		)
+	}
+
+	return f.deployResult(ctx, nil, targetResource, "", "OK", progress)
+}
+
+// Swaps the deployment slot of the Function App with its production slot
+func (f *functionAppTarget) SwapSlot(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
+	if err := f.validateTargetResource(targetResource); err != nil {
+		return nil, fmt.Errorf("validating target resource: %w", err)
+	}
+
+	slot, err := requiredDeploymentSlot(f.env, serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	progress.SetProgress(NewServiceProgress(fmt.Sprintf("Swapping slot %s with production", slot)))
+	err = f.cli.SwapAppServiceSlot(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		slot,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("swapping slot of service %s: %w", serviceConfig.Name, err)
+	}
+
+	recordSlotSwap(f.env, serviceConfig.Name, slot)
+	if err := f.envManager.Save(ctx, f.env); err != nil {
+		return nil, fmt.Errorf("saving deployment of service %s: %w", serviceConfig.Name, err)
+	}
+
+	return f.deployResult(ctx, nil, targetResource, "", "OK", progress)
+}
+
+func (f *functionAppTarget) deployResult(
+	ctx context.Context,
+	packageOutput *ServicePackageResult,
+	targetResource *environment.TargetResource,
+	slot string,
+	rawResult string,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
 	progress.SetProgress(NewServiceProgress("Fetching endpoints for function app"))
-	endpoints, err := f.Endpoints(ctx, serviceConfig, targetResource)
+	endpoints, err := f.endpoints(ctx, targetResource, slot)
 	if err != nil {
 		return nil, err
 	}
@@ -117,7 +207,7 @@ func (f *functionAppTarget) Deploy(
 			targetResource.ResourceName(),
 		),
 		AzureFunctionTarget,
-		*res,
+		rawResult,
 		endpoints,
 	)
 	sdr.Package = packageOutput
@@ -125,11 +215,24 @@ func (f *functionAppTarget) Deploy(
 	return sdr, nil
 }
 
-// Gets the exposed endpoints for the Function App
+// Gets the exposed endpoints for the Function App, or for its deployment slot when the service is deployed to a slot
 func (f *functionAppTarget) Endpoints(
 	ctx context.Context,
 	serviceConfig *ServiceConfig,
 	targetResource *environment.TargetResource,
+) ([]string, error) {
+	slot, err := DeploymentSlot(f.env, serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.endpoints(ctx, targetResource, slot)
+}
+
+func (f *functionAppTarget) endpoints(
+	ctx context.Context,
+	targetResource *environment.TargetResource,
+	slot string,
 ) ([]string, error) {
 	// TODO(azure/azure-dev#670) Implement this. For now we just return an empty set of endpoints and
 	// a nil error.  In `deploy` we just loop over the endpoint array and print any endpoints, so returning
@@ -138,7 +241,8 @@ func (f *functionAppTarget) Endpoints(
 		ctx,
 		targetResource.SubscriptionId(),
 		targetResource.ResourceGroupName(),
-		targetResource.ResourceName()); err != nil {
+		targetResource.ResourceName(),
+		slot); err != nil {
 		return nil, fmt.Errorf("fetching service properties: %w", err)
 	} else {
 		endpoints := make([]string, len(props.HostNames))
@@ -150,6 +254,34 @@ func (f *functionAppTarget) Endpoints(
 	}
 }
 
+// Streams the application logs of the Function App, or of its deployment slot, from its log stream
+func (f *functionAppTarget) StreamLogs(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	options LogStreamOptions,
+	writer io.Writer,
+) error {
+	if err := f.validateTargetResource(targetResource); err != nil {
+		return fmt.Errorf("validating target resource: %w", err)
+	}
+
+	slot, err := DeploymentSlot(f.env, serviceConfig)
+	if err != nil {
+		return err
+	}
+
+	return f.cli.StreamAppServiceLogs(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		slot,
+		options.Follow,
+		writer,
+	)
+}
+
 func (f *functionAppTarget) validateTargetResource(
 	targetResource *environment.TargetResource,
 ) error {
@@ -0,0 +1,207 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/plugin"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+)
+
+// pluginServiceTarget is a service target implemented by an out-of-process plugin. The plugin is started for each
+// operation and the operation is forwarded as a JSON-RPC request, see the plugin package for the protocol.
+type pluginServiceTarget struct {
+	host       ServiceTargetKind
+	pluginPath string
+	env        *environment.Environment
+}
+
+// NewPluginServiceTarget creates a new instance of a service target implemented by the plugin at the specified path
+func NewPluginServiceTarget(host ServiceTargetKind, pluginPath string, env *environment.Environment) ServiceTarget {
+	return &pluginServiceTarget{
+		host:       host,
+		pluginPath: pluginPath,
+		env:        env,
+	}
+}
+
+// Initializes the plugin for the service
+func (t *pluginServiceTarget) Initialize(ctx context.Context, serviceConfig *ServiceConfig) error {
+	params := plugin.ServiceParams{Service: toPluginServiceConfig(serviceConfig)}
+	return t.call(ctx, serviceConfig, plugin.MethodInitialize, params, nil, nil)
+}
+
+// Gets the tools the plugin requires to package & deploy the service
+func (t *pluginServiceTarget) RequiredExternalTools(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+) []tools.ExternalTool {
+	var pluginTools []plugin.ExternalTool
+	params := plugin.ServiceParams{Service: toPluginServiceConfig(serviceConfig)}
+	if err := t.call(ctx, serviceConfig, plugin.MethodRequiredExternalTools, params, &pluginTools, nil); err != nil {
+		// The interface does not allow returning an error, a failing plugin is reported by the next operation
+		log.Printf("failed getting the required tools of service '%s': %v", serviceConfig.Name, err)
+		return nil
+	}
+
+	requiredTools := make([]tools.ExternalTool, 0, len(pluginTools))
+	for _, tool := range pluginTools {
+		requiredTools = append(requiredTools, &pluginExternalTool{tool: tool})
+	}
+
+	return requiredTools
+}
+
+// Packages the output of the framework service with the plugin
+func (t *pluginServiceTarget) Package(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	frameworkPackageOutput *ServicePackageResult,
+	progress *async.Progress[ServiceProgress],
+) (*ServicePackageResult, error) {
+	params := plugin.PackageParams{
+		Service: toPluginServiceConfig(serviceConfig),
+		FrameworkPackage: plugin.PackageResult{
+			PackagePath: frameworkPackageOutput.PackagePath,
+			Details:     frameworkPackageOutput.Details,
+		},
+	}
+
+	var result plugin.PackageResult
+	if err := t.call(ctx, serviceConfig, plugin.MethodPackage, params, &result, progress); err != nil {
+		return nil, err
+	}
+
+	return &ServicePackageResult{
+		Build:       frameworkPackageOutput.Build,
+		PackagePath: result.PackagePath,
+		Details:     result.Details,
+	}, nil
+}
+
+// Deploys the package to the target resource with the plugin
+func (t *pluginServiceTarget) Deploy(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	servicePackage *ServicePackageResult,
+	targetResource *environment.TargetResource,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
+	params := plugin.DeployParams{
+		Service: toPluginServiceConfig(serviceConfig),
+		Package: plugin.PackageResult{
+			PackagePath: servicePackage.PackagePath,
+			Details:     servicePackage.Details,
+		},
+		TargetResource: toPluginTargetResource(targetResource),
+	}
+
+	var result plugin.DeployResult
+	if err := t.call(ctx, serviceConfig, plugin.MethodDeploy, params, &result, progress); err != nil {
+		return nil, err
+	}
+
+	return &ServiceDeployResult{
+		Package:          servicePackage,
+		TargetResourceId: result.TargetResourceId,
+		Kind:             t.host,
+		Endpoints:        result.Endpoints,
+		Details:          result.Details,
+	}, nil
+}
+
+// Gets the endpoints of the service from the plugin
+func (t *pluginServiceTarget) Endpoints(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+) ([]string, error) {
+	params := plugin.EndpointsParams{
+		Service:        toPluginServiceConfig(serviceConfig),
+		TargetResource: toPluginTargetResource(targetResource),
+	}
+
+	var endpoints []string
+	if err := t.call(ctx, serviceConfig, plugin.MethodEndpoints, params, &endpoints, nil); err != nil {
+		return nil, err
+	}
+
+	return endpoints, nil
+}
+
+// call starts the plugin, sends the request and stops the plugin once the response is received. The progress reported
+// by the plugin is forwarded to progress, when set.
+func (t *pluginServiceTarget) call(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	method string,
+	params any,
+	result any,
+	progress *async.Progress[ServiceProgress],
+) error {
+	client, err := plugin.Start(ctx, t.pluginPath, plugin.StartOptions{
+		Dir: serviceConfig.Path(),
+		Env: append(os.Environ(), t.env.Environ()...),
+		OnProgress: func(message string) {
+			if progress != nil {
+				progress.SetProgress(NewServiceProgress(message))
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("starting plugin for host '%s': %w", t.host, err)
+	}
+	defer client.Close()
+
+	return client.Call(ctx, method, params, result)
+}
+
+func toPluginServiceConfig(serviceConfig *ServiceConfig) plugin.ServiceConfig {
+	return plugin.ServiceConfig{
+		Name:        serviceConfig.Name,
+		Host:        string(serviceConfig.Host),
+		Language:    string(serviceConfig.Language),
+		ProjectPath: serviceConfig.Project.Path,
+		Path:        serviceConfig.Path(),
+		OutputPath:  serviceConfig.OutputPath,
+		Config:      serviceConfig.Config,
+	}
+}
+
+func toPluginTargetResource(targetResource *environment.TargetResource) plugin.TargetResource {
+	return plugin.TargetResource{
+		SubscriptionId:    targetResource.SubscriptionId(),
+		ResourceGroupName: targetResource.ResourceGroupName(),
+		ResourceName:      targetResource.ResourceName(),
+		ResourceType:      targetResource.ResourceType(),
+	}
+}
+
+// pluginExternalTool is a tool required by a plugin, which is installed when its command is found on the PATH
+type pluginExternalTool struct {
+	tool plugin.ExternalTool
+}
+
+func (t *pluginExternalTool) CheckInstalled(ctx context.Context) error {
+	command := t.tool.Command
+	if command == "" {
+		command = t.tool.Name
+	}
+
+	return tools.ToolInPath(command)
+}
+
+func (t *pluginExternalTool) InstallUrl() string {
+	return t.tool.InstallUrl
+}
+
+func (t *pluginExternalTool) Name() string {
+	return t.tool.Name
+}
@@ -10,11 +10,18 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/ignore"
 )
 
-func CreateFromDirectory(source string, buf *os.File) error {
+// CreateFromDirectory writes a zip archive of the source directory to buf.
+// When ignoreMatcher is set, files and directories matching its patterns are not added to the archive and are
+// returned as slash separated paths relative to source.
+func CreateFromDirectory(source string, buf *os.File, ignoreMatcher *ignore.Matcher) ([]string, error) {
+	excluded := []string{}
 	w := zip.NewWriter(buf)
-	err := filepath.WalkDir(source, func(path string, info fs.DirEntry, err error) error {
+
+	walkFn := func(path string, info fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -44,15 +51,26 @@ func CreateFromDirectory(source string, buf *os.File) error {
 		if err != nil {
 			return err
 		}
+		defer in.Close()
+
 		_, err = io.Copy(f, in)
 		if err != nil {
 			return err
 		}
 		return nil
-	})
+	}
+
+	var err error
+	if ignoreMatcher != nil {
+		err = ignoreMatcher.Walk(walkFn, func(relPath string, isDir bool) {
+			excluded = append(excluded, ignore.FormatExcluded(relPath, isDir))
+		})
+	} else {
+		err = filepath.WalkDir(source, walkFn)
+	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return w.Close()
+	return excluded, w.Close()
 }
@@ -83,3 +83,12 @@ func Test_JsonTemplateSource_GetTemplate_NoMatchFound(t *testing.T) {
 
 	require.Nil(t, template)
 }
+
+func Test_JsonTemplateSource_GetTemplate_ByName(t *testing.T) {
+	source, err := newJsonTemplateSource("test", jsonTemplates())
+	require.Nil(t, err)
+
+	template, err := source.GetTemplate(context.Background(), "Template2")
+	require.Nil(t, err)
+	require.Equal(t, "owner/template2", template.RepositoryPath)
+}
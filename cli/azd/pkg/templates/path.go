@@ -32,6 +32,33 @@ func Absolute(path string) (string, error) {
 	}
 }
 
+// ParseRef splits a template reference of the form <template>@<ref> into the template and the ref, a version, a tag or
+// a branch of the template. The ref is empty when the reference has none.
+//
+// The user info of repository URLs, like git@github.com:owner/repo or https://user@host/repo, isn't a ref.
+func ParseRef(reference string) (string, string) {
+	at := strings.LastIndex(reference, "@")
+	if at <= 0 || at == len(reference)-1 {
+		return reference, ""
+	}
+
+	// scp-like SSH URLs, git@github.com:owner/repo
+	ref := reference[at+1:]
+	if strings.Contains(ref, ":") {
+		return reference, ""
+	}
+
+	// The user info of URLs precedes the first '/' after the scheme
+	if scheme := strings.Index(reference, "://"); scheme != -1 {
+		authorityEnd := strings.Index(reference[scheme+3:], "/")
+		if authorityEnd == -1 || at < scheme+3+authorityEnd {
+			return reference, ""
+		}
+	}
+
+	return reference[:at], ref
+}
+
 // Hyperlink returns a hyperlink to the given template path.
 // If the path is cannot be resolved absolutely, it is returned as-is.
 func Hyperlink(path string) string {
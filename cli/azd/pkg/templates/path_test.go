@@ -0,0 +1,33 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseRef(t *testing.T) {
+	tests := []struct {
+		reference string
+		path      string
+		ref       string
+	}{
+		{"todo-nodejs-mongo", "todo-nodejs-mongo", ""},
+		{"my-template@v1.2.0", "my-template", "v1.2.0"},
+		{"owner/repo@release/1.0", "owner/repo", "release/1.0"},
+		{"https://github.com/owner/repo@main", "https://github.com/owner/repo", "main"},
+		{"git@github.com:owner/repo", "git@github.com:owner/repo", ""},
+		{"git@github.com:owner/repo@v1", "git@github.com:owner/repo", "v1"},
+		{"https://user@dev.azure.com/org/_git/repo", "https://user@dev.azure.com/org/_git/repo", ""},
+		{"https://user@dev.azure.com/org/_git/repo@v2", "https://user@dev.azure.com/org/_git/repo", "v2"},
+		{"my-template@", "my-template@", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reference, func(t *testing.T) {
+			path, ref := ParseRef(tt.reference)
+			require.Equal(t, tt.path, path)
+			require.Equal(t, tt.ref, ref)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"slices"
+	"strings"
 )
 
 // Source is a source of AZD compatible templates.
@@ -32,6 +33,8 @@ type SourceConfig struct {
 	Name     string     `json:"name,omitempty"`
 	Type     SourceKind `json:"type,omitempty"`
 	Location string     `json:"location,omitempty"`
+	// The authentication of the requests to a private template source
+	Auth *SourceAuth `json:"auth,omitempty"`
 }
 
 type templateSource struct {
@@ -60,10 +63,8 @@ func (ts *templateSource) ListTemplates(ctx context.Context) ([]*Template, error
 }
 
 func (ts *templateSource) GetTemplate(ctx context.Context, path string) (*Template, error) {
-	absTemplatePath, err := Absolute(path)
-	if err != nil {
-		return nil, err
-	}
+	// The path can also be the id or the name of a template, which aren't always valid repository paths
+	absTemplatePath, absErr := Absolute(path)
 
 	allTemplates, err := ts.ListTemplates(ctx)
 	if err != nil {
@@ -71,6 +72,14 @@ func (ts *templateSource) GetTemplate(ctx context.Context, path string) (*Templa
 	}
 
 	matchingIndex := slices.IndexFunc(allTemplates, func(template *Template) bool {
+		if template.Id != "" && strings.EqualFold(template.Id, path) || strings.EqualFold(template.Name, path) {
+			return true
+		}
+
+		if absErr != nil {
+			return false
+		}
+
 		absPath, err := Absolute(template.RepositoryPath)
 		if err != nil {
 			log.Printf("failed to get absolute path for template '%s': %s", template.RepositoryPath, err.Error())
@@ -81,6 +90,10 @@ func (ts *templateSource) GetTemplate(ctx context.Context, path string) (*Templa
 	})
 
 	if matchingIndex == -1 {
+		if absErr != nil {
+			return nil, absErr
+		}
+
 		return nil, fmt.Errorf("template with path '%s' was not found, %w", path, ErrTemplateNotFound)
 	}
 
@@ -0,0 +1,133 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package templates
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+)
+
+type SourceAuthKind string
+
+const (
+	// SourceAuthBearer authenticates with a bearer token: a Microsoft Entra token of the azd credential for the scope of
+	// the source, or the token of an environment variable.
+	SourceAuthBearer SourceAuthKind = "bearer"
+	// SourceAuthPat authenticates with a personal access token (PAT) of an environment variable, as the password of basic
+	// authentication.
+	SourceAuthPat SourceAuthKind = "pat"
+)
+
+var ErrSourceAuthInvalid = errors.New("invalid template source authentication")
+
+// SourceAuth is the authentication of the requests to a private template source.
+// Tokens are never stored in the azd configuration, they are read from the environment or acquired from the azd
+// credential when the source is used.
+type SourceAuth struct {
+	Type SourceAuthKind `json:"type"`
+	// The scope of the Microsoft Entra token for bearer authentication, e.g. 'api://templates/.default'.
+	Scope string `json:"scope,omitempty"`
+	// The name of the environment variable holding the token, for PAT authentication or bearer authentication without a
+	// scope.
+	TokenEnvVar string `json:"tokenEnvVar,omitempty"`
+}
+
+// Validate validates the authentication is complete, without acquiring a token.
+func (a *SourceAuth) Validate() error {
+	switch a.Type {
+	case SourceAuthBearer:
+		if a.Scope == "" && a.TokenEnvVar == "" {
+			return fmt.Errorf("%w, bearer authentication requires a scope or a token environment variable",
+				ErrSourceAuthInvalid)
+		}
+	case SourceAuthPat:
+		if a.TokenEnvVar == "" {
+			return fmt.Errorf("%w, PAT authentication requires a token environment variable", ErrSourceAuthInvalid)
+		}
+	default:
+		return fmt.Errorf("%w, unsupported type '%s'. Supported types are 'bearer' and 'pat'",
+			ErrSourceAuthInvalid, a.Type)
+	}
+
+	return nil
+}
+
+func (a *SourceAuth) token() (string, error) {
+	token := os.Getenv(a.TokenEnvVar)
+	if token == "" {
+		return "", fmt.Errorf("the environment variable '%s' holding the template source token is not set", a.TokenEnvVar)
+	}
+
+	return token, nil
+}
+
+// authPolicies returns the pipeline policies authenticating the requests to the source at the location.
+func (sm *sourceManager) authPolicies(
+	ctx context.Context, sourceAuth *SourceAuth, location string) ([]policy.Policy, error) {
+	if sourceAuth == nil {
+		return nil, nil
+	}
+
+	if err := sourceAuth.Validate(); err != nil {
+		return nil, err
+	}
+
+	sourceUrl, err := url.Parse(location)
+	if err != nil || !strings.EqualFold(sourceUrl.Scheme, "https") || sourceUrl.Host == "" {
+		return nil, fmt.Errorf("%w, authentication requires an https location", ErrSourceAuthInvalid)
+	}
+
+	if sourceAuth.Type == SourceAuthBearer && sourceAuth.Scope != "" {
+		var credential azcore.TokenCredential
+		err = sm.serviceLocator.Invoke(func(authManager *auth.Manager) error {
+			var err error
+			credential, err = authManager.CredentialForCurrentUser(ctx, nil)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("getting the credential of the template source: %w", err)
+		}
+
+		return []policy.Policy{runtime.NewBearerTokenPolicy(credential, []string{sourceAuth.Scope}, nil)}, nil
+	}
+
+	token, err := sourceAuth.token()
+	if err != nil {
+		return nil, err
+	}
+
+	value := fmt.Sprintf("Bearer %s", token)
+	if sourceAuth.Type == SourceAuthPat {
+		value = fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(":"+token)))
+	}
+
+	return []policy.Policy{&authorizationPolicy{host: sourceUrl.Host, value: value}}, nil
+}
+
+// authorizationPolicy sets the Authorization header of the https requests to the host of the source, the token is never
+// sent to other hosts or over plain http.
+type authorizationPolicy struct {
+	host  string
+	value string
+}
+
+func (p *authorizationPolicy) Do(req *policy.Request) (*http.Response, error) {
+	reqUrl := req.Raw().URL
+	if strings.EqualFold(reqUrl.Scheme, "https") && strings.EqualFold(reqUrl.Host, p.host) {
+		req.Raw().Header.Set("Authorization", p.value)
+	}
+
+	return req.Next()
+}
@@ -0,0 +1,161 @@
+package templates
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sourceManager_CreateSource_Auth(t *testing.T) {
+	const sourceUrl = "https://example.com/private.json"
+
+	tests := []struct {
+		name          string
+		sourceType    SourceKind
+		location      string
+		auth          *SourceAuth
+		env           map[string]string
+		authorization string
+		expectedErr   error
+	}{
+		{
+			name:          "Bearer",
+			sourceType:    SourceKindUrl,
+			auth:          &SourceAuth{Type: SourceAuthBearer, TokenEnvVar: "TEMPLATES_TOKEN"},
+			env:           map[string]string{"TEMPLATES_TOKEN": "secret"},
+			authorization: "Bearer secret",
+		},
+		{
+			name:       "Pat",
+			sourceType: SourceKindUrl,
+			auth:       &SourceAuth{Type: SourceAuthPat, TokenEnvVar: "TEMPLATES_PAT"},
+			env:        map[string]string{"TEMPLATES_PAT": "secret"},
+			// base64 of ':secret'
+			authorization: "Basic OnNlY3JldA==",
+		},
+		{
+			name:        "PatWithoutToken",
+			sourceType:  SourceKindUrl,
+			auth:        &SourceAuth{Type: SourceAuthPat},
+			expectedErr: ErrSourceAuthInvalid,
+		},
+		{
+			name:        "InvalidType",
+			sourceType:  SourceKindUrl,
+			auth:        &SourceAuth{Type: "basic", TokenEnvVar: "TEMPLATES_PAT"},
+			expectedErr: ErrSourceAuthInvalid,
+		},
+		{
+			name:        "HttpLocation",
+			sourceType:  SourceKindUrl,
+			location:    "http://example.com/private.json",
+			auth:        &SourceAuth{Type: SourceAuthPat, TokenEnvVar: "TEMPLATES_PAT"},
+			env:         map[string]string{"TEMPLATES_PAT": "secret"},
+			expectedErr: ErrSourceAuthInvalid,
+		},
+		{
+			name:        "NotUrlSource",
+			sourceType:  SourceKindFile,
+			auth:        &SourceAuth{Type: SourceAuthPat, TokenEnvVar: "TEMPLATES_PAT"},
+			expectedErr: ErrSourceAuthInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			mockContext := mocks.NewMockContext(context.Background())
+			mockContext.HttpClient.When(func(req *http.Request) bool {
+				return req.Method == http.MethodGet && req.URL.String() == sourceUrl
+			}).RespondFn(func(req *http.Request) (*http.Response, error) {
+				if req.Header.Get("Authorization") != tt.authorization {
+					return mocks.CreateEmptyHttpResponse(req, http.StatusUnauthorized)
+				}
+
+				return mocks.CreateHttpResponseWithBody(req, http.StatusOK, testTemplates)
+			})
+
+			location := tt.location
+			if location == "" {
+				location = sourceUrl
+			}
+
+			sm := NewSourceManager(
+				NewSourceOptions(), mockContext.Container, &mockUserConfigManager{}, mockContext.HttpClient)
+			source, err := sm.CreateSource(*mockContext.Context, &SourceConfig{
+				Key:      "private",
+				Name:     "private",
+				Type:     tt.sourceType,
+				Location: location,
+				Auth:     tt.auth,
+			})
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				require.Nil(t, source)
+				return
+			}
+
+			require.NoError(t, err)
+			templates, err := source.ListTemplates(*mockContext.Context)
+			require.NoError(t, err)
+			require.Len(t, templates, len(testTemplates))
+		})
+	}
+}
+
+func Test_sourceManager_CreateSource_AuthTokenNotSet(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	sm := NewSourceManager(NewSourceOptions(), mockContext.Container, &mockUserConfigManager{}, mockContext.HttpClient)
+
+	source, err := sm.CreateSource(*mockContext.Context, &SourceConfig{
+		Key:      "private",
+		Type:     SourceKindUrl,
+		Location: "https://example.com/private.json",
+		Auth:     &SourceAuth{Type: SourceAuthBearer, TokenEnvVar: "AZD_TEST_TEMPLATES_TOKEN_NOT_SET"},
+	})
+	require.Error(t, err)
+	require.Nil(t, source)
+}
+
+func Test_authorizationPolicy_OnlySourceHost(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.HttpClient.When(func(req *http.Request) bool {
+		return req.Method == http.MethodGet
+	}).RespondFn(func(req *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(req, http.StatusOK, map[string]string{
+			"authorization": req.Header.Get("Authorization"),
+		})
+	})
+
+	pipeline := runtime.NewPipeline("azd-templates", "1.0.0", runtime.PipelineOptions{
+		PerRetry: []policy.Policy{&authorizationPolicy{host: "example.com", value: "Bearer secret"}},
+	}, &policy.ClientOptions{Transport: mockContext.HttpClient})
+
+	tests := map[string]string{
+		"https://example.com/private.json":       "Bearer secret",
+		"https://EXAMPLE.com/other.json":         "Bearer secret",
+		"https://other.example.com/private.json": "",
+		"http://example.com/private.json":        "",
+	}
+
+	for location, authorization := range tests {
+		req, err := runtime.NewRequest(*mockContext.Context, http.MethodGet, location)
+		require.NoError(t, err)
+
+		res, err := pipeline.Do(req)
+		require.NoError(t, err)
+
+		var body map[string]string
+		require.NoError(t, runtime.UnmarshalAsJSON(res, &body))
+		require.Equal(t, authorization, body["authorization"], location)
+	}
+}
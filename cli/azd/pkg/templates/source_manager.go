@@ -221,11 +221,20 @@ func (sm *sourceManager) CreateSource(ctx context.Context, config *SourceConfig)
 	var source Source
 	var err error
 
+	if config.Auth != nil && config.Type != SourceKindUrl {
+		return nil, fmt.Errorf("unable to create template source '%s': %w, authentication is only supported by 'url' "+
+			"template sources", config.Key, ErrSourceAuthInvalid)
+	}
+
 	switch config.Type {
 	case SourceKindFile:
 		source, err = newFileTemplateSource(config.Name, config.Location)
 	case SourceKindUrl:
-		source, err = newUrlTemplateSource(ctx, config.Name, config.Location, sm.transport)
+		var policies []policy.Policy
+		policies, err = sm.authPolicies(ctx, config.Auth, config.Location)
+		if err == nil {
+			source, err = newUrlTemplateSource(ctx, config.Name, config.Location, sm.transport, policies...)
+		}
 	case SourceKindAwesomeAzd:
 		source, err = newAwesomeAzdTemplateSource(ctx, SourceAwesomeAzd.Name, SourceAwesomeAzd.Location, sm.transport)
 	case SourceKindResource:
@@ -2,6 +2,7 @@ package templates
 
 import (
 	"io"
+	"slices"
 	"strings"
 	"text/tabwriter"
 
@@ -29,6 +30,13 @@ type Template struct {
 	// or "{repo}" for GitHub repositories under Azure-Samples (default organization).
 	RepositoryPath string `json:"repositoryPath"`
 
+	// Version is the version of the template, e.g. 'v1.2.0'. A catalog can list several versions of a template.
+	Version string `json:"version,omitempty"`
+
+	// Ref is the git ref, a branch or a tag, the template is fetched at. Defaults to the version of the template, or
+	// the default branch of the repository.
+	Ref string `json:"ref,omitempty"`
+
 	// A list of tags associated with the template
 	Tags []string `json:"tags"`
 
@@ -36,6 +44,15 @@ type Template struct {
 	Metadata Metadata `json:"metadata,omitempty"`
 }
 
+// FetchRef returns the git ref the template is fetched at, or an empty string for the default branch of the repository.
+func (t *Template) FetchRef() string {
+	if t.Ref != "" {
+		return t.Ref
+	}
+
+	return t.Version
+}
+
 // Metadata contains additional metadata about the template
 // This metadata is used to modify azd project, environment config and environment variables during azd init commands.
 type Metadata struct {
@@ -61,6 +78,10 @@ func (t *Template) Display(writer io.Writer) error {
 		{"Tags", ":", strings.Join(t.Tags, ", ")},
 	}
 
+	if t.Version != "" {
+		text = slices.Insert(text, 2, []string{"Version", ":", t.Version})
+	}
+
 	for _, line := range text {
 		_, err := tabs.Write([]byte(strings.Join(line, "\t") + "\n"))
 		if err != nil {
@@ -136,6 +136,45 @@ func (tm *TemplateManager) GetTemplate(ctx context.Context, path string) (*Templ
 	return nil, ErrTemplateNotFound
 }
 
+// GetTemplateAtRef returns the template of the path, the id or the name, fetched at the ref. When a source lists a version
+// of the template matching the ref, with or without its 'v' prefix, this version of the template is returned. Otherwise,
+// the ref is used as the git ref of the template as is.
+func (tm *TemplateManager) GetTemplateAtRef(ctx context.Context, path string, ref string) (*Template, error) {
+	template, err := tm.GetTemplate(ctx, path)
+	if err != nil || ref == "" {
+		return template, err
+	}
+
+	sources, err := tm.getSources(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting template sources: %w", err)
+	}
+
+	for _, source := range sources {
+		sourceTemplates, err := source.ListTemplates(ctx)
+		if err != nil {
+			log.Printf("failed listing templates of source '%s': %s", source.Name(), err.Error())
+			continue
+		}
+
+		for _, candidate := range sourceTemplates {
+			if candidate.RepositoryPath == template.RepositoryPath && sameVersion(candidate.Version, ref) {
+				return candidate, nil
+			}
+		}
+	}
+
+	atRef := *template
+	atRef.Version = ""
+	atRef.Ref = ref
+
+	return &atRef, nil
+}
+
+func sameVersion(version string, ref string) bool {
+	return version != "" && strings.TrimPrefix(version, "v") == strings.TrimPrefix(ref, "v")
+}
+
 func (tm *TemplateManager) getSources(ctx context.Context, filter sourceFilterPredicate) ([]Source, error) {
 	if tm.sources != nil {
 		return tm.sources, nil
@@ -194,3 +194,56 @@ func Test_Templates_GetTemplate_WithNotFoundPath(t *testing.T) {
 	require.ErrorIs(t, err, ErrTemplateNotFound)
 	require.Nil(t, template)
 }
+
+func Test_Templates_GetTemplateAtRef(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	const sourceUrl = "https://example.com/catalog.json"
+
+	mockContext.HttpClient.When(func(req *http.Request) bool {
+		return req.Method == http.MethodGet && req.URL.String() == sourceUrl
+	}).RespondFn(func(req *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(req, http.StatusOK, []*Template{
+			{Id: "contoso-api", Name: "Contoso API", RepositoryPath: "contoso/api", Version: "v1.2.0", Ref: "release-1.2"},
+			{Id: "contoso-api", Name: "Contoso API", RepositoryPath: "contoso/api", Version: "v1.0.0"},
+		})
+	})
+
+	configManager := &mockUserConfigManager{}
+	configManager.On("Load").Return(config.NewConfig(map[string]interface{}{
+		"template": map[string]interface{}{
+			"sources": map[string]interface{}{
+				"catalog": map[string]interface{}{
+					"type":     "url",
+					"location": sourceUrl,
+				},
+			},
+		},
+	}), nil)
+
+	templateManager, err := NewTemplateManager(
+		NewSourceManager(NewSourceOptions(), mockContext.Container, configManager, mockContext.HttpClient),
+		mockContext.Console,
+	)
+	require.NoError(t, err)
+
+	// The latest version listed first
+	template, err := templateManager.GetTemplateAtRef(*mockContext.Context, "contoso-api", "")
+	require.NoError(t, err)
+	require.Equal(t, "release-1.2", template.FetchRef())
+
+	// A listed version, with or without the 'v' prefix
+	template, err = templateManager.GetTemplateAtRef(*mockContext.Context, "Contoso API", "1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, "v1.0.0", template.Version)
+	require.Equal(t, "v1.0.0", template.FetchRef())
+
+	// Any other ref is used as is
+	template, err = templateManager.GetTemplateAtRef(*mockContext.Context, "contoso/api", "feature/preview")
+	require.NoError(t, err)
+	require.Equal(t, "contoso/api", template.RepositoryPath)
+	require.Empty(t, template.Version)
+	require.Equal(t, "feature/preview", template.FetchRef())
+
+	_, err = templateManager.GetTemplateAtRef(*mockContext.Context, "not-in-catalog", "v1.0.0")
+	require.ErrorIs(t, err, ErrTemplateNotFound)
+}
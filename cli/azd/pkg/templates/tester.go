@@ -0,0 +1,191 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package templates
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/joho/godotenv"
+	"github.com/otiai10/copy"
+)
+
+// TestStep is an azd command run for each service of a template by a template test.
+type TestStep string
+
+const (
+	TestStepRestore TestStep = "restore"
+	TestStepBuild   TestStep = "build"
+	TestStepPackage TestStep = "package"
+)
+
+// TestSteps are the steps of a template test, in order.
+var TestSteps = []TestStep{TestStepRestore, TestStepBuild, TestStepPackage}
+
+// The environment created in the copy of the template
+const testEnvironmentName = "template-test"
+
+// The number of lines of output of a failed step kept in the report
+const testOutputLines = 50
+
+// The directories of a template which are not copied for a template test, since they hold the state of a local
+// checkout rather than the content of the template
+var testSkippedDirectories = []string{".git", azdcontext.EnvironmentDirectoryName, "node_modules", ".venv"}
+
+// TestResult is the result of a step of a template test, for a service.
+type TestResult struct {
+	Service    string   `json:"service"`
+	Step       TestStep `json:"step"`
+	Passed     bool     `json:"passed"`
+	Skipped    bool     `json:"skipped,omitempty"`
+	DurationMs int64    `json:"durationMs"`
+	// The last lines of the output of the step, when it failed
+	Output string `json:"output,omitempty"`
+}
+
+// TestReport is the result of a template test.
+type TestReport struct {
+	Template string       `json:"template"`
+	Passed   bool         `json:"passed"`
+	Results  []TestResult `json:"results"`
+}
+
+// Tester tests templates by running the restore, build and package commands of azd for each of their services, in a
+// clean copy of the template.
+type Tester struct {
+	commandRunner exec.CommandRunner
+	// The path of the azd executable running the steps
+	azdPath string
+}
+
+func NewTester(commandRunner exec.CommandRunner) (*Tester, error) {
+	azdPath, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("finding the azd executable: %w", err)
+	}
+
+	return &Tester{
+		commandRunner: commandRunner,
+		azdPath:       azdPath,
+	}, nil
+}
+
+// TestRun is a template test in progress, in a clean copy of the template.
+type TestRun struct {
+	tester *Tester
+	// The directory of the copy of the template
+	dir string
+	// The names of the services of the template, in order
+	Services []string
+}
+
+// Start copies the template to a temporary directory, and creates the environment the steps run in.
+func (t *Tester) Start(ctx context.Context, templateDir string) (*TestRun, error) {
+	projectConfig, err := project.Load(ctx, filepath.Join(templateDir, azdcontext.ProjectFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "azd-template-test-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating the directory of the template copy: %w", err)
+	}
+
+	run := &TestRun{
+		tester:   t,
+		dir:      dir,
+		Services: make([]string, 0, len(projectConfig.Services)),
+	}
+
+	options := copy.Options{
+		Skip: func(fileInfo os.FileInfo, src, dest string) (bool, error) {
+			return fileInfo.IsDir() && slices.Contains(testSkippedDirectories, fileInfo.Name()), nil
+		},
+	}
+
+	if err := copy.Copy(templateDir, dir, options); err != nil {
+		_ = run.Close()
+		return nil, fmt.Errorf("copying the template: %w", err)
+	}
+
+	azdCtx := azdcontext.NewAzdContextWithDirectory(dir)
+	if err := os.MkdirAll(azdCtx.EnvironmentRoot(testEnvironmentName), osutil.PermissionDirectory); err != nil {
+		_ = run.Close()
+		return nil, fmt.Errorf("creating the test environment: %w", err)
+	}
+
+	envFile := filepath.Join(azdCtx.EnvironmentRoot(testEnvironmentName), azdcontext.DotEnvFileName)
+	if err := godotenv.Write(map[string]string{"AZURE_ENV_NAME": testEnvironmentName}, envFile); err != nil {
+		_ = run.Close()
+		return nil, fmt.Errorf("creating the test environment: %w", err)
+	}
+
+	if err := azdCtx.SetProjectState(azdcontext.ProjectState{DefaultEnvironment: testEnvironmentName}); err != nil {
+		_ = run.Close()
+		return nil, fmt.Errorf("creating the test environment: %w", err)
+	}
+
+	for name := range projectConfig.Services {
+		run.Services = append(run.Services, name)
+	}
+	slices.Sort(run.Services)
+
+	return run, nil
+}
+
+// Run runs the step for the service, with the azd executable.
+func (r *TestRun) Run(ctx context.Context, service string, step TestStep) TestResult {
+	args := exec.NewRunArgs(
+		r.tester.azdPath,
+		string(step),
+		service,
+		"--cwd", r.dir,
+		"--environment", testEnvironmentName,
+		"--no-prompt",
+	).WithCwd(r.dir)
+
+	start := time.Now()
+	res, err := r.tester.commandRunner.Run(ctx, args)
+
+	result := TestResult{
+		Service:    service,
+		Step:       step,
+		Passed:     err == nil,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		output := strings.TrimSpace(strings.Join([]string{res.Stdout, res.Stderr}, "\n"))
+		if output == "" {
+			output = err.Error()
+		}
+
+		result.Output = lastLines(output, testOutputLines)
+	}
+
+	return result
+}
+
+// Close removes the copy of the template.
+func (r *TestRun) Close() error {
+	return os.RemoveAll(r.dir)
+}
+
+func lastLines(text string, count int) string {
+	lines := strings.Split(text, "\n")
+	if len(lines) > count {
+		lines = lines[len(lines)-count:]
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package templates
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Tester_Run(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	templateDir := writeTemplateFiles(t, map[string]string{
+		"azure.yaml": `name: tested
+services:
+  web:
+    project: src/web
+    language: js
+    host: appservice
+  api:
+    project: src/api
+    language: python
+    host: containerapp
+`,
+		"src/web/package.json":            "{}",
+		"src/api/main.py":                 "print('hello')",
+		".azure/dev/.env":                 "AZURE_ENV_NAME=dev",
+		"src/web/node_modules/dep/dep.js": "",
+	})
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return slices.Contains(args.Args, "build") && slices.Contains(args.Args, "api")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		return exec.NewRunResult(1, "building api", "no such module"), errors.New("exit code: 1")
+	})
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return slices.Contains(args.Args, "restore")
+	}).Respond(exec.NewRunResult(0, "", ""))
+
+	tester := &Tester{
+		commandRunner: mockContext.CommandRunner,
+		azdPath:       "azd",
+	}
+
+	run, err := tester.Start(*mockContext.Context, templateDir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"api", "web"}, run.Services)
+
+	// The copy has a new environment, without the local state of the template
+	require.NoDirExists(t, filepath.Join(run.dir, azdcontext.EnvironmentDirectoryName, "dev"))
+	require.NoDirExists(t, filepath.Join(run.dir, "src", "web", "node_modules"))
+	require.FileExists(t, filepath.Join(run.dir, "src", "api", "main.py"))
+	require.FileExists(t, filepath.Join(
+		run.dir, azdcontext.EnvironmentDirectoryName, testEnvironmentName, azdcontext.DotEnvFileName))
+
+	result := run.Run(*mockContext.Context, "api", TestStepRestore)
+	require.True(t, result.Passed)
+	require.Empty(t, result.Output)
+
+	result = run.Run(*mockContext.Context, "api", TestStepBuild)
+	require.False(t, result.Passed)
+	require.Equal(t, "building api\nno such module", result.Output)
+
+	dir := run.dir
+	require.NoError(t, run.Close())
+	_, err = os.Stat(dir)
+	require.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func Test_LastLines(t *testing.T) {
+	require.Equal(t, "c\nd", lastLines("a\nb\nc\nd", 2))
+	require.Equal(t, "a\nb", lastLines("a\nb", 5))
+}
@@ -10,11 +10,19 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 )
 
-// newUrlTemplateSource creates a new template source from a URL.
-func newUrlTemplateSource(ctx context.Context, name string, url string, transport policy.Transporter) (Source, error) {
-	pipeline := runtime.NewPipeline("azd-templates", "1.0.0", runtime.PipelineOptions{}, &policy.ClientOptions{
-		Transport: transport,
-	})
+// newUrlTemplateSource creates a new template source from a URL. The policies authenticate the requests to private
+// sources.
+func newUrlTemplateSource(
+	ctx context.Context,
+	name string,
+	url string,
+	transport policy.Transporter,
+	policies ...policy.Policy,
+) (Source, error) {
+	pipeline := runtime.NewPipeline("azd-templates", "1.0.0", runtime.PipelineOptions{PerRetry: policies},
+		&policy.ClientOptions{
+			Transport: transport,
+		})
 
 	req, err := runtime.NewRequest(ctx, http.MethodGet, url)
 	if err != nil {
@@ -0,0 +1,471 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package templates
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/schemas"
+	"github.com/braydonk/yaml"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// DefaultSchemaUrl is the azure.yaml schema templates are validated against, when their azure.yaml doesn't reference
+// a schema. The schema is embedded in azd, so it's not fetched.
+const DefaultSchemaUrl = "https://raw.githubusercontent.com/Azure/azure-dev/main/schemas/v1.0/azure.yaml.json"
+
+// The checks of a template validation
+const (
+	ValidationCheckSchema          = "schema"
+	ValidationCheckServicePaths    = "servicePaths"
+	ValidationCheckBicepParameters = "bicepParameters"
+	ValidationCheckHooks           = "hooks"
+)
+
+// ValidationIssue is a problem found in a template by a check of the validation.
+type ValidationIssue struct {
+	Check string `json:"check"`
+	// The file of the template with the issue, relative to the template directory.
+	File string `json:"file"`
+	// The location of the issue in the file, like services.api.project.
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+func (i ValidationIssue) String() string {
+	location := i.File
+	if i.Path != "" {
+		location += ": " + i.Path
+	}
+
+	return fmt.Sprintf("%s: %s", location, i.Message)
+}
+
+// ValidationReport is the result of the validation of a template.
+type ValidationReport struct {
+	Template string            `json:"template"`
+	Schema   string            `json:"schema"`
+	Checks   []string          `json:"checks"`
+	Passed   bool              `json:"passed"`
+	Issues   []ValidationIssue `json:"issues"`
+}
+
+func (r *ValidationReport) addIssue(issue ValidationIssue) {
+	r.Issues = append(r.Issues, issue)
+	r.Passed = false
+}
+
+// ValidateOptions are the options of a template validation.
+type ValidateOptions struct {
+	// The path or URL of the azure.yaml schema. When empty, the schema referenced by the azure.yaml of the template is
+	// used, or the default schema.
+	Schema string
+}
+
+// Validator checks templates are well-formed, for template authors.
+type Validator struct {
+	transport policy.Transporter
+}
+
+func NewValidator(transport policy.Transporter) *Validator {
+	return &Validator{
+		transport: transport,
+	}
+}
+
+// Validate validates the template in the directory: its azure.yaml conforms to the schema, the projects of its services
+// exist, the parameters of its Bicep parameters file are declared by the Bicep module and its hooks point to existing
+// scripts.
+func (v *Validator) Validate(ctx context.Context, templateDir string, options ValidateOptions) (*ValidationReport, error) {
+	projectFilePath := filepath.Join(templateDir, azdcontext.ProjectFileName)
+	content, err := os.ReadFile(projectFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no %s found in '%s', is it an azd template?", azdcontext.ProjectFileName, templateDir)
+	} else if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", azdcontext.ProjectFileName, err)
+	}
+
+	schemaLocation := options.Schema
+	if schemaLocation == "" {
+		schemaLocation = schemaAnnotation(string(content))
+	}
+
+	report := &ValidationReport{
+		Template: templateDir,
+		Schema:   schemaLocation,
+		Checks:   []string{ValidationCheckSchema},
+		Passed:   true,
+		Issues:   []ValidationIssue{},
+	}
+
+	schemaIssues, err := v.validateSchema(ctx, content, schemaLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, issue := range schemaIssues {
+		report.addIssue(issue)
+	}
+
+	projectConfig, err := project.Parse(ctx, string(content))
+	if err != nil {
+		// The other checks rely on the project configuration
+		report.addIssue(ValidationIssue{
+			Check:   ValidationCheckSchema,
+			File:    azdcontext.ProjectFileName,
+			Message: err.Error(),
+		})
+
+		return report, nil
+	}
+
+	report.Checks = append(report.Checks,
+		ValidationCheckServicePaths, ValidationCheckBicepParameters, ValidationCheckHooks)
+
+	for _, issue := range validateServicePaths(templateDir, projectConfig) {
+		report.addIssue(issue)
+	}
+
+	bicepIssues, err := validateBicepParameters(templateDir, projectConfig.Infra)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, issue := range bicepIssues {
+		report.addIssue(issue)
+	}
+
+	for _, issue := range validateHooks(templateDir, projectConfig) {
+		report.addIssue(issue)
+	}
+
+	return report, nil
+}
+
+var schemaAnnotationRegex = regexp.MustCompile(`(?m)^#\s*yaml-language-server:\s*\$schema=(\S+)`)
+
+// schemaAnnotation returns the schema referenced by the yaml-language-server annotation of the azure.yaml, or the
+// default schema.
+func schemaAnnotation(content string) string {
+	if match := schemaAnnotationRegex.FindStringSubmatch(content); match != nil {
+		return match[1]
+	}
+
+	return DefaultSchemaUrl
+}
+
+// validateSchema validates the azure.yaml content against the schema, a local file or a URL.
+func (v *Validator) validateSchema(
+	ctx context.Context,
+	content []byte,
+	schemaLocation string,
+) ([]ValidationIssue, error) {
+	schemaContent, err := v.readSchema(ctx, schemaLocation)
+	if err != nil {
+		return nil, fmt.Errorf("reading schema '%s': %w", schemaLocation, err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(schemaLocation, bytes.NewReader(schemaContent)); err != nil {
+		return nil, fmt.Errorf("schema '%s': %w", schemaLocation, err)
+	}
+
+	schema, err := compiler.Compile(schemaLocation)
+	if err != nil {
+		return nil, fmt.Errorf("schema '%s': %w", schemaLocation, err)
+	}
+
+	var document any
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return []ValidationIssue{{
+			Check:   ValidationCheckSchema,
+			File:    azdcontext.ProjectFileName,
+			Message: fmt.Sprintf("invalid YAML: %s", err.Error()),
+		}}, nil
+	}
+
+	// The schema validates the JSON representation of the document
+	jsonDocument, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("converting %s to JSON: %w", azdcontext.ProjectFileName, err)
+	}
+
+	document = nil
+	if err := json.Unmarshal(jsonDocument, &document); err != nil {
+		return nil, fmt.Errorf("converting %s to JSON: %w", azdcontext.ProjectFileName, err)
+	}
+
+	var validationErr *jsonschema.ValidationError
+	if err := schema.Validate(document); errors.As(err, &validationErr) {
+		return schemaIssues(validationErr), nil
+	} else if err != nil {
+		return nil, fmt.Errorf("schema '%s': %w", schemaLocation, err)
+	}
+
+	return []ValidationIssue{}, nil
+}
+
+// schemaIssues returns an issue for each of the violations of the schema the validation error is caused by
+func schemaIssues(validationErr *jsonschema.ValidationError) []ValidationIssue {
+	if len(validationErr.Causes) > 0 {
+		issues := []ValidationIssue{}
+		for _, cause := range validationErr.Causes {
+			issues = append(issues, schemaIssues(cause)...)
+		}
+
+		sortIssues(issues)
+		return issues
+	}
+
+	// The instance location is a JSON pointer, like /services/api
+	segments := strings.Split(strings.TrimPrefix(validationErr.InstanceLocation, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+	}
+
+	return []ValidationIssue{{
+		Check:   ValidationCheckSchema,
+		File:    azdcontext.ProjectFileName,
+		Path:    strings.Join(segments, "."),
+		Message: validationErr.Message,
+	}}
+}
+
+func (v *Validator) readSchema(ctx context.Context, schemaLocation string) ([]byte, error) {
+	if schemaLocation == DefaultSchemaUrl {
+		return schemas.AzureYamlV1, nil
+	}
+
+	if !strings.HasPrefix(schemaLocation, "https://") && !strings.HasPrefix(schemaLocation, "http://") {
+		return os.ReadFile(schemaLocation)
+	}
+
+	pipeline := runtime.NewPipeline("azd-templates", "1.0.0", runtime.PipelineOptions{}, &policy.ClientOptions{
+		Transport: v.transport,
+	})
+
+	req, err := runtime.NewRequest(ctx, http.MethodGet, schemaLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := pipeline.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, runtime.NewResponseError(resp)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// validateServicePaths validates the projects of the services exist.
+func validateServicePaths(templateDir string, projectConfig *project.ProjectConfig) []ValidationIssue {
+	issues := []ValidationIssue{}
+
+	for _, svc := range projectConfig.Services {
+		// Services of pre-built images have no project
+		if svc.RelativePath == "" && !svc.Image.Empty() {
+			continue
+		}
+
+		if _, err := os.Stat(filepath.Join(templateDir, svc.RelativePath)); err != nil {
+			issues = append(issues, ValidationIssue{
+				Check:   ValidationCheckServicePaths,
+				File:    azdcontext.ProjectFileName,
+				Path:    fmt.Sprintf("services.%s.project", svc.Name),
+				Message: fmt.Sprintf("the project '%s' does not exist", filepath.ToSlash(svc.RelativePath)),
+			})
+		}
+	}
+
+	sortIssues(issues)
+	return issues
+}
+
+// Matches the declarations of parameters of a Bicep module, including the ones with decorators on the same line
+var bicepParamRegex = regexp.MustCompile(`(?m)^\s*(?:@[\w.]+(?:\([^)]*\))?\s+)*param\s+([A-Za-z_][A-Za-z0-9_]*)\s`)
+
+// validateBicepParameters validates the parameters of the parameters file of the Bicep module are declared by the
+// module.
+func validateBicepParameters(templateDir string, infra provisioning.Options) ([]ValidationIssue, error) {
+	if infra.Provider != provisioning.NotSpecified && infra.Provider != provisioning.Bicep {
+		return nil, nil
+	}
+
+	module := infra.Module
+	if module == "" {
+		module = "main"
+	}
+
+	parametersFile := filepath.Join(infra.Path, module+".parameters.json")
+	parametersContent, err := os.ReadFile(filepath.Join(templateDir, parametersFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		// The parameters file is optional, azd prompts for the parameters without a default value
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", parametersFile, err)
+	}
+
+	parametersFile = filepath.ToSlash(parametersFile)
+
+	var parameters struct {
+		Parameters map[string]any `json:"parameters"`
+	}
+	if err := json.Unmarshal(parametersContent, &parameters); err != nil {
+		return []ValidationIssue{{
+			Check:   ValidationCheckBicepParameters,
+			File:    parametersFile,
+			Message: fmt.Sprintf("invalid JSON: %s", err.Error()),
+		}}, nil
+	}
+
+	moduleFile := filepath.Join(infra.Path, module+".bicep")
+	moduleContent, err := os.ReadFile(filepath.Join(templateDir, moduleFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return []ValidationIssue{{
+			Check:   ValidationCheckBicepParameters,
+			File:    parametersFile,
+			Message: fmt.Sprintf("the Bicep module '%s' does not exist", filepath.ToSlash(moduleFile)),
+		}}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", moduleFile, err)
+	}
+
+	declared := map[string]bool{}
+	for _, match := range bicepParamRegex.FindAllStringSubmatch(string(moduleContent), -1) {
+		declared[match[1]] = true
+	}
+
+	issues := []ValidationIssue{}
+	for name := range parameters.Parameters {
+		if !declared[name] {
+			issues = append(issues, ValidationIssue{
+				Check: ValidationCheckBicepParameters,
+				File:  parametersFile,
+				Path:  fmt.Sprintf("parameters.%s", name),
+				Message: fmt.Sprintf(
+					"the parameter is not declared by the Bicep module '%s'", filepath.ToSlash(moduleFile)),
+			})
+		}
+	}
+
+	sortIssues(issues)
+	return issues, nil
+}
+
+// validateHooks validates the hooks of the project and services run existing scripts, or inline scripts of a known shell.
+func validateHooks(templateDir string, projectConfig *project.ProjectConfig) []ValidationIssue {
+	issues := []ValidationIssue{}
+
+	for name, hooks := range projectConfig.Hooks {
+		issues = append(issues, validateHookConfigs(templateDir, fmt.Sprintf("hooks.%s", name), hooks)...)
+	}
+
+	for _, svc := range projectConfig.Services {
+		serviceDir := filepath.Join(templateDir, svc.RelativePath)
+		for name, hooks := range svc.Hooks {
+			issues = append(issues,
+				validateHookConfigs(serviceDir, fmt.Sprintf("services.%s.hooks.%s", svc.Name, name), hooks)...)
+		}
+	}
+
+	sortIssues(issues)
+	return issues
+}
+
+func validateHookConfigs(cwd string, path string, hooks []*ext.HookConfig) []ValidationIssue {
+	issues := []ValidationIssue{}
+
+	for i, hook := range hooks {
+		hookPath := path
+		if len(hooks) > 1 {
+			hookPath = fmt.Sprintf("%s[%d]", path, i)
+		}
+
+		issues = append(issues, validateHookConfig(cwd, hookPath, hook)...)
+	}
+
+	return issues
+}
+
+func validateHookConfig(cwd string, path string, hook *ext.HookConfig) []ValidationIssue {
+	if hook == nil {
+		return nil
+	}
+
+	issues := []ValidationIssue{}
+	hasOverrides := hook.Windows != nil || hook.Posix != nil
+
+	if hook.Windows != nil {
+		issues = append(issues, validateHookConfig(cwd, path+".windows", hook.Windows)...)
+	}
+
+	if hook.Posix != nil {
+		issues = append(issues, validateHookConfig(cwd, path+".posix", hook.Posix)...)
+	}
+
+	run := strings.TrimSpace(hook.Run)
+	switch {
+	case run == "":
+		if !hasOverrides {
+			issues = append(issues, hookIssue(path, "the hook has no script to run"))
+		}
+	case isScriptPath(run):
+		if stat, err := os.Stat(filepath.Join(cwd, run)); err != nil || stat.IsDir() {
+			issues = append(issues, hookIssue(path, fmt.Sprintf("the script '%s' does not exist", run)))
+		}
+	case hook.Shell == ext.ScriptTypeUnknown:
+		issues = append(issues, hookIssue(path, "inline scripts require the shell of the hook to be set"))
+	}
+
+	return issues
+}
+
+// isScriptPath returns true when the run value of a hook is the path of a script, rather than an inline script.
+func isScriptPath(run string) bool {
+	if strings.ContainsAny(run, " \t\r\n") {
+		return false
+	}
+
+	extension := strings.ToLower(filepath.Ext(run))
+	return extension == ".sh" || extension == ".ps1"
+}
+
+func hookIssue(path string, message string) ValidationIssue {
+	return ValidationIssue{
+		Check:   ValidationCheckHooks,
+		File:    azdcontext.ProjectFileName,
+		Path:    path,
+		Message: message,
+	}
+}
+
+// sortIssues sorts issues by location, so reports are stable.
+func sortIssues(issues []ValidationIssue) {
+	slices.SortFunc(issues, func(a, b ValidationIssue) int {
+		return strings.Compare(a.File+":"+a.Path, b.File+":"+b.Path)
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package templates
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+const validatorTestSchema = "../../../../schemas/v1.0/azure.yaml.json"
+
+func writeTemplateFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), osutil.PermissionDirectory))
+		require.NoError(t, os.WriteFile(path, []byte(content), osutil.PermissionFile))
+	}
+
+	return dir
+}
+
+func Test_Validator_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  map[string]string
+		issues []ValidationIssue
+	}{
+		{
+			name: "Valid",
+			files: map[string]string{
+				"azure.yaml": `name: valid
+hooks:
+  preprovision:
+    run: scripts/setup.sh
+services:
+  api:
+    project: src/api
+    language: python
+    host: containerapp
+`,
+				"scripts/setup.sh":           "echo setup",
+				"src/api/main.py":            "print('hello')",
+				"infra/main.bicep":           "@description('The location')\nparam location string\nparam environmentName string\n",
+				"infra/main.parameters.json": `{"parameters": {"location": {"value": "eastus"}, "environmentName": {"value": "dev"}}}`,
+			},
+			issues: []ValidationIssue{},
+		},
+		{
+			name: "SchemaViolation",
+			files: map[string]string{
+				"azure.yaml": `name: invalid
+services:
+  api:
+    project: src/api
+    language: python
+    host: containerapp
+    hots: containerapp
+`,
+				"src/api/main.py": "print('hello')",
+			},
+			issues: []ValidationIssue{
+				{Check: ValidationCheckSchema, File: "azure.yaml", Path: "services.api"},
+			},
+		},
+		{
+			name: "MissingServiceProject",
+			files: map[string]string{
+				"azure.yaml": `name: missing
+services:
+  web:
+    project: src/web
+    language: js
+    host: appservice
+`,
+			},
+			issues: []ValidationIssue{
+				{Check: ValidationCheckServicePaths, File: "azure.yaml", Path: "services.web.project"},
+			},
+		},
+		{
+			name: "UndeclaredBicepParameter",
+			files: map[string]string{
+				"azure.yaml":                 "name: bicep\n",
+				"infra/main.bicep":           "param location string\n",
+				"infra/main.parameters.json": `{"parameters": {"location": {"value": "eastus"}, "sku": {"value": "B1"}}}`,
+			},
+			issues: []ValidationIssue{
+				{Check: ValidationCheckBicepParameters, File: "infra/main.parameters.json", Path: "parameters.sku"},
+			},
+		},
+		{
+			name: "Hooks",
+			files: map[string]string{
+				"azure.yaml": `name: hooks
+hooks:
+  preprovision:
+    run: scripts/missing.sh
+  postprovision:
+    run: echo done
+`,
+			},
+			issues: []ValidationIssue{
+				{Check: ValidationCheckHooks, File: "azure.yaml", Path: "hooks.postprovision"},
+				{Check: ValidationCheckHooks, File: "azure.yaml", Path: "hooks.preprovision"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockContext := mocks.NewMockContext(context.Background())
+			dir := writeTemplateFiles(t, tt.files)
+
+			validator := NewValidator(mockContext.HttpClient)
+			report, err := validator.Validate(*mockContext.Context, dir, ValidateOptions{Schema: validatorTestSchema})
+			require.NoError(t, err)
+
+			require.Equal(t, len(tt.issues) == 0, report.Passed)
+			require.Len(t, report.Issues, len(tt.issues), "issues: %v", report.Issues)
+			for i, expected := range tt.issues {
+				require.Equal(t, expected.Check, report.Issues[i].Check)
+				require.Equal(t, expected.File, report.Issues[i].File)
+				require.Equal(t, expected.Path, report.Issues[i].Path)
+				require.NotEmpty(t, report.Issues[i].Message)
+			}
+		})
+	}
+}
+
+func Test_Validator_Validate_NoProject(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+
+	validator := NewValidator(mockContext.HttpClient)
+	_, err := validator.Validate(*mockContext.Context, t.TempDir(), ValidateOptions{Schema: validatorTestSchema})
+	require.Error(t, err)
+}
+
+func Test_Validator_Validate_DefaultSchema(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	dir := writeTemplateFiles(t, map[string]string{
+		"azure.yaml": "name: default\nservices: 3\n",
+	})
+
+	// The default schema is embedded, it isn't fetched
+	validator := NewValidator(mockContext.HttpClient)
+	report, err := validator.Validate(*mockContext.Context, dir, ValidateOptions{})
+	require.NoError(t, err)
+
+	require.Equal(t, DefaultSchemaUrl, report.Schema)
+	require.False(t, report.Passed)
+	require.Equal(t, ValidationCheckSchema, report.Issues[0].Check)
+	require.Equal(t, "services", report.Issues[0].Path)
+}
@@ -39,19 +39,35 @@ type AzCli interface {
 	PurgeCognitiveAccount(ctx context.Context, subscriptionId, location, resourceGroup, accountName string) error
 	GetApim(
 		ctx context.Context, subscriptionId string, resourceGroupName string, apimName string) (*AzCliApim, error)
+	// Deploys the zip package to the App Service, or to its deployment slot when slotName is set
 	DeployAppServiceZip(
 		ctx context.Context,
 		subscriptionId string,
 		resourceGroup string,
 		appName string,
+		slotName string,
 		deployZipFile io.ReadSeeker,
 		logProgress func(string),
 	) (*string, error)
+	// Streams the application logs of an App Service or a Function App, or of its deployment slot when slotName is set, to
+	// the writer. The log stream only contains new entries, so when follow is false the stream is closed once no entry was
+	// received for a few seconds.
+	StreamAppServiceLogs(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroup string,
+		appName string,
+		slotName string,
+		follow bool,
+		writer io.Writer,
+	) error
+	// Deploys the zip package to the Function App, or to its deployment slot when slotName is set
 	DeployFunctionAppUsingZipFile(
 		ctx context.Context,
 		subscriptionID string,
 		resourceGroup string,
 		funcName string,
+		slotName string,
 		deployZipFile io.ReadSeeker,
 		remoteBuild bool,
 	) (*string, error)
@@ -60,7 +76,16 @@ type AzCli interface {
 		subscriptionID string,
 		resourceGroup string,
 		funcName string,
+		slotName string,
 	) (*AzCliFunctionAppProperties, error)
+	// Swaps the deployment slot of an App Service or a Function App with its production slot
+	SwapAppServiceSlot(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroup string,
+		appName string,
+		slotName string,
+	) error
 	// CreateOrUpdateServicePrincipal creates a service principal using a given name and returns a JSON object which
 	// may be used by tools which understand the `AZURE_CREDENTIALS` format (i.e. the `sdk-auth` format). The service
 	// principal is assigned a given role. If an existing principal exists with the given name,
@@ -70,6 +95,7 @@ type AzCli interface {
 		subscriptionId string,
 		resourceGroupName string,
 		applicationName string,
+		slotName string,
 	) (*AzCliAppServiceProperties, error)
 	GetStaticWebAppProperties(
 		ctx context.Context,
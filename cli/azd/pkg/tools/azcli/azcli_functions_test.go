@@ -48,6 +48,7 @@ func Test_GetFunctionAppProperties(t *testing.T) {
 			"SUBSCRIPTION_ID",
 			"RESOURCE_GROUP_ID",
 			"FUNC_APP_NAME",
+			"",
 		)
 		require.NoError(t, err)
 		require.Equal(t, []string{"FUNC_APP_NAME.azurewebsites.net"}, props.HostNames)
@@ -73,6 +74,7 @@ func Test_GetFunctionAppProperties(t *testing.T) {
 			"SUBSCRIPTION_ID",
 			"RESOURCE_GROUP_ID",
 			"FUNC_APP_NAME",
+			"",
 		)
 
 		require.Nil(t, props)
@@ -98,6 +100,7 @@ func Test_DeployFunctionAppUsingZipFile(t *testing.T) {
 			"SUBSCRIPTION_ID",
 			"RESOURCE_GROUP_ID",
 			"FUNC_APP_NAME",
+			"",
 			zipFile,
 			false,
 		)
@@ -122,6 +125,7 @@ func Test_DeployFunctionAppUsingZipFile(t *testing.T) {
 			"SUBSCRIPTION_ID",
 			"RESOURCE_GROUP_ID",
 			"FUNC_APP_NAME",
+			"",
 			zipFile,
 			false,
 		)
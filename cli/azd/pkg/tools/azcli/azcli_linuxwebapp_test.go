@@ -6,6 +6,7 @@ package azcli
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"strings"
 	"testing"
@@ -35,6 +36,7 @@ func Test_DeployTrackLinuxWebAppStatus(t *testing.T) {
 			"SUBSCRIPTION_ID",
 			"RESOURCE_GROUP_ID",
 			"LINUX_WEB_APP_NAME",
+			"",
 			zipFile,
 			func(s string) {},
 		)
@@ -60,6 +62,7 @@ func Test_DeployTrackLinuxWebAppStatus(t *testing.T) {
 			"SUBSCRIPTION_ID",
 			"RESOURCE_GROUP_ID",
 			"LINUX_WEB_APP_NAME",
+			"",
 			zipFile,
 			func(s string) {},
 		)
@@ -85,6 +88,7 @@ func Test_DeployTrackLinuxWebAppStatus(t *testing.T) {
 			"SUBSCRIPTION_ID",
 			"RESOURCE_GROUP_ID",
 			"WINDOWS_LOGIC_APP_NAME",
+			"",
 			zipFile,
 			func(s string) {},
 		)
@@ -95,6 +99,95 @@ func Test_DeployTrackLinuxWebAppStatus(t *testing.T) {
 	})
 }
 
+// Deployments to a slot are not tracked with the deployment status api, which only supports the production slot
+func Test_DeployAppServiceZip_Slot(t *testing.T) {
+	ran := false
+	mockContext := mocks.NewMockContext(context.Background())
+	azCli := newAzCliFromMockContext(mockContext)
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet &&
+			strings.Contains(
+				request.URL.Path,
+				//nolint:lll
+				"/subscriptions/SUBSCRIPTION_ID/resourceGroups/RESOURCE_GROUP_ID/providers/Microsoft.Web/sites/LINUX_WEB_APP_NAME/slots/staging",
+			)
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		response := armappservice.WebAppsClientGetSlotResponse{
+			Site: armappservice.Site{
+				Location: to.Ptr("eastus2"),
+				Kind:     to.Ptr("app,linux"),
+				Name:     to.Ptr("LINUX_WEB_APP_NAME/staging"),
+				Properties: &armappservice.SiteProperties{
+					DefaultHostName: to.Ptr("LINUX_WEB_APP_NAME-staging.azurewebsites.net"),
+					SiteConfig: &armappservice.SiteConfig{
+						LinuxFxVersion: to.Ptr("Python"),
+					},
+					HostNameSSLStates: []*armappservice.HostNameSSLState{
+						{
+							HostType: to.Ptr(armappservice.HostTypeRepository),
+							Name:     to.Ptr("LINUX_WEB_APP_NAME_STAGING_SCM_HOST"),
+						},
+					},
+				},
+			},
+		}
+
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, response)
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost &&
+			request.URL.Host == "LINUX_WEB_APP_NAME_STAGING_SCM_HOST" &&
+			strings.Contains(request.URL.Path, "/api/zipdeploy")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		response, _ := mocks.CreateEmptyHttpResponse(request, http.StatusAccepted)
+		response.Header.Set("Location", "https://LINUX_WEB_APP_NAME_STAGING_SCM_HOST/deployments/latest")
+
+		return response, nil
+	})
+	registerLogicAppPollingMocks(mockContext, &ran)
+
+	res, err := azCli.DeployAppServiceZip(
+		*mockContext.Context,
+		"SUBSCRIPTION_ID",
+		"RESOURCE_GROUP_ID",
+		"LINUX_WEB_APP_NAME",
+		"staging",
+		bytes.NewReader([]byte{}),
+		func(s string) {},
+	)
+
+	require.NoError(t, err)
+	require.True(t, ran)
+	require.Equal(t, "OK", *res)
+}
+
+func Test_SwapAppServiceSlot(t *testing.T) {
+	var swapRequest *http.Request
+	mockContext := mocks.NewMockContext(context.Background())
+	azCli := newAzCliFromMockContext(mockContext)
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost &&
+			strings.Contains(
+				request.URL.Path,
+				"/subscriptions/SUBSCRIPTION_ID/resourceGroups/RESOURCE_GROUP_ID/providers/Microsoft.Web/sites/WEB_APP_NAME/slotsswap",
+			)
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		swapRequest = request
+		return mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+	})
+
+	err := azCli.SwapAppServiceSlot(*mockContext.Context, "SUBSCRIPTION_ID", "RESOURCE_GROUP_ID", "WEB_APP_NAME", "staging")
+	require.NoError(t, err)
+	require.NotNil(t, swapRequest)
+
+	var slotEntity armappservice.CsmSlotEntity
+	require.NoError(t, json.NewDecoder(swapRequest.Body).Decode(&slotEntity))
+	require.Equal(t, "staging", *slotEntity.TargetSlot)
+}
+
 func registerIsLinuxWebAppMocks(mockContext *mocks.MockContext, ran *bool) {
 	mockContext.HttpClient.When(func(request *http.Request) bool {
 		return request.Method == http.MethodGet &&
@@ -21,8 +21,9 @@ func (cli *azCli) GetFunctionAppProperties(
 	subscriptionId string,
 	resourceGroup string,
 	appName string,
+	slotName string,
 ) (*AzCliFunctionAppProperties, error) {
-	webApp, err := cli.appService(ctx, subscriptionId, resourceGroup, appName)
+	webApp, err := cli.appService(ctx, subscriptionId, resourceGroup, appName, slotName)
 	if err != nil {
 		return nil, err
 	}
@@ -37,10 +38,11 @@ func (cli *azCli) DeployFunctionAppUsingZipFile(
 	subscriptionId string,
 	resourceGroup string,
 	appName string,
+	slotName string,
 	deployZipFile io.ReadSeeker,
 	remoteBuild bool,
 ) (*string, error) {
-	app, err := cli.appService(ctx, subscriptionId, resourceGroup, appName)
+	app, err := cli.appService(ctx, subscriptionId, resourceGroup, appName, slotName)
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +73,10 @@ func (cli *azCli) DeployFunctionAppUsingZipFile(
 	}
 
 	if strings.ToLower(*plan.SKU.Tier) == "flexconsumption" {
+		if slotName != "" {
+			return nil, fmt.Errorf("deployment slots are not supported by the Flex Consumption plan of '%s'", appName)
+		}
+
 		cred, err := cli.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
 		if err != nil {
 			return nil, err
@@ -22,8 +22,9 @@ func (cli *azCli) GetAppServiceProperties(
 	subscriptionId string,
 	resourceGroup string,
 	appName string,
+	slotName string,
 ) (*AzCliAppServiceProperties, error) {
-	webApp, err := cli.appService(ctx, subscriptionId, resourceGroup, appName)
+	webApp, err := cli.appService(ctx, subscriptionId, resourceGroup, appName, slotName)
 	if err != nil {
 		return nil, err
 	}
@@ -33,26 +34,37 @@ func (cli *azCli) GetAppServiceProperties(
 	}, nil
 }
 
+// appService gets the site of the app, or the site of its deployment slot when slotName is set
 func (cli *azCli) appService(
 	ctx context.Context,
 	subscriptionId string,
 	resourceGroup string,
 	appName string,
-) (*armappservice.WebAppsClientGetResponse, error) {
+	slotName string,
+) (*armappservice.Site, error) {
 	client, err := cli.createWebAppsClient(ctx, subscriptionId)
 	if err != nil {
 		return nil, err
 	}
 
+	if slotName != "" {
+		slot, err := client.GetSlot(ctx, resourceGroup, appName, slotName, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed retrieving webapp slot '%s' properties: %w", slotName, err)
+		}
+
+		return &slot.Site, nil
+	}
+
 	webApp, err := client.Get(ctx, resourceGroup, appName, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed retrieving webapp properties: %w", err)
 	}
 
-	return &webApp, nil
+	return &webApp.Site, nil
 }
 
-func isLinuxWebApp(response *armappservice.WebAppsClientGetResponse) bool {
+func isLinuxWebApp(response *armappservice.Site) bool {
 	if *response.Kind == "app,linux" && response.Properties != nil && response.Properties.SiteConfig != nil &&
 		response.Properties.SiteConfig.LinuxFxVersion != nil &&
 		*response.Properties.SiteConfig.LinuxFxVersion != "" {
@@ -62,7 +74,7 @@ func isLinuxWebApp(response *armappservice.WebAppsClientGetResponse) bool {
 }
 
 func appServiceRepositoryHost(
-	response *armappservice.WebAppsClientGetResponse,
+	response *armappservice.Site,
 	appName string,
 ) (string, error) {
 	hostName := ""
@@ -120,10 +132,11 @@ func (cli *azCli) DeployAppServiceZip(
 	subscriptionId string,
 	resourceGroup string,
 	appName string,
+	slotName string,
 	deployZipFile io.ReadSeeker,
 	progressLog func(string),
 ) (*string, error) {
-	app, err := cli.appService(ctx, subscriptionId, resourceGroup, appName)
+	app, err := cli.appService(ctx, subscriptionId, resourceGroup, appName, slotName)
 	if err != nil {
 		return nil, err
 	}
@@ -138,8 +151,8 @@ func (cli *azCli) DeployAppServiceZip(
 		return nil, err
 	}
 
-	// Deployment Status API only support linux web app for now
-	if isLinuxWebApp(app) {
+	// Deployment Status API only support linux web app for now, and only tracks the production slot
+	if isLinuxWebApp(app) && slotName == "" {
 		if err := client.DeployTrackStatus(
 			ctx, deployZipFile, subscriptionId, resourceGroup, appName, progressLog); err != nil {
 			if !resumeDeployment(err, progressLog) {
@@ -160,6 +173,34 @@ func (cli *azCli) DeployAppServiceZip(
 	return to.Ptr(response.StatusText), nil
 }
 
+// SwapAppServiceSlot swaps the deployment slot of an App Service or a Function App with its production slot
+func (cli *azCli) SwapAppServiceSlot(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	slotName string,
+) error {
+	client, err := cli.createWebAppsClient(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	poller, err := client.BeginSwapSlotWithProduction(ctx, resourceGroup, appName, armappservice.CsmSlotEntity{
+		TargetSlot:   to.Ptr(slotName),
+		PreserveVnet: to.Ptr(true),
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("swapping slot '%s' with production: %w", slotName, err)
+	}
+
+	if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+		return fmt.Errorf("swapping slot '%s' with production: %w", slotName, err)
+	}
+
+	return nil
+}
+
 func (cli *azCli) createWebAppsClient(ctx context.Context, subscriptionId string) (*armappservice.WebAppsClient, error) {
 	credential, err := cli.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
 	if err != nil {
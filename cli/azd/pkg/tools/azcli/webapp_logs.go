@@ -0,0 +1,111 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azcli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	armruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/arm/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// appServiceLogStreamIdleTimeout is how long to wait for a new log entry before closing a log stream that is not followed
+const appServiceLogStreamIdleTimeout = 5 * time.Second
+
+// StreamAppServiceLogs streams the application logs from the log stream of the SCM site of the app, or of its deployment
+// slot when slotName is set
+func (cli *azCli) StreamAppServiceLogs(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	slotName string,
+	follow bool,
+	writer io.Writer,
+) error {
+	app, err := cli.appService(ctx, subscriptionId, resourceGroup, appName, slotName)
+	if err != nil {
+		return err
+	}
+
+	hostName, err := appServiceRepositoryHost(app, appName)
+	if err != nil {
+		return err
+	}
+
+	credential, err := cli.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	logStreamOptions := &arm.ClientOptions{}
+	if cli.armClientOptions != nil {
+		optionsCopy := *cli.armClientOptions
+		logStreamOptions = &optionsCopy
+	}
+
+	// We do not have a Resource provider to register
+	logStreamOptions.DisableRPRegistration = true
+
+	pipeline, err := armruntime.NewPipeline("log-stream", "1.0.0", credential, runtime.PipelineOptions{}, logStreamOptions)
+	if err != nil {
+		return fmt.Errorf("failed creating HTTP pipeline: %w", err)
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	request, err := runtime.NewRequest(streamCtx, http.MethodGet, fmt.Sprintf("https://%s/api/logstream", hostName))
+	if err != nil {
+		return err
+	}
+
+	// The body is streamed to the writer instead of being read in memory
+	runtime.SkipBodyDownload(request)
+
+	response, err := pipeline.Do(request)
+	if err != nil {
+		return fmt.Errorf("opening log stream: %w", err)
+	}
+	defer response.Body.Close()
+
+	if !runtime.HasStatusCode(response, http.StatusOK) {
+		return runtime.NewResponseError(response)
+	}
+
+	if !follow {
+		idleTimer := time.AfterFunc(appServiceLogStreamIdleTimeout, cancel)
+		defer idleTimer.Stop()
+
+		writer = &activityWriter{
+			next: writer,
+			onWrite: func() {
+				idleTimer.Reset(appServiceLogStreamIdleTimeout)
+			},
+		}
+	}
+
+	// The stream ends when the context is cancelled, by the user or because the stream is idle
+	if _, err := io.Copy(writer, response.Body); err != nil && streamCtx.Err() == nil {
+		return fmt.Errorf("reading log stream: %w", err)
+	}
+
+	return nil
+}
+
+// activityWriter is an io.Writer that notifies each write to the underlying writer
+type activityWriter struct {
+	next    io.Writer
+	onWrite func()
+}
+
+func (w *activityWriter) Write(p []byte) (int, error) {
+	w.onWrite()
+	return w.next.Write(p)
+}
@@ -13,26 +13,38 @@ import (
 
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
-	"github.com/blang/semver/v4"
 )
 
 const DefaultPlatform string = "linux/amd64"
 
 var _ tools.ExternalTool = (*Cli)(nil)
 
+// NewCli creates a Cli running the container commands with Docker.
 func NewCli(commandRunner exec.CommandRunner) *Cli {
+	return NewCliWithEngine(commandRunner, EngineDocker)
+}
+
+// NewCliWithEngine creates a Cli running the container commands with the container engine.
+func NewCliWithEngine(commandRunner exec.CommandRunner, kind EngineKind) *Cli {
 	return &Cli{
 		commandRunner: commandRunner,
+		engine:        newEngine(kind),
 	}
 }
 
 type Cli struct {
 	commandRunner exec.CommandRunner
+	engine        engine
+}
+
+// Command returns the executable of the container engine, e.g. 'docker' or 'podman'.
+func (d *Cli) Command() string {
+	return d.engine.command()
 }
 
 func (d *Cli) Login(ctx context.Context, loginServer string, username string, password string) error {
 	runArgs := exec.NewRunArgs(
-		"docker", "login",
+		d.engine.command(), "login",
 		"--username", username,
 		"--password-stdin",
 		loginServer,
@@ -40,7 +52,7 @@ func (d *Cli) Login(ctx context.Context, loginServer string, username string, pa
 
 	_, err := d.commandRunner.Run(ctx, runArgs)
 	if err != nil {
-		return fmt.Errorf("failed logging into docker: %w", err)
+		return fmt.Errorf("failed logging into %s: %w", d.engine.command(), err)
 	}
 
 	return nil
@@ -81,8 +93,8 @@ func (d *Cli) Build(
 	args := []string{
 		"build",
 		"-f", dockerFilePath,
-		"--platform", platform,
 	}
+	args = append(args, d.engine.platformArgs(platform)...)
 
 	if target != "" {
 		args = append(args, "--target", target)
@@ -96,16 +108,18 @@ func (d *Cli) Build(
 		args = append(args, "--build-arg", arg)
 	}
 
-	for _, arg := range buildSecrets {
-		args = append(args, "--secret", arg)
+	secretArgs, err := d.engine.secretArgs(buildSecrets, buildEnv, tmpFolder)
+	if err != nil {
+		return "", fmt.Errorf("building image: %w", err)
 	}
+	args = append(args, secretArgs...)
 	args = append(args, buildContext)
 
 	// create a file with the docker img id
 	args = append(args, "--iidfile", imgIdFile)
 
 	// Build and produce output
-	runArgs := exec.NewRunArgs("docker", args...).WithCwd(cwd).WithEnv(buildEnv)
+	runArgs := exec.NewRunArgs(d.engine.command(), args...).WithCwd(cwd).WithEnv(buildEnv)
 
 	if buildProgress != nil {
 		// setting stderr and stdout both, as it's been noticed
@@ -161,16 +175,6 @@ func (d *Cli) Inspect(ctx context.Context, imageName string, format string) (str
 	return out.Stdout, nil
 }
 
-func (d *Cli) versionInfo() tools.VersionInfo {
-	return tools.VersionInfo{
-		MinimumVersion: semver.Version{
-			Major: 17,
-			Minor: 9,
-			Patch: 0},
-		UpdateCommand: "Visit https://docs.docker.com/engine/release-notes/ to upgrade",
-	}
-}
-
 // dockerVersionRegexp is a regular expression which matches the text printed by "docker --version"
 // and captures the version and build components.
 var dockerVersionStringRegexp = regexp.MustCompile(`Docker version ([^,]*), build ([a-f0-9]*)`)
@@ -247,35 +251,35 @@ func isSupportedDockerVersion(cliOutput string) (bool, error) {
 	return false, fmt.Errorf("could not determine version from docker version string: %s", version)
 }
 func (d *Cli) CheckInstalled(ctx context.Context) error {
-	err := tools.ToolInPath("docker")
+	err := tools.ToolInPath(d.engine.command())
 	if err != nil {
 		return err
 	}
-	dockerRes, err := tools.ExecuteCommand(ctx, d.commandRunner, "docker", "--version")
+	versionRes, err := tools.ExecuteCommand(ctx, d.commandRunner, d.engine.command(), "--version")
 	if err != nil {
 		return fmt.Errorf("checking %s version: %w", d.Name(), err)
 	}
-	log.Printf("docker version: %s", dockerRes)
-	supported, err := isSupportedDockerVersion(dockerRes)
+	log.Printf("%s version: %s", d.engine.command(), versionRes)
+	supported, err := d.engine.isSupportedVersion(versionRes)
 	if err != nil {
 		return err
 	}
 	if !supported {
-		return &tools.ErrSemver{ToolName: d.Name(), VersionInfo: d.versionInfo()}
+		return &tools.ErrSemver{ToolName: d.Name(), VersionInfo: d.engine.versionInfo()}
 	}
 	return nil
 }
 
 func (d *Cli) InstallUrl() string {
-	return "https://aka.ms/azure-dev/docker-install"
+	return d.engine.installUrl()
 }
 
 func (d *Cli) Name() string {
-	return "Docker"
+	return d.engine.name()
 }
 
 func (d *Cli) executeCommand(ctx context.Context, cwd string, args ...string) (exec.RunResult, error) {
-	runArgs := exec.NewRunArgs("docker", args...).
+	runArgs := exec.NewRunArgs(d.engine.command(), args...).
 		WithCwd(cwd)
 
 	return d.commandRunner.Run(ctx, runArgs)
@@ -0,0 +1,280 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/blang/semver/v4"
+)
+
+// EngineKind is the kind of container engine running the container commands of azd.
+type EngineKind string
+
+const (
+	EngineDocker  EngineKind = "docker"
+	EnginePodman  EngineKind = "podman"
+	EngineNerdctl EngineKind = "nerdctl"
+)
+
+// EngineConfigPath is the path of the user configuration selecting the container engine, e.g.
+// 'azd config set container.engine podman'.
+const EngineConfigPath = "container.engine"
+
+var ErrUnsupportedEngine = errors.New("unsupported container engine")
+
+// ParseEngineKind parses the kind of container engine of the user configuration. An empty value is Docker.
+func ParseEngineKind(value string) (EngineKind, error) {
+	switch kind := EngineKind(strings.ToLower(strings.TrimSpace(value))); kind {
+	case "":
+		return EngineDocker, nil
+	case EngineDocker, EnginePodman, EngineNerdctl:
+		return kind, nil
+	default:
+		return "", fmt.Errorf(
+			"%w '%s'. Supported engines are 'docker', 'podman' and 'nerdctl'", ErrUnsupportedEngine, value)
+	}
+}
+
+// engine holds what differs between the command line interfaces of the container engines. The commands which are the
+// same for all engines, like tag, push, pull and login, are run by the Cli with the executable of the engine.
+type engine interface {
+	// The name of the executable of the engine
+	command() string
+	name() string
+	installUrl() string
+	versionInfo() tools.VersionInfo
+	// isSupportedVersion returns true when the output of '<command> --version' is a supported version of the engine
+	isSupportedVersion(versionOutput string) (bool, error)
+	// platformArgs returns the arguments of a build selecting the platform of the image
+	platformArgs(platform string) []string
+	// secretArgs returns the arguments of a build passing the secrets to the build. Files the secrets are written to
+	// are created in dir, which is removed after the build.
+	secretArgs(secrets []string, buildEnv []string, dir string) ([]string, error)
+}
+
+func newEngine(kind EngineKind) engine {
+	switch kind {
+	case EnginePodman:
+		return &podmanEngine{}
+	case EngineNerdctl:
+		return &nerdctlEngine{}
+	default:
+		return &dockerEngine{}
+	}
+}
+
+type dockerEngine struct{}
+
+func (e *dockerEngine) command() string {
+	return "docker"
+}
+
+func (e *dockerEngine) name() string {
+	return "Docker"
+}
+
+func (e *dockerEngine) installUrl() string {
+	return "https://aka.ms/azure-dev/docker-install"
+}
+
+func (e *dockerEngine) versionInfo() tools.VersionInfo {
+	return tools.VersionInfo{
+		MinimumVersion: semver.Version{
+			Major: 17,
+			Minor: 9,
+			Patch: 0},
+		UpdateCommand: "Visit https://docs.docker.com/engine/release-notes/ to upgrade",
+	}
+}
+
+func (e *dockerEngine) isSupportedVersion(versionOutput string) (bool, error) {
+	return isSupportedDockerVersion(versionOutput)
+}
+
+func (e *dockerEngine) platformArgs(platform string) []string {
+	return []string{"--platform", platform}
+}
+
+func (e *dockerEngine) secretArgs(secrets []string, buildEnv []string, dir string) ([]string, error) {
+	return buildKitSecretArgs(secrets), nil
+}
+
+// nerdctl builds with BuildKit, like Docker.
+type nerdctlEngine struct{}
+
+func (e *nerdctlEngine) command() string {
+	return "nerdctl"
+}
+
+func (e *nerdctlEngine) name() string {
+	return "nerdctl"
+}
+
+func (e *nerdctlEngine) installUrl() string {
+	return "https://github.com/containerd/nerdctl#install"
+}
+
+func (e *nerdctlEngine) versionInfo() tools.VersionInfo {
+	return tools.VersionInfo{
+		MinimumVersion: semver.Version{
+			Major: 1,
+			Minor: 0,
+			Patch: 0},
+		UpdateCommand: "Visit https://github.com/containerd/nerdctl/releases to upgrade",
+	}
+}
+
+// nerdctlVersionRegexp matches the text printed by "nerdctl --version", e.g. 'nerdctl version 1.7.6'.
+var nerdctlVersionRegexp = regexp.MustCompile(`nerdctl version v?(\d+\.\d+\.\d+)`)
+
+func (e *nerdctlEngine) isSupportedVersion(versionOutput string) (bool, error) {
+	return isSupportedSemverVersion(nerdctlVersionRegexp, versionOutput, e.versionInfo().MinimumVersion)
+}
+
+func (e *nerdctlEngine) platformArgs(platform string) []string {
+	return []string{"--platform", platform}
+}
+
+func (e *nerdctlEngine) secretArgs(secrets []string, buildEnv []string, dir string) ([]string, error) {
+	return buildKitSecretArgs(secrets), nil
+}
+
+type podmanEngine struct{}
+
+func (e *podmanEngine) command() string {
+	return "podman"
+}
+
+func (e *podmanEngine) name() string {
+	return "Podman"
+}
+
+func (e *podmanEngine) installUrl() string {
+	return "https://podman.io/docs/installation"
+}
+
+func (e *podmanEngine) versionInfo() tools.VersionInfo {
+	return tools.VersionInfo{
+		MinimumVersion: semver.Version{
+			Major: 4,
+			Minor: 0,
+			Patch: 0},
+		UpdateCommand: "Visit https://podman.io/docs/installation to upgrade",
+	}
+}
+
+// podmanVersionRegexp matches the text printed by "podman --version", e.g. 'podman version 4.9.3'.
+var podmanVersionRegexp = regexp.MustCompile(`podman version (\d+\.\d+\.\d+)`)
+
+func (e *podmanEngine) isSupportedVersion(versionOutput string) (bool, error) {
+	return isSupportedSemverVersion(podmanVersionRegexp, versionOutput, e.versionInfo().MinimumVersion)
+}
+
+// platformArgs selects the platform with --os, --arch and --variant, which all the supported Podman releases accept
+// for builds, unlike --platform.
+func (e *podmanEngine) platformArgs(platform string) []string {
+	parts := strings.Split(platform, "/")
+	args := []string{"--os", parts[0]}
+	if len(parts) > 1 {
+		args = append(args, "--arch", parts[1])
+	}
+	if len(parts) > 2 {
+		args = append(args, "--variant", parts[2])
+	}
+
+	return args
+}
+
+// secretArgs writes the secrets without a source to files, since Podman doesn't read the secret of the environment
+// variable named by the id of the secret, as BuildKit does.
+func (e *podmanEngine) secretArgs(secrets []string, buildEnv []string, dir string) ([]string, error) {
+	args := []string{}
+	for _, secret := range secrets {
+		id, hasSource := secretIdAndSource(secret)
+		if hasSource {
+			args = append(args, "--secret", secret)
+			continue
+		}
+
+		value, has := envValue(buildEnv, id)
+		if !has {
+			value, has = os.LookupEnv(id)
+		}
+		if !has {
+			return nil, fmt.Errorf("the value of the build secret '%s' is not set", id)
+		}
+
+		path := filepath.Join(dir, fmt.Sprintf("secret-%d", len(args)/2))
+		if err := os.WriteFile(path, []byte(value), osutil.PermissionFileOwnerOnly); err != nil {
+			return nil, fmt.Errorf("writing build secret '%s': %w", id, err)
+		}
+
+		args = append(args, "--secret", fmt.Sprintf("id=%s,src=%s", id, path))
+	}
+
+	return args, nil
+}
+
+func buildKitSecretArgs(secrets []string) []string {
+	args := []string{}
+	for _, secret := range secrets {
+		args = append(args, "--secret", secret)
+	}
+
+	return args
+}
+
+// secretIdAndSource returns the id of a build secret like 'id=KEY,src=path', and whether it has a source.
+func secretIdAndSource(secret string) (id string, hasSource bool) {
+	for _, option := range strings.Split(secret, ",") {
+		key, value, _ := strings.Cut(option, "=")
+		switch key {
+		case "id":
+			id = value
+		case "src", "source", "env":
+			hasSource = true
+		}
+	}
+
+	return id, hasSource
+}
+
+// envValue returns the value of the variable of the environment, a list of 'KEY=VALUE' items.
+func envValue(env []string, name string) (string, bool) {
+	// The last value of a variable wins, as for the environment of a process
+	for i := len(env) - 1; i >= 0; i-- {
+		if key, value, has := strings.Cut(env[i], "="); has && key == name {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// isSupportedSemverVersion returns true if the version captured by the regular expression from the output of a version
+// command is the minimum version or later.
+func isSupportedSemverVersion(versionRegexp *regexp.Regexp, versionOutput string, minimum semver.Version) (bool, error) {
+	log.Printf("determining version from version string: %s", versionOutput)
+
+	matches := versionRegexp.FindStringSubmatch(versionOutput)
+	if len(matches) != 2 {
+		return false, fmt.Errorf("could not extract version component from version string: %s", versionOutput)
+	}
+
+	version, err := semver.Parse(matches[1])
+	if err != nil {
+		return false, fmt.Errorf("could not parse version %s: %w", matches[1], err)
+	}
+
+	return version.GTE(minimum), nil
+}
@@ -0,0 +1,178 @@
+package docker
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseEngineKind(t *testing.T) {
+	cases := []struct {
+		value       string
+		expected    EngineKind
+		expectError bool
+	}{
+		{value: "", expected: EngineDocker},
+		{value: "docker", expected: EngineDocker},
+		{value: "Podman", expected: EnginePodman},
+		{value: "nerdctl", expected: EngineNerdctl},
+		{value: "rkt", expectError: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.value, func(t *testing.T) {
+			kind, err := ParseEngineKind(c.value)
+			if c.expectError {
+				require.ErrorIs(t, err, ErrUnsupportedEngine)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, c.expected, kind)
+		})
+	}
+}
+
+func Test_IsSupportedEngineVersion(t *testing.T) {
+	cases := []struct {
+		name        string
+		kind        EngineKind
+		version     string
+		supported   bool
+		expectError bool
+	}{
+		{name: "Podman", kind: EnginePodman, version: "podman version 4.9.3", supported: true},
+		{name: "PodmanNotNewEnough", kind: EnginePodman, version: "podman version 3.4.4", supported: false},
+		{name: "Nerdctl", kind: EngineNerdctl, version: "nerdctl version 1.7.6", supported: true},
+		{name: "NerdctlNotNewEnough", kind: EngineNerdctl, version: "nerdctl version 0.23.0", supported: false},
+		{name: "UnknownScheme", kind: EnginePodman, version: "Docker version 20.10.17, build 100c701", expectError: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			supported, err := newEngine(c.kind).isSupportedVersion(c.version)
+			if c.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, c.supported, supported)
+		})
+	}
+}
+
+func Test_PodmanBuild(t *testing.T) {
+	t.Setenv("SECRET_FROM_PROCESS", "process-value")
+
+	ran := false
+	mockContext := mocks.NewMockContext(context.Background())
+	podman := NewCliWithEngine(mockContext.CommandRunner, EnginePodman)
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "podman build")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		ran = true
+
+		argsNoFile, value := args.Args[:len(args.Args)-2], args.Args[len(args.Args)-1]
+
+		require.Equal(t, "podman", args.Cmd)
+		require.Equal(t, []string{
+			"build",
+			"-f", "./Dockerfile",
+			"--os", "linux",
+			"--arch", "arm64",
+			"--variant", "v8",
+			"-t", "IMAGE_NAME",
+			"--secret", "id=FILE_SECRET,src=./secret.txt",
+		}, argsNoFile[:13])
+
+		// The secrets without a source are written to files
+		for i, id := range []string{"ENV_SECRET", "SECRET_FROM_PROCESS"} {
+			secret := argsNoFile[14+i*2]
+			require.Equal(t, "--secret", argsNoFile[13+i*2])
+
+			src, has := strings.CutPrefix(secret, "id="+id+",src=")
+			require.True(t, has, secret)
+
+			contents, err := os.ReadFile(src)
+			require.NoError(t, err)
+			require.Equal(t, map[string]string{
+				"ENV_SECRET":          "env-value",
+				"SECRET_FROM_PROCESS": "process-value",
+			}[id], string(contents))
+		}
+
+		require.Equal(t, "../", argsNoFile[len(argsNoFile)-1])
+
+		err := os.WriteFile(value, []byte(mockedDockerImgId), 0600)
+		require.NoError(t, err)
+
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	result, err := podman.Build(
+		context.Background(),
+		".",
+		"./Dockerfile",
+		"linux/arm64/v8",
+		"",
+		"../",
+		"IMAGE_NAME",
+		nil,
+		[]string{"id=FILE_SECRET,src=./secret.txt", "id=ENV_SECRET", "id=SECRET_FROM_PROCESS"},
+		[]string{"ENV_SECRET=env-value"},
+		nil,
+	)
+
+	require.True(t, ran)
+	require.NoError(t, err)
+	require.Equal(t, mockedDockerImgId, result)
+}
+
+func Test_PodmanBuildMissingSecret(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	podman := NewCliWithEngine(mockContext.CommandRunner, EnginePodman)
+
+	_, err := podman.Build(
+		context.Background(),
+		".",
+		"./Dockerfile",
+		"",
+		"",
+		"../",
+		"IMAGE_NAME",
+		nil,
+		[]string{"id=AZD_TEST_UNSET_SECRET"},
+		nil,
+		nil,
+	)
+
+	require.ErrorContains(t, err, "the value of the build secret 'AZD_TEST_UNSET_SECRET' is not set")
+}
+
+func Test_NerdctlPush(t *testing.T) {
+	ran := false
+	mockContext := mocks.NewMockContext(context.Background())
+	nerdctl := NewCliWithEngine(mockContext.CommandRunner, EngineNerdctl)
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "nerdctl push")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		ran = true
+		require.Equal(t, "nerdctl", args.Cmd)
+		require.Equal(t, []string{"push", "registry.azurecr.io/app:latest"}, args.Args)
+
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	err := nerdctl.Push(context.Background(), ".", "registry.azurecr.io/app:latest")
+
+	require.True(t, ran)
+	require.NoError(t, err)
+	require.Equal(t, "nerdctl", nerdctl.Name())
+	require.Equal(t, "nerdctl", nerdctl.Command())
+}
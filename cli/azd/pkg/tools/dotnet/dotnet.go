@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -121,6 +122,22 @@ func (cli *Cli) Publish(ctx context.Context, project string, configuration strin
 	return nil
 }
 
+// Run runs the project, writing its output to writer, until it exits or the context is cancelled. The variables of env are
+// added to the environment of the project.
+func (cli *Cli) Run(ctx context.Context, project string, env []string, writer io.Writer) error {
+	runArgs := newDotNetRunArgs("run", "--project", project)
+	runArgs = runArgs.
+		WithEnv(append(runArgs.Env, env...)).
+		WithStdOut(writer).
+		WithStdErr(writer)
+
+	_, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("dotnet run on project '%s' failed: %w", project, err)
+	}
+	return nil
+}
+
 func (cli *Cli) PublishAppHostManifest(
 	ctx context.Context, hostProject string, manifestPath string, dotnetEnv string,
 ) error {
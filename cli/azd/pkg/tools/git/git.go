@@ -91,6 +91,24 @@ func (cli *Cli) ShallowClone(ctx context.Context, repositoryPath string, branch
 	return nil
 }
 
+// ShallowCloneCommit clones the commit of the repository, without its history, into the target directory.
+func (cli *Cli) ShallowCloneCommit(ctx context.Context, repositoryPath string, commit string, target string) error {
+	// Like ShallowClone, the default authentication of codespaces is kept to fetch private repositories.
+	commands := [][]string{
+		{"init", "--quiet", target},
+		{"-C", target, "fetch", "--quiet", "--depth", "1", repositoryPath, commit},
+		{"-C", target, "checkout", "--quiet", "FETCH_HEAD"},
+	}
+
+	for _, args := range commands {
+		if _, err := cli.commandRunner.Run(ctx, exec.NewRunArgs("git", args...)); err != nil {
+			return fmt.Errorf("failed to clone commit '%s' of repository %s: %w", commit, repositoryPath, err)
+		}
+	}
+
+	return nil
+}
+
 var noSuchRemoteRegex = regexp.MustCompile("(fatal|error): No such remote")
 var notGitRepositoryRegex = regexp.MustCompile("(fatal|error): not a git repository")
 var ErrNoSuchRemote = errors.New("no such remote")
@@ -249,6 +267,179 @@ func (cli *Cli) IsUntrackedFile(ctx context.Context, repositoryPath string, file
 	return false, nil
 }
 
+var remoteRefNotFoundRegex = regexp.MustCompile("couldn't find remote ref")
+var pathNotFoundRegex = regexp.MustCompile("does not exist in|exists on disk, but not in")
+var pushRejectedRegex = regexp.MustCompile(`\[rejected\]|\[remote rejected\]|non-fast-forward|fetch first`)
+var ErrRemoteBranchNotFound = errors.New("remote branch not found")
+var ErrPathNotFound = errors.New("path not found")
+var ErrPushRejected = errors.New("push rejected")
+
+// Fetch fetches the branch from the remote and updates its remote tracking branch, ex) origin/main.
+// Returns ErrRemoteBranchNotFound when the branch does not exist in the remote.
+func (cli *Cli) Fetch(ctx context.Context, repositoryPath string, remoteName string, branch string) error {
+	refSpec := fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", branch, remoteName, branch)
+	runArgs := newRunArgs("-C", repositoryPath, "fetch", "--quiet", remoteName, refSpec)
+	res, err := cli.commandRunner.Run(ctx, runArgs)
+	if remoteRefNotFoundRegex.MatchString(res.Stderr) {
+		return ErrRemoteBranchNotFound
+	} else if err != nil {
+		return fmt.Errorf("failed to fetch: %w", err)
+	}
+
+	return nil
+}
+
+// CheckoutBranch creates or resets the local branch to the start point and checks it out,
+// discarding any local changes. When the start point is empty, the branch is created from the current HEAD.
+func (cli *Cli) CheckoutBranch(ctx context.Context, repositoryPath string, branch string, startPoint string) error {
+	args := []string{"-C", repositoryPath, "checkout", "--quiet", "--force", "-B", branch}
+	if startPoint != "" {
+		args = append(args, startPoint)
+	}
+
+	_, err := cli.commandRunner.Run(ctx, newRunArgs(args...))
+	if err != nil {
+		return fmt.Errorf("failed to checkout branch: %w", err)
+	}
+
+	return nil
+}
+
+// RevParse returns the commit id of the specified revision, ex) HEAD
+func (cli *Cli) RevParse(ctx context.Context, repositoryPath string, revision string) (string, error) {
+	runArgs := newRunArgs("-C", repositoryPath, "rev-parse", "--verify", "--quiet", revision)
+	res, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve revision '%s': %w", revision, err)
+	}
+
+	return strings.TrimSpace(res.Stdout), nil
+}
+
+// UpdateRef sets the reference to the commit of the specified revision
+func (cli *Cli) UpdateRef(ctx context.Context, repositoryPath string, ref string, revision string) error {
+	runArgs := newRunArgs("-C", repositoryPath, "update-ref", ref, revision)
+	_, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed to update ref '%s': %w", ref, err)
+	}
+
+	return nil
+}
+
+// ShowFile returns the content of the file at the specified revision.
+// Returns ErrPathNotFound when the file does not exist at the revision.
+func (cli *Cli) ShowFile(ctx context.Context, repositoryPath string, revision string, filePath string) (string, error) {
+	runArgs := newRunArgs("-C", repositoryPath, "show", fmt.Sprintf("%s:%s", revision, filePath))
+	res, err := cli.commandRunner.Run(ctx, runArgs)
+	if pathNotFoundRegex.MatchString(res.Stderr) {
+		return "", ErrPathNotFound
+	} else if err != nil {
+		return "", fmt.Errorf("failed to show file: %w", err)
+	}
+
+	return res.Stdout, nil
+}
+
+// ListFiles returns the paths of the files under the directory at the specified revision
+func (cli *Cli) ListFiles(ctx context.Context, repositoryPath string, revision string, directory string) ([]string, error) {
+	args := []string{"-C", repositoryPath, "ls-tree", "-r", "--name-only", revision}
+	if directory != "" {
+		args = append(args, "--", directory)
+	}
+
+	res, err := cli.commandRunner.Run(ctx, newRunArgs(args...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	files := []string{}
+	for _, line := range strings.Split(res.Stdout, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+
+	return files, nil
+}
+
+// HasStagedChanges returns true when the index contains changes that have not been committed
+func (cli *Cli) HasStagedChanges(ctx context.Context, repositoryPath string) (bool, error) {
+	runArgs := newRunArgs("-C", repositoryPath, "diff", "--cached", "--quiet")
+	res, err := cli.commandRunner.Run(ctx, runArgs)
+	if res.ExitCode == 1 {
+		return true, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to check staged changes: %w", err)
+	}
+
+	return false, nil
+}
+
+// GetConfig returns the value of the git config key, or an empty string when the key is not set
+func (cli *Cli) GetConfig(ctx context.Context, repositoryPath string, key string) (string, error) {
+	runArgs := newRunArgs("-C", repositoryPath, "config", "--get", key)
+	res, err := cli.commandRunner.Run(ctx, runArgs)
+	if res.ExitCode == 1 {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get config '%s': %w", key, err)
+	}
+
+	return strings.TrimSpace(res.Stdout), nil
+}
+
+// SetConfig sets the value of the git config key for the repository
+func (cli *Cli) SetConfig(ctx context.Context, repositoryPath string, key string, value string) error {
+	runArgs := newRunArgs("-C", repositoryPath, "config", "--local", key, value)
+	_, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed to set config '%s': %w", key, err)
+	}
+
+	return nil
+}
+
+// Push pushes the current HEAD to the branch of the remote without prompting.
+// Returns ErrPushRejected when the remote branch contains commits that are not in HEAD.
+func (cli *Cli) Push(ctx context.Context, repositoryPath string, remoteName string, branch string) error {
+	runArgs := newRunArgs(
+		"-C", repositoryPath, "push", "--porcelain", remoteName, fmt.Sprintf("HEAD:refs/heads/%s", branch))
+	res, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil && pushRejectedRegex.MatchString(res.Stdout+res.Stderr) {
+		return ErrPushRejected
+	} else if err != nil {
+		return fmt.Errorf("failed to push: %w", err)
+	}
+
+	return nil
+}
+
+// MergeFile merges the changes from the base file to the other file into the current file, like `git merge-file`,
+// and returns the merged content without modifying the files. The changes which conflict are surrounded by conflict
+// markers, labelled with the labels of the current and other files, and conflicts is the number of these changes.
+func (cli *Cli) MergeFile(
+	ctx context.Context,
+	currentPath string,
+	basePath string,
+	otherPath string,
+	currentLabel string,
+	otherLabel string,
+) (merged string, conflicts int, err error) {
+	runArgs := newRunArgs(
+		"merge-file", "-p", "-L", currentLabel, "-L", "base", "-L", otherLabel, currentPath, basePath, otherPath)
+	res, err := cli.commandRunner.Run(ctx, runArgs)
+
+	// merge-file exits with the number of conflicts, up to 127, or a negative value on errors
+	if err != nil && res.ExitCode > 0 && res.ExitCode < 128 {
+		return res.Stdout, res.ExitCode, nil
+	} else if err != nil {
+		return "", 0, fmt.Errorf("failed to merge file '%s': %w", currentPath, err)
+	}
+
+	return res.Stdout, 0, nil
+}
+
 // SetGitHubAuthForRepo creates git config for the repositoryPath like
 //
 // [credential "https://github.com"]  (when credential is equal to "https://github.com")
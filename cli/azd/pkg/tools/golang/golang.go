@@ -0,0 +1,114 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package golang
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/blang/semver/v4"
+)
+
+var _ tools.ExternalTool = (*Cli)(nil)
+
+type Cli struct {
+	commandRunner exec.CommandRunner
+}
+
+func NewCli(commandRunner exec.CommandRunner) *Cli {
+	return &Cli{
+		commandRunner: commandRunner,
+	}
+}
+
+func (cli *Cli) versionInfo() tools.VersionInfo {
+	return tools.VersionInfo{
+		MinimumVersion: semver.Version{
+			Major: 1,
+			Minor: 21,
+			Patch: 0},
+		UpdateCommand: "Visit https://go.dev/dl/ to upgrade",
+	}
+}
+
+func (cli *Cli) CheckInstalled(ctx context.Context) error {
+	err := tools.ToolInPath("go")
+	if err != nil {
+		return err
+	}
+
+	goRes, err := tools.ExecuteCommand(ctx, cli.commandRunner, "go", "version")
+	if err != nil {
+		return fmt.Errorf("checking %s version: %w", cli.Name(), err)
+	}
+
+	log.Printf("go version: %s", goRes)
+
+	goSemver, err := tools.ExtractVersion(goRes)
+	if err != nil {
+		return fmt.Errorf("converting to semver version fails: %w", err)
+	}
+	updateDetail := cli.versionInfo()
+	if goSemver.LT(updateDetail.MinimumVersion) {
+		return &tools.ErrSemver{ToolName: cli.Name(), VersionInfo: updateDetail}
+	}
+	return nil
+}
+
+func (cli *Cli) InstallUrl() string {
+	return "https://go.dev/doc/install"
+}
+
+func (cli *Cli) Name() string {
+	return "Go CLI"
+}
+
+// ModDownload downloads the modules required by the Go module in the project directory.
+func (cli *Cli) ModDownload(ctx context.Context, projectPath string) error {
+	runArgs := exec.NewRunArgs("go", "mod", "download").WithCwd(projectPath)
+	if _, err := cli.commandRunner.Run(ctx, runArgs); err != nil {
+		return fmt.Errorf("failed to download modules for project '%s': %w", projectPath, err)
+	}
+
+	return nil
+}
+
+// BuildOptions are the options used to compile a Go package
+type BuildOptions struct {
+	// The target operating system, GOOS
+	OS string
+	// The target architecture, GOARCH
+	Arch string
+	// The path of the executable to write
+	Output string
+	// The package to build, relative to the project directory. Defaults to the package of the project directory.
+	Package string
+}
+
+// Build compiles the package of the project into a statically linked executable for the target OS and architecture.
+func (cli *Cli) Build(ctx context.Context, projectPath string, options BuildOptions) error {
+	pkg := options.Package
+	if pkg == "" {
+		pkg = "."
+	}
+
+	runArgs := exec.
+		NewRunArgs("go", "build", "-o", options.Output, pkg).
+		WithCwd(projectPath).
+		WithEnv([]string{
+			// The hosts of the executable don't provide the C libraries the executable could be linked to
+			"CGO_ENABLED=0",
+			"GOOS=" + options.OS,
+			"GOARCH=" + options.Arch,
+		})
+
+	if _, err := cli.commandRunner.Run(ctx, runArgs); err != nil {
+		return fmt.Errorf("failed to build project '%s' for %s/%s: %w", projectPath, options.OS, options.Arch, err)
+	}
+
+	return nil
+}
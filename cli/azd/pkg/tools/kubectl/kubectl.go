@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -240,6 +241,41 @@ func (cli *Cli) RolloutStatus(
 	return &res, nil
 }
 
+// K8s logs options
+type LogsOptions struct {
+	// Keeps streaming the new log entries
+	Follow bool
+	// The number of recent log lines to display, all lines when 0
+	Tail int
+}
+
+// Streams the logs of all the containers of a resource, ex) deployment/my-app, to the writer
+func (cli *Cli) Logs(
+	ctx context.Context,
+	resource string,
+	options *LogsOptions,
+	writer io.Writer,
+	flags *KubeCliFlags,
+) error {
+	runArgs := exec.
+		NewRunArgs("kubectl", "logs", resource, "--all-containers", "--prefix").
+		WithStdOut(writer)
+
+	if options.Follow {
+		runArgs = runArgs.AppendParams("--follow")
+	}
+
+	if options.Tail > 0 {
+		runArgs = runArgs.AppendParams(fmt.Sprintf("--tail=%d", options.Tail))
+	}
+
+	if _, err := cli.executeCommandWithArgs(ctx, runArgs, flags); err != nil {
+		return fmt.Errorf("failed streaming logs of '%s', %w", resource, err)
+	}
+
+	return nil
+}
+
 // Executes a k8s CLI command from the specified arguments and flags
 func (cli *Cli) Exec(ctx context.Context, flags *KubeCliFlags, args ...string) (exec.RunResult, error) {
 	runArgs := exec.
@@ -125,6 +125,22 @@ func Test_Command_Args(t *testing.T) {
 				return err
 			},
 		},
+		"logs": {
+			mockCommandPredicate: "kubectl logs",
+			expectedCmd:          "kubectl",
+			expectedArgs: []string{
+				"logs", "deployment/deployment-name", "--all-containers", "--prefix", "--follow", "--tail=100",
+				"-n", "test-namespace",
+			},
+			testFn: func() error {
+				return cli.Logs(*mockContext.Context, "deployment/deployment-name", &LogsOptions{
+					Follow: true,
+					Tail:   100,
+				}, io.Discard, &KubeCliFlags{
+					Namespace: "test-namespace",
+				})
+			},
+		},
 		"exec": {
 			mockCommandPredicate: "kubectl get deployment",
 			expectedCmd:          "kubectl",
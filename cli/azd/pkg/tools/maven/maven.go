@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -199,6 +200,27 @@ func (cli *Cli) Package(ctx context.Context, projectPath string) error {
 	return nil
 }
 
+// SpringBootRun runs the Spring Boot application of the project, writing its output to writer, until it exits or the
+// context is cancelled. The variables of env are added to the environment of the application.
+func (cli *Cli) SpringBootRun(ctx context.Context, projectPath string, env []string, writer io.Writer) error {
+	mvnCmd, err := cli.mvnCmd()
+	if err != nil {
+		return err
+	}
+
+	runArgs := exec.NewRunArgs(mvnCmd, "spring-boot:run").
+		WithCwd(projectPath).
+		WithEnv(env).
+		WithStdOut(writer).
+		WithStdErr(writer)
+	_, err = cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("mvn spring-boot:run on project '%s' failed: %w", projectPath, err)
+	}
+
+	return nil
+}
+
 func (cli *Cli) ResolveDependencies(ctx context.Context, projectPath string) error {
 	mvnCmd, err := cli.mvnCmd()
 	if err != nil {
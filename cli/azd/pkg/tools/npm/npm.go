@@ -6,6 +6,7 @@ package npm
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
@@ -92,6 +93,24 @@ func (cli *Cli) RunScript(ctx context.Context, projectPath string, scriptName st
 	return nil
 }
 
+// Start runs the start script of the project, writing its output to writer, until it exits or the context is cancelled.
+// The variables of env are added to the environment of the script.
+func (cli *Cli) Start(ctx context.Context, projectPath string, env []string, writer io.Writer) error {
+	runArgs := exec.
+		NewRunArgs("npm", "start").
+		WithCwd(projectPath).
+		WithEnv(env).
+		WithStdOut(writer).
+		WithStdErr(writer)
+
+	_, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed to start project %s: %w", projectPath, err)
+	}
+
+	return nil
+}
+
 func (cli *Cli) Prune(ctx context.Context, projectPath string, production bool) error {
 	runArgs := exec.
 		NewRunArgs("npm", "prune").
@@ -0,0 +1,232 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package pulumi
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/blang/semver/v4"
+)
+
+var _ tools.ExternalTool = (*Cli)(nil)
+
+type Cli struct {
+	commandRunner exec.CommandRunner
+	env           []string
+}
+
+func NewCli(commandRunner exec.CommandRunner) *Cli {
+	return &Cli{
+		commandRunner: commandRunner,
+	}
+}
+
+func (cli *Cli) Name() string {
+	return "Pulumi CLI"
+}
+
+func (cli *Cli) InstallUrl() string {
+	return "https://www.pulumi.com/docs/install/"
+}
+
+func (cli *Cli) versionInfo() tools.VersionInfo {
+	return tools.VersionInfo{
+		MinimumVersion: semver.Version{
+			Major: 3,
+			Minor: 50,
+			Patch: 0},
+		UpdateCommand: "Download newer version from https://www.pulumi.com/docs/install/",
+	}
+}
+
+func (cli *Cli) CheckInstalled(ctx context.Context) error {
+	err := tools.ToolInPath("pulumi")
+	if err != nil {
+		return err
+	}
+
+	versionOutput, err := tools.ExecuteCommand(ctx, cli.commandRunner, "pulumi", "version")
+	if err != nil {
+		return fmt.Errorf("checking %s version: %w", cli.Name(), err)
+	}
+
+	log.Printf("pulumi version: %s", versionOutput)
+
+	pulumiSemver, err := tools.ExtractVersion(versionOutput)
+	if err != nil {
+		return fmt.Errorf("converting to semver version fails: %w", err)
+	}
+
+	updateDetail := cli.versionInfo()
+	if pulumiSemver.LT(updateDetail.MinimumVersion) {
+		return &tools.ErrSemver{ToolName: cli.Name(), VersionInfo: updateDetail}
+	}
+
+	return nil
+}
+
+// Set environment variables to be used in all pulumi commands
+func (cli *Cli) SetEnv(env []string) {
+	cli.env = env
+}
+
+func (cli *Cli) runCommand(ctx context.Context, projectPath string, args ...string) (exec.RunResult, error) {
+	runArgs := exec.
+		NewRunArgs("pulumi", append(args, "--cwd", projectPath, "--non-interactive")...).
+		WithEnv(cli.env)
+
+	return cli.commandRunner.Run(ctx, runArgs)
+}
+
+func (cli *Cli) runInteractive(ctx context.Context, projectPath string, args ...string) (exec.RunResult, error) {
+	runArgs := exec.
+		NewRunArgs("pulumi", append(args, "--cwd", projectPath)...).
+		WithEnv(cli.env).
+		WithInteractive(true)
+
+	return cli.commandRunner.Run(ctx, runArgs)
+}
+
+// SelectStack selects the specified stack of the project, optionally creating it when it does not exist yet.
+func (cli *Cli) SelectStack(ctx context.Context, projectPath string, stack string, create bool) error {
+	args := []string{"stack", "select", stack}
+	if create {
+		args = append(args, "--create")
+	}
+
+	cmdRes, err := cli.runCommand(ctx, projectPath, args...)
+	if err != nil {
+		return fmt.Errorf(
+			"failed running pulumi stack select: %s (%w)",
+			cmdRes.Stderr,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// SetConfig sets the specified configuration values on the stack. Values are stored in plain text within the stack
+// configuration file, use SetSecretConfig for sensitive values.
+func (cli *Cli) SetConfig(ctx context.Context, projectPath string, stack string, values map[string]string) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	args := []string{"config", "set-all", "--stack", stack}
+	// Sort the keys to ensure a stable command line
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		args = append(args, "--plaintext", fmt.Sprintf("%s=%s", key, values[key]))
+	}
+
+	cmdRes, err := cli.runCommand(ctx, projectPath, args...)
+	if err != nil {
+		return fmt.Errorf(
+			"failed running pulumi config set-all: %s (%w)",
+			cmdRes.Stderr,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// SetSecretConfig sets the specified configuration values on the stack as secrets, which pulumi encrypts within the
+// stack configuration file. The values are written to the standard input of pulumi, so they never appear in the
+// arguments of the process.
+func (cli *Cli) SetSecretConfig(ctx context.Context, projectPath string, stack string, secrets map[string]string) error {
+	for _, key := range slices.Sorted(maps.Keys(secrets)) {
+		// pulumi reads the value from stdin when it is not set as an argument and the command is non-interactive
+		runArgs := exec.
+			NewRunArgs("pulumi", "config", "set", "--secret", "--stack", stack, key, "--cwd", projectPath, "--non-interactive").
+			WithEnv(cli.env).
+			WithStdIn(strings.NewReader(secrets[key]))
+
+		cmdRes, err := cli.commandRunner.Run(ctx, runArgs)
+		if err != nil {
+			return fmt.Errorf(
+				"failed running pulumi config set for secret '%s': %s (%w)",
+				key,
+				cmdRes.Stderr,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+// Preview runs `pulumi preview` and returns the JSON representation of the changes
+func (cli *Cli) Preview(ctx context.Context, projectPath string, stack string) (string, error) {
+	cmdRes, err := cli.runCommand(ctx, projectPath, "preview", "--json", "--stack", stack)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed running pulumi preview: %s (%w)",
+			cmdRes.Stderr,
+			err,
+		)
+	}
+	return cmdRes.Stdout, nil
+}
+
+// Up deploys the stack without prompting for confirmation
+func (cli *Cli) Up(ctx context.Context, projectPath string, stack string, additionalArgs ...string) (string, error) {
+	args := []string{"up", "--stack", stack, "--yes", "--skip-preview"}
+	args = append(args, additionalArgs...)
+
+	// The output of interactive commands is written to the console, it isn't captured in the result
+	cmdRes, err := cli.runInteractive(ctx, projectPath, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed running pulumi up: %w", err)
+	}
+	return cmdRes.Stdout, nil
+}
+
+// Destroy deletes all the resources of the stack. When autoApprove is not set, pulumi prompts for confirmation.
+func (cli *Cli) Destroy(ctx context.Context, projectPath string, stack string, autoApprove bool) (string, error) {
+	args := []string{"destroy", "--stack", stack}
+	if autoApprove {
+		args = append(args, "--yes", "--skip-preview")
+	}
+
+	// The output of interactive commands is written to the console, it isn't captured in the result
+	cmdRes, err := cli.runInteractive(ctx, projectPath, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed running pulumi destroy: %w", err)
+	}
+	return cmdRes.Stdout, nil
+}
+
+// StackOutput returns the JSON representation of the stack outputs, including secret values
+func (cli *Cli) StackOutput(ctx context.Context, projectPath string, stack string) (string, error) {
+	cmdRes, err := cli.runCommand(ctx, projectPath, "stack", "output", "--stack", stack, "--json", "--show-secrets")
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed running pulumi stack output: %s (%w)",
+			cmdRes.Stderr,
+			err,
+		)
+	}
+	return cmdRes.Stdout, nil
+}
+
+// StackExport returns the JSON representation of the stack deployment state
+func (cli *Cli) StackExport(ctx context.Context, projectPath string, stack string) (string, error) {
+	cmdRes, err := cli.runCommand(ctx, projectPath, "stack", "export", "--stack", stack)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed running pulumi stack export: %s (%w)",
+			cmdRes.Stderr,
+			err,
+		)
+	}
+	return cmdRes.Stdout, nil
+}
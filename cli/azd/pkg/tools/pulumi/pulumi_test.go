@@ -0,0 +1,100 @@
+package pulumi
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithEnv(t *testing.T) {
+	ran := false
+	expectedEnvVars := []string{"PULUMI_BACKEND_URL=file://MYDIR"}
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi"
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		ran = true
+		require.Equal(t, expectedEnvVars, args.Env)
+		require.Equal(t, []string{
+			"stack", "select", "dev", "--create", "--cwd", "path/to/project", "--non-interactive",
+		}, args.Args)
+
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	cli := NewCli(mockContext.CommandRunner)
+	cli.SetEnv(expectedEnvVars)
+
+	err := cli.SelectStack(*mockContext.Context, "path/to/project", "dev", true)
+
+	require.NoError(t, err)
+	require.True(t, ran)
+}
+
+func Test_SetConfig(t *testing.T) {
+	var actualArgs []string
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi"
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		actualArgs = args.Args
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	cli := NewCli(mockContext.CommandRunner)
+	err := cli.SetConfig(*mockContext.Context, "path/to/project", "dev", map[string]string{
+		"b": "2",
+		"a": "1",
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"config", "set-all", "--stack", "dev", "--plaintext", "a=1", "--plaintext", "b=2",
+		"--cwd", "path/to/project", "--non-interactive",
+	}, actualArgs)
+}
+
+func Test_SetSecretConfig(t *testing.T) {
+	commands := [][]string{}
+	stdIns := []string{}
+
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "pulumi"
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		commands = append(commands, args.Args)
+
+		stdIn, err := io.ReadAll(args.StdIn)
+		require.NoError(t, err)
+		stdIns = append(stdIns, string(stdIn))
+
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	cli := NewCli(mockContext.CommandRunner)
+	err := cli.SetSecretConfig(*mockContext.Context, "path/to/project", "dev", map[string]string{
+		"dbPassword":  "p@ssw0rd",
+		"adminSecret": "s3cr3t",
+	})
+
+	require.NoError(t, err)
+
+	// The values are only written to stdin, never passed as arguments
+	require.Equal(t, [][]string{
+		{
+			"config", "set", "--secret", "--stack", "dev", "adminSecret",
+			"--cwd", "path/to/project", "--non-interactive",
+		},
+		{
+			"config", "set", "--secret", "--stack", "dev", "dbPassword",
+			"--cwd", "path/to/project", "--non-interactive",
+		},
+	}, commands)
+	require.Equal(t, []string{"s3cr3t", "p@ssw0rd"}, stdIns)
+}
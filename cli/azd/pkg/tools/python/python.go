@@ -6,6 +6,7 @@ package python
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"path/filepath"
 	"runtime"
@@ -131,6 +132,37 @@ func (cli *Cli) Run(
 	return &runResult, nil
 }
 
+// RunApp runs the Python script of the working directory with the Python of the virtual environment, writing its output
+// to writer, until it exits or the context is cancelled. The variables of env are added to the environment of the script.
+func (cli *Cli) RunApp(
+	ctx context.Context,
+	workingDir string,
+	environment string,
+	args []string,
+	env []string,
+	writer io.Writer,
+) error {
+	// Windows & Posix have different layouts of virtual environments
+	pyString := filepath.Join(workingDir, environment, "bin", "python")
+	if runtime.GOOS == "windows" {
+		pyString = filepath.Join(workingDir, environment, "Scripts", "python.exe")
+	}
+
+	runArgs := exec.
+		NewRunArgs(pyString, args...).
+		WithCwd(workingDir).
+		WithEnv(env).
+		WithStdOut(writer).
+		WithStdErr(writer)
+
+	_, err := cli.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		return fmt.Errorf("failed to run Python app: %w", err)
+	}
+
+	return nil
+}
+
 func checkPath() (pyString string, err error) {
 	if runtime.GOOS == "windows" {
 		// py for https://peps.python.org/pep-0397
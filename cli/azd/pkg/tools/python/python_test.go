@@ -3,6 +3,7 @@ package python
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -89,3 +90,27 @@ func Test_Python_CreateVirtualEnv(t *testing.T) {
 	require.Equal(t, tempDir, runArgs.Cwd)
 	require.Equal(t, []string{"-m", "venv", ".venv"}, runArgs.Args)
 }
+
+func Test_Python_RunApp(t *testing.T) {
+	tempDir := t.TempDir()
+	mockContext := mocks.NewMockContext(context.Background())
+
+	var runArgs exec.RunArgs
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		runArgs = args
+		return strings.Contains(command, "app.py")
+	}).Respond(exec.NewRunResult(0, "", ""))
+
+	cli := NewCli(mockContext.CommandRunner)
+
+	output := &strings.Builder{}
+	err := cli.RunApp(*mockContext.Context, tempDir, ".venv", []string{"app.py"}, []string{"PORT=8000"}, output)
+	require.NoError(t, err)
+	require.Equal(t, tempDir, runArgs.Cwd)
+	require.Contains(t, runArgs.Cmd, filepath.Join(tempDir, ".venv"))
+	require.Equal(t, []string{"app.py"}, runArgs.Args)
+	require.Equal(t, []string{"PORT=8000"}, runArgs.Env)
+	require.Equal(t, output, runArgs.StdOut)
+	require.Equal(t, output, runArgs.Stderr)
+}
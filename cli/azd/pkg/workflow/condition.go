@@ -0,0 +1,211 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// conditionOperandRegex matches an environment variable reference, optionally wrapped as $NAME or ${NAME}
+var conditionOperandRegex = regexp.MustCompile(`^(?:\$\{([A-Za-z_][A-Za-z0-9_]*)\}|\$?([A-Za-z_][A-Za-z0-9_]*))$`)
+
+// EvaluateCondition evaluates the `if` condition of a workflow step against the environment values.
+//
+// The following expressions are supported, where NAME is the name of an environment value (optionally written as
+// $NAME or ${NAME}):
+//   - NAME: true when the value is set and is not empty, 'false' or '0'
+//   - !NAME: the negation of the above
+//   - NAME == value and NAME != value: compares the value, which must be quoted when it contains spaces or operators
+//
+// Expressions can be combined with && and ||, where && takes precedence over ||.
+// An empty condition is always true.
+func EvaluateCondition(condition string, getenv func(string) string) (bool, error) {
+	if strings.TrimSpace(condition) == "" {
+		return true, nil
+	}
+
+	tokens, err := tokenizeCondition(condition)
+	if err != nil {
+		return false, fmt.Errorf("invalid condition '%s': %w", condition, err)
+	}
+
+	for _, orExpr := range splitTokens(tokens, "||") {
+		result := true
+		for _, andExpr := range splitTokens(orExpr, "&&") {
+			value, err := evaluateExpression(andExpr, getenv)
+			if err != nil {
+				return false, fmt.Errorf("invalid condition '%s': %w", condition, err)
+			}
+
+			result = result && value
+		}
+
+		if result {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// conditionOperators are the operators of a condition, operators within quoted values are part of the value
+var conditionOperators = []string{"&&", "||", "==", "!="}
+
+// conditionToken is an operator, a name or a value of a condition
+type conditionToken struct {
+	// The value of the token, without the quotes of a quoted value
+	value string
+	// The token as written in the condition
+	text     string
+	operator bool
+	quoted   bool
+}
+
+// tokenizeCondition splits the condition into operators, names and values, where quoted values are a single token
+func tokenizeCondition(condition string) ([]conditionToken, error) {
+	tokens := []conditionToken{}
+
+	for i := 0; i < len(condition); {
+		rest := condition[i:]
+
+		if unicode.IsSpace(rune(rest[0])) {
+			i++
+			continue
+		}
+
+		if operator, has := conditionOperator(rest); has {
+			tokens = append(tokens, conditionToken{value: operator, text: operator, operator: true})
+			i += len(operator)
+			continue
+		}
+
+		if rest[0] == '!' {
+			tokens = append(tokens, conditionToken{value: "!", text: "!", operator: true})
+			i++
+			continue
+		}
+
+		if rest[0] == '\'' || rest[0] == '"' {
+			end := strings.IndexByte(rest[1:], rest[0])
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quoted value %s", rest)
+			}
+
+			tokens = append(tokens, conditionToken{value: rest[1 : end+1], text: rest[:end+2], quoted: true})
+			i += end + 2
+			continue
+		}
+
+		end := 1
+		for end < len(rest) {
+			if _, has := conditionOperator(rest[end:]); has ||
+				unicode.IsSpace(rune(rest[end])) || rest[end] == '\'' || rest[end] == '"' {
+				break
+			}
+
+			end++
+		}
+
+		tokens = append(tokens, conditionToken{value: rest[:end], text: rest[:end]})
+		i += end
+	}
+
+	return tokens, nil
+}
+
+// conditionOperator returns the binary operator the text starts with
+func conditionOperator(text string) (string, bool) {
+	for _, operator := range conditionOperators {
+		if strings.HasPrefix(text, operator) {
+			return operator, true
+		}
+	}
+
+	return "", false
+}
+
+// splitTokens splits the tokens around each occurrence of the operator
+func splitTokens(tokens []conditionToken, operator string) [][]conditionToken {
+	groups := [][]conditionToken{}
+	start := 0
+
+	for i, token := range tokens {
+		if token.operator && token.value == operator {
+			groups = append(groups, tokens[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(groups, tokens[start:])
+}
+
+// evaluateExpression evaluates a single comparison or truthy check
+func evaluateExpression(expr []conditionToken, getenv func(string) string) (bool, error) {
+	switch {
+	case len(expr) == 0:
+		return false, fmt.Errorf("empty expression")
+	case len(expr) == 3 && expr[1].operator && (expr[1].value == "==" || expr[1].value == "!="):
+		name, err := operandName(expr[0])
+		if err != nil {
+			return false, err
+		}
+
+		if expr[2].operator {
+			return false, fmt.Errorf("missing value to compare '%s' to", name)
+		}
+
+		equal := getenv(name) == expr[2].value
+		if expr[1].value == "==" {
+			return equal, nil
+		}
+
+		return !equal, nil
+	case len(expr) <= 2:
+		negate := len(expr) == 2
+		if negate && (!expr[0].operator || expr[0].value != "!") {
+			return false, fmt.Errorf("'%s' is not a valid expression", joinTokens(expr))
+		}
+
+		name, err := operandName(expr[len(expr)-1])
+		if err != nil {
+			return false, err
+		}
+
+		value := strings.ToLower(strings.TrimSpace(getenv(name)))
+		truthy := value != "" && value != "false" && value != "0"
+
+		return truthy != negate, nil
+	default:
+		return false, fmt.Errorf("'%s' is not a valid expression", joinTokens(expr))
+	}
+}
+
+func operandName(operand conditionToken) (string, error) {
+	var matches []string
+	if !operand.operator && !operand.quoted {
+		matches = conditionOperandRegex.FindStringSubmatch(operand.value)
+	}
+
+	if matches == nil {
+		return "", fmt.Errorf("'%s' is not a valid environment value name", operand.text)
+	}
+
+	if matches[1] != "" {
+		return matches[1], nil
+	}
+
+	return matches[2], nil
+}
+
+func joinTokens(tokens []conditionToken) string {
+	texts := make([]string, len(tokens))
+	for i, token := range tokens {
+		texts[i] = token.text
+	}
+
+	return strings.Join(texts, " ")
+}
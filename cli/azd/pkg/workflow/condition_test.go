@@ -0,0 +1,81 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EvaluateCondition(t *testing.T) {
+	values := map[string]string{
+		"AZURE_ENV_TYPE": "prod",
+		"ENABLED":        "true",
+		"DISABLED":       "false",
+		"ZERO":           "0",
+		"FILTER":         "a || b && c == d",
+		"EXPRESSION":     "x != y",
+	}
+	getenv := func(name string) string {
+		return values[name]
+	}
+
+	tests := []struct {
+		condition string
+		expected  bool
+	}{
+		{"", true},
+		{"ENABLED", true},
+		{"DISABLED", false},
+		{"ZERO", false},
+		{"MISSING", false},
+		{"!MISSING", true},
+		{"!ENABLED", false},
+		{"$ENABLED", true},
+		{"${ENABLED}", true},
+		{"AZURE_ENV_TYPE == prod", true},
+		{"AZURE_ENV_TYPE == 'prod'", true},
+		{`AZURE_ENV_TYPE == "dev"`, false},
+		{"AZURE_ENV_TYPE != dev", true},
+		{"MISSING == ''", true},
+		{"ENABLED && AZURE_ENV_TYPE == prod", true},
+		{"DISABLED && AZURE_ENV_TYPE == prod", false},
+		{"DISABLED || AZURE_ENV_TYPE == prod", true},
+		{"DISABLED || ZERO", false},
+		{"DISABLED && ZERO || ENABLED", true},
+		{"FILTER == 'a || b && c == d'", true},
+		{`FILTER != "a || b && c == d"`, false},
+		{"FILTER == 'a || b'", false},
+		{"EXPRESSION == 'x != y' && ENABLED", true},
+		{"DISABLED || EXPRESSION == \"x != y\"", true},
+		{"AZURE_ENV_TYPE == 'dev || prod'", false},
+		{"AZURE_ENV_TYPE==prod&&ENABLED", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.condition, func(t *testing.T) {
+			actual, err := EvaluateCondition(test.condition, getenv)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_EvaluateCondition_Invalid(t *testing.T) {
+	getenv := func(string) string { return "" }
+
+	for _, condition := range []string{
+		"ENABLED &&",
+		"1INVALID",
+		"A B == c",
+		"== prod",
+		"A == 'unterminated || B",
+		"A ==",
+		"'A' == b",
+		"A == b c",
+	} {
+		t.Run(condition, func(t *testing.T) {
+			_, err := EvaluateCondition(condition, getenv)
+			require.Error(t, err)
+		})
+	}
+}
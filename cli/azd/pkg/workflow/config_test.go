@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
 	"github.com/braydonk/yaml"
 	"github.com/stretchr/testify/require"
 )
@@ -97,6 +98,54 @@ func Test_WorkflowMap_UnmarshallYAML(t *testing.T) {
 		assertWorkflow(t, upWorkflow)
 	})
 
+	t.Run("run, hook and conditional steps", func(t *testing.T) {
+		var workflowMap WorkflowMap
+		yamlString := heredoc.Doc(`
+			up:
+			  - azd: provision
+			  - name: seed database
+			    run: ./scripts/seed.sh
+			    if: AZURE_ENV_TYPE != prod
+			    continueOnError: true
+			  - run: echo "deployed"
+			    shell: sh
+			  - hook: predeploy
+		`)
+
+		err := yaml.Unmarshal([]byte(yamlString), &workflowMap)
+		require.NoError(t, err)
+
+		steps := workflowMap["up"].Steps
+		require.Len(t, steps, 4)
+
+		require.Equal(t, StepKindAzd, steps[0].Kind())
+		require.Equal(t, "azd provision", steps[0].DisplayName())
+
+		require.Equal(t, StepKindRun, steps[1].Kind())
+		require.Equal(t, "seed database", steps[1].DisplayName())
+		require.Equal(t, "AZURE_ENV_TYPE != prod", steps[1].If)
+		require.True(t, steps[1].ContinueOnError)
+
+		require.Equal(t, StepKindRun, steps[2].Kind())
+		require.Equal(t, ext.ShellTypeBash, steps[2].Shell)
+		require.Equal(t, `run: echo "deployed"`, steps[2].DisplayName())
+
+		require.Equal(t, StepKindHook, steps[3].Kind())
+		require.Equal(t, "hook: predeploy", steps[3].DisplayName())
+	})
+
+	t.Run("step with multiple kinds", func(t *testing.T) {
+		var workflowMap WorkflowMap
+		yamlString := heredoc.Doc(`
+			up:
+			  - azd: provision
+			    run: ./scripts/seed.sh
+		`)
+
+		err := yaml.Unmarshal([]byte(yamlString), &workflowMap)
+		require.ErrorContains(t, err, "only specify one of 'azd', 'run' or 'hook'")
+	})
+
 	t.Run("invalid workflow", func(t *testing.T) {
 		var workflowMap WorkflowMap
 		yamlString := heredoc.Doc(`
@@ -3,9 +3,16 @@ package workflow
 import (
 	"context"
 	"fmt"
-	"strings"
+	"log"
+	"time"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
 )
 
 // AzdCommandRunner abstracts the execution of an azd command given an set of arguments and context.
@@ -14,31 +21,203 @@ type AzdCommandRunner interface {
 	ExecuteContext(ctx context.Context) error
 }
 
+// StepStatus is the outcome of a single workflow step
+type StepStatus string
+
+const (
+	StepStatusSucceeded StepStatus = "succeeded"
+	StepStatusFailed    StepStatus = "failed"
+	// The step was not executed because its condition evaluated to false
+	StepStatusSkipped StepStatus = "skipped"
+)
+
+// StepResult is the result of a single workflow step
+type StepResult struct {
+	Name   string
+	Kind   StepKind
+	Status StepStatus
+	// The error of the step when it failed
+	Error    error
+	Duration time.Duration
+}
+
+// RunResult is the result of a workflow, containing the result of every step that was evaluated
+type RunResult struct {
+	Steps []*StepResult
+}
+
 // Runner is responsible for executing a workflow
 type Runner struct {
-	azdRunner AzdCommandRunner
-	console   input.Console
+	azdRunner      AzdCommandRunner
+	console        input.Console
+	commandRunner  exec.CommandRunner
+	lazyEnvManager *lazy.Lazy[environment.Manager]
+	lazyEnv        *lazy.Lazy[*environment.Environment]
+	lazyAzdContext *lazy.Lazy[*azdcontext.AzdContext]
 }
 
 // NewRunner creates a new instance of the Runner.
-func NewRunner(azdRunner AzdCommandRunner, console input.Console) *Runner {
+func NewRunner(
+	azdRunner AzdCommandRunner,
+	console input.Console,
+	commandRunner exec.CommandRunner,
+	lazyEnvManager *lazy.Lazy[environment.Manager],
+	lazyEnv *lazy.Lazy[*environment.Environment],
+	lazyAzdContext *lazy.Lazy[*azdcontext.AzdContext],
+) *Runner {
 	return &Runner{
-		azdRunner: azdRunner,
-		console:   console,
+		azdRunner:      azdRunner,
+		console:        console,
+		commandRunner:  commandRunner,
+		lazyEnvManager: lazyEnvManager,
+		lazyEnv:        lazyEnv,
+		lazyAzdContext: lazyAzdContext,
 	}
 }
 
-// Run executes the specified workflow against the root cobra command
-func (r *Runner) Run(ctx context.Context, workflow *Workflow) error {
+// Run executes the specified workflow against the root cobra command.
+// Steps are executed in order, the workflow stops at the first failing step unless the step sets `continueOnError`.
+// The returned result contains the result of every step evaluated, including when an error is returned.
+func (r *Runner) Run(ctx context.Context, workflow *Workflow) (*RunResult, error) {
+	result := &RunResult{
+		Steps: []*StepResult{},
+	}
+
 	for _, step := range workflow.Steps {
-		if len(step.AzdCommand.Args) > 0 {
-			r.azdRunner.SetArgs(step.AzdCommand.Args)
+		stepResult := &StepResult{
+			Name: step.DisplayName(),
+			Kind: step.Kind(),
 		}
+		result.Steps = append(result.Steps, stepResult)
 
-		if err := r.azdRunner.ExecuteContext(ctx); err != nil {
-			return fmt.Errorf("error executing step command '%s': %w", strings.Join(step.AzdCommand.Args, " "), err)
+		run, err := r.evaluateCondition(ctx, step)
+		if err != nil {
+			stepResult.Status = StepStatusFailed
+			stepResult.Error = err
+			return result, fmt.Errorf("error evaluating condition of step '%s': %w", stepResult.Name, err)
 		}
+
+		if !run {
+			log.Printf("skipping workflow step '%s', condition '%s' evaluated to false", stepResult.Name, step.If)
+			stepResult.Status = StepStatusSkipped
+			r.console.Message(
+				ctx,
+				output.WithGrayFormat("Skipping step '%s' since condition '%s' is false", stepResult.Name, step.If),
+			)
+			continue
+		}
+
+		startTime := time.Now()
+		err = r.runStep(ctx, step)
+		stepResult.Duration = time.Since(startTime)
+
+		if err == nil {
+			stepResult.Status = StepStatusSucceeded
+			continue
+		}
+
+		stepResult.Status = StepStatusFailed
+		stepResult.Error = err
+
+		if !step.ContinueOnError {
+			return result, fmt.Errorf("error executing step '%s': %w", stepResult.Name, err)
+		}
+
+		r.console.Message(
+			ctx,
+			output.WithBold("%s", output.WithWarningFormat("WARNING: step '%s' failed: %s", stepResult.Name, err.Error())),
+		)
+		r.console.Message(
+			ctx,
+			output.WithWarningFormat("Execution will continue since continueOnError has been set to true."),
+		)
+		log.Printf("workflow step '%s' failed: %v", stepResult.Name, err)
+	}
+
+	return result, nil
+}
+
+// evaluateCondition evaluates the `if` condition of the step against the latest values of the environment
+func (r *Runner) evaluateCondition(ctx context.Context, step *Step) (bool, error) {
+	if step.If == "" {
+		return true, nil
+	}
+
+	env, err := r.reloadEnv(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return EvaluateCondition(step.If, env.Getenv)
+}
+
+// runStep executes the work of a single step
+func (r *Runner) runStep(ctx context.Context, step *Step) error {
+	switch step.Kind() {
+	case StepKindRun:
+		return r.runScript(ctx, step)
+	case StepKindHook:
+		// Named hooks are defined at both the project & service level,
+		// `azd hooks run` already takes care of executing all of them.
+		r.azdRunner.SetArgs([]string{"hooks", "run", step.Hook})
+		return r.azdRunner.ExecuteContext(ctx)
+	default:
+		r.azdRunner.SetArgs(step.AzdCommand.Args)
+		return r.azdRunner.ExecuteContext(ctx)
+	}
+}
+
+// runScript executes the inline or file script of the step with the same semantics as hooks
+func (r *Runner) runScript(ctx context.Context, step *Step) error {
+	env, err := r.reloadEnv(ctx)
+	if err != nil {
+		return err
+	}
+
+	envManager, err := r.lazyEnvManager.GetValue()
+	if err != nil {
+		return err
+	}
+
+	azdContext, err := r.lazyAzdContext.GetValue()
+	if err != nil {
+		return err
+	}
+
+	cwd := azdContext.ProjectDirectory()
+	hookName := "run"
+	hooksMap := map[string][]*ext.HookConfig{
+		hookName: {
+			{
+				Name:        step.DisplayName(),
+				Run:         step.Run,
+				Shell:       step.Shell,
+				Interactive: step.Interactive,
+			},
+		},
+	}
+
+	hooksManager := ext.NewHooksManager(cwd)
+	hooksRunner := ext.NewHooksRunner(hooksManager, r.commandRunner, envManager, r.console, cwd, hooksMap, env)
+
+	return hooksRunner.RunHooks(ctx, ext.HookTypeNone, nil, hookName)
+}
+
+// reloadEnv reloads the environment so conditions & scripts observe the values set by previous steps
+func (r *Runner) reloadEnv(ctx context.Context) (*environment.Environment, error) {
+	env, err := r.lazyEnv.GetValue()
+	if err != nil {
+		return nil, fmt.Errorf("loading environment: %w", err)
+	}
+
+	envManager, err := r.lazyEnvManager.GetValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := envManager.Reload(ctx, env); err != nil {
+		return nil, fmt.Errorf("reloading environment: %w", err)
 	}
 
-	return nil
+	return env, nil
 }
@@ -0,0 +1,148 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Runner_Run(t *testing.T) {
+	t.Run("AllStepKinds", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		azdRunner := &fakeAzdRunner{}
+		runner := createTestRunner(t, mockContext, azdRunner, map[string]string{"AZURE_ENV_NAME": "dev"})
+
+		ranScript := false
+		mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+			return args.Cmd == "bash"
+		}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+			ranScript = true
+			require.Contains(t, args.Env, "AZURE_ENV_NAME=dev")
+			return exec.NewRunResult(0, "", ""), nil
+		})
+
+		result, err := runner.Run(*mockContext.Context, &Workflow{
+			Name: "up",
+			Steps: []*Step{
+				NewAzdCommandStep("provision"),
+				{Run: "echo 'hello'", Shell: ext.ShellTypeBash},
+				{Hook: "predeploy"},
+			},
+		})
+		require.NoError(t, err)
+		require.True(t, ranScript)
+		require.Equal(t, [][]string{{"provision"}, {"hooks", "run", "predeploy"}}, azdRunner.executed)
+
+		require.Len(t, result.Steps, 3)
+		for _, step := range result.Steps {
+			require.Equal(t, StepStatusSucceeded, step.Status)
+		}
+	})
+
+	t.Run("ConditionSkipsStep", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		azdRunner := &fakeAzdRunner{}
+		runner := createTestRunner(t, mockContext, azdRunner, map[string]string{"AZURE_ENV_TYPE": "dev"})
+
+		result, err := runner.Run(*mockContext.Context, &Workflow{
+			Name: "up",
+			Steps: []*Step{
+				{AzdCommand: Command{Args: []string{"provision"}}, If: "AZURE_ENV_TYPE == prod"},
+				{AzdCommand: Command{Args: []string{"deploy", "--all"}}, If: "AZURE_ENV_TYPE != prod"},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, [][]string{{"deploy", "--all"}}, azdRunner.executed)
+		require.Equal(t, StepStatusSkipped, result.Steps[0].Status)
+		require.Equal(t, StepStatusSucceeded, result.Steps[1].Status)
+	})
+
+	t.Run("FailureStopsWorkflow", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		azdRunner := &fakeAzdRunner{fail: "provision"}
+		runner := createTestRunner(t, mockContext, azdRunner, nil)
+
+		result, err := runner.Run(*mockContext.Context, &Workflow{
+			Name: "up",
+			Steps: []*Step{
+				NewAzdCommandStep("provision"),
+				NewAzdCommandStep("deploy", "--all"),
+			},
+		})
+		require.ErrorContains(t, err, "error executing step 'azd provision'")
+		require.Len(t, result.Steps, 1)
+		require.Equal(t, StepStatusFailed, result.Steps[0].Status)
+		require.Equal(t, [][]string{{"provision"}}, azdRunner.executed)
+	})
+
+	t.Run("ContinueOnError", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		azdRunner := &fakeAzdRunner{fail: "provision"}
+		runner := createTestRunner(t, mockContext, azdRunner, nil)
+
+		result, err := runner.Run(*mockContext.Context, &Workflow{
+			Name: "up",
+			Steps: []*Step{
+				{AzdCommand: Command{Args: []string{"provision"}}, ContinueOnError: true},
+				NewAzdCommandStep("deploy", "--all"),
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Steps, 2)
+		require.Equal(t, StepStatusFailed, result.Steps[0].Status)
+		require.Error(t, result.Steps[0].Error)
+		require.Equal(t, StepStatusSucceeded, result.Steps[1].Status)
+	})
+}
+
+func createTestRunner(
+	t *testing.T,
+	mockContext *mocks.MockContext,
+	azdRunner AzdCommandRunner,
+	values map[string]string,
+) *Runner {
+	env := environment.NewWithValues("dev", values)
+	envManager := &mockenv.MockEnvManager{}
+	envManager.On("Reload", mock.Anything, env).Return(nil)
+
+	return NewRunner(
+		azdRunner,
+		mockContext.Console,
+		mockContext.CommandRunner,
+		lazy.From[environment.Manager](envManager),
+		lazy.From(env),
+		lazy.From(azdcontext.NewAzdContextWithDirectory(t.TempDir())),
+	)
+}
+
+// fakeAzdRunner records the azd commands executed by the workflow runner
+type fakeAzdRunner struct {
+	args     []string
+	executed [][]string
+	// The command that fails when executed
+	fail string
+}
+
+func (f *fakeAzdRunner) SetArgs(args []string) {
+	f.args = args
+}
+
+func (f *fakeAzdRunner) ExecuteContext(ctx context.Context) error {
+	f.executed = append(f.executed, f.args)
+	if f.fail != "" && strings.Join(f.args, " ") == f.fail {
+		return errors.New("command failed")
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
 	"github.com/braydonk/yaml"
 )
 
@@ -78,6 +79,10 @@ func (w *Workflow) unmarshalSteps(rawSteps any) ([]*Step, error) {
 			return nil, err
 		}
 
+		if err := step.validate(); err != nil {
+			return nil, err
+		}
+
 		steps = append(steps, &step)
 	}
 
@@ -85,9 +90,80 @@ func (w *Workflow) unmarshalSteps(rawSteps any) ([]*Step, error) {
 }
 
 // Step stores a single step to execute within a workflow
-// This struct can be expanded over time to support other types of steps/commands
+// A step executes one of an azd command, a script or a named hook.
 type Step struct {
+	// Optional name used to report the result of the step
+	Name string `yaml:"name,omitempty"`
+	// The azd command to execute
 	AzdCommand Command `yaml:"azd,omitempty"`
+	// The inline script or relative path of a script from the project path to execute.
+	// Scripts are executed with the same semantics as hooks.
+	Run string `yaml:"run,omitempty"`
+	// The type of shell used to execute the script (sh or pwsh)
+	Shell ext.ShellType `yaml:"shell,omitempty"`
+	// When set to true will bind the stdin, stdout & stderr of the script to the running console
+	Interactive bool `yaml:"interactive,omitempty"`
+	// The name of the project & service hooks to execute, ex) predeploy
+	Hook string `yaml:"hook,omitempty"`
+	// Optional condition over the environment values, the step is skipped when the condition is false.
+	// ex) AZURE_ENV_TYPE == 'prod'
+	If string `yaml:"if,omitempty"`
+	// When set to true the workflow continues running the next steps even when this step fails
+	ContinueOnError bool `yaml:"continueOnError,omitempty"`
+}
+
+// StepKind is the kind of work a step executes
+type StepKind string
+
+const (
+	StepKindAzd  StepKind = "azd"
+	StepKindRun  StepKind = "run"
+	StepKindHook StepKind = "hook"
+)
+
+// Kind returns the kind of work the step executes
+func (s *Step) Kind() StepKind {
+	switch {
+	case s.Run != "":
+		return StepKindRun
+	case s.Hook != "":
+		return StepKindHook
+	default:
+		return StepKindAzd
+	}
+}
+
+// DisplayName returns the name of the step, or a name generated from the work it executes when not set
+func (s *Step) DisplayName() string {
+	if s.Name != "" {
+		return s.Name
+	}
+
+	switch s.Kind() {
+	case StepKindRun:
+		script, _, _ := strings.Cut(strings.TrimSpace(s.Run), "\n")
+		return fmt.Sprintf("run: %s", script)
+	case StepKindHook:
+		return fmt.Sprintf("hook: %s", s.Hook)
+	default:
+		return fmt.Sprintf("azd %s", strings.Join(s.AzdCommand.Args, " "))
+	}
+}
+
+// validate ensures the step doesn't execute more than one kind of work
+func (s *Step) validate() error {
+	kinds := 0
+	for _, isSet := range []bool{len(s.AzdCommand.Args) > 0, s.Run != "", s.Hook != ""} {
+		if isSet {
+			kinds++
+		}
+	}
+
+	if kinds > 1 {
+		return fmt.Errorf("workflow step can only specify one of 'azd', 'run' or 'hook'")
+	}
+
+	return nil
 }
 
 // NewAzdCommandStep creates a new step that executes an azd command with the specified name and args
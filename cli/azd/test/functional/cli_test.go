@@ -534,6 +534,7 @@ func Test_CLI_ProjectIsNeeded(t *testing.T) {
 		{command: "env set", args: []string{"testKey", "testValue"}},
 		{command: "infra create"},
 		{command: "infra delete"},
+		{command: "logs"},
 		{command: "monitor"},
 		{command: "pipeline config"},
 		{command: "restore"},
@@ -568,7 +569,7 @@ func Test_CLI_ProvisionIsNeeded(t *testing.T) {
 
 	cli := azdcli.NewCLI(t)
 	cli.WorkingDirectory = dir
-	cli.Env = append(os.Environ(), "AZURE_LOCATION=eastus2")
+	cli.Env = append(os.Environ(), "AZURE_LOCATION=eastus2", "AZD_ALPHA_ENABLE_LOGS=true")
 
 	err := copySample(dir, "webapp")
 	require.NoError(t, err, "failed expanding sample")
@@ -582,6 +583,7 @@ func Test_CLI_ProvisionIsNeeded(t *testing.T) {
 		errorToStdOut bool
 	}{
 		{command: "deploy"},
+		{command: "logs"},
 		{command: "monitor"},
 	}
 
@@ -135,6 +135,35 @@ func MockContainerAppRevisionGet(
 	return mockRequest
 }
 
+func MockContainerAppRevisionActivate(
+	mockContext *mocks.MockContext,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	revisionName string,
+) *http.Request {
+	mockRequest := &http.Request{}
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost && strings.Contains(
+			request.URL.Path,
+			fmt.Sprintf(
+				"/subscriptions/%s/resourceGroups/%s/providers/Microsoft.App/containerApps/%s/revisions/%s/activate",
+				subscriptionId,
+				resourceGroup,
+				appName,
+				revisionName,
+			),
+		)
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		*mockRequest = *request
+
+		return mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+	})
+
+	return mockRequest
+}
+
 func MockContainerAppSecretsList(
 	mockContext *mocks.MockContext,
 	subscriptionId string,
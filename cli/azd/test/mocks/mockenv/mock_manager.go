@@ -31,6 +31,11 @@ func (m *MockEnvManager) Get(ctx context.Context, name string) (*environment.Env
 	return args.Get(0).(*environment.Environment), args.Error(1)
 }
 
+func (m *MockEnvManager) GetRemote(ctx context.Context, name string) (*environment.Environment, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(*environment.Environment), args.Error(1)
+}
+
 func (m *MockEnvManager) Save(ctx context.Context, env *environment.Environment) error {
 	args := m.Called(ctx, env)
 	return args.Error(0)
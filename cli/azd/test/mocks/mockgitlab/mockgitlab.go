@@ -0,0 +1,216 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package mockgitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/gitlab"
+)
+
+// Token is the only token the mock GitLab instance accepts.
+const Token = "TOKEN"
+
+// MockGitLab is a GitLab instance serving the projects and the CI/CD variables APIs from memory, over https and under
+// a relative path like a self-hosted instance can be.
+type MockGitLab struct {
+	// BaseUrl is the URL of the instance, e.g. https://127.0.0.1:port/gitlab
+	BaseUrl string
+	// Transport sends requests to the instance, trusting its certificate
+	Transport *http.Client
+
+	mu        sync.Mutex
+	projects  []gitlab.Project
+	variables map[int]map[string]gitlab.Variable
+}
+
+func NewMockGitLab(t *testing.T) *MockGitLab {
+	mock := &MockGitLab{
+		variables: map[int]map[string]gitlab.Variable{},
+	}
+
+	server := httptest.NewTLSServer(http.StripPrefix("/gitlab/api/v4", mock))
+	t.Cleanup(server.Close)
+	mock.BaseUrl = server.URL + "/gitlab"
+	mock.Transport = server.Client()
+
+	return mock
+}
+
+// AddProject adds a project with the full path, e.g. group/subgroup/project.
+func (m *MockGitLab) AddProject(pathWithNamespace string) gitlab.Project {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.addProject(pathWithNamespace)
+}
+
+func (m *MockGitLab) addProject(pathWithNamespace string) gitlab.Project {
+	id := len(m.projects) + 1
+	name := pathWithNamespace[strings.LastIndex(pathWithNamespace, "/")+1:]
+	m.projects = append(m.projects, gitlab.Project{
+		Id:                id,
+		Name:              name,
+		Path:              name,
+		PathWithNamespace: pathWithNamespace,
+		WebUrl:            m.BaseUrl + "/" + pathWithNamespace,
+		HttpUrlToRepo:     m.BaseUrl + "/" + pathWithNamespace + ".git",
+	})
+	m.variables[id] = map[string]gitlab.Variable{}
+
+	return m.projects[id-1]
+}
+
+// Variables returns a copy of the CI/CD variables of the project.
+func (m *MockGitLab) Variables(projectId int) map[string]gitlab.Variable {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	variables := map[string]gitlab.Variable{}
+	for key, variable := range m.variables[projectId] {
+		variables[key] = variable
+	}
+
+	return variables
+}
+
+// SetVariable sets a CI/CD variable of the project.
+func (m *MockGitLab) SetVariable(projectId int, variable gitlab.Variable) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.variables[projectId][variable.Key] = variable
+}
+
+func (m *MockGitLab) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if req.Header.Get("PRIVATE-TOKEN") != Token {
+		writeJson(w, http.StatusUnauthorized, map[string]string{"message": "401 Unauthorized"})
+		return
+	}
+
+	// The path of the projects is escaped, e.g. /projects/group%2Fproject
+	segments := strings.Split(strings.TrimPrefix(req.URL.EscapedPath(), "/"), "/")
+	switch {
+	case len(segments) == 1 && segments[0] == "user":
+		writeJson(w, http.StatusOK, gitlab.User{Id: 1, Username: "user"})
+	case len(segments) == 1 && segments[0] == "projects" && req.Method == http.MethodGet:
+		writePage(w, req, m.projects)
+	case len(segments) == 1 && segments[0] == "projects" && req.Method == http.MethodPost:
+		var body map[string]string
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		for _, project := range m.projects {
+			if project.PathWithNamespace == "user/"+body["name"] {
+				writeJson(w, http.StatusBadRequest, map[string]any{"message": map[string]any{
+					"name": []string{"has already been taken"},
+				}})
+				return
+			}
+		}
+
+		writeJson(w, http.StatusCreated, m.addProject("user/"+body["name"]))
+	case len(segments) >= 2 && segments[0] == "projects":
+		projectPath, _ := url.PathUnescape(segments[1])
+		var project *gitlab.Project
+		for i := range m.projects {
+			if projectPath == m.projects[i].PathWithNamespace || projectPath == strconv.Itoa(m.projects[i].Id) {
+				project = &m.projects[i]
+			}
+		}
+
+		if project == nil {
+			writeJson(w, http.StatusNotFound, map[string]string{"message": "404 Project Not Found"})
+			return
+		}
+
+		if len(segments) == 2 {
+			writeJson(w, http.StatusOK, project)
+			return
+		}
+
+		m.serveVariables(w, req, project.Id, segments[3:])
+	default:
+		writeJson(w, http.StatusNotFound, map[string]string{"error": "404 Not Found"})
+	}
+}
+
+func (m *MockGitLab) serveVariables(w http.ResponseWriter, req *http.Request, projectId int, segments []string) {
+	variables := m.variables[projectId]
+	if len(segments) == 0 {
+		if req.Method == http.MethodGet {
+			list := []gitlab.Variable{}
+			for _, variable := range variables {
+				list = append(list, variable)
+			}
+			writePage(w, req, list)
+			return
+		}
+
+		var variable gitlab.Variable
+		_ = json.NewDecoder(req.Body).Decode(&variable)
+		if _, has := variables[variable.Key]; has {
+			writeJson(w, http.StatusBadRequest, map[string]any{"message": map[string]any{
+				"key": []string{"has already been taken"},
+			}})
+			return
+		}
+
+		variables[variable.Key] = variable
+		writeJson(w, http.StatusCreated, variable)
+		return
+	}
+
+	if req.URL.Query().Get("filter[environment_scope]") != "*" {
+		writeJson(w, http.StatusConflict, map[string]string{"message": "There are multiple variables with provided parameters"})
+		return
+	}
+
+	key := segments[0]
+	if _, has := variables[key]; !has {
+		writeJson(w, http.StatusNotFound, map[string]string{"message": "404 Variable Not Found"})
+		return
+	}
+
+	switch req.Method {
+	case http.MethodPut:
+		var variable gitlab.Variable
+		_ = json.NewDecoder(req.Body).Decode(&variable)
+		variables[key] = variable
+		writeJson(w, http.StatusOK, variable)
+	case http.MethodDelete:
+		delete(variables, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// writePage writes the page of the items, with pages of 2 items to exercise the pagination.
+func writePage(w http.ResponseWriter, req *http.Request, items any) {
+	content, _ := json.Marshal(items)
+	var all []json.RawMessage
+	_ = json.Unmarshal(content, &all)
+
+	page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+	start := min((page-1)*2, len(all))
+	end := min(start+2, len(all))
+	if end < len(all) {
+		w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+	}
+
+	writeJson(w, http.StatusOK, all[start:end])
+}
+
+func writeJson(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
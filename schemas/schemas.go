@@ -0,0 +1,14 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Package schemas embeds the JSON schemas of azure.yaml, so they are available to azd without fetching them.
+package schemas
+
+import (
+	_ "embed"
+)
+
+// AzureYamlV1 is the v1.0 schema of azure.yaml
+//
+//go:embed v1.0/azure.yaml.json
+var AzureYamlV1 []byte